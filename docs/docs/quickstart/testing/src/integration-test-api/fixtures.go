@@ -1,19 +1,23 @@
 package apitest
 
-import "github.com/justtrackio/gosoline/pkg/fixtures"
+import (
+	"github.com/justtrackio/gosoline/pkg/currency"
+	"github.com/justtrackio/gosoline/pkg/fixtures"
+	"github.com/shopspring/decimal"
+)
 
 var fixtureSets = []*fixtures.FixtureSet{
 	{
 		Enabled: true,
-		Writer:  fixtures.ConfigurableKvStoreFixtureWriterFactory[float64]("currency"),
+		Writer:  fixtures.ConfigurableKvStoreFixtureWriterFactory[currency.StoredValue]("currency"),
 		Fixtures: []interface{}{
 			&fixtures.KvStoreFixture{
 				Key:   "GBP",
-				Value: 1.25,
+				Value: currency.NewStoredValue(decimal.RequireFromString("1.25")),
 			},
 			&fixtures.KvStoreFixture{
 				Key:   "2021-01-03-GBP",
-				Value: 0.8,
+				Value: currency.NewStoredValue(decimal.RequireFromString("0.8")),
 			},
 		},
 	},
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -33,9 +35,38 @@ func RequestWithSubject(ginCtx *gin.Context, subject *Subject) {
 }
 
 func GetSubject(ctx context.Context) *Subject {
-	if user, ok := ctx.Value(subjectKey).(*Subject); ok {
+	if user, ok := LookupSubject(ctx); ok {
 		return user
 	}
 
 	panic(fmt.Errorf("there is no subject in the context"))
 }
+
+// LookupSubject returns the subject of the context and whether there is one at all.
+func LookupSubject(ctx context.Context) (*Subject, bool) {
+	user, ok := ctx.Value(subjectKey).(*Subject)
+
+	return user, ok
+}
+
+// Roles returns the roles of the subject, they are only available if the subject was authenticated by an oidc token.
+func (s *Subject) Roles() []string {
+	roles, _ := s.Attributes[AttributeRoles].([]string)
+
+	return roles
+}
+
+// Scopes returns the scopes of the subject, they are only available if the subject was authenticated by an oidc token.
+func (s *Subject) Scopes() []string {
+	scopes, _ := s.Attributes[AttributeScopes].([]string)
+
+	return scopes
+}
+
+func (s *Subject) HasRole(role string) bool {
+	return slices.Contains(s.Roles(), role)
+}
+
+func (s *Subject) HasScope(scope string) bool {
+	return slices.Contains(s.Scopes(), scope)
+}
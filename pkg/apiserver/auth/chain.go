@@ -1,11 +1,40 @@
 package auth
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
 )
 
+const configChain = "api_auth_chain"
+
+type AuthenticatorFactory func(ctx context.Context, config cfg.Config, logger log.Logger) (Authenticator, error)
+
+var authenticatorFactories = map[string]AuthenticatorFactory{
+	ByApiKey: func(_ context.Context, config cfg.Config, logger log.Logger) (Authenticator, error) {
+		return NewConfigKeyAuthenticator(config, logger, ProvideValueFromHeader(HeaderApiKey)), nil
+	},
+	ByBasicAuth: func(_ context.Context, config cfg.Config, logger log.Logger) (Authenticator, error) {
+		return NewBasicAuthAuthenticator(config, logger)
+	},
+	ByGoogle: func(_ context.Context, config cfg.Config, logger log.Logger) (Authenticator, error) {
+		return NewConfigGoogleAuthenticator(config, logger)
+	},
+	ByJWT: func(_ context.Context, config cfg.Config, _ log.Logger) (Authenticator, error) {
+		return NewJWTAuthAuthenticator(config), nil
+	},
+	ByOidc: NewOidcAuthenticator,
+}
+
+// AddAuthenticatorFactory registers a custom authenticator which can be used in the api_auth_chain list.
+func AddAuthenticatorFactory(name string, factory AuthenticatorFactory) {
+	authenticatorFactories[name] = factory
+}
+
 func NewChainHandler(authenticators map[string]Authenticator) gin.HandlerFunc {
 	return func(ginCtx *gin.Context) {
 		errors := make(map[string]string)
@@ -26,3 +55,30 @@ func NewChainHandler(authenticators map[string]Authenticator) gin.HandlerFunc {
 		ginCtx.Abort()
 	}
 }
+
+// NewConfigChainHandler creates a chain of the authenticators listed at api_auth_chain, e.g. [apiKey, oidc]. Each of
+// them reads its own settings, the api key is read from the X-API-KEY header.
+func NewConfigChainHandler(ctx context.Context, config cfg.Config, logger log.Logger) (gin.HandlerFunc, error) {
+	var ok bool
+	var err error
+	var factory AuthenticatorFactory
+
+	names := config.GetStringSlice(configChain, []string{})
+	if len(names) == 0 {
+		return nil, fmt.Errorf("there are no authenticators configured at %s", configChain)
+	}
+
+	authenticators := make(map[string]Authenticator, len(names))
+
+	for _, name := range names {
+		if factory, ok = authenticatorFactories[name]; !ok {
+			return nil, fmt.Errorf("there is no authenticator named %s", name)
+		}
+
+		if authenticators[name], err = factory(ctx, config, logger); err != nil {
+			return nil, fmt.Errorf("can not create authenticator %s: %w", name, err)
+		}
+	}
+
+	return NewChainHandler(authenticators), nil
+}
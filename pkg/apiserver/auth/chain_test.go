@@ -0,0 +1,108 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/justtrackio/gosoline/pkg/apiserver/auth"
+	"github.com/justtrackio/gosoline/pkg/appctx"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/oauth2/oauth2test"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestConfigChainHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigChainHandlerTestSuite))
+}
+
+type ConfigChainHandlerTestSuite struct {
+	suite.Suite
+
+	idp    *oauth2test.FakeIdentityProvider
+	config cfg.GosoConf
+	router *gin.Engine
+}
+
+func (s *ConfigChainHandlerTestSuite) SetupTest() {
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	s.idp, err = oauth2test.NewFakeIdentityProvider("client", "secret")
+	s.Require().NoError(err)
+
+	s.config = cfg.New()
+	err = s.config.Option(cfg.WithConfigMap(map[string]interface{}{
+		"api_auth_keys": []string{"key"},
+		"api_auth_oidc": map[string]interface{}{
+			"issuer":    s.idp.Issuer(),
+			"audiences": []string{"api"},
+		},
+	}))
+	s.Require().NoError(err)
+}
+
+func (s *ConfigChainHandlerTestSuite) TearDownTest() {
+	s.idp.Close()
+}
+
+func (s *ConfigChainHandlerTestSuite) TestApiKeyAndOidc() {
+	s.setupRouter("apiKey", "oidc")
+
+	token, err := s.idp.SignToken("RS256", jwt.MapClaims{
+		"iss": s.idp.Issuer(),
+		"sub": "user",
+		"aud": "api",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+
+	s.Equal(http.StatusOK, s.serve(auth.HeaderApiKey, "key"))
+	s.Equal(http.StatusOK, s.serve("Authorization", fmt.Sprintf("Bearer %s", token)))
+	s.Equal(http.StatusUnauthorized, s.serve("Authorization", "Bearer invalid"))
+	s.Equal(http.StatusUnauthorized, s.serve(auth.HeaderApiKey, "wrong"))
+}
+
+func (s *ConfigChainHandlerTestSuite) TestUnknownAuthenticator() {
+	err := s.config.Option(cfg.WithConfigSetting("api_auth_chain", []string{"oidc", "carrierPigeon"}))
+	s.Require().NoError(err)
+
+	_, err = auth.NewConfigChainHandler(appctx.WithContainer(context.Background()), s.config, logMocks.NewLoggerMockedAll())
+	s.EqualError(err, "there is no authenticator named carrierPigeon")
+}
+
+func (s *ConfigChainHandlerTestSuite) TestNoAuthenticators() {
+	_, err := auth.NewConfigChainHandler(appctx.WithContainer(context.Background()), s.config, logMocks.NewLoggerMockedAll())
+	s.EqualError(err, "there are no authenticators configured at api_auth_chain")
+}
+
+func (s *ConfigChainHandlerTestSuite) setupRouter(authenticators ...string) {
+	err := s.config.Option(cfg.WithConfigSetting("api_auth_chain", authenticators))
+	s.Require().NoError(err)
+
+	handler, err := auth.NewConfigChainHandler(appctx.WithContainer(context.Background()), s.config, logMocks.NewLoggerMockedAll())
+	s.Require().NoError(err)
+
+	s.router = gin.New()
+	s.router.Use(handler)
+	s.router.GET("/", func(ginCtx *gin.Context) {
+		ginCtx.Status(http.StatusOK)
+	})
+}
+
+func (s *ConfigChainHandlerTestSuite) serve(header string, value string) int {
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(header, value)
+
+	s.router.ServeHTTP(response, request)
+
+	return response.Code
+}
@@ -0,0 +1,263 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/funk"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/oauth2"
+	"github.com/spf13/cast"
+)
+
+const (
+	ByOidc          = "oidc"
+	configOidc      = "api_auth_oidc"
+	AttributeClaims = "claims"
+	AttributeRoles  = "roles"
+	AttributeScopes = "scopes"
+)
+
+type OidcSettings struct {
+	Issuer string `cfg:"issuer" validate:"required"`
+	// JwksUrl is discovered from the issuer if it isn't configured
+	JwksUrl   string   `cfg:"jwks_url"`
+	Audiences []string `cfg:"audiences" validate:"min=1"`
+	// Leeway is the tolerated clock skew when checking the expiry and not before claims
+	Leeway time.Duration `cfg:"leeway" default:"1m"`
+	// The claims are looked up by their name, nested claims can be addressed with dots, e.g. realm_access.roles
+	SubjectClaim string `cfg:"subject_claim" default:"sub"`
+	RolesClaim   string `cfg:"roles_claim" default:"roles"`
+	// ScopesClaim can either contain a space separated string or a list of scopes
+	ScopesClaim        string        `cfg:"scopes_claim" default:"scope"`
+	CacheTtl           time.Duration `cfg:"cache_ttl" default:"1h"`
+	MinRefreshInterval time.Duration `cfg:"min_refresh_interval" default:"1m"`
+	HttpClient         string        `cfg:"http_client" default:"oauth2"`
+}
+
+type oidcAuthenticator struct {
+	logger   log.Logger
+	clock    clock.Clock
+	keySet   oauth2.KeySet
+	settings *OidcSettings
+	parser   *jwt.Parser
+}
+
+func NewOidcHandler(ctx context.Context, config cfg.Config, logger log.Logger) (gin.HandlerFunc, error) {
+	auth, err := NewOidcAuthenticator(ctx, config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("can not create oidcAuthenticator: %w", err)
+	}
+
+	return func(ginCtx *gin.Context) {
+		valid, err := auth.IsValid(ginCtx)
+
+		if valid {
+			return
+		}
+
+		if err == nil {
+			err = fmt.Errorf("the oidc token wasn't valid nor was there an error")
+		}
+
+		ginCtx.JSON(http.StatusUnauthorized, gin.H{"err": err.Error()})
+		ginCtx.Abort()
+	}, nil
+}
+
+func NewOidcAuthenticator(ctx context.Context, config cfg.Config, logger log.Logger) (Authenticator, error) {
+	settings := &OidcSettings{}
+	config.UnmarshalKey(configOidc, settings)
+
+	keySet, err := oauth2.NewKeySet(ctx, config, logger, &oauth2.KeySetSettings{
+		Url:                settings.JwksUrl,
+		Issuer:             settings.Issuer,
+		CacheTtl:           settings.CacheTtl,
+		MinRefreshInterval: settings.MinRefreshInterval,
+		HttpClient:         settings.HttpClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can not create key set: %w", err)
+	}
+
+	return NewOidcAuthenticatorWithInterfaces(logger, clock.Provider, keySet, settings), nil
+}
+
+func NewOidcAuthenticatorWithInterfaces(logger log.Logger, clock clock.Clock, keySet oauth2.KeySet, settings *OidcSettings) Authenticator {
+	return &oidcAuthenticator{
+		logger:   logger,
+		clock:    clock,
+		keySet:   keySet,
+		settings: settings,
+		parser: &jwt.Parser{
+			ValidMethods: []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()},
+			// the time based claims are checked by us, as the parser neither supports a leeway nor our clock
+			SkipClaimsValidation: true,
+		},
+	}
+}
+
+func (a *oidcAuthenticator) IsValid(ginCtx *gin.Context) (bool, error) {
+	bearerAuth := ginCtx.GetHeader(headerJwtAuth)
+
+	if bearerAuth == "" {
+		return false, fmt.Errorf("no credentials provided")
+	}
+
+	if !strings.HasPrefix(bearerAuth, "Bearer ") {
+		return false, fmt.Errorf("could not find oidc token in header")
+	}
+
+	ctx := ginCtx.Request.Context()
+	claims := jwt.MapClaims{}
+
+	if _, err := a.parser.ParseWithClaims(bearerAuth[len("Bearer "):], claims, func(token *jwt.Token) (interface{}, error) {
+		return a.getKey(ctx, token)
+	}); err != nil {
+		// the validation error of the jwt package doesn't support unwrapping, so errors of the key set would be lost
+		validationErr := &jwt.ValidationError{}
+		if errors.As(err, &validationErr) && validationErr.Inner != nil {
+			err = validationErr.Inner
+		}
+
+		return false, fmt.Errorf("oidc auth: invalid token: %w", err)
+	}
+
+	if err := a.validateClaims(claims); err != nil {
+		return false, fmt.Errorf("oidc auth: %w", err)
+	}
+
+	name, ok := lookupClaim(claims, a.settings.SubjectClaim).(string)
+	if !ok || name == "" {
+		return false, fmt.Errorf("oidc auth: token has no subject claim %s", a.settings.SubjectClaim)
+	}
+
+	subject := &Subject{
+		Name:            name,
+		Anonymous:       false,
+		AuthenticatedBy: ByOidc,
+		Attributes: map[string]interface{}{
+			AttributeClaims: map[string]interface{}(claims),
+			AttributeRoles:  claimToStrings(lookupClaim(claims, a.settings.RolesClaim)),
+			AttributeScopes: claimToStrings(lookupClaim(claims, a.settings.ScopesClaim)),
+		},
+	}
+	RequestWithSubject(ginCtx, subject)
+
+	return true, nil
+}
+
+// getKey returns the key matching the key id of the token. The type of the key has to match the algorithm of the
+// token, otherwise a public key could be misused as the secret of another algorithm.
+func (a *oidcAuthenticator) getKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, err := a.keySet.Key(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Alg != "" && key.Alg != token.Method.Alg() {
+		return nil, fmt.Errorf("key %s can't be used with algorithm %s", kid, token.Method.Alg())
+	}
+
+	switch publicKey := key.Key.(type) {
+	case *rsa.PublicKey:
+		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, fmt.Errorf("rsa key %s can't be used with algorithm %s", kid, token.Method.Alg())
+		}
+
+		return publicKey, nil
+	case *ecdsa.PublicKey:
+		if token.Method.Alg() != jwt.SigningMethodES256.Alg() {
+			return nil, fmt.Errorf("ec key %s can't be used with algorithm %s", kid, token.Method.Alg())
+		}
+
+		return publicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key.Key)
+	}
+}
+
+func (a *oidcAuthenticator) validateClaims(claims jwt.MapClaims) error {
+	now := a.clock.Now()
+
+	if issuer, _ := claims["iss"].(string); strings.TrimSuffix(issuer, "/") != strings.TrimSuffix(a.settings.Issuer, "/") {
+		return fmt.Errorf("invalid issuer %s", issuer)
+	}
+
+	if len(funk.Intersect(claimToStrings(claims["aud"]), a.settings.Audiences)) == 0 {
+		return fmt.Errorf("invalid audience")
+	}
+
+	exp, ok := claims["exp"]
+	if !ok {
+		return fmt.Errorf("token has no expiry")
+	}
+
+	expiresAt, err := cast.ToInt64E(exp)
+	if err != nil {
+		return fmt.Errorf("invalid expiry: %w", err)
+	}
+
+	if !now.Add(-a.settings.Leeway).Before(time.Unix(expiresAt, 0)) {
+		return fmt.Errorf("token is expired")
+	}
+
+	if nbf, ok := claims["nbf"]; ok {
+		notBefore, err := cast.ToInt64E(nbf)
+		if err != nil {
+			return fmt.Errorf("invalid not before: %w", err)
+		}
+
+		if now.Add(a.settings.Leeway).Before(time.Unix(notBefore, 0)) {
+			return fmt.Errorf("token is not valid yet")
+		}
+	}
+
+	return nil
+}
+
+func lookupClaim(claims jwt.MapClaims, path string) interface{} {
+	var current interface{} = map[string]interface{}(claims)
+
+	for _, part := range strings.Split(path, ".") {
+		values, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		current = values[part]
+	}
+
+	return current
+}
+
+func claimToStrings(claim interface{}) []string {
+	switch claim := claim.(type) {
+	case string:
+		return strings.Fields(claim)
+	case []interface{}:
+		values := make([]string, 0, len(claim))
+
+		for _, value := range claim {
+			if value, ok := value.(string); ok {
+				values = append(values, value)
+			}
+		}
+
+		return values
+	default:
+		return []string{}
+	}
+}
@@ -0,0 +1,215 @@
+package auth_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt"
+	"github.com/justtrackio/gosoline/pkg/apiserver/auth"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	gosoHttp "github.com/justtrackio/gosoline/pkg/http"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/justtrackio/gosoline/pkg/oauth2"
+	"github.com/justtrackio/gosoline/pkg/oauth2/oauth2test"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestOidcAuthTestSuite(t *testing.T) {
+	suite.Run(t, new(OidcAuthTestSuite))
+}
+
+type OidcAuthTestSuite struct {
+	suite.Suite
+
+	idp           *oauth2test.FakeIdentityProvider
+	clock         clock.FakeClock
+	authenticator auth.Authenticator
+}
+
+func (s *OidcAuthTestSuite) SetupTest() {
+	var err error
+
+	s.idp, err = oauth2test.NewFakeIdentityProvider("client", "secret")
+	s.Require().NoError(err)
+
+	logger := logMocks.NewLoggerMockedAll()
+	httpClient := gosoHttp.NewHttpClientWithInterfaces(logger, clock.NewRealClock(), metricMocks.NewWriterMockedAll(), resty.New())
+
+	s.clock = clock.NewFakeClock()
+	keySet := oauth2.NewKeySetWithInterfaces(logger, s.clock, httpClient, &oauth2.KeySetSettings{
+		Issuer:             s.idp.Issuer(),
+		CacheTtl:           time.Hour,
+		MinRefreshInterval: time.Minute,
+	})
+
+	s.authenticator = auth.NewOidcAuthenticatorWithInterfaces(logger, s.clock, keySet, &auth.OidcSettings{
+		Issuer:       s.idp.Issuer(),
+		Audiences:    []string{"api"},
+		Leeway:       time.Minute,
+		SubjectClaim: "sub",
+		RolesClaim:   "realm_access.roles",
+		ScopesClaim:  "scope",
+	})
+}
+
+func (s *OidcAuthTestSuite) TearDownTest() {
+	s.idp.Close()
+}
+
+func (s *OidcAuthTestSuite) TestValid() {
+	for _, alg := range []string{"RS256", "ES256"} {
+		ginCtx := s.ginContext(s.sign(alg, jwt.MapClaims{
+			"realm_access": map[string]interface{}{
+				"roles": []string{"admin", "reader"},
+			},
+			"scope": "items:read items:write",
+		}))
+
+		valid, err := s.authenticator.IsValid(ginCtx)
+		s.NoError(err, alg)
+		s.True(valid, alg)
+
+		subject := auth.GetSubject(ginCtx.Request.Context())
+		s.Equal("user", subject.Name)
+		s.Equal(auth.ByOidc, subject.AuthenticatedBy)
+		s.False(subject.Anonymous)
+		s.Equal([]string{"admin", "reader"}, subject.Roles())
+		s.Equal([]string{"items:read", "items:write"}, subject.Scopes())
+		s.True(subject.HasRole("admin"))
+		s.False(subject.HasScope("items:delete"))
+		s.Equal("user", subject.Attributes[auth.AttributeClaims].(map[string]interface{})["sub"])
+	}
+}
+
+func (s *OidcAuthTestSuite) TestAudienceList() {
+	valid, err := s.authenticator.IsValid(s.ginContext(s.sign("RS256", jwt.MapClaims{
+		"aud": []string{"other", "api"},
+	})))
+
+	s.NoError(err)
+	s.True(valid)
+}
+
+func (s *OidcAuthTestSuite) TestLeeway() {
+	valid, err := s.authenticator.IsValid(s.ginContext(s.sign("RS256", jwt.MapClaims{
+		"exp": s.clock.Now().Add(-30 * time.Second).Unix(),
+		"nbf": s.clock.Now().Add(30 * time.Second).Unix(),
+	})))
+
+	s.NoError(err)
+	s.True(valid)
+}
+
+func (s *OidcAuthTestSuite) TestInvalid() {
+	tests := map[string]struct {
+		claims jwt.MapClaims
+		err    string
+	}{
+		"wrong issuer": {
+			claims: jwt.MapClaims{"iss": "https://evil.example.com"},
+			err:    "oidc auth: invalid issuer https://evil.example.com",
+		},
+		"wrong audience": {
+			claims: jwt.MapClaims{"aud": "other"},
+			err:    "oidc auth: invalid audience",
+		},
+		"expired": {
+			claims: jwt.MapClaims{"exp": s.clock.Now().Add(-2 * time.Minute).Unix()},
+			err:    "oidc auth: token is expired",
+		},
+		"no expiry": {
+			claims: jwt.MapClaims{"exp": nil},
+			err:    "oidc auth: token has no expiry",
+		},
+		"not valid yet": {
+			claims: jwt.MapClaims{"nbf": s.clock.Now().Add(2 * time.Minute).Unix()},
+			err:    "oidc auth: token is not valid yet",
+		},
+		"no subject": {
+			claims: jwt.MapClaims{"sub": ""},
+			err:    "oidc auth: token has no subject claim sub",
+		},
+	}
+
+	for name, test := range tests {
+		valid, err := s.authenticator.IsValid(s.ginContext(s.sign("RS256", test.claims)))
+
+		s.False(valid, name)
+		s.EqualError(err, test.err, name)
+	}
+}
+
+func (s *OidcAuthTestSuite) TestInvalidSignature() {
+	token := s.sign("RS256", jwt.MapClaims{})
+
+	// the old key isn't part of the key set anymore after the rotation
+	s.NoError(s.idp.RotateKeys())
+	s.clock.Advance(time.Hour)
+
+	valid, err := s.authenticator.IsValid(s.ginContext(token))
+	s.False(valid)
+	s.ErrorIs(err, oauth2.ErrKeyNotFound)
+
+	// tokens with the new key are accepted as the key set was refreshed
+	valid, err = s.authenticator.IsValid(s.ginContext(s.sign("RS256", jwt.MapClaims{})))
+	s.NoError(err)
+	s.True(valid)
+}
+
+func (s *OidcAuthTestSuite) TestUnsupportedAlgorithm() {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user"}).SignedString([]byte("secret"))
+	s.NoError(err)
+
+	valid, err := s.authenticator.IsValid(s.ginContext(token))
+	s.False(valid)
+	s.EqualError(err, "oidc auth: invalid token: signing method HS256 is invalid")
+}
+
+func (s *OidcAuthTestSuite) TestNoCredentials() {
+	ginCtx := s.ginContext("")
+	ginCtx.Request.Header.Del("Authorization")
+
+	valid, err := s.authenticator.IsValid(ginCtx)
+	s.False(valid)
+	s.EqualError(err, "no credentials provided")
+}
+
+func (s *OidcAuthTestSuite) sign(alg string, claims jwt.MapClaims) string {
+	defaults := jwt.MapClaims{
+		"iss": s.idp.Issuer(),
+		"sub": "user",
+		"aud": "api",
+		"exp": s.clock.Now().Add(time.Hour).Unix(),
+	}
+
+	for key, value := range claims {
+		defaults[key] = value
+	}
+
+	for key, value := range defaults {
+		if value == nil {
+			delete(defaults, key)
+		}
+	}
+
+	token, err := s.idp.SignToken(alg, defaults)
+	s.Require().NoError(err)
+
+	return token
+}
+
+func (s *OidcAuthTestSuite) ginContext(token string) *gin.Context {
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	return &gin.Context{
+		Request: &http.Request{
+			Header: header,
+		},
+	}
+}
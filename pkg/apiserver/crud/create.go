@@ -53,7 +53,8 @@ func (ch createHandler) Handle(ctx context.Context, request *apiserver.Request)
 	}
 
 	reload := ch.transformer.GetModel()
-	err = repo.Read(ctx, model.GetId(), reload)
+	// we have to read our own write, which the replicas might not know about yet
+	err = repo.Read(db.WithPrimaryReads(ctx), model.GetId(), reload)
 
 	if err != nil {
 		return nil, err
@@ -10,6 +10,7 @@ import (
 	"github.com/justtrackio/gosoline/pkg/apiserver"
 	"github.com/justtrackio/gosoline/pkg/apiserver/crud"
 	"github.com/justtrackio/gosoline/pkg/apiserver/crud/mocks"
+	"github.com/justtrackio/gosoline/pkg/db"
 	"github.com/justtrackio/gosoline/pkg/db-repo"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
 	"github.com/justtrackio/gosoline/pkg/mdl"
@@ -131,7 +132,7 @@ func TestCreateHandler_Handle(t *testing.T) {
 		model := args.Get(1).(*Model)
 		model.Id = mdl.Box(uint(1))
 	}).Return(nil)
-	transformer.Repo.On("Read", mock.AnythingOfType("context.backgroundCtx"), mdl.Box(uint(1)), &Model{}).Run(func(args mock.Arguments) {
+	transformer.Repo.On("Read", mock.MatchedBy(db.IsPrimaryReadsForced), mdl.Box(uint(1)), &Model{}).Run(func(args mock.Arguments) {
 		model := args.Get(2).(*Model)
 		model.Id = mdl.Box(uint(1))
 		model.Name = mdl.Box("foobar")
@@ -212,14 +213,17 @@ func TestUpdateHandler_Handle(t *testing.T) {
 	logger := logMocks.NewLoggerMockedAll()
 	transformer := NewTransformer()
 
-	transformer.Repo.On("Update", mock.AnythingOfType("context.backgroundCtx"), updateModel).Return(nil)
-	transformer.Repo.On("Read", mock.AnythingOfType("context.backgroundCtx"), mdl.Box(uint(1)), readModel).Run(func(args mock.Arguments) {
+	readRun := func(args mock.Arguments) {
 		model := args.Get(2).(*Model)
 		model.Id = mdl.Box(uint(1))
 		model.Name = mdl.Box("updated")
 		model.UpdatedAt = &time.Time{}
 		model.CreatedAt = &time.Time{}
-	}).Return(nil)
+	}
+
+	transformer.Repo.On("Update", mock.AnythingOfType("context.backgroundCtx"), updateModel).Return(nil)
+	// the model has to be read from the primary before and after the update
+	transformer.Repo.On("Read", mock.MatchedBy(db.IsPrimaryReadsForced), mdl.Box(uint(1)), readModel).Run(readRun).Return(nil).Twice()
 
 	handler := crud.NewUpdateHandler(logger, transformer)
 
@@ -251,7 +255,7 @@ func TestUpdateHandler_Handle_ValidationError(t *testing.T) {
 	transformer.Repo.On("Update", mock.AnythingOfType("context.backgroundCtx"), updateModel).Return(&validation.Error{
 		Errors: []error{fmt.Errorf("invalid foobar")},
 	})
-	transformer.Repo.On("Read", mock.AnythingOfType("context.backgroundCtx"), mdl.Box(uint(1)), readModel).Run(func(args mock.Arguments) {
+	transformer.Repo.On("Read", mock.MatchedBy(db.IsPrimaryReadsForced), mdl.Box(uint(1)), readModel).Run(func(args mock.Arguments) {
 		model := args.Get(2).(*Model)
 		model.Id = mdl.Box(uint(1))
 		model.Name = mdl.Box("updated")
@@ -285,7 +289,7 @@ func TestDeleteHandler_Handle(t *testing.T) {
 
 	logger := logMocks.NewLoggerMockedAll()
 	transformer := NewTransformer()
-	transformer.Repo.On("Read", mock.AnythingOfType("context.backgroundCtx"), mock.AnythingOfType("*uint"), model).Run(func(args mock.Arguments) {
+	transformer.Repo.On("Read", mock.MatchedBy(db.IsPrimaryReadsForced), mock.AnythingOfType("*uint"), model).Run(func(args mock.Arguments) {
 		model := args.Get(2).(*Model)
 		model.Id = id1
 		model.Name = mdl.Box("foobar")
@@ -319,7 +323,7 @@ func TestDeleteHandler_Handle_ValidationError(t *testing.T) {
 
 	logger := logMocks.NewLoggerMockedAll()
 	transformer := NewTransformer()
-	transformer.Repo.On("Read", mock.AnythingOfType("context.backgroundCtx"), mock.AnythingOfType("*uint"), model).Run(func(args mock.Arguments) {
+	transformer.Repo.On("Read", mock.MatchedBy(db.IsPrimaryReadsForced), mock.AnythingOfType("*uint"), model).Run(func(args mock.Arguments) {
 		model := args.Get(2).(*Model)
 		model.Id = id1
 		model.Name = mdl.Box("foobar")
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/justtrackio/gosoline/pkg/apiserver"
+	"github.com/justtrackio/gosoline/pkg/db"
 	"github.com/justtrackio/gosoline/pkg/db-repo"
 	"github.com/justtrackio/gosoline/pkg/log"
 	"github.com/justtrackio/gosoline/pkg/validation"
@@ -36,7 +37,8 @@ func (dh deleteHandler) Handle(ctx context.Context, request *apiserver.Request)
 	repo := dh.transformer.GetRepository()
 	model := dh.transformer.GetModel()
 
-	err := repo.Read(ctx, id, model)
+	// a replica might not know about the model yet or return an outdated version of it
+	err := repo.Read(db.WithPrimaryReads(ctx), id, model)
 
 	var notFound db_repo.RecordNotFoundError
 	if errors.As(err, &notFound) {
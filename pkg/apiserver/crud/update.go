@@ -38,9 +38,13 @@ func (uh updateHandler) Handle(ctx context.Context, request *apiserver.Request)
 		return nil, errors.New("no valid id provided")
 	}
 
+	// the model is written back as a whole, so it has to be read from the primary. Otherwise, we might overwrite newer
+	// data with the stale state of a replica.
+	primaryCtx := db.WithPrimaryReads(ctx)
+
 	repo := uh.transformer.GetRepository()
 	model := uh.transformer.GetModel()
-	err := repo.Read(ctx, id, model)
+	err := repo.Read(primaryCtx, id, model)
 
 	var notFound db_repo.RecordNotFoundError
 	if errors.As(err, &notFound) {
@@ -78,7 +82,8 @@ func (uh updateHandler) Handle(ctx context.Context, request *apiserver.Request)
 	}
 
 	reload := uh.transformer.GetModel()
-	err = repo.Read(ctx, model.GetId(), reload)
+	// we have to read our own write, which the replicas might not know about yet
+	err = repo.Read(primaryCtx, model.GetId(), reload)
 
 	if err != nil {
 		return nil, err
@@ -18,8 +18,45 @@ func init() {
 }
 
 type ApiHealthCheckSettings struct {
-	Port int    `cfg:"port" default:"8090"`
-	Path string `cfg:"path" default:"/health"`
+	Port      int    `cfg:"port" default:"8090"`
+	Path      string `cfg:"path" default:"/health"`
+	LivePath  string `cfg:"live_path" default:"/live"`
+	ReadyPath string `cfg:"ready_path" default:"/ready"`
+}
+
+type moduleLivenessResponse struct {
+	Healthy    bool   `json:"healthy"`
+	Restarting bool   `json:"restarting,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type stageLivenessResponse struct {
+	Index   int                               `json:"index"`
+	Healthy bool                              `json:"healthy"`
+	Modules map[string]moduleLivenessResponse `json:"modules"`
+}
+
+type livenessResponse struct {
+	Healthy bool                    `json:"healthy"`
+	Stages  []stageLivenessResponse `json:"stages"`
+}
+
+type moduleReadinessResponse struct {
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+}
+
+type stageReadinessResponse struct {
+	Index   int                                `json:"index"`
+	Running bool                               `json:"running"`
+	Ready   bool                               `json:"ready"`
+	Modules map[string]moduleReadinessResponse `json:"modules"`
+}
+
+type readinessResponse struct {
+	Ready    bool                     `json:"ready"`
+	Draining bool                     `json:"draining"`
+	Stages   []stageReadinessResponse `json:"stages"`
 }
 
 type ApiHealthCheck struct {
@@ -43,15 +80,28 @@ func NewApiHealthCheck() kernel.ModuleFactory {
 			return nil, fmt.Errorf("can not get health checker: %w", err)
 		}
 
-		return NewApiHealthCheckWithInterfaces(logger, router, healthChecker, settings), nil
+		readinessChecker, err := kernel.GetReadinessChecker(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("can not get readiness checker: %w", err)
+		}
+
+		return NewApiHealthCheckWithInterfaces(logger, router, healthChecker, readinessChecker, settings), nil
 	}
 }
 
-func NewApiHealthCheckWithInterfaces(logger log.Logger, router *gin.Engine, healthChecker kernel.HealthChecker, settings *ApiHealthCheckSettings) *ApiHealthCheck {
+func NewApiHealthCheckWithInterfaces(
+	logger log.Logger,
+	router *gin.Engine,
+	healthChecker kernel.HealthChecker,
+	readinessChecker kernel.ReadinessChecker,
+	settings *ApiHealthCheckSettings,
+) *ApiHealthCheck {
 	logger = logger.WithChannel("health-check")
 
 	router.Use(LoggingMiddleware(logger, LoggingSettings{}))
 	router.GET(settings.Path, buildHealthCheckHandler(logger, healthChecker))
+	router.GET(settings.LivePath, buildLivenessHandler(logger, healthChecker))
+	router.GET(settings.ReadyPath, buildReadinessHandler(logger, readinessChecker))
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", settings.Port),
@@ -113,3 +163,96 @@ func buildHealthCheckHandler(logger log.Logger, healthChecker kernel.HealthCheck
 		c.JSON(http.StatusInternalServerError, resp)
 	}
 }
+
+// buildLivenessHandler reports the health of every module grouped by stage. An unhealthy module means the
+// application should be restarted.
+func buildLivenessHandler(logger log.Logger, healthChecker kernel.HealthChecker) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		result := healthChecker()
+
+		if result.Err() != nil {
+			logger.WithContext(c.Request.Context()).Warn("encountered an error during the liveness check: %s", result.Err())
+		}
+
+		resp := livenessResponse{
+			Healthy: result.IsHealthy(),
+			Stages:  make([]stageLivenessResponse, 0),
+		}
+
+		for _, module := range result {
+			if len(resp.Stages) == 0 || resp.Stages[len(resp.Stages)-1].Index != module.StageIndex {
+				resp.Stages = append(resp.Stages, stageLivenessResponse{
+					Index:   module.StageIndex,
+					Healthy: true,
+					Modules: map[string]moduleLivenessResponse{},
+				})
+			}
+
+			stage := &resp.Stages[len(resp.Stages)-1]
+			stage.Healthy = stage.Healthy && module.Healthy
+			stage.Modules[module.Name] = moduleLivenessResponse{
+				Healthy:    module.Healthy,
+				Restarting: module.Restarting,
+				Error:      errorString(module.Err),
+			}
+		}
+
+		statusCode := http.StatusOK
+		if !resp.Healthy {
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		c.JSON(statusCode, resp)
+	}
+}
+
+// buildReadinessHandler reports whether the application should receive work. It is not ready while its stages are
+// booting, a module reports it is not ready or the kernel is draining before a shutdown.
+func buildReadinessHandler(logger log.Logger, readinessChecker kernel.ReadinessChecker) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		result := readinessChecker()
+
+		if result.Err() != nil {
+			logger.WithContext(c.Request.Context()).Warn("encountered an error during the readiness check: %s", result.Err())
+		}
+
+		resp := readinessResponse{
+			Ready:    result.IsReady(),
+			Draining: result.Draining,
+			Stages:   make([]stageReadinessResponse, 0, len(result.Stages)),
+		}
+
+		for _, stage := range result.Stages {
+			stageResp := stageReadinessResponse{
+				Index:   stage.Index,
+				Running: stage.Running,
+				Ready:   stage.IsReady(),
+				Modules: make(map[string]moduleReadinessResponse, len(stage.Modules)),
+			}
+
+			for _, module := range stage.Modules {
+				stageResp.Modules[module.Name] = moduleReadinessResponse{
+					Ready: module.Ready,
+					Error: errorString(module.Err),
+				}
+			}
+
+			resp.Stages = append(resp.Stages, stageResp)
+		}
+
+		statusCode := http.StatusOK
+		if !resp.Ready {
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		c.JSON(statusCode, resp)
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
@@ -1,6 +1,7 @@
 package apiserver_test
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,21 +10,131 @@ import (
 	"github.com/justtrackio/gosoline/pkg/apiserver"
 	"github.com/justtrackio/gosoline/pkg/kernel"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/assert"
 )
 
 func HealthCheckerMock() kernel.HealthCheckResult {
 	return make(kernel.HealthCheckResult, 0)
 }
 
+func ReadinessCheckerMock() kernel.ReadinessCheckResult {
+	return kernel.ReadinessCheckResult{}
+}
+
 func TestNewApiHealthCheck(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	ginEngine := gin.New()
 	logger := logMocks.NewLoggerMockedAll()
 
-	apiserver.NewApiHealthCheckWithInterfaces(logger, ginEngine, HealthCheckerMock, &apiserver.ApiHealthCheckSettings{
-		Path: "/health",
+	apiserver.NewApiHealthCheckWithInterfaces(logger, ginEngine, HealthCheckerMock, ReadinessCheckerMock, &apiserver.ApiHealthCheckSettings{
+		Path:      "/health",
+		LivePath:  "/live",
+		ReadyPath: "/ready",
 	})
 
 	httpRecorder := httptest.NewRecorder()
 	assertRouteReturnsResponse(t, ginEngine, httpRecorder, "/health", http.StatusOK)
+
+	httpRecorder = httptest.NewRecorder()
+	assertRouteReturnsResponse(t, ginEngine, httpRecorder, "/live", http.StatusOK)
+
+	httpRecorder = httptest.NewRecorder()
+	assertRouteReturnsResponse(t, ginEngine, httpRecorder, "/ready", http.StatusOK)
+}
+
+func TestApiHealthCheck_Live(t *testing.T) {
+	healthChecker := func() kernel.HealthCheckResult {
+		return kernel.HealthCheckResult{
+			{StageIndex: 0, Name: "database", Healthy: true},
+			{StageIndex: 2048, Name: "consumer", Healthy: false, Restarting: true, Err: fmt.Errorf("not connected")},
+			{StageIndex: 2048, Name: "api", Healthy: true},
+		}
+	}
+
+	ginEngine := newHealthCheckEngine(healthChecker, ReadinessCheckerMock)
+
+	httpRecorder := httptest.NewRecorder()
+	assertRouteReturnsResponse(t, ginEngine, httpRecorder, "/live", http.StatusServiceUnavailable)
+	assert.JSONEq(t, `{
+		"healthy": false,
+		"stages": [
+			{"index": 0, "healthy": true, "modules": {"database": {"healthy": true}}},
+			{"index": 2048, "healthy": false, "modules": {
+				"consumer": {"healthy": false, "restarting": true, "error": "not connected"},
+				"api": {"healthy": true}
+			}}
+		]
+	}`, httpRecorder.Body.String())
+}
+
+func TestApiHealthCheck_Ready(t *testing.T) {
+	tests := map[string]struct {
+		result     kernel.ReadinessCheckResult
+		statusCode int
+		body       string
+	}{
+		"ready": {
+			result: kernel.ReadinessCheckResult{
+				Stages: []kernel.StageReadinessCheckResult{
+					{Index: 0, Running: true, Modules: []kernel.ModuleReadinessCheckResult{{Name: "cache", Ready: true}}},
+				},
+			},
+			statusCode: http.StatusOK,
+			body:       `{"ready": true, "draining": false, "stages": [{"index": 0, "running": true, "ready": true, "modules": {"cache": {"ready": true}}}]}`,
+		},
+		"booting": {
+			result: kernel.ReadinessCheckResult{
+				Stages: []kernel.StageReadinessCheckResult{
+					{Index: 0, Running: true},
+					{Index: 2048, Running: false},
+				},
+			},
+			statusCode: http.StatusServiceUnavailable,
+			body:       `{"ready": false, "draining": false, "stages": [{"index": 0, "running": true, "ready": true, "modules": {}}, {"index": 2048, "running": false, "ready": false, "modules": {}}]}`,
+		},
+		"module_not_ready": {
+			result: kernel.ReadinessCheckResult{
+				Stages: []kernel.StageReadinessCheckResult{
+					{Index: 0, Running: true, Modules: []kernel.ModuleReadinessCheckResult{{Name: "cache", Ready: false, Err: fmt.Errorf("warming")}}},
+				},
+			},
+			statusCode: http.StatusServiceUnavailable,
+			body:       `{"ready": false, "draining": false, "stages": [{"index": 0, "running": true, "ready": false, "modules": {"cache": {"ready": false, "error": "warming"}}}]}`,
+		},
+		"draining": {
+			result: kernel.ReadinessCheckResult{
+				Draining: true,
+				Stages: []kernel.StageReadinessCheckResult{
+					{Index: 0, Running: true},
+				},
+			},
+			statusCode: http.StatusServiceUnavailable,
+			body:       `{"ready": false, "draining": true, "stages": [{"index": 0, "running": true, "ready": true, "modules": {}}]}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ginEngine := newHealthCheckEngine(HealthCheckerMock, func() kernel.ReadinessCheckResult {
+				return test.result
+			})
+
+			httpRecorder := httptest.NewRecorder()
+			assertRouteReturnsResponse(t, ginEngine, httpRecorder, "/ready", test.statusCode)
+			assert.JSONEq(t, test.body, httpRecorder.Body.String())
+		})
+	}
+}
+
+func newHealthCheckEngine(healthChecker kernel.HealthChecker, readinessChecker kernel.ReadinessChecker) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ginEngine := gin.New()
+
+	apiserver.NewApiHealthCheckWithInterfaces(logMocks.NewLoggerMockedAll(), ginEngine, healthChecker, readinessChecker, &apiserver.ApiHealthCheckSettings{
+		Path:      "/health",
+		LivePath:  "/live",
+		ReadyPath: "/ready",
+	})
+
+	return ginEngine
 }
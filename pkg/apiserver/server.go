@@ -72,48 +72,34 @@ func New(definer Definer) kernel.ModuleFactory {
 		settings := &Settings{}
 		config.UnmarshalKey("api", settings)
 
-		gin.SetMode(settings.Mode)
-
 		var err error
 		var tracer tracing.Tracer
-		var definitions *Definitions
+		var router *gin.Engine
+		var definitionList []Definition
 		var metadata *appctx.Metadata
-		var compressionMiddlewares []gin.HandlerFunc
 		var healthChecker kernel.HealthChecker
 
 		if tracer, err = tracing.ProvideTracer(config, logger); err != nil {
 			return nil, fmt.Errorf("can not create tracer: %w", err)
 		}
 
-		metricMiddleware, setupMetricMiddleware := NewMetricMiddleware()
-
-		if compressionMiddlewares, err = configureCompression(settings.Compression); err != nil {
-			return nil, fmt.Errorf("could not configure compression: %w", err)
-		}
-
-		router := gin.New()
-		router.Use(metricMiddleware)
-		router.Use(LoggingMiddleware(logger, settings.Logging))
-		router.Use(compressionMiddlewares...)
-		router.Use(RecoveryWithSentry(logger))
-		router.Use(location.Default())
-
 		if healthChecker, err = kernel.GetHealthChecker(ctx); err != nil {
 			return nil, fmt.Errorf("can not get health checker: %w", err)
 		}
-		router.GET("/health", buildHealthCheckHandler(logger, healthChecker))
 
-		if definitions, err = definer(ctx, config, logger.WithChannel("handler")); err != nil {
-			return nil, fmt.Errorf("could not define routes: %w", err)
+		metricMiddleware, setupMetricMiddleware := NewMetricMiddleware()
+
+		if router, definitionList, err = newRouter(ctx, config, logger, settings, definer, metricMiddleware); err != nil {
+			return nil, err
 		}
 
+		setupMetricMiddleware(definitionList)
+		router.GET("/health", buildHealthCheckHandler(logger, healthChecker))
+
 		if metadata, err = appctx.ProvideMetadata(ctx); err != nil {
 			return nil, fmt.Errorf("can not access appctx metadata: %w", err)
 		}
 
-		definitionList := buildRouter(definitions, router)
-		setupMetricMiddleware(definitionList)
-
 		for _, route := range router.Routes() {
 			err = metadata.Append("apiserver.routes", HandlerMetadata{
 				Method: route.Method,
@@ -128,6 +114,47 @@ func New(definer Definer) kernel.ModuleFactory {
 	}
 }
 
+// NewRouter creates a gin engine serving the routes of the definer with the logging, compression and recovery
+// middlewares of the api server. As it doesn't need a running kernel, it can serve the routes without an ApiServer,
+// e.g. from within a lambda function.
+func NewRouter(ctx context.Context, config cfg.Config, logger log.Logger, definer Definer) (*gin.Engine, error) {
+	logger = logger.WithChannel("api")
+
+	settings := &Settings{}
+	config.UnmarshalKey("api", settings)
+
+	router, _, err := newRouter(ctx, config, logger, settings, definer)
+
+	return router, err
+}
+
+// newRouter sets up the middlewares shared by the api server and NewRouter and adds the routes of the definer. The
+// given middlewares run before all others.
+func newRouter(ctx context.Context, config cfg.Config, logger log.Logger, settings *Settings, definer Definer, middlewares ...gin.HandlerFunc) (*gin.Engine, []Definition, error) {
+	gin.SetMode(settings.Mode)
+
+	var err error
+	var definitions *Definitions
+	var compressionMiddlewares []gin.HandlerFunc
+
+	if compressionMiddlewares, err = configureCompression(settings.Compression); err != nil {
+		return nil, nil, fmt.Errorf("could not configure compression: %w", err)
+	}
+
+	router := gin.New()
+	router.Use(middlewares...)
+	router.Use(LoggingMiddleware(logger, settings.Logging))
+	router.Use(compressionMiddlewares...)
+	router.Use(RecoveryWithSentry(logger))
+	router.Use(location.Default())
+
+	if definitions, err = definer(ctx, config, logger.WithChannel("handler")); err != nil {
+		return nil, nil, fmt.Errorf("could not define routes: %w", err)
+	}
+
+	return router, buildRouter(definitions, router), nil
+}
+
 func NewWithInterfaces(logger log.Logger, router *gin.Engine, tracer tracing.Tracer, s *Settings) (*ApiServer, error) {
 	server := &http.Server{
 		Addr:         ":" + s.Port,
@@ -47,6 +47,7 @@ func Default(options ...Option) kernel.Kernel {
 		WithConfigSanitizers(cfg.TimeSanitizer),
 		WithMetadataServer,
 		WithConsumerMessagesPerRunnerMetrics,
+		WithKernelRestartMetrics,
 		WithLoggerGroupTag,
 		WithLoggerApplicationTag,
 		WithLoggerContextFieldsMessageEncoder,
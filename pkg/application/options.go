@@ -163,6 +163,12 @@ func WithKernelExitHandler(handler kernelPkg.ExitHandler) Option {
 	}
 }
 
+func WithKernelRestartMetrics(app *App) {
+	app.addKernelOption(func(config cfg.GosoConf) kernelPkg.Option {
+		return kernelPkg.WithRestartListener(metric.NewKernelRestartListener())
+	})
+}
+
 func WithLoggerGroupTag(app *App) {
 	app.addLoggerOption(func(config cfg.GosoConf, logger log.GosoLogger) error {
 		if !config.IsSet("app_group") {
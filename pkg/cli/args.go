@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUsage is returned if the command line doesn't match the usage of the application or of a command.
+var ErrUsage = errors.New("invalid usage")
+
+// Invocation is the result of parsing the command line of an application with subcommands.
+type Invocation struct {
+	Command     *Command
+	ConfigFiles []string
+	AssumeYes   bool
+	// Settings contains the values of the flags and arguments which were given for the command
+	Settings map[string]interface{}
+}
+
+type stringsValue []string
+
+func (v *stringsValue) String() string {
+	if v == nil {
+		return ""
+	}
+
+	return strings.Join(*v, ",")
+}
+
+func (v *stringsValue) Set(value string) error {
+	*v = append(*v, value)
+
+	return nil
+}
+
+func (v *stringsValue) Get() interface{} {
+	return []string(*v)
+}
+
+type commandArg struct {
+	key      string
+	variadic bool
+	optional bool
+}
+
+// ParseArgs parses the arguments of the form [global flags] <command> [flags] [args]. The help of the application or
+// the command is written to out if it is requested (returning flag.ErrHelp) or if the usage is invalid (returning an
+// error wrapping ErrUsage).
+func ParseArgs(out io.Writer, program string, commands []*Command, args []string) (*Invocation, error) {
+	var err error
+	var configFiles stringsValue
+	var assumeYes bool
+
+	invocation := &Invocation{
+		Settings: make(map[string]interface{}),
+	}
+
+	global := flag.NewFlagSet(program, flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	global.Var(&configFiles, "config", "path to a config file, can be repeated")
+	global.BoolVar(&assumeYes, "yes", false, "answer all confirmation prompts with yes")
+	global.Usage = func() {
+		printUsage(out, program, global, commands)
+	}
+
+	if err = global.Parse(args); err != nil {
+		return nil, usageErr(err)
+	}
+
+	invocation.ConfigFiles = configFiles
+	invocation.AssumeYes = assumeYes
+
+	if global.NArg() == 0 {
+		global.Usage()
+
+		return nil, usageErr(fmt.Errorf("no command given"))
+	}
+
+	name := global.Arg(0)
+
+	if name == "help" {
+		if global.NArg() < 2 {
+			global.Usage()
+
+			return nil, flag.ErrHelp
+		}
+
+		name = global.Arg(1)
+		args = []string{"-help"}
+	} else {
+		args = global.Args()[1:]
+	}
+
+	for _, command := range commands {
+		if command.name == name {
+			invocation.Command = command
+		}
+	}
+
+	if invocation.Command == nil {
+		global.Usage()
+
+		return nil, usageErr(fmt.Errorf("unknown command %s", name))
+	}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	var commandArgs []commandArg
+	if commandArgs, err = bindSettings(flags, invocation.Command.settings); err != nil {
+		return nil, fmt.Errorf("can not bind the settings of command %s: %w", name, err)
+	}
+
+	flags.Usage = func() {
+		printCommandUsage(out, program, invocation.Command, flags, commandArgs)
+	}
+
+	if err = flags.Parse(args); err != nil {
+		return nil, usageErr(err)
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		invocation.Settings[f.Name] = f.Value.(flag.Getter).Get()
+	})
+
+	if err = bindArgs(invocation.Settings, commandArgs, flags.Args()); err != nil {
+		flags.Usage()
+
+		return nil, usageErr(err)
+	}
+
+	return invocation, nil
+}
+
+// usageErr wraps the error with ErrUsage, the flag package already printed the usage for its errors.
+func usageErr(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", ErrUsage, err.Error())
+}
+
+// bindSettings defines a flag for every field of the settings and returns the positional arguments.
+func bindSettings(flags *flag.FlagSet, settings reflect.Type) ([]commandArg, error) {
+	var err error
+	args := make([]commandArg, 0)
+
+	if settings.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("the settings have to be a struct but are a %s", settings.Kind())
+	}
+
+	for i := 0; i < settings.NumField(); i++ {
+		field := settings.Field(i)
+		key, ok := field.Tag.Lookup("cfg")
+
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		def, hasDefault := field.Tag.Lookup("default")
+
+		if field.Tag.Get("cli") == "arg" {
+			if len(args) > 0 && args[len(args)-1].variadic {
+				return nil, fmt.Errorf("argument %s can not follow the variadic argument %s", key, args[len(args)-1].key)
+			}
+
+			args = append(args, commandArg{
+				key:      key,
+				variadic: field.Type == reflect.TypeOf([]string{}),
+				optional: hasDefault,
+			})
+
+			continue
+		}
+
+		if err = bindFlag(flags, key, field.Type, def, field.Tag.Get("usage")); err != nil {
+			return nil, fmt.Errorf("can not bind field %s: %w", field.Name, err)
+		}
+	}
+
+	return args, nil
+}
+
+func bindFlag(flags *flag.FlagSet, name string, typ reflect.Type, def string, usage string) (err error) {
+	if typ == reflect.TypeOf(time.Duration(0)) {
+		var value time.Duration
+		if def != "" {
+			if value, err = time.ParseDuration(def); err != nil {
+				return fmt.Errorf("can not parse default %s: %w", def, err)
+			}
+		}
+
+		flags.Duration(name, value, usage)
+
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		flags.String(name, def, usage)
+	case reflect.Bool:
+		value, _ := strconv.ParseBool(def)
+		flags.Bool(name, value, usage)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, _ := strconv.ParseInt(def, 10, 64)
+		flags.Int64(name, value, usage)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, _ := strconv.ParseUint(def, 10, 64)
+		flags.Uint64(name, value, usage)
+	case reflect.Float32, reflect.Float64:
+		value, _ := strconv.ParseFloat(def, 64)
+		flags.Float64(name, value, usage)
+	case reflect.Slice:
+		if typ.Elem().Kind() != reflect.String {
+			return fmt.Errorf("only slices of strings are supported, got %s", typ)
+		}
+
+		flags.Var(&stringsValue{}, name, usage+" (can be repeated)")
+	default:
+		return fmt.Errorf("type %s is not supported", typ)
+	}
+
+	return nil
+}
+
+func bindArgs(settings map[string]interface{}, commandArgs []commandArg, values []string) error {
+	for _, arg := range commandArgs {
+		if arg.variadic {
+			settings[arg.key] = values
+			values = nil
+
+			break
+		}
+
+		if len(values) == 0 {
+			if arg.optional {
+				continue
+			}
+
+			return fmt.Errorf("missing argument %s", arg.key)
+		}
+
+		settings[arg.key] = values[0]
+		values = values[1:]
+	}
+
+	if len(values) > 0 {
+		return fmt.Errorf("too many arguments: %s", strings.Join(values, " "))
+	}
+
+	return nil
+}
+
+func printUsage(out io.Writer, program string, global *flag.FlagSet, commands []*Command) {
+	width := 0
+	for _, command := range commands {
+		width = max(width, len(command.name))
+	}
+
+	fmt.Fprintf(out, "usage: %s [flags] <command> [command flags] [args]\n\ncommands:\n", program)
+
+	for _, command := range commands {
+		fmt.Fprintf(out, "  %-*s  %s\n", width, command.name, command.description)
+	}
+
+	fmt.Fprintf(out, "\nflags:\n")
+	global.SetOutput(out)
+	global.PrintDefaults()
+	global.SetOutput(io.Discard)
+
+	fmt.Fprintf(out, "\nrun '%s help <command>' for the usage of a command\n", program)
+}
+
+func printCommandUsage(out io.Writer, program string, command *Command, flags *flag.FlagSet, args []commandArg) {
+	usage := []string{program, "[flags]", command.name}
+	hasFlags := false
+
+	flags.VisitAll(func(*flag.Flag) {
+		hasFlags = true
+	})
+
+	if hasFlags {
+		usage = append(usage, "[command flags]")
+	}
+
+	for _, arg := range args {
+		switch {
+		case arg.variadic:
+			usage = append(usage, fmt.Sprintf("[%s...]", arg.key))
+		case arg.optional:
+			usage = append(usage, fmt.Sprintf("[%s]", arg.key))
+		default:
+			usage = append(usage, fmt.Sprintf("<%s>", arg.key))
+		}
+	}
+
+	fmt.Fprintf(out, "usage: %s\n", strings.Join(usage, " "))
+
+	if command.description != "" {
+		fmt.Fprintf(out, "\n%s\n", command.description)
+	}
+
+	if hasFlags {
+		fmt.Fprintf(out, "\ncommand flags:\n")
+		flags.SetOutput(out)
+		flags.PrintDefaults()
+		flags.SetOutput(io.Discard)
+	}
+}
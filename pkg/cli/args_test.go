@@ -0,0 +1,191 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/cli"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type importSettings struct {
+	BatchSize int           `cfg:"batch_size" default:"100" usage:"number of rows per batch"`
+	DryRun    bool          `cfg:"dry_run" usage:"only print what would be imported"`
+	Timeout   time.Duration `cfg:"timeout" default:"1m" usage:"timeout of the import"`
+	Tags      []string      `cfg:"tags" usage:"tag of the imported rows"`
+	File      string        `cfg:"file" cli:"arg" validate:"required"`
+	Ids       []string      `cfg:"ids" cli:"arg"`
+}
+
+type statusSettings struct{}
+
+func newModuleFactory[S any](settings **S) cli.CommandFactory[S] {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger, s *S) (kernel.Module, error) {
+		*settings = s
+
+		return nil, nil
+	}
+}
+
+func TestArgsTestSuite(t *testing.T) {
+	suite.Run(t, new(ArgsTestSuite))
+}
+
+type ArgsTestSuite struct {
+	suite.Suite
+
+	out            *bytes.Buffer
+	importSettings *importSettings
+	commands       []*cli.Command
+}
+
+func (s *ArgsTestSuite) SetupTest() {
+	s.out = &bytes.Buffer{}
+	s.importSettings = nil
+	s.commands = []*cli.Command{
+		cli.NewCommand("import", "import the rows of a file", newModuleFactory(&s.importSettings)),
+		cli.NewCommand("status", "show the status of the imports", newModuleFactory(new(*statusSettings))),
+	}
+}
+
+func (s *ArgsTestSuite) TestParse() {
+	invocation, err := cli.ParseArgs(s.out, "app", s.commands, []string{
+		"-config", "a.yml", "-config", "b.yml", "-yes",
+		"import", "-batch_size", "5", "-dry_run", "-timeout", "5s", "-tags", "a", "-tags", "b",
+		"rows.csv", "1", "2",
+	})
+
+	s.NoError(err)
+	s.Empty(s.out.String())
+	s.Equal("import", invocation.Command.Name())
+	s.Equal([]string{"a.yml", "b.yml"}, invocation.ConfigFiles)
+	s.True(invocation.AssumeYes)
+	s.Equal(map[string]interface{}{
+		"batch_size": int64(5),
+		"dry_run":    true,
+		"timeout":    5 * time.Second,
+		"tags":       []string{"a", "b"},
+		"file":       "rows.csv",
+		"ids":        []string{"1", "2"},
+	}, invocation.Settings)
+
+	s.Equal(&importSettings{
+		BatchSize: 5,
+		DryRun:    true,
+		Timeout:   5 * time.Second,
+		Tags:      []string{"a", "b"},
+		File:      "rows.csv",
+		Ids:       []string{"1", "2"},
+	}, s.unmarshal(invocation))
+}
+
+func (s *ArgsTestSuite) TestParseDefaults() {
+	invocation, err := cli.ParseArgs(s.out, "app", s.commands, []string{"import", "rows.csv"})
+
+	s.NoError(err)
+	s.False(invocation.AssumeYes)
+	s.Equal(&importSettings{
+		BatchSize: 100,
+		Timeout:   time.Minute,
+		Tags:      []string{},
+		File:      "rows.csv",
+		Ids:       []string{},
+	}, s.unmarshal(invocation))
+}
+
+func (s *ArgsTestSuite) TestHelp() {
+	_, err := cli.ParseArgs(s.out, "app", s.commands, []string{"-help"})
+
+	s.ErrorIs(err, flag.ErrHelp)
+	s.Equal(`usage: app [flags] <command> [command flags] [args]
+
+commands:
+  import  import the rows of a file
+  status  show the status of the imports
+
+flags:
+  -config value
+    	path to a config file, can be repeated
+  -yes
+    	answer all confirmation prompts with yes
+
+run 'app help <command>' for the usage of a command
+`, s.out.String())
+}
+
+func (s *ArgsTestSuite) TestHelpCommand() {
+	_, err := cli.ParseArgs(s.out, "app", s.commands, []string{"help", "import"})
+
+	s.ErrorIs(err, flag.ErrHelp)
+	s.Equal(`usage: app [flags] import [command flags] <file> [ids...]
+
+import the rows of a file
+
+command flags:
+  -batch_size int
+    	number of rows per batch (default 100)
+  -dry_run
+    	only print what would be imported
+  -tags value
+    	tag of the imported rows (can be repeated)
+  -timeout duration
+    	timeout of the import (default 1m0s)
+`, s.out.String())
+
+	s.out.Reset()
+	_, err = cli.ParseArgs(s.out, "app", s.commands, []string{"status", "-h"})
+
+	s.ErrorIs(err, flag.ErrHelp)
+	s.Equal("usage: app [flags] status\n\nshow the status of the imports\n", s.out.String())
+}
+
+func (s *ArgsTestSuite) TestUsageErrors() {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"no_command":       {args: []string{}, err: "invalid usage: no command given"},
+		"unknown_command":  {args: []string{"export"}, err: "invalid usage: unknown command export"},
+		"unknown_flag":     {args: []string{"import", "-size", "5", "rows.csv"}, err: "invalid usage: flag provided but not defined: -size"},
+		"invalid_flag":     {args: []string{"import", "-batch_size", "many", "rows.csv"}, err: `invalid usage: invalid value "many" for flag -batch_size: parse error`},
+		"missing_argument": {args: []string{"import"}, err: "invalid usage: missing argument file"},
+		"too_many_args":    {args: []string{"status", "now"}, err: "invalid usage: too many arguments: now"},
+	}
+
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.out.Reset()
+			_, err := cli.ParseArgs(s.out, "app", s.commands, test.args)
+
+			s.ErrorIs(err, cli.ErrUsage)
+			s.EqualError(err, test.err)
+			s.Contains(s.out.String(), "usage: app")
+		})
+	}
+}
+
+func (s *ArgsTestSuite) unmarshal(invocation *cli.Invocation) *importSettings {
+	config := cfg.New()
+	err := config.Option(cfg.WithConfigSetting("cli.commands.import", invocation.Settings))
+	s.NoError(err)
+
+	_, err = invocation.Command.ModuleFactory()(context.Background(), config, nil)
+	s.NoError(err)
+
+	return s.importSettings
+}
+
+func TestExitError(t *testing.T) {
+	err := cli.NewExitError(3, assert.AnError)
+
+	assert.Equal(t, 3, err.Code)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.EqualError(t, cli.NewExitError(4, nil), "exit code 4")
+}
@@ -2,6 +2,11 @@ package cli
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/justtrackio/gosoline/pkg/appctx"
 	"github.com/justtrackio/gosoline/pkg/cfg"
@@ -9,15 +14,72 @@ import (
 )
 
 func Run(module kernel.ModuleFactory, otherModuleMaps ...map[string]kernel.ModuleFactory) {
-	var err error
-	var cfgPostProcessors map[string]int
-
 	configOptions := []cfg.Option{
 		cfg.WithErrorHandlers(defaultErrorHandler),
 		cfg.WithConfigFile("./config.dist.yml", "yml"),
 		cfg.WithConfigFileFlag("config"),
 	}
 
+	options := []kernel.Option{
+		kernel.WithModuleFactory("cli", module, kernel.ModuleType(kernel.TypeEssential), kernel.ModuleStage(kernel.StageApplication)),
+	}
+
+	run(configOptions, options, otherModuleMaps)
+}
+
+// RunCommands runs the command named by the first argument of the process:
+//
+//	app [-config file] [-yes] <command> [command flags] [args]
+//
+// Next to the module of the command, the progress of the status manager is printed while the command is running.
+// The application exits with the code of an ExitError returned by the command, with ExitCodeUsage if the command
+// line is invalid and with the exit code of the kernel otherwise.
+func RunCommands(commands []*Command, otherModuleMaps ...map[string]kernel.ModuleFactory) {
+	invocation, err := ParseArgs(os.Stdout, filepath.Base(os.Args[0]), commands, os.Args[1:])
+
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(ExitCodeOk)
+	case errors.Is(err, ErrUsage):
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(ExitCodeUsage)
+	case err != nil:
+		defaultErrorHandler("can not parse the arguments: %w", err)
+
+		return
+	}
+
+	recorder := &exitCodeRecorder{}
+
+	configOptions := []cfg.Option{
+		cfg.WithErrorHandlers(defaultErrorHandler),
+		cfg.WithConfigFile("./config.dist.yml", "yml"),
+	}
+
+	for _, configFile := range invocation.ConfigFiles {
+		configOptions = append(configOptions, cfg.WithConfigFile(configFile, "yml"))
+	}
+
+	configOptions = append(configOptions,
+		cfg.WithConfigSetting(commandSettingsKey(invocation.Command.name), invocation.Settings),
+		cfg.WithConfigSetting("cli.assume_yes", invocation.AssumeYes),
+	)
+
+	// like the module of Run, the command is the application: the kernel has to stop as soon as it is done, no matter
+	// which type the module declares. The wrapper recording the exit code hides the type and stage of the module anyway.
+	options := []kernel.Option{
+		kernel.WithModuleFactory(invocation.Command.name, recorder.wrap(invocation.Command.ModuleFactory()), kernel.ModuleType(kernel.TypeEssential), kernel.ModuleStage(kernel.StageApplication)),
+		kernel.WithModuleFactory("cli-progress", NewProgressModule()),
+		kernel.WithExitHandler(recorder.exitHandler(os.Exit)),
+	}
+
+	run(configOptions, options, otherModuleMaps)
+}
+
+func run(configOptions []cfg.Option, options []kernel.Option, otherModuleMaps []map[string]kernel.ModuleFactory) {
+	var err error
+	var cfgPostProcessors map[string]int
+
 	config := cfg.New()
 	if err := config.Option(configOptions...); err != nil {
 		defaultErrorHandler("can not initialize the config: %w", err)
@@ -41,10 +103,6 @@ func Run(module kernel.ModuleFactory, otherModuleMaps ...map[string]kernel.Modul
 
 	ctx := appctx.WithContainer(context.Background())
 
-	options := []kernel.Option{
-		kernel.WithModuleFactory("cli", module, kernel.ModuleType(kernel.TypeEssential), kernel.ModuleStage(kernel.StageApplication)),
-	}
-
 	for _, otherModuleMap := range otherModuleMaps {
 		for name, otherModule := range otherModuleMap {
 			options = append(options, kernel.WithModuleFactory(name, otherModule))
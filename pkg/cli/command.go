@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+// CommandFactory creates the module running a command. The settings are bound from the flags and arguments of the
+// command line, see NewCommand.
+type CommandFactory[S any] func(ctx context.Context, config cfg.Config, logger log.Logger, settings *S) (kernel.Module, error)
+
+// A Command is a named subcommand of a cli application which is run by a module of the kernel.
+type Command struct {
+	name        string
+	description string
+	settings    reflect.Type
+	factory     func(key string) kernel.ModuleFactory
+}
+
+// NewCommand creates a command whose settings are bound to the command line. Every field of the settings with a cfg
+// tag becomes a flag, fields which are additionally tagged with cli:"arg" are read from the positional arguments in
+// the order of their declaration instead. A trailing []string argument collects all remaining arguments. The usage
+// tag is shown in the help output:
+//
+//	type ImportSettings struct {
+//		BatchSize int    `cfg:"batch_size" default:"100" usage:"number of rows per batch"`
+//		File      string `cfg:"file" cli:"arg" validate:"required"`
+//	}
+//
+// The values are written to cli.commands.<name> before the settings are unmarshalled with the config, so defaults
+// and validations work the same as for any other settings and can be provided by config files as well.
+func NewCommand[S any](name string, description string, factory CommandFactory[S]) *Command {
+	return &Command{
+		name:        name,
+		description: description,
+		settings:    reflect.TypeOf((*S)(nil)).Elem(),
+		factory: func(key string) kernel.ModuleFactory {
+			return func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+				settings := new(S)
+				config.UnmarshalKey(key, settings)
+
+				return factory(ctx, config, logger, settings)
+			}
+		},
+	}
+}
+
+func (c *Command) Name() string {
+	return c.name
+}
+
+func (c *Command) Description() string {
+	return c.description
+}
+
+// ModuleFactory returns the factory of the module running the command with the settings read from
+// cli.commands.<name>.
+func (c *Command) ModuleFactory() kernel.ModuleFactory {
+	return c.factory(commandSettingsKey(c.name))
+}
+
+func commandSettingsKey(name string) string {
+	return fmt.Sprintf("cli.commands.%s", name)
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+const (
+	ExitCodeOk    = kernel.ExitCodeOk
+	ExitCodeErr   = kernel.ExitCodeErr
+	ExitCodeUsage = 2
+)
+
+// An ExitError is returned by the module of a command to exit the application with a specific exit code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func NewExitError(code int, err error) *ExitError {
+	return &ExitError{
+		Code: code,
+		Err:  err,
+	}
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// exitCodeRecorder remembers the exit code of the ExitError returned by the module of the command.
+type exitCodeRecorder struct {
+	code atomic.Int32
+}
+
+type exitCodeModule struct {
+	kernel.Module
+	recorder *exitCodeRecorder
+}
+
+func (r *exitCodeRecorder) wrap(factory kernel.ModuleFactory) kernel.ModuleFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+		module, err := factory(ctx, config, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		return &exitCodeModule{
+			Module:   module,
+			recorder: r,
+		}, nil
+	}
+}
+
+// exitHandler uses the exit code of the command instead of the generic one of the kernel if the command failed
+// with an ExitError.
+func (r *exitCodeRecorder) exitHandler(exit kernel.ExitHandler) kernel.ExitHandler {
+	return func(code int) {
+		if commandCode := int(r.code.Load()); code != ExitCodeOk && commandCode != ExitCodeOk {
+			code = commandCode
+		}
+
+		exit(code)
+	}
+}
+
+func (m *exitCodeModule) Run(ctx context.Context) error {
+	err := m.Module.Run(ctx)
+
+	exitErr := &ExitError{}
+	if errors.As(err, &exitErr) {
+		m.recorder.code.Store(int32(exitErr.Code))
+	}
+
+	return err
+}
+
+func (m *exitCodeModule) IsHealthy(ctx context.Context) (bool, error) {
+	if hm, ok := m.Module.(kernel.HealthCheckedModule); ok {
+		return hm.IsHealthy(ctx)
+	}
+
+	return true, nil
+}
+
+func (m *exitCodeModule) IsReady(ctx context.Context) (bool, error) {
+	if rm, ok := m.Module.(kernel.ReadinessCheckedModule); ok {
+		return rm.IsReady(ctx)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type checkedModule struct {
+	err     error
+	healthy bool
+	ready   bool
+}
+
+func (m *checkedModule) Run(_ context.Context) error {
+	return m.err
+}
+
+func (m *checkedModule) IsHealthy(_ context.Context) (bool, error) {
+	return m.healthy, nil
+}
+
+func (m *checkedModule) IsReady(_ context.Context) (bool, error) {
+	return m.ready, nil
+}
+
+func wrapModule(t *testing.T, recorder *exitCodeRecorder, module kernel.Module) kernel.Module {
+	factory := recorder.wrap(func(_ context.Context, _ cfg.Config, _ log.Logger) (kernel.Module, error) {
+		return module, nil
+	})
+
+	wrapped, err := factory(context.Background(), nil, logMocks.NewLoggerMockedAll())
+	assert.NoError(t, err)
+
+	return wrapped
+}
+
+func TestExitCodeModuleForwardsChecks(t *testing.T) {
+	module := wrapModule(t, &exitCodeRecorder{}, &checkedModule{healthy: true, ready: false})
+
+	healthy, err := module.(kernel.HealthCheckedModule).IsHealthy(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, healthy)
+
+	ready, err := module.(kernel.ReadinessCheckedModule).IsReady(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, ready, "the readiness of the command module should be forwarded")
+}
+
+func TestExitCodeModuleRecordsExitCode(t *testing.T) {
+	recorder := &exitCodeRecorder{}
+	module := wrapModule(t, recorder, &checkedModule{err: NewExitError(3, fmt.Errorf("not found"))})
+
+	assert.EqualError(t, module.Run(context.Background()), "not found")
+
+	exitCode := -1
+	recorder.exitHandler(func(code int) {
+		exitCode = code
+	})(ExitCodeErr)
+
+	assert.Equal(t, 3, exitCode)
+}
@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/db"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+const migrationsUsage = `usage: [-config file] <command>
+
+commands:
+  status          show the current version and the pending migrations
+  up [n]          apply the next n or all pending migrations
+  down <n>        revert the last n migrations
+  force <version> set the version without running migrations, e.g. after fixing a failed migration by hand
+  create <name>   create a new up and down migration`
+
+type migrationsModule struct {
+	out      io.Writer
+	clock    clock.Clock
+	migrator db.Migrator
+	path     string
+	args     []string
+}
+
+// NewMigrationsModule manages the migrations of the db with the given name from the command line:
+//
+//	cli.Run(cli.NewMigrationsModule("default", os.Args[1:]))
+//
+// The command and its arguments are read from the given arguments, see migrationsUsage.
+func NewMigrationsModule(name string, args []string) kernel.ModuleFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+		var err error
+		var migrator db.Migrator
+
+		flags := flag.NewFlagSet("migrations", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+		// the config files are already read by cfg, we only need to skip them here
+		flags.Func("config", "path to a config file", func(string) error { return nil })
+
+		if err = flags.Parse(args); err != nil {
+			return nil, fmt.Errorf("can not parse arguments: %w\n%s", err, migrationsUsage)
+		}
+
+		settings := db.Settings{}
+		config.UnmarshalKey(fmt.Sprintf("db.%s", name), &settings)
+
+		// creating migrations doesn't need a db, so we don't require one to be reachable
+		if flags.Arg(0) != "create" {
+			connection, err := db.NewConnectionWithInterfaces(settings)
+			if err != nil {
+				return nil, fmt.Errorf("can not create connection: %w", err)
+			}
+
+			if migrator, err = db.NewMigrator(logger, settings, connection); err != nil {
+				return nil, fmt.Errorf("can not create migrator: %w", err)
+			}
+		}
+
+		return NewMigrationsModuleWithInterfaces(os.Stdout, clock.Provider, migrator, settings.Migrations.Path, flags.Args()), nil
+	}
+}
+
+func NewMigrationsModuleWithInterfaces(out io.Writer, clock clock.Clock, migrator db.Migrator, path string, args []string) kernel.Module {
+	return &migrationsModule{
+		out:      out,
+		clock:    clock,
+		migrator: migrator,
+		path:     path,
+		args:     args,
+	}
+}
+
+func (m *migrationsModule) Run(_ context.Context) error {
+	if m.migrator != nil {
+		defer m.migrator.Close()
+	}
+
+	if len(m.args) == 0 {
+		return fmt.Errorf("missing command\n%s", migrationsUsage)
+	}
+
+	command, args := m.args[0], m.args[1:]
+
+	switch command {
+	case "status":
+		return m.status()
+	case "up":
+		n, err := m.intArg(args, 0)
+		if err != nil {
+			return err
+		}
+
+		// the migrator applies all pending migrations for n <= 0, which a mistyped negative n must not trigger
+		if n < 0 {
+			return fmt.Errorf("up needs a positive number of migrations to apply\n%s", migrationsUsage)
+		}
+
+		return m.migrator.Up(n)
+	case "down":
+		// reverting everything by accident would be fatal, so we require the number of steps here
+		n, err := m.intArg(args, -1)
+		if err != nil {
+			return err
+		}
+
+		if n < 1 {
+			return fmt.Errorf("down needs the number of migrations to revert\n%s", migrationsUsage)
+		}
+
+		return m.migrator.Down(n)
+	case "force":
+		version, err := m.intArg(args, -1)
+		if err != nil {
+			return err
+		}
+
+		if version < 0 {
+			return fmt.Errorf("force needs the version to set\n%s", migrationsUsage)
+		}
+
+		return m.migrator.Force(version)
+	case "create":
+		return m.create(args)
+	default:
+		return fmt.Errorf("unknown command %s\n%s", command, migrationsUsage)
+	}
+}
+
+func (m *migrationsModule) status() error {
+	status, err := m.migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	dirty := ""
+	if status.Dirty {
+		dirty = " (dirty)"
+	}
+
+	fmt.Fprintf(m.out, "version: %d%s\n", status.Version, dirty)
+	fmt.Fprintf(m.out, "pending: %d\n", len(status.Pending))
+
+	for _, version := range status.Pending {
+		fmt.Fprintf(m.out, "  %d\n", version)
+	}
+
+	return nil
+}
+
+func (m *migrationsModule) create(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("create needs the name of the migration\n%s", migrationsUsage)
+	}
+
+	files, err := db.CreateMigrationFiles(m.path, strings.Join(args, "_"), m.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		fmt.Fprintf(m.out, "created %s\n", file)
+	}
+
+	return nil
+}
+
+func (m *migrationsModule) intArg(args []string, def int) (int, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+
+	value, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("argument %s is not a number\n%s", args[0], migrationsUsage)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,92 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/cli"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/db"
+	dbMocks "github.com/justtrackio/gosoline/pkg/db/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationsModule_Status(t *testing.T) {
+	out := &bytes.Buffer{}
+	migrator := dbMocks.NewMigrator(t)
+	migrator.EXPECT().Status().Return(&db.MigrationStatus{
+		Version: 2,
+		Dirty:   true,
+		Pending: []uint{3, 4},
+	}, nil).Once()
+	migrator.EXPECT().Close().Return(nil).Once()
+
+	module := cli.NewMigrationsModuleWithInterfaces(out, clock.NewFakeClock(), migrator, "", []string{"status"})
+	err := module.Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "version: 2 (dirty)\npending: 2\n  3\n  4\n", out.String())
+}
+
+func TestMigrationsModule_Steps(t *testing.T) {
+	migrator := dbMocks.NewMigrator(t)
+	migrator.EXPECT().Up(0).Return(nil).Once()
+	migrator.EXPECT().Up(2).Return(nil).Once()
+	migrator.EXPECT().Down(1).Return(nil).Once()
+	migrator.EXPECT().Force(5).Return(nil).Once()
+	migrator.EXPECT().Close().Return(nil).Times(4)
+
+	for _, args := range [][]string{{"up"}, {"up", "2"}, {"down", "1"}, {"force", "5"}} {
+		module := cli.NewMigrationsModuleWithInterfaces(&bytes.Buffer{}, clock.NewFakeClock(), migrator, "", args)
+		assert.NoError(t, module.Run(context.Background()), "args %v", args)
+	}
+}
+
+func TestMigrationsModule_InvalidArgs(t *testing.T) {
+	migrator := dbMocks.NewMigrator(t)
+	migrator.EXPECT().Close().Return(nil).Times(5)
+
+	for _, args := range [][]string{{}, {"down"}, {"up", "many"}, {"up", "-1"}, {"sideways"}} {
+		module := cli.NewMigrationsModuleWithInterfaces(&bytes.Buffer{}, clock.NewFakeClock(), migrator, "", args)
+		assert.Error(t, module.Run(context.Background()), "args %v", args)
+	}
+}
+
+func TestMigrationsModule_CreateFromArgs(t *testing.T) {
+	dir := t.TempDir()
+
+	config := cfg.New()
+	err := config.Option(cfg.WithConfigMap(map[string]interface{}{
+		"app_name": "test",
+		"db": map[string]interface{}{
+			"default": map[string]interface{}{
+				"migrations": map[string]interface{}{
+					"path": "file://" + dir,
+				},
+			},
+		},
+	}))
+	assert.NoError(t, err)
+
+	factory := cli.NewMigrationsModule("default", []string{"-config", "config.test.yml", "create", "add", "users"})
+	module, err := factory(context.Background(), config, logMocks.NewLoggerMockedAll())
+	assert.NoError(t, err)
+
+	err = module.Run(context.Background())
+	assert.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*_add_users.*.sql"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestMigrationsModule_UnknownFlag(t *testing.T) {
+	factory := cli.NewMigrationsModule("default", []string{"-verbose", "status"})
+
+	_, err := factory(context.Background(), cfg.New(), logMocks.NewLoggerMockedAll())
+	assert.ErrorContains(t, err, "can not parse arguments: flag provided but not defined: -verbose")
+}
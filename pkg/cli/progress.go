@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/log/status"
+)
+
+type ProgressSettings struct {
+	// Interval in which the progress of the work items of the status manager is printed, 0 only prints it at the end
+	Interval time.Duration `cfg:"interval" default:"10s"`
+}
+
+type progressModule struct {
+	kernel.BackgroundModule
+	kernel.ServiceStage
+
+	logger        log.Logger
+	clock         clock.Clock
+	statusManager status.Manager
+	interval      time.Duration
+}
+
+// NewProgressModule prints the progress reported to the status manager (see status.ProvideManager) to the console
+// while a command is running and once more after it finished.
+func NewProgressModule() kernel.ModuleFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+		settings := &ProgressSettings{}
+		config.UnmarshalKey("cli.progress", settings)
+
+		return NewProgressModuleWithInterfaces(log.NewCliLogger(), clock.Provider, status.ProvideManager(), settings.Interval), nil
+	}
+}
+
+func NewProgressModuleWithInterfaces(logger log.Logger, clock clock.Clock, statusManager status.Manager, interval time.Duration) kernel.Module {
+	return &progressModule{
+		logger:        logger,
+		clock:         clock,
+		statusManager: statusManager,
+		interval:      interval,
+	}
+}
+
+func (m *progressModule) Run(ctx context.Context) error {
+	defer m.statusManager.PrintReport(m.logger)
+
+	if m.interval <= 0 {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	ticker := m.clock.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.Chan():
+			m.statusManager.PrintReport(m.logger)
+		}
+	}
+}
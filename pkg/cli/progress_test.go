@@ -0,0 +1,44 @@
+package cli_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cli"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/log"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	statusMocks "github.com/justtrackio/gosoline/pkg/log/status/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressModule(t *testing.T) {
+	logger := logMocks.NewLoggerMockedAll()
+	fakeClock := clock.NewFakeClock()
+	statusManager := statusMocks.NewManager(t)
+	reports := make(chan struct{}, 3)
+	statusManager.EXPECT().PrintReport(logger).Run(func(log.Logger) {
+		reports <- struct{}{}
+	}).Times(3)
+
+	module := cli.NewProgressModuleWithInterfaces(logger, fakeClock, statusManager, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- module.Run(ctx)
+	}()
+
+	fakeClock.BlockUntilTickers(1)
+	fakeClock.Advance(time.Second)
+	<-reports
+
+	fakeClock.Advance(time.Second)
+	<-reports
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Len(t, reports, 1, "the report should be printed once more after the command finished")
+}
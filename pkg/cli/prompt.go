@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/justtrackio/gosoline/pkg/appctx"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+)
+
+type PromptSettings struct {
+	// AssumeYes answers all confirmations with yes without asking, it is set by the -yes flag
+	AssumeYes bool `cfg:"assume_yes"`
+}
+
+// A Prompt asks the user of a command for confirmation before doing something dangerous.
+//
+//go:generate mockery --name Prompt
+type Prompt interface {
+	// Confirm asks the question until it is answered with yes or no. An empty answer counts as no.
+	Confirm(question string) (bool, error)
+}
+
+type prompt struct {
+	lck       sync.Mutex
+	in        *bufio.Reader
+	out       io.Writer
+	assumeYes bool
+}
+
+type promptCtxKey int
+
+func ProvidePrompt(ctx context.Context, config cfg.Config) (Prompt, error) {
+	return appctx.Provide(ctx, promptCtxKey(0), func() (Prompt, error) {
+		settings := &PromptSettings{}
+		config.UnmarshalKey("cli", settings)
+
+		return NewPromptWithInterfaces(os.Stdin, os.Stdout, settings.AssumeYes), nil
+	})
+}
+
+func NewPromptWithInterfaces(in io.Reader, out io.Writer, assumeYes bool) Prompt {
+	return &prompt{
+		in:        bufio.NewReader(in),
+		out:       out,
+		assumeYes: assumeYes,
+	}
+}
+
+func (p *prompt) Confirm(question string) (bool, error) {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	if p.assumeYes {
+		fmt.Fprintf(p.out, "%s [y/N]: yes\n", question)
+
+		return true, nil
+	}
+
+	for {
+		fmt.Fprintf(p.out, "%s [y/N]: ", question)
+
+		answer, err := p.in.ReadString('\n')
+		if err != nil && (err != io.EOF || answer == "") {
+			return false, fmt.Errorf("can not read the answer, use the -yes flag to confirm without a terminal: %w", err)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true, nil
+		case "", "n", "no":
+			return false, nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cli_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/cli"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompt_Confirm(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		result bool
+		output string
+	}{
+		"yes":       {input: "y\n", result: true, output: "delete? [y/N]: "},
+		"yes_long":  {input: " YES \n", result: true, output: "delete? [y/N]: "},
+		"no":        {input: "n\n", result: false, output: "delete? [y/N]: "},
+		"empty":     {input: "\n", result: false, output: "delete? [y/N]: "},
+		"no_eof":    {input: "no", result: false, output: "delete? [y/N]: "},
+		"ask_again": {input: "maybe\nyes\n", result: true, output: "delete? [y/N]: delete? [y/N]: "},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			prompt := cli.NewPromptWithInterfaces(strings.NewReader(test.input), out, false)
+
+			result, err := prompt.Confirm("delete?")
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.output, out.String())
+		})
+	}
+}
+
+func TestPrompt_ConfirmAssumeYes(t *testing.T) {
+	out := &bytes.Buffer{}
+	prompt := cli.NewPromptWithInterfaces(strings.NewReader(""), out, true)
+
+	result, err := prompt.Confirm("delete?")
+
+	assert.NoError(t, err)
+	assert.True(t, result)
+	assert.Equal(t, "delete? [y/N]: yes\n", out.String())
+}
+
+func TestPrompt_ConfirmWithoutInput(t *testing.T) {
+	prompt := cli.NewPromptWithInterfaces(strings.NewReader(""), io.Discard, false)
+
+	result, err := prompt.Confirm("delete?")
+
+	assert.ErrorIs(t, err, io.EOF)
+	assert.False(t, result)
+}
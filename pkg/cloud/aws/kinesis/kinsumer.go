@@ -30,6 +30,7 @@ const (
 type KinsumerMetadata struct {
 	AwsClientName  string    `json:"aws_client_name"`
 	ClientId       ClientId  `json:"client_id"`
+	ConsumerArn    string    `json:"consumer_arn,omitempty"`
 	Name           string    `json:"name"`
 	OpenShardCount int       `json:"open_shard_count"`
 	StreamAppId    cfg.AppId `json:"stream_app_id"`
@@ -76,6 +77,8 @@ type Settings struct {
 	ReleaseDelay time.Duration `cfg:"release_delay" default:"5s" validate:"min=1000000000"`
 	// Should we write how many milliseconds behind each shard is or only the whole stream?
 	ShardLevelMetrics bool `cfg:"shard_level_metrics" default:"false"`
+	// Read the shards with enhanced fan-out instead of polling them
+	EnhancedFanOut SettingsEnhancedFanOut `cfg:"enhanced_fan_out"`
 }
 
 func (s Settings) GetAppId() cfg.AppId {
@@ -97,15 +100,16 @@ type Kinsumer interface {
 }
 
 type kinsumer struct {
-	logger             log.Logger
-	settings           Settings
-	stream             Stream
-	kinesisClient      Client
-	metadataRepository MetadataRepository
-	metricWriter       metric.Writer
-	clock              clock.Clock
-	shardReaderFactory func(logger log.Logger, shardId ShardId) ShardReader
-	stop               func()
+	logger                 log.Logger
+	settings               Settings
+	stream                 Stream
+	kinesisClient          Client
+	metadataRepository     MetadataRepository
+	streamConsumerRegistry StreamConsumerRegistry
+	metricWriter           metric.Writer
+	clock                  clock.Clock
+	shardReaderFactory     func(logger log.Logger, shardId ShardId) ShardReader
+	stop                   func()
 }
 
 type runtimeContext struct {
@@ -160,27 +164,45 @@ func NewKinsumer(ctx context.Context, config cfg.Config, logger log.Logger, sett
 		return nil, fmt.Errorf("failed to create metadata manager: %w", err)
 	}
 
-	if err = appctx.MetadataAppend(ctx, MetadataKeyKinsumers, kinsumerMetadata); err != nil {
-		return nil, fmt.Errorf("can not access the appctx metadata: %w", err)
-	}
-
 	shardReaderFactory := func(logger log.Logger, shardId ShardId) ShardReader {
 		return NewShardReaderWithInterfaces(fullStreamName, shardId, logger, metricWriter, metadataRepository, kinesisClient, *settings, clock.Provider)
 	}
 
-	return NewKinsumerWithInterfaces(logger, *settings, fullStreamName, kinesisClient, metadataRepository, metricWriter, clock.Provider, shardReaderFactory), nil
+	var streamConsumerRegistry StreamConsumerRegistry
+
+	if settings.EnhancedFanOut.Enabled {
+		streamConsumerRegistry = NewStreamConsumerRegistry(config, logger, kinesisClient, kinsumerMetadata.StreamArn, *settings)
+
+		if kinsumerMetadata.ConsumerArn, err = streamConsumerRegistry.Register(ctx); err != nil {
+			return nil, fmt.Errorf("failed to register stream consumer: %w", err)
+		}
+
+		subscriber := NewShardSubscriberWithInterfaces(kinesisClient)
+		shardReaderFactory = func(logger log.Logger, shardId ShardId) ShardReader {
+			return NewFanOutShardReaderWithInterfaces(fullStreamName, shardId, logger, metricWriter, metadataRepository, subscriber, kinsumerMetadata.ConsumerArn, *settings, clock.Provider)
+		}
+	}
+
+	if err = appctx.MetadataAppend(ctx, MetadataKeyKinsumers, kinsumerMetadata); err != nil {
+		return nil, fmt.Errorf("can not access the appctx metadata: %w", err)
+	}
+
+	return NewKinsumerWithInterfaces(logger, *settings, fullStreamName, kinesisClient, metadataRepository, streamConsumerRegistry, metricWriter, clock.Provider, shardReaderFactory), nil
 }
 
-func NewKinsumerWithInterfaces(logger log.Logger, settings Settings, stream Stream, kinesisClient Client, metadataRepository MetadataRepository, metricWriter metric.Writer, clock clock.Clock, shardReaderFactory func(logger log.Logger, shardId ShardId) ShardReader) Kinsumer {
+// NewKinsumerWithInterfaces creates a kinsumer reading the shards with the readers built by the shardReaderFactory. The
+// streamConsumerRegistry is only needed for enhanced fan-out and can be nil otherwise.
+func NewKinsumerWithInterfaces(logger log.Logger, settings Settings, stream Stream, kinesisClient Client, metadataRepository MetadataRepository, streamConsumerRegistry StreamConsumerRegistry, metricWriter metric.Writer, clock clock.Clock, shardReaderFactory func(logger log.Logger, shardId ShardId) ShardReader) Kinsumer {
 	return &kinsumer{
-		logger:             logger,
-		settings:           settings,
-		stream:             stream,
-		kinesisClient:      kinesisClient,
-		metadataRepository: metadataRepository,
-		metricWriter:       metricWriter,
-		clock:              clock,
-		shardReaderFactory: shardReaderFactory,
+		logger:                 logger,
+		settings:               settings,
+		stream:                 stream,
+		kinesisClient:          kinesisClient,
+		metadataRepository:     metadataRepository,
+		streamConsumerRegistry: streamConsumerRegistry,
+		metricWriter:           metricWriter,
+		clock:                  clock,
+		shardReaderFactory:     shardReaderFactory,
 	}
 }
 
@@ -198,6 +220,14 @@ func (k *kinsumer) Run(ctx context.Context, handler MessageHandler) (finalErr er
 		}
 	}()
 
+	if k.streamConsumerRegistry != nil && k.settings.EnhancedFanOut.DeregisterOnStop {
+		defer func() {
+			if err := k.streamConsumerRegistry.Deregister(deregisterCtx); err != nil {
+				finalErr = multierror.Append(finalErr, fmt.Errorf("failed to deregister stream consumer: %w", err))
+			}
+		}()
+	}
+
 	runtimeCtx := &runtimeContext{
 		clientIndex:  0,
 		totalClients: 0,
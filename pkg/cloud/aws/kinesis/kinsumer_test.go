@@ -86,7 +86,7 @@ func (s *kinsumerTestSuite) SetupTest() {
 		ReleaseDelay:      time.Second * 5,
 	}
 
-	s.kinsumer = gosoKinesis.NewKinsumerWithInterfaces(s.logger, settings, s.stream, s.kinesisClient, s.metadataRepository, s.metricWriter, s.clock, func(logger log.Logger, shardId gosoKinesis.ShardId) gosoKinesis.ShardReader {
+	s.kinsumer = gosoKinesis.NewKinsumerWithInterfaces(s.logger, settings, s.stream, s.kinesisClient, s.metadataRepository, nil, s.metricWriter, s.clock, func(logger log.Logger, shardId gosoKinesis.ShardId) gosoKinesis.ShardReader {
 		s.shardReadersLck.Lock()
 		defer s.shardReadersLck.Unlock()
 
@@ -49,7 +49,15 @@ type shardReader struct {
 	clock              clock.Clock
 }
 
+// recordConsumer reads the records of an acquired shard and hands them to the handler until the shard is finished or
+// the context is canceled. It reports how many milliseconds it is behind the tip of the shard on the given channel.
+type recordConsumer func(ctx context.Context, millisecondsBehindChan chan float64, handler func(record []byte) error) error
+
 func NewShardReaderWithInterfaces(stream Stream, shardId ShardId, logger log.Logger, metricWriter metric.Writer, metadataRepository MetadataRepository, kinesisClient Client, settings Settings, clock clock.Clock) ShardReader {
+	return newShardReader(stream, shardId, logger, metricWriter, metadataRepository, kinesisClient, settings, clock)
+}
+
+func newShardReader(stream Stream, shardId ShardId, logger log.Logger, metricWriter metric.Writer, metadataRepository MetadataRepository, kinesisClient Client, settings Settings, clock clock.Clock) *shardReader {
 	r := &shardReader{
 		stream:             stream,
 		shardId:            shardId,
@@ -67,7 +75,13 @@ func NewShardReaderWithInterfaces(stream Stream, shardId ShardId, logger log.Log
 	return r
 }
 
-func (s *shardReader) Run(ctx context.Context, handler func(record []byte) error) (finalErr error) {
+func (s *shardReader) Run(ctx context.Context, handler func(record []byte) error) error {
+	return s.run(ctx, handler, s.preparePolling)
+}
+
+// run acquires the shard, reads it with the record consumer returned by prepare and persists the checkpoint until the
+// consumer returns. The checkpoint is released again in the end, no matter whether the shard is finished or not.
+func (s *shardReader) run(ctx context.Context, handler func(record []byte) error, prepare func(ctx context.Context, sequenceNumber SequenceNumber) (recordConsumer, error)) (finalErr error) {
 	if ok, err := s.acquireShard(ctx); errors.Is(err, ErrShardAlreadyFinished) {
 		return nil
 	} else if err != nil {
@@ -91,9 +105,9 @@ func (s *shardReader) Run(ctx context.Context, handler func(record []byte) error
 	}()
 
 	sequenceNumber := s.getCheckpoint().GetSequenceNumber()
-	iterator, err := s.getShardIterator(ctx, sequenceNumber)
+	consume, err := prepare(ctx, sequenceNumber)
 	if err != nil {
-		return fmt.Errorf("failed to get shard iterator: %w", err)
+		return err
 	}
 
 	cfn, cfnCtx := coffin.WithContext(ctx)
@@ -120,7 +134,7 @@ func (s *shardReader) Run(ctx context.Context, handler func(record []byte) error
 			}
 		}()
 
-		return s.iterateRecords(ctx, millisecondsBehindChan, iterator, handler)
+		return consume(ctx, millisecondsBehindChan, handler)
 	})
 
 	// if we get a canceled error, drop it here. We used to do this at our caller, but this makes a test harder:
@@ -135,6 +149,17 @@ func (s *shardReader) Run(ctx context.Context, handler func(record []byte) error
 	return nil
 }
 
+func (s *shardReader) preparePolling(ctx context.Context, sequenceNumber SequenceNumber) (recordConsumer, error) {
+	iterator, err := s.getShardIterator(ctx, sequenceNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shard iterator: %w", err)
+	}
+
+	return func(ctx context.Context, millisecondsBehindChan chan float64, handler func(record []byte) error) error {
+		return s.iterateRecords(ctx, millisecondsBehindChan, iterator, handler)
+	}, nil
+}
+
 func (s *shardReader) getCheckpoint() CheckpointWithoutRelease {
 	return s.checkpoint.Load().(checkpointWrapper).Checkpoint
 }
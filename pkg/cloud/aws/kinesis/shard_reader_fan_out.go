@@ -0,0 +1,253 @@
+package kinesis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/exec"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/mdl"
+	"github.com/justtrackio/gosoline/pkg/metric"
+)
+
+const metricNameSubscribeCount = "SubscribeCount"
+
+//go:generate mockery --name ShardSubscriber
+type ShardSubscriber interface {
+	// SubscribeToShard opens a subscription to a shard for a registered stream consumer. Kinesis pushes the records of the
+	// shard over the returned event stream until it closes the subscription after at most five minutes.
+	SubscribeToShard(ctx context.Context, params *kinesis.SubscribeToShardInput) (kinesis.SubscribeToShardEventStreamReader, error)
+}
+
+type shardSubscriber struct {
+	kinesisClient Client
+}
+
+func NewShardSubscriberWithInterfaces(kinesisClient Client) ShardSubscriber {
+	return &shardSubscriber{
+		kinesisClient: kinesisClient,
+	}
+}
+
+func (s *shardSubscriber) SubscribeToShard(ctx context.Context, params *kinesis.SubscribeToShardInput) (kinesis.SubscribeToShardEventStreamReader, error) {
+	output, err := s.kinesisClient.SubscribeToShard(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.GetStream(), nil
+}
+
+// fanOutShardReader reads a shard with enhanced fan-out. Instead of polling the shard with GetRecords, the records are
+// pushed to a stream consumer registered for the application. Acquiring the shard and checkpointing works exactly like
+// for the polling shard reader.
+type fanOutShardReader struct {
+	*shardReader
+	subscriber  ShardSubscriber
+	consumerArn string
+}
+
+func NewFanOutShardReaderWithInterfaces(stream Stream, shardId ShardId, logger log.Logger, metricWriter metric.Writer, metadataRepository MetadataRepository, subscriber ShardSubscriber, consumerArn string, settings Settings, clock clock.Clock) ShardReader {
+	return &fanOutShardReader{
+		shardReader: newShardReader(stream, shardId, logger, metricWriter, metadataRepository, nil, settings, clock),
+		subscriber:  subscriber,
+		consumerArn: consumerArn,
+	}
+}
+
+func (s *fanOutShardReader) Run(ctx context.Context, handler func(record []byte) error) error {
+	return s.run(ctx, handler, func(_ context.Context, _ SequenceNumber) (recordConsumer, error) {
+		return s.iterateSubscriptions, nil
+	})
+}
+
+func (s *fanOutShardReader) iterateSubscriptions(ctx context.Context, millisecondsBehindChan chan float64, handler func(record []byte) error) error {
+	var lastSequenceNumber SequenceNumber
+	var continuationSequenceNumber SequenceNumber
+
+	for {
+		events, err := s.subscribe(ctx, continuationSequenceNumber)
+
+		var errResourceInUseException *types.ResourceInUseException
+		var errLimitExceededException *types.LimitExceededException
+
+		if exec.IsRequestCanceled(err) {
+			return nil
+		} else if errors.As(err, &errResourceInUseException) || errors.As(err, &errLimitExceededException) {
+			// the subscription we had before is not yet closed on the side of kinesis or we subscribed too fast again,
+			// so we just have to wait a bit before trying again
+			s.logger.Warn("can not subscribe to shard yet, retrying in %s: %s", s.settings.WaitTime, err.Error())
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-s.clock.After(s.settings.WaitTime):
+				continue
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to subscribe to shard: %w", err)
+		}
+
+		finished, err := s.consumeSubscription(ctx, events, millisecondsBehindChan, &lastSequenceNumber, &continuationSequenceNumber, handler)
+		if err != nil {
+			return err
+		}
+
+		if finished {
+			if err := s.getCheckpoint().Done(lastSequenceNumber); err != nil {
+				return fmt.Errorf("failed to mark checkpoint as done: %w", err)
+			}
+
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (s *fanOutShardReader) subscribe(ctx context.Context, continuationSequenceNumber SequenceNumber) (kinesis.SubscribeToShardEventStreamReader, error) {
+	input := &kinesis.SubscribeToShardInput{
+		ConsumerARN:      aws.String(s.consumerArn),
+		ShardId:          aws.String(string(s.shardId)),
+		StartingPosition: s.getStartingPosition(continuationSequenceNumber),
+	}
+
+	events, err := s.subscriber.SubscribeToShard(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	s.writeMetric(metricNameSubscribeCount, 1.0, metric.UnitCount)
+
+	return events, nil
+}
+
+// getStartingPosition continues right where the last subscription ended. If we didn't have a subscription yet, we start
+// after the checkpoint or at the initial position if there is no checkpoint for the shard yet.
+func (s *fanOutShardReader) getStartingPosition(continuationSequenceNumber SequenceNumber) *types.StartingPosition {
+	if continuationSequenceNumber != "" {
+		return &types.StartingPosition{
+			Type:           types.ShardIteratorTypeAtSequenceNumber,
+			SequenceNumber: aws.String(string(continuationSequenceNumber)),
+		}
+	}
+
+	if sequenceNumber := s.getCheckpoint().GetSequenceNumber(); sequenceNumber != "" {
+		return &types.StartingPosition{
+			Type:           types.ShardIteratorTypeAfterSequenceNumber,
+			SequenceNumber: aws.String(string(sequenceNumber)),
+		}
+	}
+
+	position := &types.StartingPosition{
+		Type: s.settings.InitialPosition.Type,
+	}
+
+	if position.Type == types.ShardIteratorTypeAtTimestamp {
+		position.Timestamp = mdl.Box(s.settings.InitialPosition.Timestamp)
+	}
+
+	return position
+}
+
+// consumeSubscription processes the events of a single subscription. It returns true if the shard was closed and all of
+// its records have been processed.
+func (s *fanOutShardReader) consumeSubscription(
+	ctx context.Context,
+	events kinesis.SubscribeToShardEventStreamReader,
+	millisecondsBehindChan chan float64,
+	lastSequenceNumber *SequenceNumber,
+	continuationSequenceNumber *SequenceNumber,
+	handler func(record []byte) error,
+) (bool, error) {
+	defer func() {
+		if err := events.Close(); err != nil && !exec.IsRequestCanceled(err) {
+			s.logger.Warn("failed to close shard subscription: %s", err.Error())
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case event, ok := <-events.Events():
+			if !ok {
+				// kinesis closes every subscription after five minutes. If it was closed because of an error, we log it and
+				// subscribe again - if the error persists, subscribing will fail as well
+				if err := events.Err(); err != nil && !exec.IsRequestCanceled(err) {
+					s.logger.Warn("shard subscription failed, subscribing again: %s", err.Error())
+				}
+
+				return false, nil
+			}
+
+			shardEvent, ok := event.(*types.SubscribeToShardEventStreamMemberSubscribeToShardEvent)
+			if !ok {
+				continue
+			}
+
+			finished, err := s.processEvent(ctx, shardEvent.Value, millisecondsBehindChan, lastSequenceNumber, continuationSequenceNumber, handler)
+			if err != nil || finished || ctx.Err() != nil {
+				return finished, err
+			}
+		}
+	}
+}
+
+func (s *fanOutShardReader) processEvent(
+	ctx context.Context,
+	event types.SubscribeToShardEvent,
+	millisecondsBehindChan chan float64,
+	lastSequenceNumber *SequenceNumber,
+	continuationSequenceNumber *SequenceNumber,
+	handler func(record []byte) error,
+) (bool, error) {
+	s.writeMetric(metricNameReadCount, 1.0, metric.UnitCount)
+	millisecondsBehindChan <- float64(mdl.EmptyIfNil(event.MillisBehindLatest))
+
+	processStart := s.clock.Now()
+
+	processedSize, err := s.processRecords(ctx, event.Records, lastSequenceNumber, handler)
+	if err != nil {
+		return false, err
+	}
+
+	processDuration := s.clock.Since(processStart)
+	s.writeMetric(metricNameProcessDuration, float64(processDuration.Milliseconds()), metric.UnitMillisecondsAverage)
+	s.writeMetric(metricNameReadRecords, float64(processedSize), metric.UnitCount)
+
+	s.logger.WithChannel("kinsumer-read").WithFields(log.Fields{
+		"count":       processedSize,
+		"duration_ms": processDuration.Milliseconds(),
+	}).Info("processed batch of %d records in %s", processedSize, processDuration)
+
+	// we got canceled while processing the event, so we must not continue after it (and especially not mark the shard
+	// as finished, we would lose the remaining records of the event otherwise)
+	if processedSize < len(event.Records) {
+		return false, nil
+	}
+
+	// without a continuation sequence number the shard was closed because of resharding. The kinsumer will pick up the
+	// child shards once it discovers that this shard is finished.
+	if event.ContinuationSequenceNumber == nil {
+		childShardIds := make([]string, 0, len(event.ChildShards))
+		for _, childShard := range event.ChildShards {
+			childShardIds = append(childShardIds, mdl.EmptyIfNil(childShard.ShardId))
+		}
+
+		s.logger.Info("shard was closed, child shards are %v", childShardIds)
+
+		return true, nil
+	}
+
+	*continuationSequenceNumber = SequenceNumber(*event.ContinuationSequenceNumber)
+
+	return false, nil
+}
@@ -0,0 +1,269 @@
+package kinesis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/hashicorp/go-multierror"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	gosoKinesis "github.com/justtrackio/gosoline/pkg/cloud/aws/kinesis"
+	"github.com/justtrackio/gosoline/pkg/cloud/aws/kinesis/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/metric"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type fanOutShardReaderTestSuite struct {
+	suite.Suite
+
+	ctx                context.Context
+	stream             gosoKinesis.Stream
+	shardId            gosoKinesis.ShardId
+	consumerArn        string
+	logger             *logMocks.Logger
+	metricWriter       *metricMocks.Writer
+	metadataRepository *mocks.MetadataRepository
+	subscriber         *mocks.ShardSubscriber
+	settings           gosoKinesis.Settings
+	clock              clock.FakeClock
+	shardReader        gosoKinesis.ShardReader
+	consumedRecords    [][]byte
+}
+
+func TestFanOutShardReader(t *testing.T) {
+	suite.Run(t, new(fanOutShardReaderTestSuite))
+}
+
+func (s *fanOutShardReaderTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.stream = "testStream"
+	s.shardId = "shard-007"
+	s.consumerArn = "arn:aws:kinesis:eu-central-1:123456789012:stream/testStream/consumer/app:1"
+	s.metadataRepository = mocks.NewMetadataRepository(s.T())
+	s.subscriber = mocks.NewShardSubscriber(s.T())
+	s.logger = logMocks.NewLogger(s.T())
+	s.metricWriter = metricMocks.NewWriter(s.T())
+	s.settings = gosoKinesis.Settings{
+		InitialPosition: gosoKinesis.SettingsInitialPosition{
+			Type: types.ShardIteratorTypeLatest,
+		},
+		WaitTime:         time.Second,
+		PersistFrequency: time.Second * 10,
+		ReleaseDelay:     time.Second * 30,
+	}
+	s.clock = clock.NewFakeClock()
+	s.consumedRecords = nil
+
+	s.shardReader = gosoKinesis.NewFanOutShardReaderWithInterfaces(s.stream, s.shardId, s.logger, s.metricWriter, s.metadataRepository, s.subscriber, s.consumerArn, s.settings, s.clock)
+}
+
+func (s *fanOutShardReaderTestSuite) TestSubscribeFails() {
+	checkpoint := mocks.NewCheckpoint(s.T())
+	checkpoint.EXPECT().Persist(mock.AnythingOfType("*exec.stoppableContext")).Return(true, nil).Once()
+	checkpoint.EXPECT().Release(mock.AnythingOfType("*exec.stoppableContext")).Return(fmt.Errorf("fail again")).Once()
+	checkpoint.EXPECT().GetSequenceNumber().Return(gosoKinesis.SequenceNumber("")).Twice()
+
+	s.mockMetricCall("MillisecondsBehind", 0, metric.UnitMillisecondsMaximum).Once()
+
+	s.metadataRepository.EXPECT().AcquireShard(s.ctx, s.shardId).Return(checkpoint, nil).Once()
+	s.logger.EXPECT().Info("acquired shard").Once()
+	s.logger.EXPECT().Info("releasing shard").Once()
+	s.subscriber.EXPECT().SubscribeToShard(mock.AnythingOfType("*context.cancelCtx"), &kinesis.SubscribeToShardInput{
+		ConsumerARN: aws.String(s.consumerArn),
+		ShardId:     aws.String(string(s.shardId)),
+		StartingPosition: &types.StartingPosition{
+			Type: types.ShardIteratorTypeLatest,
+		},
+	}).Return(nil, fmt.Errorf("fail")).Once()
+
+	err := s.shardReader.Run(s.ctx, s.consumeRecord)
+	s.EqualError(err, multierror.Append(
+		fmt.Errorf("failed to subscribe to shard: fail"),
+		fmt.Errorf("failed to release checkpoint for shard: fail again"),
+	).Error())
+}
+
+func (s *fanOutShardReaderTestSuite) TestResubscribeUntilShardIsClosed() {
+	checkpoint := mocks.NewCheckpoint(s.T())
+	checkpoint.EXPECT().Persist(mock.AnythingOfType("*exec.stoppableContext")).Return(true, nil).Once()
+	checkpoint.EXPECT().Release(mock.AnythingOfType("*exec.stoppableContext")).Return(nil).Once()
+	checkpoint.EXPECT().GetSequenceNumber().Return(gosoKinesis.SequenceNumber("sequence number")).Twice()
+	checkpoint.EXPECT().Advance(gosoKinesis.SequenceNumber("seq 1")).Return(nil).Once()
+	checkpoint.EXPECT().Advance(gosoKinesis.SequenceNumber("seq 2")).Return(nil).Once()
+	checkpoint.EXPECT().Done(gosoKinesis.SequenceNumber("seq 2")).Return(nil).Once()
+
+	s.mockMetricCall("SubscribeCount", 1, metric.UnitCount).Twice()
+	s.mockMetricCall("ReadCount", 1, metric.UnitCount).Twice()
+	s.mockMetricCall("ReadRecords", 1, metric.UnitCount).Twice()
+	s.mockMetricCall("ProcessDuration", 0, metric.UnitMillisecondsAverage).Twice()
+	s.mockMetricCall("MillisecondsBehind", 1000, metric.UnitMillisecondsMaximum).Once()
+	s.mockMetricCall("MillisecondsBehind", 0, metric.UnitMillisecondsMaximum).Twice()
+
+	s.metadataRepository.EXPECT().AcquireShard(s.ctx, s.shardId).Return(checkpoint, nil).Once()
+	s.logger.EXPECT().Info("acquired shard").Once()
+	s.logger.EXPECT().Info("releasing shard").Once()
+	s.logger.EXPECT().WithChannel("kinsumer-read").Return(s.logger)
+	s.logger.EXPECT().WithFields(mock.AnythingOfType("log.Fields")).Return(s.logger)
+	s.logger.EXPECT().Info("processed batch of %d records in %s", 1, mock.AnythingOfType("time.Duration")).Twice()
+	s.logger.EXPECT().Info("shard was closed, child shards are %v", []string{"shard-008", "shard-009"}).Once()
+
+	s.subscriber.EXPECT().SubscribeToShard(mock.AnythingOfType("*context.cancelCtx"), &kinesis.SubscribeToShardInput{
+		ConsumerARN: aws.String(s.consumerArn),
+		ShardId:     aws.String(string(s.shardId)),
+		StartingPosition: &types.StartingPosition{
+			Type:           types.ShardIteratorTypeAfterSequenceNumber,
+			SequenceNumber: aws.String("sequence number"),
+		},
+	}).Return(newEventStreamReader(nil, &types.SubscribeToShardEventStreamMemberSubscribeToShardEvent{
+		Value: types.SubscribeToShardEvent{
+			Records: []types.Record{
+				{
+					Data:           []byte("data 1"),
+					SequenceNumber: aws.String("seq 1"),
+				},
+			},
+			ContinuationSequenceNumber: aws.String("continuation 1"),
+			MillisBehindLatest:         aws.Int64(1000),
+		},
+	}), nil).Once()
+
+	s.subscriber.EXPECT().SubscribeToShard(mock.AnythingOfType("*context.cancelCtx"), &kinesis.SubscribeToShardInput{
+		ConsumerARN: aws.String(s.consumerArn),
+		ShardId:     aws.String(string(s.shardId)),
+		StartingPosition: &types.StartingPosition{
+			Type:           types.ShardIteratorTypeAtSequenceNumber,
+			SequenceNumber: aws.String("continuation 1"),
+		},
+	}).Return(newEventStreamReader(nil, &types.SubscribeToShardEventStreamMemberSubscribeToShardEvent{
+		Value: types.SubscribeToShardEvent{
+			Records: []types.Record{
+				{
+					Data:           []byte("data 2"),
+					SequenceNumber: aws.String("seq 2"),
+				},
+			},
+			ChildShards: []types.ChildShard{
+				{
+					ShardId: aws.String("shard-008"),
+				},
+				{
+					ShardId: aws.String("shard-009"),
+				},
+			},
+			MillisBehindLatest: aws.Int64(0),
+		},
+	}), nil).Once()
+
+	err := s.shardReader.Run(s.ctx, s.consumeRecord)
+	s.NoError(err)
+	s.Equal([][]byte{
+		[]byte("data 1"),
+		[]byte("data 2"),
+	}, s.consumedRecords)
+}
+
+func (s *fanOutShardReaderTestSuite) TestSubscriptionInUse() {
+	checkpoint := mocks.NewCheckpoint(s.T())
+	checkpoint.EXPECT().Persist(mock.AnythingOfType("*exec.stoppableContext")).Return(true, nil).Once()
+	checkpoint.EXPECT().Release(mock.AnythingOfType("*exec.stoppableContext")).Return(nil).Once()
+	checkpoint.EXPECT().GetSequenceNumber().Return(gosoKinesis.SequenceNumber("")).Times(3)
+	checkpoint.EXPECT().Done(gosoKinesis.SequenceNumber("")).Return(nil).Once()
+
+	s.mockMetricCall("SubscribeCount", 1, metric.UnitCount).Once()
+	s.mockMetricCall("ReadCount", 1, metric.UnitCount).Once()
+	s.mockMetricCall("ReadRecords", 0, metric.UnitCount).Once()
+	s.mockMetricCall("ProcessDuration", 0, metric.UnitMillisecondsAverage).Once()
+	s.mockMetricCall("MillisecondsBehind", 0, metric.UnitMillisecondsMaximum).Twice()
+
+	s.metadataRepository.EXPECT().AcquireShard(s.ctx, s.shardId).Return(checkpoint, nil).Once()
+	s.logger.EXPECT().Info("acquired shard").Once()
+	s.logger.EXPECT().Info("releasing shard").Once()
+	s.logger.EXPECT().Warn("can not subscribe to shard yet, retrying in %s: %s", time.Second, mock.AnythingOfType("string")).Once()
+	s.logger.EXPECT().WithChannel("kinsumer-read").Return(s.logger)
+	s.logger.EXPECT().WithFields(mock.AnythingOfType("log.Fields")).Return(s.logger)
+	s.logger.EXPECT().Info("processed batch of %d records in %s", 0, mock.AnythingOfType("time.Duration")).Once()
+	s.logger.EXPECT().Info("shard was closed, child shards are %v", []string{}).Once()
+
+	input := &kinesis.SubscribeToShardInput{
+		ConsumerARN: aws.String(s.consumerArn),
+		ShardId:     aws.String(string(s.shardId)),
+		StartingPosition: &types.StartingPosition{
+			Type: types.ShardIteratorTypeLatest,
+		},
+	}
+
+	s.subscriber.EXPECT().SubscribeToShard(mock.AnythingOfType("*context.cancelCtx"), input).Run(func(ctx context.Context, params *kinesis.SubscribeToShardInput) {
+		testClock := s.clock
+		go func() {
+			testClock.BlockUntil(1)
+			testClock.Advance(time.Second)
+		}()
+	}).Return(nil, &types.ResourceInUseException{}).Once()
+
+	s.subscriber.EXPECT().SubscribeToShard(mock.AnythingOfType("*context.cancelCtx"), input).Return(newEventStreamReader(nil, &types.SubscribeToShardEventStreamMemberSubscribeToShardEvent{
+		Value: types.SubscribeToShardEvent{
+			MillisBehindLatest: aws.Int64(0),
+		},
+	}), nil).Once()
+
+	err := s.shardReader.Run(s.ctx, s.consumeRecord)
+	s.NoError(err)
+	s.Nil(s.consumedRecords)
+}
+
+func (s *fanOutShardReaderTestSuite) consumeRecord(record []byte) error {
+	s.consumedRecords = append(s.consumedRecords, record)
+
+	return nil
+}
+
+func (s *fanOutShardReaderTestSuite) mockMetricCall(metricName string, value float64, unit metric.StandardUnit) *metricMocks.Writer_WriteOne_Call {
+	return s.metricWriter.EXPECT().WriteOne(&metric.Datum{
+		Priority:   metric.PriorityHigh,
+		MetricName: metricName,
+		Dimensions: metric.Dimensions{
+			"StreamName": string(s.stream),
+		},
+		Value: value,
+		Unit:  unit,
+	})
+}
+
+type eventStreamReader struct {
+	events chan types.SubscribeToShardEventStream
+	err    error
+}
+
+func newEventStreamReader(err error, events ...types.SubscribeToShardEventStream) *eventStreamReader {
+	reader := &eventStreamReader{
+		events: make(chan types.SubscribeToShardEventStream, len(events)),
+		err:    err,
+	}
+
+	for _, event := range events {
+		reader.events <- event
+	}
+
+	close(reader.events)
+
+	return reader
+}
+
+func (r *eventStreamReader) Events() <-chan types.SubscribeToShardEventStream {
+	return r.events
+}
+
+func (r *eventStreamReader) Close() error {
+	return nil
+}
+
+func (r *eventStreamReader) Err() error {
+	return r.err
+}
@@ -0,0 +1,158 @@
+package kinesis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/mdl"
+)
+
+type SettingsEnhancedFanOut struct {
+	// Enabled switches from polling the shards to enhanced fan-out. Kinesis then pushes the records of every shard to a
+	// stream consumer registered for the application, which gives each application its own read throughput.
+	Enabled bool `cfg:"enabled" default:"false"`
+	// Name of the stream consumer. Defaults to the app id of the application, so all tasks of an application share it.
+	ConsumerName string `cfg:"consumer_name"`
+	// How long to wait for a new consumer to become active
+	RegistrationTimeout time.Duration `cfg:"registration_timeout" default:"1m"`
+	// Deregister the consumer once the kinsumer stops. This only makes sense if a single task consumes the stream, as the
+	// consumer would be removed for all other tasks as well.
+	DeregisterOnStop bool `cfg:"deregister_on_stop" default:"false"`
+}
+
+//go:generate mockery --name StreamConsumerRegistry
+type StreamConsumerRegistry interface {
+	// Register registers the stream consumer if it doesn't exist yet and waits until it is active. It returns the arn of the consumer.
+	Register(ctx context.Context) (string, error)
+	// Deregister removes the stream consumer again.
+	Deregister(ctx context.Context) error
+}
+
+type streamConsumerRegistry struct {
+	logger        log.Logger
+	kinesisClient Client
+	clock         clock.Clock
+	streamArn     string
+	consumerName  string
+	settings      SettingsEnhancedFanOut
+	waitTime      time.Duration
+}
+
+func NewStreamConsumerRegistry(config cfg.Config, logger log.Logger, kinesisClient Client, streamArn string, settings Settings) StreamConsumerRegistry {
+	consumerName := settings.EnhancedFanOut.ConsumerName
+
+	if consumerName == "" {
+		// same as for the metadata, we need the app id of the application we are running in, not the one of the stream
+		appId := cfg.AppId{}
+		appId.PadFromConfig(config)
+
+		consumerName = fmt.Sprintf("%s-%s-%s-%s-%s", appId.Project, appId.Environment, appId.Family, appId.Group, appId.Application)
+	}
+
+	logger = logger.WithFields(log.Fields{
+		"stream_consumer_name": consumerName,
+	})
+
+	return NewStreamConsumerRegistryWithInterfaces(logger, kinesisClient, clock.Provider, streamArn, consumerName, settings)
+}
+
+func NewStreamConsumerRegistryWithInterfaces(logger log.Logger, kinesisClient Client, clock clock.Clock, streamArn string, consumerName string, settings Settings) StreamConsumerRegistry {
+	return &streamConsumerRegistry{
+		logger:        logger,
+		kinesisClient: kinesisClient,
+		clock:         clock,
+		streamArn:     streamArn,
+		consumerName:  consumerName,
+		settings:      settings.EnhancedFanOut,
+		waitTime:      settings.WaitTime,
+	}
+}
+
+func (r *streamConsumerRegistry) Register(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.settings.RegistrationTimeout)
+	defer cancel()
+
+	for {
+		description, err := r.describe(ctx)
+
+		var errResourceNotFoundException *types.ResourceNotFoundException
+		if errors.As(err, &errResourceNotFoundException) {
+			if err = r.register(ctx); err != nil {
+				return "", err
+			}
+		} else if err != nil {
+			return "", fmt.Errorf("failed to describe stream consumer %s: %w", r.consumerName, err)
+		} else if description.ConsumerStatus == types.ConsumerStatusActive {
+			r.logger.Info("using stream consumer %s", r.consumerName)
+
+			return mdl.EmptyIfNil(description.ConsumerARN), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("stream consumer %s did not become active: %w", r.consumerName, ctx.Err())
+		case <-r.clock.After(r.waitTime):
+		}
+	}
+}
+
+func (r *streamConsumerRegistry) Deregister(ctx context.Context) error {
+	r.logger.Info("deregistering stream consumer %s", r.consumerName)
+
+	_, err := r.kinesisClient.DeregisterStreamConsumer(ctx, &kinesis.DeregisterStreamConsumerInput{
+		ConsumerName: aws.String(r.consumerName),
+		StreamARN:    aws.String(r.streamArn),
+	})
+
+	var errResourceNotFoundException *types.ResourceNotFoundException
+	if errors.As(err, &errResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to deregister stream consumer %s: %w", r.consumerName, err)
+	}
+
+	return nil
+}
+
+func (r *streamConsumerRegistry) register(ctx context.Context) error {
+	r.logger.Info("registering stream consumer %s", r.consumerName)
+
+	_, err := r.kinesisClient.RegisterStreamConsumer(ctx, &kinesis.RegisterStreamConsumerInput{
+		ConsumerName: aws.String(r.consumerName),
+		StreamARN:    aws.String(r.streamArn),
+	})
+
+	// another task registering the consumer at the same time is fine, we just wait for it to become active
+	var errResourceInUseException *types.ResourceInUseException
+	if errors.As(err, &errResourceInUseException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to register stream consumer %s: %w", r.consumerName, err)
+	}
+
+	return nil
+}
+
+func (r *streamConsumerRegistry) describe(ctx context.Context) (*types.ConsumerDescription, error) {
+	output, err := r.kinesisClient.DescribeStreamConsumer(ctx, &kinesis.DescribeStreamConsumerInput{
+		ConsumerName: aws.String(r.consumerName),
+		StreamARN:    aws.String(r.streamArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.ConsumerDescription, nil
+}
@@ -0,0 +1,121 @@
+package kinesis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	gosoKinesis "github.com/justtrackio/gosoline/pkg/cloud/aws/kinesis"
+	"github.com/justtrackio/gosoline/pkg/cloud/aws/kinesis/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type streamConsumerRegistryTestSuite struct {
+	suite.Suite
+
+	ctx           context.Context
+	kinesisClient *mocks.Client
+	clock         clock.FakeClock
+	registry      gosoKinesis.StreamConsumerRegistry
+}
+
+func TestStreamConsumerRegistry(t *testing.T) {
+	suite.Run(t, new(streamConsumerRegistryTestSuite))
+}
+
+func (s *streamConsumerRegistryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.kinesisClient = mocks.NewClient(s.T())
+	s.clock = clock.NewFakeClock()
+
+	settings := gosoKinesis.Settings{
+		WaitTime: time.Second,
+		EnhancedFanOut: gosoKinesis.SettingsEnhancedFanOut{
+			Enabled:             true,
+			RegistrationTimeout: time.Minute,
+		},
+	}
+
+	s.registry = gosoKinesis.NewStreamConsumerRegistryWithInterfaces(logMocks.NewLoggerMockedAll(), s.kinesisClient, s.clock, "stream arn", "consumer", settings)
+}
+
+func (s *streamConsumerRegistryTestSuite) TestRegisterExisting() {
+	s.mockDescribe(types.ConsumerStatusActive, nil).Once()
+
+	arn, err := s.registry.Register(s.ctx)
+	s.NoError(err)
+	s.Equal("consumer arn", arn)
+}
+
+func (s *streamConsumerRegistryTestSuite) TestRegisterNew() {
+	s.mockDescribe("", &types.ResourceNotFoundException{}).Once()
+	s.kinesisClient.EXPECT().RegisterStreamConsumer(mock.Anything, &kinesis.RegisterStreamConsumerInput{
+		ConsumerName: aws.String("consumer"),
+		StreamARN:    aws.String("stream arn"),
+	}).Run(func(ctx context.Context, params *kinesis.RegisterStreamConsumerInput, optFns ...func(*kinesis.Options)) {
+		s.advanceClock()
+	}).Return(&kinesis.RegisterStreamConsumerOutput{}, nil).Once()
+	s.mockDescribe(types.ConsumerStatusCreating, nil).Run(func(ctx context.Context, params *kinesis.DescribeStreamConsumerInput, optFns ...func(*kinesis.Options)) {
+		s.advanceClock()
+	}).Once()
+	s.mockDescribe(types.ConsumerStatusActive, nil).Once()
+
+	arn, err := s.registry.Register(s.ctx)
+	s.NoError(err)
+	s.Equal("consumer arn", arn)
+}
+
+func (s *streamConsumerRegistryTestSuite) TestRegisterFails() {
+	s.mockDescribe("", &types.ResourceNotFoundException{}).Once()
+	s.kinesisClient.EXPECT().RegisterStreamConsumer(mock.Anything, &kinesis.RegisterStreamConsumerInput{
+		ConsumerName: aws.String("consumer"),
+		StreamARN:    aws.String("stream arn"),
+	}).Return(nil, fmt.Errorf("fail")).Once()
+
+	_, err := s.registry.Register(s.ctx)
+	s.EqualError(err, "failed to register stream consumer consumer: fail")
+}
+
+func (s *streamConsumerRegistryTestSuite) TestDeregister() {
+	s.kinesisClient.EXPECT().DeregisterStreamConsumer(s.ctx, &kinesis.DeregisterStreamConsumerInput{
+		ConsumerName: aws.String("consumer"),
+		StreamARN:    aws.String("stream arn"),
+	}).Return(nil, &types.ResourceNotFoundException{}).Once()
+
+	err := s.registry.Deregister(s.ctx)
+	s.NoError(err)
+}
+
+func (s *streamConsumerRegistryTestSuite) mockDescribe(status types.ConsumerStatus, err error) *mocks.Client_DescribeStreamConsumer_Call {
+	var output *kinesis.DescribeStreamConsumerOutput
+
+	if err == nil {
+		output = &kinesis.DescribeStreamConsumerOutput{
+			ConsumerDescription: &types.ConsumerDescription{
+				ConsumerARN:    aws.String("consumer arn"),
+				ConsumerName:   aws.String("consumer"),
+				ConsumerStatus: status,
+			},
+		}
+	}
+
+	return s.kinesisClient.EXPECT().DescribeStreamConsumer(mock.Anything, &kinesis.DescribeStreamConsumerInput{
+		ConsumerName: aws.String("consumer"),
+		StreamARN:    aws.String("stream arn"),
+	}).Return(output, err)
+}
+
+func (s *streamConsumerRegistryTestSuite) advanceClock() {
+	testClock := s.clock
+	go func() {
+		testClock.BlockUntil(1)
+		testClock.Advance(time.Second)
+	}()
+}
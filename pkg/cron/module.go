@@ -0,0 +1,319 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/coffin"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/metric"
+)
+
+const (
+	OverlapSkip  = "skip"
+	OverlapQueue = "queue"
+
+	MetricNameRunSuccess  = "CronJobRunSuccess"
+	MetricNameRunFailure  = "CronJobRunFailure"
+	MetricNameRunSkipped  = "CronJobRunSkipped"
+	MetricNameRunDuration = "CronJobRunDuration"
+)
+
+// ErrJobBusy is returned by a manual trigger if the job can't accept another execution right now.
+var ErrJobBusy = errors.New("the job is busy")
+
+type JobSettings struct {
+	// Schedule is a cron expression like "*/5 * * * *", a descriptor like "@hourly" or an interval like "@every 30s"
+	Schedule string `cfg:"schedule" validate:"required"`
+	// Timezone the cron expression is interpreted in
+	Timezone string `cfg:"timezone" default:"UTC"`
+	// Jitter delays every scheduled execution by a random duration up to the given value
+	Jitter time.Duration `cfg:"jitter" default:"0s"`
+	// Overlap decides what happens if an execution is due while the previous one is still running: skip drops it,
+	// queue runs it after the previous one finished as long as there are less than QueueSize executions waiting
+	Overlap   string            `cfg:"overlap" default:"skip" validate:"oneof=skip queue"`
+	QueueSize int               `cfg:"queue_size" default:"1" validate:"min=1"`
+	Singleton SingletonSettings `cfg:"singleton"`
+}
+
+//go:generate mockery --name Job
+type Job interface {
+	Run(ctx context.Context) error
+}
+
+type (
+	JobFactory func(ctx context.Context, config cfg.Config, logger log.Logger) (Job, error)
+	JobFunc    func(ctx context.Context) error
+)
+
+func (f JobFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+type execution struct {
+	scheduledAt time.Time
+	manual      bool
+}
+
+type Module struct {
+	kernel.ForegroundModule
+	kernel.ApplicationStage
+
+	logger     log.Logger
+	clock      clock.Clock
+	metric     metric.Writer
+	job        Job
+	schedule   Schedule
+	singleton  Singleton
+	name       string
+	settings   *JobSettings
+	executions chan execution
+	busy       atomic.Bool
+	random     *rand.Rand
+}
+
+func ReadJobSettings(config cfg.Config, name string) *JobSettings {
+	settings := &JobSettings{}
+	config.UnmarshalKey(fmt.Sprintf("cron.jobs.%s", name), settings)
+
+	return settings
+}
+
+// NewModule runs the job on the schedule configured at cron.jobs.<name>. The job can be triggered manually as well,
+// see AddTriggerHandler.
+func NewModule(name string, jobFactory JobFactory) kernel.ModuleFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+		var err error
+		var job Job
+		var location *time.Location
+		var schedule Schedule
+		var singleton Singleton
+		var registry *Registry
+
+		logger = logger.WithChannel(fmt.Sprintf("cron_%s", name))
+		settings := ReadJobSettings(config, name)
+
+		if location, err = time.LoadLocation(settings.Timezone); err != nil {
+			return nil, fmt.Errorf("can not load timezone %s of cron job %s: %w", settings.Timezone, name, err)
+		}
+
+		if schedule, err = ParseSchedule(settings.Schedule, location); err != nil {
+			return nil, fmt.Errorf("can not parse schedule of cron job %s: %w", name, err)
+		}
+
+		if singleton, err = NewSingleton(ctx, config, logger, name, settings.Singleton); err != nil {
+			return nil, fmt.Errorf("can not create singleton of cron job %s: %w", name, err)
+		}
+
+		if job, err = jobFactory(ctx, config, logger); err != nil {
+			return nil, fmt.Errorf("can not create cron job %s: %w", name, err)
+		}
+
+		if registry, err = ProvideRegistry(ctx); err != nil {
+			return nil, fmt.Errorf("can not provide cron registry: %w", err)
+		}
+
+		metricWriter := metric.NewWriter(getDefaultMetrics(name)...)
+		module := NewModuleWithInterfaces(logger, clock.Provider, metricWriter, job, schedule, singleton, name, settings)
+
+		if err = registry.Register(name, module); err != nil {
+			return nil, err
+		}
+
+		return module, nil
+	}
+}
+
+func NewModuleWithInterfaces(
+	logger log.Logger,
+	clock clock.Clock,
+	metricWriter metric.Writer,
+	job Job,
+	schedule Schedule,
+	singleton Singleton,
+	name string,
+	settings *JobSettings,
+) *Module {
+	queueSize := 1
+	if settings.Overlap == OverlapQueue {
+		queueSize = settings.QueueSize
+	}
+
+	return &Module{
+		logger:     logger,
+		clock:      clock,
+		metric:     metricWriter,
+		job:        job,
+		schedule:   schedule,
+		singleton:  singleton,
+		name:       name,
+		settings:   settings,
+		executions: make(chan execution, queueSize),
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (m *Module) Run(ctx context.Context) error {
+	cfn, cfnCtx := coffin.WithContext(ctx)
+
+	cfn.GoWithContext(cfnCtx, m.runExecutions)
+	cfn.GoWithContext(cfnCtx, m.runSchedule)
+
+	if err := cfn.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
+
+// Trigger runs the job outside its schedule. The execution follows the overlap policy of the job, but ignores the
+// jitter and singleton settings as it was explicitly requested for this instance.
+func (m *Module) Trigger() error {
+	if !m.enqueue(execution{scheduledAt: m.clock.Now(), manual: true}) {
+		return ErrJobBusy
+	}
+
+	return nil
+}
+
+func (m *Module) runSchedule(ctx context.Context) error {
+	for {
+		now := m.clock.Now()
+		next := m.schedule.Next(now)
+
+		if next.IsZero() {
+			m.logger.Warn("the schedule %s of cron job %s never runs again", m.settings.Schedule, m.name)
+
+			return nil
+		}
+
+		timer := m.clock.NewTimer(next.Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil
+		case <-timer.Chan():
+			if !m.enqueue(execution{scheduledAt: next}) {
+				m.logger.Warn("skipping execution of cron job %s scheduled at %s as the previous one is still running", m.name, next.Format(time.RFC3339))
+				m.writeMetric(MetricNameRunSkipped, metric.UnitCount, 1)
+			}
+		}
+	}
+}
+
+func (m *Module) enqueue(e execution) bool {
+	if m.settings.Overlap != OverlapQueue {
+		// the flag is reset once the execution finished, so there is always room in the channel if we could set it
+		if !m.busy.CompareAndSwap(false, true) {
+			return false
+		}
+
+		m.executions <- e
+
+		return true
+	}
+
+	select {
+	case m.executions <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *Module) runExecutions(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-m.executions:
+			m.execute(ctx, e)
+			m.busy.Store(false)
+		}
+	}
+}
+
+func (m *Module) execute(ctx context.Context, e execution) {
+	if !e.manual {
+		if m.settings.Jitter > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.clock.After(time.Duration(m.random.Int63n(int64(m.settings.Jitter)))):
+			}
+		}
+
+		// the singleton might only be unavailable for a moment, so we skip this execution and try again on the next one
+		shouldRun, err := m.singleton.ShouldRun(ctx, e.scheduledAt)
+		if err != nil {
+			m.logger.Error("can not decide whether to run cron job %s scheduled at %s: %w", m.name, e.scheduledAt.Format(time.RFC3339), err)
+			m.writeMetric(MetricNameRunFailure, metric.UnitCount, 1)
+
+			return
+		}
+
+		if !shouldRun {
+			m.logger.Info("not running cron job %s scheduled at %s as it is run by another instance", m.name, e.scheduledAt.Format(time.RFC3339))
+
+			return
+		}
+	}
+
+	m.logger.Info("running cron job %s scheduled at %s", m.name, e.scheduledAt.Format(time.RFC3339))
+
+	start := m.clock.Now()
+	err := m.job.Run(ctx)
+	took := m.clock.Now().Sub(start)
+
+	m.writeMetric(MetricNameRunDuration, metric.UnitMillisecondsAverage, float64(took.Milliseconds()))
+
+	if err != nil {
+		m.logger.Error("cron job %s failed after %s: %w", m.name, took, err)
+		m.writeMetric(MetricNameRunFailure, metric.UnitCount, 1)
+
+		return
+	}
+
+	m.logger.Info("finished cron job %s after %s", m.name, took)
+	m.writeMetric(MetricNameRunSuccess, metric.UnitCount, 1)
+}
+
+func (m *Module) writeMetric(name string, unit metric.StandardUnit, value float64) {
+	m.metric.WriteOne(&metric.Datum{
+		Priority:   metric.PriorityHigh,
+		Timestamp:  m.clock.Now(),
+		MetricName: name,
+		Dimensions: metric.Dimensions{
+			"Job": m.name,
+		},
+		Unit:  unit,
+		Value: value,
+	})
+}
+
+func getDefaultMetrics(name string) []*metric.Datum {
+	defaults := make([]*metric.Datum, 0, 3)
+
+	for _, metricName := range []string{MetricNameRunSuccess, MetricNameRunFailure, MetricNameRunSkipped} {
+		defaults = append(defaults, &metric.Datum{
+			Priority:   metric.PriorityHigh,
+			MetricName: metricName,
+			Dimensions: metric.Dimensions{
+				"Job": name,
+			},
+			Unit:  metric.UnitCount,
+			Value: 0.0,
+		})
+	}
+
+	return defaults
+}
@@ -0,0 +1,245 @@
+package cron_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/cron"
+	cronMocks "github.com/justtrackio/gosoline/pkg/cron/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/metric"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type moduleTestSuite struct {
+	suite.Suite
+
+	ctx       context.Context
+	clock     clock.FakeClock
+	metric    *metricMocks.Writer
+	job       *cronMocks.Job
+	singleton *cronMocks.Singleton
+	settings  *cron.JobSettings
+
+	started chan time.Time
+	release chan struct{}
+}
+
+func TestModule(t *testing.T) {
+	suite.Run(t, new(moduleTestSuite))
+}
+
+func (s *moduleTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.clock = clock.NewFakeClockAt(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	s.metric = metricMocks.NewWriter(s.T())
+	s.job = cronMocks.NewJob(s.T())
+	s.singleton = cronMocks.NewSingleton(s.T())
+	s.settings = &cron.JobSettings{
+		Schedule:  "@every 1m",
+		Overlap:   cron.OverlapSkip,
+		QueueSize: 1,
+	}
+
+	s.started = make(chan time.Time, 3)
+	s.release = make(chan struct{})
+}
+
+func (s *moduleTestSuite) TestScheduledRun() {
+	s.expectShouldRun(s.at(1), true)
+	s.expectJob(nil).Once()
+	s.expectMetric(cron.MetricNameRunDuration).Once()
+	s.expectMetric(cron.MetricNameRunSuccess).Once()
+	close(s.release)
+
+	module := s.newModule()
+	stop := s.run(module)
+
+	s.tick()
+	s.Equal(s.at(1), <-s.started)
+
+	stop()
+}
+
+func (s *moduleTestSuite) TestFailingJobKeepsRunning() {
+	s.expectShouldRun(s.at(1), true)
+	s.expectShouldRun(s.at(2), true)
+	s.expectJob(fmt.Errorf("boom")).Twice()
+	s.expectMetric(cron.MetricNameRunDuration).Twice()
+	s.expectMetric(cron.MetricNameRunFailure).Twice()
+	close(s.release)
+
+	module := s.newModule()
+	stop := s.run(module)
+
+	s.tick()
+	<-s.started
+	s.tick()
+	<-s.started
+
+	stop()
+}
+
+func (s *moduleTestSuite) TestOverlapSkip() {
+	s.expectShouldRun(s.at(1), true)
+	s.expectJob(nil).Once()
+	s.expectMetric(cron.MetricNameRunSkipped).Once()
+	s.expectMetric(cron.MetricNameRunDuration).Once()
+	s.expectMetric(cron.MetricNameRunSuccess).Once()
+
+	module := s.newModule()
+	stop := s.run(module)
+
+	s.tick()
+	<-s.started
+
+	// the second execution is due while the first one is still running
+	s.tick()
+	s.clock.BlockUntilTimers(1)
+
+	close(s.release)
+	stop()
+}
+
+func (s *moduleTestSuite) TestOverlapQueue() {
+	s.settings.Overlap = cron.OverlapQueue
+
+	s.expectShouldRun(s.at(1), true)
+	s.expectShouldRun(s.at(2), true)
+	s.expectJob(nil).Twice()
+	s.expectMetric(cron.MetricNameRunSkipped).Once()
+	s.expectMetric(cron.MetricNameRunDuration).Twice()
+	s.expectMetric(cron.MetricNameRunSuccess).Twice()
+
+	module := s.newModule()
+	stop := s.run(module)
+
+	s.tick()
+	s.Equal(s.at(1), <-s.started)
+
+	// the second execution is queued, the third one exceeds the queue size
+	s.tick()
+	s.tick()
+	s.clock.BlockUntilTimers(1)
+
+	close(s.release)
+	<-s.started
+
+	stop()
+}
+
+func (s *moduleTestSuite) TestSingletonPreventsRun() {
+	ran := make(chan struct{})
+
+	s.singleton.EXPECT().ShouldRun(mock.Anything, s.at(1)).RunAndReturn(func(_ context.Context, _ time.Time) (bool, error) {
+		close(ran)
+
+		return false, nil
+	}).Once()
+
+	module := s.newModule()
+	stop := s.run(module)
+
+	s.tick()
+	<-ran
+
+	stop()
+}
+
+func (s *moduleTestSuite) TestSingletonErrorSkipsExecution() {
+	skipped := make(chan struct{})
+
+	s.singleton.EXPECT().ShouldRun(mock.Anything, s.at(1)).Return(false, fmt.Errorf("ddb down")).Once()
+	s.expectMetric(cron.MetricNameRunFailure).Run(func(_ *metric.Datum) {
+		close(skipped)
+	}).Once()
+
+	// the job keeps on running on the next schedule
+	s.expectShouldRun(s.at(2), true)
+	s.expectJob(nil).Once()
+	s.expectMetric(cron.MetricNameRunDuration).Once()
+	s.expectMetric(cron.MetricNameRunSuccess).Once()
+	close(s.release)
+
+	module := s.newModule()
+	stop := s.run(module)
+
+	s.tick()
+	<-skipped
+
+	s.tick()
+	s.Equal(s.at(2), <-s.started)
+
+	stop()
+}
+
+func (s *moduleTestSuite) TestTrigger() {
+	s.expectJob(nil).Once()
+	s.expectMetric(cron.MetricNameRunDuration).Once()
+	s.expectMetric(cron.MetricNameRunSuccess).Once()
+
+	module := s.newModule()
+	stop := s.run(module)
+
+	s.NoError(module.Trigger())
+	s.Equal(s.clock.Now(), <-s.started)
+
+	s.ErrorIs(module.Trigger(), cron.ErrJobBusy)
+
+	close(s.release)
+	stop()
+}
+
+func (s *moduleTestSuite) newModule() *cron.Module {
+	schedule, err := cron.ParseSchedule(s.settings.Schedule, time.UTC)
+	s.NoError(err)
+
+	return cron.NewModuleWithInterfaces(logMocks.NewLoggerMockedAll(), s.clock, s.metric, s.job, schedule, s.singleton, "test", s.settings)
+}
+
+func (s *moduleTestSuite) run(module *cron.Module) (stop func()) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	done := make(chan error)
+
+	go func() {
+		done <- module.Run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		s.NoError(<-done)
+	}
+}
+
+func (s *moduleTestSuite) tick() {
+	s.clock.BlockUntilTimers(1)
+	s.clock.Advance(time.Minute)
+}
+
+func (s *moduleTestSuite) at(minutes int) time.Time {
+	return time.Date(2024, 1, 1, 10, minutes, 0, 0, time.UTC)
+}
+
+func (s *moduleTestSuite) expectShouldRun(scheduledAt time.Time, shouldRun bool) {
+	s.singleton.EXPECT().ShouldRun(mock.Anything, scheduledAt).Return(shouldRun, nil).Once()
+}
+
+func (s *moduleTestSuite) expectJob(err error) *cronMocks.Job_Run_Call {
+	return s.job.EXPECT().Run(mock.Anything).RunAndReturn(func(_ context.Context) error {
+		s.started <- s.clock.Now()
+		<-s.release
+
+		return err
+	})
+}
+
+func (s *moduleTestSuite) expectMetric(name string) *metricMocks.Writer_WriteOne_Call {
+	return s.metric.EXPECT().WriteOne(mock.MatchedBy(func(datum *metric.Datum) bool {
+		return datum.MetricName == name && datum.Dimensions["Job"] == "test"
+	}))
+}
@@ -0,0 +1,113 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/justtrackio/gosoline/pkg/apiserver"
+	"github.com/justtrackio/gosoline/pkg/appctx"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+var ErrJobNotFound = errors.New("there is no such job")
+
+type registryAppCtxKey int
+
+// Trigger starts an execution of a job outside its schedule.
+//
+//go:generate mockery --name Trigger
+type Trigger interface {
+	Trigger() error
+}
+
+// Registry knows all cron jobs of the application and allows triggering them by name.
+type Registry struct {
+	lck  sync.RWMutex
+	jobs map[string]Trigger
+}
+
+func ProvideRegistry(ctx context.Context) (*Registry, error) {
+	return appctx.Provide(ctx, registryAppCtxKey(0), func() (*Registry, error) {
+		return NewRegistry(), nil
+	})
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		jobs: map[string]Trigger{},
+	}
+}
+
+func (r *Registry) Register(name string, job Trigger) error {
+	r.lck.Lock()
+	defer r.lck.Unlock()
+
+	if _, ok := r.jobs[name]; ok {
+		return fmt.Errorf("there is already a cron job with the name %s", name)
+	}
+
+	r.jobs[name] = job
+
+	return nil
+}
+
+func (r *Registry) Trigger(name string) error {
+	r.lck.RLock()
+	job, ok := r.jobs[name]
+	r.lck.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("can not trigger cron job %s: %w", name, ErrJobNotFound)
+	}
+
+	if err := job.Trigger(); err != nil {
+		return fmt.Errorf("can not trigger cron job %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// AddTriggerHandler adds the route POST /cron/jobs/:name/trigger to run a job of the application on demand.
+func AddTriggerHandler(ctx context.Context, logger log.Logger, d *apiserver.Definitions) error {
+	registry, err := ProvideRegistry(ctx)
+	if err != nil {
+		return fmt.Errorf("can not provide cron registry: %w", err)
+	}
+
+	d.POST("/cron/jobs/:name/trigger", apiserver.CreateHandler(NewTriggerHandler(logger, registry)))
+
+	return nil
+}
+
+type triggerHandler struct {
+	logger   log.Logger
+	registry *Registry
+}
+
+func NewTriggerHandler(logger log.Logger, registry *Registry) apiserver.HandlerWithoutInput {
+	return &triggerHandler{
+		logger:   logger,
+		registry: registry,
+	}
+}
+
+func (h *triggerHandler) Handle(ctx context.Context, request *apiserver.Request) (*apiserver.Response, error) {
+	name := request.Params.ByName("name")
+	err := h.registry.Trigger(name)
+
+	switch {
+	case err == nil:
+		h.logger.WithContext(ctx).Info("triggered cron job %s", name)
+
+		return apiserver.NewStatusResponse(http.StatusAccepted), nil
+	case errors.Is(err, ErrJobNotFound):
+		return apiserver.NewStatusResponse(http.StatusNotFound), nil
+	case errors.Is(err, ErrJobBusy):
+		return apiserver.NewStatusResponse(http.StatusConflict), nil
+	default:
+		return nil, err
+	}
+}
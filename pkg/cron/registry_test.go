@@ -0,0 +1,53 @@
+package cron_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/justtrackio/gosoline/pkg/apiserver"
+	"github.com/justtrackio/gosoline/pkg/cron"
+	cronMocks "github.com/justtrackio/gosoline/pkg/cron/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	registry := cron.NewRegistry()
+
+	assert.NoError(t, registry.Register("job", cronMocks.NewTrigger(t)))
+	assert.EqualError(t, registry.Register("job", cronMocks.NewTrigger(t)), "there is already a cron job with the name job")
+}
+
+func TestTriggerHandler(t *testing.T) {
+	tests := map[string]struct {
+		name       string
+		err        error
+		statusCode int
+	}{
+		"accepted":  {name: "job", statusCode: http.StatusAccepted},
+		"busy":      {name: "job", err: cron.ErrJobBusy, statusCode: http.StatusConflict},
+		"not_found": {name: "unknown", statusCode: http.StatusNotFound},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := cronMocks.NewTrigger(t)
+			if test.name == "job" {
+				job.EXPECT().Trigger().Return(test.err).Once()
+			}
+
+			registry := cron.NewRegistry()
+			assert.NoError(t, registry.Register("job", job))
+
+			handler := cron.NewTriggerHandler(logMocks.NewLoggerMockedAll(), registry)
+			response, err := handler.Handle(context.Background(), &apiserver.Request{
+				Params: gin.Params{{Key: "name", Value: test.name}},
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.statusCode, response.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes when a job runs next.
+type Schedule interface {
+	// Next returns the first time after the given time the job should run.
+	Next(after time.Time) time.Time
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	fieldMinute = field{name: "minute", min: 0, max: 59}
+	fieldHour   = field{name: "hour", min: 0, max: 23}
+	fieldDom    = field{name: "day of month", min: 1, max: 31}
+	fieldMonth  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as sunday as well and mapped to 0 after parsing
+	fieldDow = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// ParseSchedule parses a standard cron expression with the five fields minute, hour, day of month, month and day of
+// week, one of the descriptors like @hourly or @daily or a fixed interval like "@every 90s". The times of cron
+// expressions are interpreted in the given location.
+func ParseSchedule(expression string, location *time.Location) (Schedule, error) {
+	expression = strings.TrimSpace(expression)
+
+	if strings.HasPrefix(expression, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expression, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("can not parse interval of %q: %w", expression, err)
+		}
+
+		if interval <= 0 {
+			return nil, fmt.Errorf("the interval of %q has to be positive", expression)
+		}
+
+		return &intervalSchedule{interval: interval}, nil
+	}
+
+	if descriptor, ok := descriptors[strings.ToLower(expression)]; ok {
+		expression = descriptor
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("the cron expression %q has to consist of 5 fields, but has %d", expression, len(fields))
+	}
+
+	var err error
+	schedule := &cronSchedule{
+		location: location,
+		domStar:  fields[2] == "*" || fields[2] == "?",
+		dowStar:  fields[4] == "*" || fields[4] == "?",
+	}
+
+	if schedule.minute, err = parseField(fields[0], fieldMinute); err != nil {
+		return nil, err
+	}
+
+	if schedule.hour, err = parseField(fields[1], fieldHour); err != nil {
+		return nil, err
+	}
+
+	if schedule.dom, err = parseField(fields[2], fieldDom); err != nil {
+		return nil, err
+	}
+
+	if schedule.month, err = parseField(fields[3], fieldMonth); err != nil {
+		return nil, err
+	}
+
+	if schedule.dow, err = parseField(fields[4], fieldDow); err != nil {
+		return nil, err
+	}
+
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+
+	return schedule, nil
+}
+
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(value, ",") {
+		partBits, err := parsePart(strings.ToLower(part), f)
+		if err != nil {
+			return 0, fmt.Errorf("can not parse %s field %q: %w", f.name, value, err)
+		}
+
+		bits |= partBits
+	}
+
+	return bits, nil
+}
+
+func parsePart(part string, f field) (uint64, error) {
+	var err error
+	var bits uint64
+
+	step := 1
+	rangePart := part
+
+	if i := strings.Index(part, "/"); i >= 0 {
+		rangePart = part[:i]
+
+		if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %q", part[i+1:])
+		}
+	}
+
+	start, end := f.min, f.max
+
+	switch {
+	case rangePart == "*" || rangePart == "?":
+	case strings.Contains(rangePart, "-"):
+		bounds := strings.SplitN(rangePart, "-", 2)
+
+		if start, err = parseValue(bounds[0], f); err != nil {
+			return 0, err
+		}
+
+		if end, err = parseValue(bounds[1], f); err != nil {
+			return 0, err
+		}
+	default:
+		if start, err = parseValue(rangePart, f); err != nil {
+			return 0, err
+		}
+
+		// a single value with a step like 5/15 runs from the value until the end of the range
+		if step == 1 {
+			end = start
+		}
+	}
+
+	if start > end {
+		return 0, fmt.Errorf("the start %d of the range is after its end %d", start, end)
+	}
+
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+
+	return bits, nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	if n, ok := f.names[value]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("the value %d is out of the range %d-%d", n, f.min, f.max)
+	}
+
+	return n, nil
+}
+
+type cronSchedule struct {
+	location *time.Location
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domStar  bool
+	dowStar  bool
+}
+
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	original := after.Location()
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+
+	// a valid expression matches at least once in a leap year cycle
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t.In(original)
+	}
+
+	return time.Time{}
+}
+
+// dayMatches follows the usual cron semantics: if both day fields are restricted, a day matching either one is used.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Next aligns the executions to the unix epoch instead of the start of the instance, so every instance of an
+// application schedules the same times and the singletons can tell the executions apart.
+func (s *intervalSchedule) Next(after time.Time) time.Time {
+	nanos := after.UnixNano()
+	next := nanos - nanos%int64(s.interval) + int64(s.interval)
+
+	return time.Unix(0, next).In(after.Location())
+}
@@ -0,0 +1,118 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cron"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+
+	tests := map[string]struct {
+		expression string
+		location   *time.Location
+		after      time.Time
+		expected   time.Time
+	}{
+		"every_15_minutes": {
+			expression: "*/15 * * * *",
+			after:      time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC),
+			expected:   time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
+		},
+		"exact_match_is_skipped": {
+			expression: "*/15 * * * *",
+			after:      time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
+			expected:   time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC),
+		},
+		"weekdays_by_name": {
+			expression: "0 9 * * mon-fri",
+			after:      time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC),
+			expected:   time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+		},
+		"descriptor": {
+			expression: "@daily",
+			after:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"leap_day": {
+			expression: "0 0 29 2 *",
+			after:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"day_of_month_or_day_of_week": {
+			expression: "0 12 1 * 0",
+			after:      time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+			expected:   time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC),
+		},
+		"sunday_as_7": {
+			expression: "30 2 * * 7",
+			after:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2024, 1, 7, 2, 30, 0, 0, time.UTC),
+		},
+		"month_list": {
+			expression: "0 0 1 jan,jul *",
+			after:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"value_with_step": {
+			expression: "5/20 * * * *",
+			after:      time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC),
+			expected:   time.Date(2024, 1, 1, 10, 25, 0, 0, time.UTC),
+		},
+		"interval": {
+			expression: "@every 90s",
+			after:      time.Date(2024, 1, 1, 10, 0, 10, 0, time.UTC),
+			expected:   time.Date(2024, 1, 1, 10, 1, 30, 0, time.UTC),
+		},
+		"interval_exact_match_is_skipped": {
+			expression: "@every 90s",
+			after:      time.Date(2024, 1, 1, 10, 1, 30, 0, time.UTC),
+			expected:   time.Date(2024, 1, 1, 10, 3, 0, 0, time.UTC),
+		},
+		"location": {
+			expression: "0 9 * * *",
+			location:   cet,
+			after:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			location := test.location
+			if location == nil {
+				location = time.UTC
+			}
+
+			schedule, err := cron.ParseSchedule(test.expression, location)
+			assert.NoError(t, err)
+
+			next := schedule.Next(test.after)
+			assert.True(t, test.expected.Equal(next), "expected %s, got %s", test.expected, next)
+			assert.Equal(t, test.after.Location(), next.Location())
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"missing_field":      "* * * *",
+		"out_of_range":       "60 * * * *",
+		"unknown_name":       "* * * * foo",
+		"negative_interval":  "@every -1s",
+		"invalid_interval":   "@every often",
+		"inverted_range":     "5-1 * * * *",
+		"zero_step":          "*/0 * * * *",
+		"unknown_descriptor": "@sometimes",
+	}
+
+	for name, expression := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := cron.ParseSchedule(expression, time.UTC)
+			assert.Error(t, err)
+		})
+	}
+}
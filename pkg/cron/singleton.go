@@ -0,0 +1,148 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/conc"
+	concDdb "github.com/justtrackio/gosoline/pkg/conc/ddb"
+	"github.com/justtrackio/gosoline/pkg/exec"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/uuid"
+)
+
+const (
+	SingletonTypeNone           = "none"
+	SingletonTypeLock           = "lock"
+	SingletonTypeLeaderElection = "leader_election"
+)
+
+type SingletonSettings struct {
+	// Type selects how a scheduled execution is limited to a single instance of the application: none runs it
+	// everywhere, lock runs it on the instance acquiring the lock of the execution first and leader_election runs it
+	// on the current leader only
+	Type string `cfg:"type" default:"none" validate:"oneof=none lock leader_election"`
+	// LeaderElection is the name of the leader election configured at conc.leader_election.<name>
+	LeaderElection string `cfg:"leader_election"`
+	// LockTime should be larger than the clock skew between the instances, the lock of an execution is never released
+	// to prevent a slower instance from running it again afterwards
+	LockTime time.Duration `cfg:"lock_time" default:"1m"`
+	// AcquireTimeout is the time an instance tries to acquire the lock before assuming another instance owns it
+	AcquireTimeout time.Duration `cfg:"acquire_timeout" default:"5s"`
+}
+
+// Singleton decides whether this instance of the application runs a scheduled execution of a job.
+//
+//go:generate mockery --name Singleton
+type Singleton interface {
+	ShouldRun(ctx context.Context, scheduledAt time.Time) (bool, error)
+}
+
+func NewSingleton(ctx context.Context, config cfg.Config, logger log.Logger, name string, settings SingletonSettings) (Singleton, error) {
+	switch settings.Type {
+	case SingletonTypeNone, "":
+		return NewNoSingleton(), nil
+	case SingletonTypeLock:
+		appId := cfg.AppId{}
+		appId.PadFromConfig(config)
+
+		lockProvider, err := concDdb.NewDdbLockProvider(ctx, config, logger, conc.DistributedLockSettings{
+			AppId:           appId,
+			DefaultLockTime: settings.LockTime,
+			Domain:          "cron",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("can not create lock provider: %w", err)
+		}
+
+		return NewLockSingletonWithInterfaces(lockProvider, name, settings.AcquireTimeout), nil
+	case SingletonTypeLeaderElection:
+		leaderElection, err := concDdb.NewLeaderElection(ctx, config, logger, settings.LeaderElection)
+		if err != nil {
+			return nil, fmt.Errorf("can not create leader election: %w", err)
+		}
+
+		return NewLeaderElectionSingletonWithInterfaces(logger, leaderElection, uuid.New().NewV4()), nil
+	default:
+		return nil, fmt.Errorf("unknown singleton type %s", settings.Type)
+	}
+}
+
+type noSingleton struct{}
+
+func NewNoSingleton() Singleton {
+	return noSingleton{}
+}
+
+func (s noSingleton) ShouldRun(_ context.Context, _ time.Time) (bool, error) {
+	return true, nil
+}
+
+type lockSingleton struct {
+	lockProvider   conc.DistributedLockProvider
+	name           string
+	acquireTimeout time.Duration
+}
+
+// NewLockSingletonWithInterfaces runs an execution on the instance which acquires the lock of the job and the
+// scheduled time first.
+func NewLockSingletonWithInterfaces(lockProvider conc.DistributedLockProvider, name string, acquireTimeout time.Duration) Singleton {
+	return &lockSingleton{
+		lockProvider:   lockProvider,
+		name:           name,
+		acquireTimeout: acquireTimeout,
+	}
+}
+
+func (s *lockSingleton) ShouldRun(ctx context.Context, scheduledAt time.Time) (bool, error) {
+	acquireCtx, cancel := context.WithTimeout(ctx, s.acquireTimeout)
+	defer cancel()
+
+	// the lock expires on its own, releasing it would allow an instance with a late clock to run the execution again
+	_, err := s.lockProvider.Acquire(acquireCtx, fmt.Sprintf("%s-%d", s.name, scheduledAt.Unix()))
+
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, conc.ErrOwnedLock):
+		return false, nil
+	case ctx.Err() == nil && (exec.IsRequestCanceled(err) || errors.Is(err, context.DeadlineExceeded)):
+		// we ran into the acquire timeout while retrying, so the lock is owned by another instance
+		return false, nil
+	default:
+		return false, fmt.Errorf("can not acquire lock: %w", err)
+	}
+}
+
+type leaderElectionSingleton struct {
+	logger         log.Logger
+	leaderElection concDdb.LeaderElection
+	memberId       string
+}
+
+// NewLeaderElectionSingletonWithInterfaces runs the executions on the current leader only.
+func NewLeaderElectionSingletonWithInterfaces(logger log.Logger, leaderElection concDdb.LeaderElection, memberId string) Singleton {
+	return &leaderElectionSingleton{
+		logger:         logger,
+		leaderElection: leaderElection,
+		memberId:       memberId,
+	}
+}
+
+func (s *leaderElectionSingleton) ShouldRun(ctx context.Context, _ time.Time) (bool, error) {
+	isLeader, err := s.leaderElection.IsLeader(ctx, s.memberId)
+	if err == nil {
+		return isLeader, nil
+	}
+
+	if conc.IsLeaderElectionFatalError(err) {
+		return false, fmt.Errorf("can not decide on leader: %w", err)
+	}
+
+	s.logger.Warn("will assume leader role as election failed: %s", err)
+
+	return true, nil
+}
@@ -0,0 +1,91 @@
+package cron_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/conc"
+	concMocks "github.com/justtrackio/gosoline/pkg/conc/mocks"
+	"github.com/justtrackio/gosoline/pkg/cron"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockSingletonAcrossInstances(t *testing.T) {
+	lck := sync.Mutex{}
+	locked := make(map[string]bool)
+	acquired := make(chan string, 2)
+
+	lockProvider := concMocks.NewDistributedLockProvider(t)
+	lockProvider.EXPECT().Acquire(mock.Anything, mock.AnythingOfType("string")).RunAndReturn(func(_ context.Context, resource string) (conc.DistributedLock, error) {
+		lck.Lock()
+		defer lck.Unlock()
+
+		defer func() {
+			acquired <- resource
+		}()
+
+		if locked[resource] {
+			return nil, conc.ErrOwnedLock
+		}
+
+		locked[resource] = true
+
+		return nil, nil
+	}).Times(2)
+
+	runs := int32(0)
+	job := cron.JobFunc(func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+
+		return nil
+	})
+
+	settings := &cron.JobSettings{
+		Schedule:  "@every 1m",
+		Overlap:   cron.OverlapSkip,
+		QueueSize: 1,
+	}
+
+	schedule, err := cron.ParseSchedule(settings.Schedule, time.UTC)
+	require.NoError(t, err)
+
+	// the instances of the application were started at different times
+	clocks := []clock.FakeClock{
+		clock.NewFakeClockAt(time.Date(2024, 1, 1, 10, 0, 7, 0, time.UTC)),
+		clock.NewFakeClockAt(time.Date(2024, 1, 1, 10, 0, 41, 0, time.UTC)),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+
+	for _, instanceClock := range clocks {
+		singleton := cron.NewLockSingletonWithInterfaces(lockProvider, "test", time.Second)
+		module := cron.NewModuleWithInterfaces(logMocks.NewLoggerMockedAll(), instanceClock, metricMocks.NewWriterMockedAll(), job, schedule, singleton, "test", settings)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, module.Run(ctx))
+		}()
+
+		instanceClock.BlockUntilTimers(1)
+		instanceClock.Advance(time.Minute)
+	}
+
+	expectedResource := "test-1704103260"
+	assert.Equal(t, expectedResource, <-acquired)
+	assert.Equal(t, expectedResource, <-acquired)
+
+	cancel()
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs), "only one instance should run the execution")
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	currency "github.com/justtrackio/gosoline/pkg/currency"
+	decimal "github.com/shopspring/decimal"
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
@@ -241,6 +243,119 @@ func (_c *Service_ToCurrencyAtDate_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// ToCurrencyDecimal provides a mock function with given fields: ctx, toCurrency, value, fromCurrency, rounding
+func (_m *Service) ToCurrencyDecimal(ctx context.Context, toCurrency string, value decimal.Decimal, fromCurrency string, rounding currency.Rounding) (decimal.Decimal, error) {
+	ret := _m.Called(ctx, toCurrency, value, fromCurrency, rounding)
+
+	var r0 decimal.Decimal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, decimal.Decimal, string, currency.Rounding) (decimal.Decimal, error)); ok {
+		return rf(ctx, toCurrency, value, fromCurrency, rounding)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, decimal.Decimal, string, currency.Rounding) decimal.Decimal); ok {
+		r0 = rf(ctx, toCurrency, value, fromCurrency, rounding)
+	} else {
+		r0 = ret.Get(0).(decimal.Decimal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, decimal.Decimal, string, currency.Rounding) error); ok {
+		r1 = rf(ctx, toCurrency, value, fromCurrency, rounding)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ToCurrencyDecimal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToCurrencyDecimal'
+type Service_ToCurrencyDecimal_Call struct {
+	*mock.Call
+}
+
+// ToCurrencyDecimal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - toCurrency string
+//   - value decimal.Decimal
+//   - fromCurrency string
+//   - rounding currency.Rounding
+func (_e *Service_Expecter) ToCurrencyDecimal(ctx interface{}, toCurrency interface{}, value interface{}, fromCurrency interface{}, rounding interface{}) *Service_ToCurrencyDecimal_Call {
+	return &Service_ToCurrencyDecimal_Call{Call: _e.mock.On("ToCurrencyDecimal", ctx, toCurrency, value, fromCurrency, rounding)}
+}
+
+func (_c *Service_ToCurrencyDecimal_Call) Run(run func(ctx context.Context, toCurrency string, value decimal.Decimal, fromCurrency string, rounding currency.Rounding)) *Service_ToCurrencyDecimal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(decimal.Decimal), args[3].(string), args[4].(currency.Rounding))
+	})
+	return _c
+}
+
+func (_c *Service_ToCurrencyDecimal_Call) Return(_a0 decimal.Decimal, _a1 error) *Service_ToCurrencyDecimal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_ToCurrencyDecimal_Call) RunAndReturn(run func(context.Context, string, decimal.Decimal, string, currency.Rounding) (decimal.Decimal, error)) *Service_ToCurrencyDecimal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ToCurrencyDecimalAtDate provides a mock function with given fields: ctx, toCurrency, value, fromCurrency, date, rounding
+func (_m *Service) ToCurrencyDecimalAtDate(ctx context.Context, toCurrency string, value decimal.Decimal, fromCurrency string, date time.Time, rounding currency.Rounding) (decimal.Decimal, error) {
+	ret := _m.Called(ctx, toCurrency, value, fromCurrency, date, rounding)
+
+	var r0 decimal.Decimal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, decimal.Decimal, string, time.Time, currency.Rounding) (decimal.Decimal, error)); ok {
+		return rf(ctx, toCurrency, value, fromCurrency, date, rounding)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, decimal.Decimal, string, time.Time, currency.Rounding) decimal.Decimal); ok {
+		r0 = rf(ctx, toCurrency, value, fromCurrency, date, rounding)
+	} else {
+		r0 = ret.Get(0).(decimal.Decimal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, decimal.Decimal, string, time.Time, currency.Rounding) error); ok {
+		r1 = rf(ctx, toCurrency, value, fromCurrency, date, rounding)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ToCurrencyDecimalAtDate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToCurrencyDecimalAtDate'
+type Service_ToCurrencyDecimalAtDate_Call struct {
+	*mock.Call
+}
+
+// ToCurrencyDecimalAtDate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - toCurrency string
+//   - value decimal.Decimal
+//   - fromCurrency string
+//   - date time.Time
+//   - rounding currency.Rounding
+func (_e *Service_Expecter) ToCurrencyDecimalAtDate(ctx interface{}, toCurrency interface{}, value interface{}, fromCurrency interface{}, date interface{}, rounding interface{}) *Service_ToCurrencyDecimalAtDate_Call {
+	return &Service_ToCurrencyDecimalAtDate_Call{Call: _e.mock.On("ToCurrencyDecimalAtDate", ctx, toCurrency, value, fromCurrency, date, rounding)}
+}
+
+func (_c *Service_ToCurrencyDecimalAtDate_Call) Run(run func(ctx context.Context, toCurrency string, value decimal.Decimal, fromCurrency string, date time.Time, rounding currency.Rounding)) *Service_ToCurrencyDecimalAtDate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(decimal.Decimal), args[3].(string), args[4].(time.Time), args[5].(currency.Rounding))
+	})
+	return _c
+}
+
+func (_c *Service_ToCurrencyDecimalAtDate_Call) Return(_a0 decimal.Decimal, _a1 error) *Service_ToCurrencyDecimalAtDate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_ToCurrencyDecimalAtDate_Call) RunAndReturn(run func(context.Context, string, decimal.Decimal, string, time.Time, currency.Rounding) (decimal.Decimal, error)) *Service_ToCurrencyDecimalAtDate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ToEur provides a mock function with given fields: ctx, value, fromCurrency
 func (_m *Service) ToEur(ctx context.Context, value float64, fromCurrency string) (float64, error) {
 	ret := _m.Called(ctx, value, fromCurrency)
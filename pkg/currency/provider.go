@@ -0,0 +1,115 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+const (
+	ConfigKeyProviders = "currency.providers"
+
+	ProviderEcb               = "ecb"
+	ProviderOpenExchangeRates = "open_exchange_rates"
+	ProviderStatic            = "static"
+)
+
+// RateProvider fetches exchange rates from a single source. All rates are relative to EUR, providers with a
+// different base currency have to convert them.
+//
+//go:generate mockery --name RateProvider
+type RateProvider interface {
+	Name() string
+	GetLatestRates(ctx context.Context) ([]Rate, error)
+	GetHistoricalRates(ctx context.Context) ([]Content, error)
+}
+
+type RateProviderFactory func(ctx context.Context, config cfg.Config, logger log.Logger) (RateProvider, error)
+
+var rateProviderFactories = map[string]RateProviderFactory{
+	ProviderEcb:               NewEcbRateProvider,
+	ProviderOpenExchangeRates: NewOpenExchangeRatesProvider,
+	ProviderStatic:            NewStaticRateProvider,
+}
+
+// AddRateProvider registers a custom provider which can be used in the currency.providers list.
+func AddRateProvider(name string, factory RateProviderFactory) {
+	rateProviderFactories[name] = factory
+}
+
+// NewRateProviders creates the providers configured at currency.providers in the configured order. The ECB is used if
+// nothing is configured.
+func NewRateProviders(ctx context.Context, config cfg.Config, logger log.Logger) ([]RateProvider, error) {
+	var ok bool
+	var err error
+	var factory RateProviderFactory
+
+	names := config.GetStringSlice(ConfigKeyProviders, []string{ProviderEcb})
+	providers := make([]RateProvider, len(names))
+
+	for i, name := range names {
+		if factory, ok = rateProviderFactories[name]; !ok {
+			return nil, fmt.Errorf("there is no currency rate provider named %s", name)
+		}
+
+		if providers[i], err = factory(ctx, config, logger); err != nil {
+			return nil, fmt.Errorf("can not create currency rate provider %s: %w", name, err)
+		}
+	}
+
+	return providers, nil
+}
+
+func providerSettingsKey(name string) string {
+	return fmt.Sprintf("currency.provider.%s", name)
+}
+
+// mergeRates combines the rates of all providers. If multiple providers know a currency, the rate of the provider
+// which comes first wins.
+func mergeRates(rateLists ...[]Rate) []Rate {
+	seen := make(map[string]struct{})
+	merged := make([]Rate, 0)
+
+	for _, rates := range rateLists {
+		for _, rate := range rates {
+			if _, ok := seen[rate.Currency]; ok {
+				continue
+			}
+
+			seen[rate.Currency] = struct{}{}
+			merged = append(merged, rate)
+		}
+	}
+
+	return merged
+}
+
+// mergeHistoricalRates combines the historical rates of all providers day by day with the same precedence as
+// mergeRates.
+func mergeHistoricalRates(contentLists ...[]Content) []Content {
+	days := make(map[string][][]Rate)
+
+	for _, contents := range contentLists {
+		for _, content := range contents {
+			days[content.Time] = append(days[content.Time], content.Rates)
+		}
+	}
+
+	merged := make([]Content, 0, len(days))
+
+	for day, rateLists := range days {
+		merged = append(merged, Content{
+			Time:  day,
+			Rates: mergeRates(rateLists...),
+		})
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Time > merged[j].Time
+	})
+
+	return merged
+}
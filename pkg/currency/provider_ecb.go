@@ -0,0 +1,80 @@
+package currency
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/http"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+type EcbRateProviderSettings struct {
+	Url           string `cfg:"url" default:"https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"`
+	HistoricalUrl string `cfg:"historical_url" default:"https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"`
+}
+
+type ecbRateProvider struct {
+	http     http.Client
+	settings *EcbRateProviderSettings
+}
+
+func NewEcbRateProvider(ctx context.Context, config cfg.Config, logger log.Logger) (RateProvider, error) {
+	settings := &EcbRateProviderSettings{}
+	config.UnmarshalKey(providerSettingsKey(ProviderEcb), settings)
+
+	httpClient, err := http.ProvideHttpClient(ctx, config, logger, "currencyUpdater")
+	if err != nil {
+		return nil, fmt.Errorf("can not create http client: %w", err)
+	}
+
+	return NewEcbRateProviderWithInterfaces(httpClient, settings), nil
+}
+
+func NewEcbRateProviderWithInterfaces(httpClient http.Client, settings *EcbRateProviderSettings) RateProvider {
+	return &ecbRateProvider{
+		http:     httpClient,
+		settings: settings,
+	}
+}
+
+func (p *ecbRateProvider) Name() string {
+	return ProviderEcb
+}
+
+func (p *ecbRateProvider) GetLatestRates(ctx context.Context) ([]Rate, error) {
+	request := p.http.NewRequest().WithUrl(p.settings.Url)
+
+	response, err := p.http.Get(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting exchange rates: %w", err)
+	}
+
+	exchangeRateResult := ExchangeResponse{}
+	err = xml.Unmarshal(response.Body, &exchangeRateResult)
+
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling exchange rates: %w", err)
+	}
+
+	return exchangeRateResult.Body.Content.Rates, nil
+}
+
+func (p *ecbRateProvider) GetHistoricalRates(ctx context.Context) ([]Content, error) {
+	request := p.http.NewRequest().WithUrl(p.settings.HistoricalUrl)
+
+	response, err := p.http.Get(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting historical exchange rates: %w", err)
+	}
+
+	exchangeRateResult := HistoricalExchangeResponse{}
+	err = xml.Unmarshal(response.Body, &exchangeRateResult)
+
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling historical exchange rates: %w", err)
+	}
+
+	return exchangeRateResult.Body.Content, nil
+}
@@ -0,0 +1,151 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/http"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/shopspring/decimal"
+)
+
+// rebased rates keep enough digits for crypto currencies with tiny rates
+const openExchangeRatesPrecision = 24
+
+type OpenExchangeRatesSettings struct {
+	Url   string `cfg:"url" default:"https://openexchangerates.org/api"`
+	AppId string `cfg:"app_id" validate:"required"`
+	// HistoricalDays is the number of days including today for which historical rates are requested, every day is a
+	// separate request
+	HistoricalDays int `cfg:"historical_days" default:"7" validate:"min=1"`
+}
+
+type OpenExchangeRatesResponse struct {
+	Timestamp int64                      `json:"timestamp"`
+	Base      string                     `json:"base"`
+	Rates     map[string]decimal.Decimal `json:"rates"`
+}
+
+type openExchangeRatesProvider struct {
+	http     http.Client
+	clock    clock.Clock
+	settings *OpenExchangeRatesSettings
+}
+
+// NewOpenExchangeRatesProvider reads the rates from an api compatible with openexchangerates.org, which also provides
+// rates for many non ECB and crypto currencies.
+func NewOpenExchangeRatesProvider(ctx context.Context, config cfg.Config, logger log.Logger) (RateProvider, error) {
+	settings := &OpenExchangeRatesSettings{}
+	config.UnmarshalKey(providerSettingsKey(ProviderOpenExchangeRates), settings)
+
+	httpClient, err := http.ProvideHttpClient(ctx, config, logger, "currencyUpdater")
+	if err != nil {
+		return nil, fmt.Errorf("can not create http client: %w", err)
+	}
+
+	return NewOpenExchangeRatesProviderWithInterfaces(httpClient, clock.Provider, settings), nil
+}
+
+func NewOpenExchangeRatesProviderWithInterfaces(httpClient http.Client, clock clock.Clock, settings *OpenExchangeRatesSettings) RateProvider {
+	return &openExchangeRatesProvider{
+		http:     httpClient,
+		clock:    clock,
+		settings: settings,
+	}
+}
+
+func (p *openExchangeRatesProvider) Name() string {
+	return ProviderOpenExchangeRates
+}
+
+func (p *openExchangeRatesProvider) GetLatestRates(ctx context.Context) ([]Rate, error) {
+	return p.fetch(ctx, "latest.json")
+}
+
+func (p *openExchangeRatesProvider) GetHistoricalRates(ctx context.Context) ([]Content, error) {
+	contents := make([]Content, 0, p.settings.HistoricalDays)
+	today := p.clock.Now().UTC()
+
+	for i := 0; i < p.settings.HistoricalDays; i++ {
+		day := today.AddDate(0, 0, -i).Format(YMDLayout)
+
+		rates, err := p.fetch(ctx, fmt.Sprintf("historical/%s.json", day))
+		if err != nil {
+			return nil, fmt.Errorf("can not get historical exchange rates of %s: %w", day, err)
+		}
+
+		contents = append(contents, Content{
+			Time:  day,
+			Rates: rates,
+		})
+	}
+
+	return contents, nil
+}
+
+func (p *openExchangeRatesProvider) fetch(ctx context.Context, path string) ([]Rate, error) {
+	request := p.http.NewRequest().
+		WithUrl(fmt.Sprintf("%s/%s", strings.TrimSuffix(p.settings.Url, "/"), path)).
+		WithQueryParam("app_id", p.settings.AppId)
+
+	response, err := p.http.Get(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting exchange rates: %w", err)
+	}
+
+	if response.StatusCode >= 300 {
+		return nil, fmt.Errorf("error requesting exchange rates: unexpected status code %d", response.StatusCode)
+	}
+
+	result := OpenExchangeRatesResponse{}
+	if err = json.Unmarshal(response.Body, &result); err != nil {
+		return nil, fmt.Errorf("error unmarshalling exchange rates: %w", err)
+	}
+
+	return rebaseToEur(result.Base, result.Rates)
+}
+
+// rebaseToEur converts rates relative to the base currency into rates relative to EUR.
+func rebaseToEur(base string, rates map[string]decimal.Decimal) ([]Rate, error) {
+	base = strings.ToUpper(base)
+	eurRate := decimal.NewFromInt(1)
+
+	if base != Eur {
+		var ok bool
+
+		if eurRate, ok = rates[Eur]; !ok || eurRate.IsZero() {
+			return nil, fmt.Errorf("the rates with base %s contain no rate for %s", base, Eur)
+		}
+	}
+
+	result := make([]Rate, 0, len(rates))
+
+	for currency, rate := range rates {
+		currency = strings.ToUpper(currency)
+
+		if currency == Eur {
+			continue
+		}
+
+		result = append(result, Rate{
+			Currency: currency,
+			Rate:     rate.DivRound(eurRate, openExchangeRatesPrecision),
+		})
+	}
+
+	// the base currency itself usually isn't part of the rates
+	if _, ok := rates[base]; !ok && base != Eur {
+		result = append(result, Rate{
+			Currency: base,
+			Rate:     decimal.NewFromInt(1).DivRound(eurRate, openExchangeRatesPrecision),
+		})
+	}
+
+	sortRates(result)
+
+	return result, nil
+}
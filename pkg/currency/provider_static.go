@@ -0,0 +1,97 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/shopspring/decimal"
+)
+
+type StaticRateProviderSettings struct {
+	Path string `cfg:"path" validate:"required"`
+}
+
+// StaticRates is the content of the file of the static provider, all rates are relative to EUR.
+type StaticRates struct {
+	Latest     map[string]decimal.Decimal            `json:"latest"`
+	Historical map[string]map[string]decimal.Decimal `json:"historical"`
+}
+
+type staticRateProvider struct {
+	rates StaticRates
+}
+
+// NewStaticRateProvider reads the rates from a json file, which is mostly useful for tests and local development.
+func NewStaticRateProvider(_ context.Context, config cfg.Config, _ log.Logger) (RateProvider, error) {
+	settings := &StaticRateProviderSettings{}
+	config.UnmarshalKey(providerSettingsKey(ProviderStatic), settings)
+
+	body, err := os.ReadFile(settings.Path)
+	if err != nil {
+		return nil, fmt.Errorf("can not read static rates from %s: %w", settings.Path, err)
+	}
+
+	rates := StaticRates{}
+	if err = json.Unmarshal(body, &rates); err != nil {
+		return nil, fmt.Errorf("can not unmarshal static rates from %s: %w", settings.Path, err)
+	}
+
+	return NewStaticRateProviderWithInterfaces(rates), nil
+}
+
+func NewStaticRateProviderWithInterfaces(rates StaticRates) RateProvider {
+	return &staticRateProvider{
+		rates: rates,
+	}
+}
+
+func (p *staticRateProvider) Name() string {
+	return ProviderStatic
+}
+
+func (p *staticRateProvider) GetLatestRates(_ context.Context) ([]Rate, error) {
+	return toRates(p.rates.Latest), nil
+}
+
+func (p *staticRateProvider) GetHistoricalRates(_ context.Context) ([]Content, error) {
+	contents := make([]Content, 0, len(p.rates.Historical))
+
+	for day, rates := range p.rates.Historical {
+		contents = append(contents, Content{
+			Time:  day,
+			Rates: toRates(rates),
+		})
+	}
+
+	sort.Slice(contents, func(i, j int) bool {
+		return contents[i].Time > contents[j].Time
+	})
+
+	return contents, nil
+}
+
+func toRates(values map[string]decimal.Decimal) []Rate {
+	rates := make([]Rate, 0, len(values))
+
+	for currency, rate := range values {
+		rates = append(rates, Rate{
+			Currency: currency,
+			Rate:     rate,
+		})
+	}
+
+	sortRates(rates)
+
+	return rates
+}
+
+func sortRates(rates []Rate) {
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Currency < rates[j].Currency
+	})
+}
@@ -0,0 +1,230 @@
+package currency_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/currency"
+	currencyMocks "github.com/justtrackio/gosoline/pkg/currency/mocks"
+	"github.com/justtrackio/gosoline/pkg/http"
+	httpMock "github.com/justtrackio/gosoline/pkg/http/mocks"
+	kvStoreMock "github.com/justtrackio/gosoline/pkg/kvstore/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const openExchangeRatesResponse = `{
+	"timestamp": 1622073600,
+	"base": "USD",
+	"rates": {
+		"EUR": 0.8,
+		"GBP": 0.7,
+		"BTC": 0.00002
+	}
+}`
+
+type providerTestSuite struct {
+	suite.Suite
+	ctx context.Context
+
+	client *httpMock.Client
+	store  *kvStoreMock.KvStore[currency.StoredValue]
+	clock  clock.FakeClock
+}
+
+func TestProviders(t *testing.T) {
+	suite.Run(t, new(providerTestSuite))
+}
+
+func (s *providerTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.client = httpMock.NewClient(s.T())
+	s.store = kvStoreMock.NewKvStore[currency.StoredValue](s.T())
+	s.clock = clock.NewFakeClockAt(time.Date(2021, 5, 27, 10, 0, 0, 0, time.UTC))
+}
+
+func (s *providerTestSuite) TestOpenExchangeRatesLatest() {
+	s.client.EXPECT().NewRequest().Return(http.NewRequest(nil)).Once()
+	s.client.EXPECT().Get(s.ctx, mock.MatchedBy(func(request *http.Request) bool {
+		return request.GetUrl() == "https://openexchangerates.org/api/latest.json?app_id=secret"
+	})).Return(&http.Response{StatusCode: 200, Body: []byte(openExchangeRatesResponse)}, nil).Once()
+
+	provider := currency.NewOpenExchangeRatesProviderWithInterfaces(s.client, s.clock, &currency.OpenExchangeRatesSettings{
+		Url:            "https://openexchangerates.org/api/",
+		AppId:          "secret",
+		HistoricalDays: 1,
+	})
+
+	rates, err := provider.GetLatestRates(s.ctx)
+	s.NoError(err)
+	s.Equal([]string{"BTC 0.000025", "GBP 0.875", "USD 1.25"}, formatRates(rates))
+}
+
+func (s *providerTestSuite) TestOpenExchangeRatesKeepsPrecision() {
+	s.client.EXPECT().NewRequest().Return(http.NewRequest(nil)).Once()
+	s.client.EXPECT().Get(s.ctx, mock.AnythingOfType("*http.Request")).Return(&http.Response{StatusCode: 200, Body: []byte(`{"base": "USD", "rates": {"EUR": 0.9, "GBP": 0.7}}`)}, nil).Once()
+
+	provider := currency.NewOpenExchangeRatesProviderWithInterfaces(s.client, s.clock, &currency.OpenExchangeRatesSettings{
+		AppId: "secret",
+	})
+
+	rates, err := provider.GetLatestRates(s.ctx)
+	s.NoError(err)
+	s.Equal([]string{"GBP 0.777777777777777777777778", "USD 1.111111111111111111111111"}, formatRates(rates))
+}
+
+func (s *providerTestSuite) TestOpenExchangeRatesHistorical() {
+	s.client.EXPECT().NewRequest().RunAndReturn(func() *http.Request {
+		return http.NewRequest(nil)
+	}).Twice()
+	s.client.EXPECT().Get(s.ctx, mock.MatchedBy(func(request *http.Request) bool {
+		return request.GetUrl() == "https://openexchangerates.org/api/historical/2021-05-27.json?app_id=secret"
+	})).Return(&http.Response{StatusCode: 200, Body: []byte(openExchangeRatesResponse)}, nil).Once()
+	s.client.EXPECT().Get(s.ctx, mock.MatchedBy(func(request *http.Request) bool {
+		return request.GetUrl() == "https://openexchangerates.org/api/historical/2021-05-26.json?app_id=secret"
+	})).Return(&http.Response{StatusCode: 200, Body: []byte(`{"base": "EUR", "rates": {"USD": 1.2}}`)}, nil).Once()
+
+	provider := currency.NewOpenExchangeRatesProviderWithInterfaces(s.client, s.clock, &currency.OpenExchangeRatesSettings{
+		Url:            "https://openexchangerates.org/api",
+		AppId:          "secret",
+		HistoricalDays: 2,
+	})
+
+	contents, err := provider.GetHistoricalRates(s.ctx)
+	s.NoError(err)
+	s.Require().Len(contents, 2)
+	s.Equal("2021-05-27", contents[0].Time)
+	s.Equal([]string{"BTC 0.000025", "GBP 0.875", "USD 1.25"}, formatRates(contents[0].Rates))
+	s.Equal("2021-05-26", contents[1].Time)
+	s.Equal([]string{"USD 1.2"}, formatRates(contents[1].Rates))
+}
+
+func (s *providerTestSuite) TestOpenExchangeRatesWithoutEur() {
+	s.client.EXPECT().NewRequest().Return(http.NewRequest(nil)).Once()
+	s.client.EXPECT().Get(s.ctx, mock.AnythingOfType("*http.Request")).Return(&http.Response{StatusCode: 200, Body: []byte(`{"base": "USD", "rates": {"GBP": 0.7}}`)}, nil).Once()
+
+	provider := currency.NewOpenExchangeRatesProviderWithInterfaces(s.client, s.clock, &currency.OpenExchangeRatesSettings{
+		AppId: "secret",
+	})
+
+	_, err := provider.GetLatestRates(s.ctx)
+	s.EqualError(err, "the rates with base USD contain no rate for EUR")
+}
+
+func (s *providerTestSuite) TestStaticProvider() {
+	provider := currency.NewStaticRateProviderWithInterfaces(currency.StaticRates{
+		Latest: map[string]decimal.Decimal{
+			"USD": decimal.RequireFromString("1.2"),
+			"BTC": decimal.RequireFromString("0.00003"),
+		},
+		Historical: map[string]map[string]decimal.Decimal{
+			"2021-05-25": {"USD": decimal.RequireFromString("1.1")},
+			"2021-05-26": {"USD": decimal.RequireFromString("1.15")},
+		},
+	})
+
+	rates, err := provider.GetLatestRates(s.ctx)
+	s.NoError(err)
+	s.Equal([]currency.Rate{{Currency: "BTC", Rate: decimal.RequireFromString("0.00003")}, {Currency: "USD", Rate: decimal.RequireFromString("1.2")}}, rates)
+
+	contents, err := provider.GetHistoricalRates(s.ctx)
+	s.NoError(err)
+	s.Equal([]currency.Content{
+		{Time: "2021-05-26", Rates: []currency.Rate{{Currency: "USD", Rate: decimal.RequireFromString("1.15")}}},
+		{Time: "2021-05-25", Rates: []currency.Rate{{Currency: "USD", Rate: decimal.RequireFromString("1.1")}}},
+	}, contents)
+}
+
+func (s *providerTestSuite) TestUpdaterMergesProvidersInOrder() {
+	first := currencyMocks.NewRateProvider(s.T())
+	first.EXPECT().GetLatestRates(s.ctx).Return([]currency.Rate{{Currency: "USD", Rate: decimal.RequireFromString("1.2")}}, nil).Once()
+
+	second := currencyMocks.NewRateProvider(s.T())
+	second.EXPECT().GetLatestRates(s.ctx).Return([]currency.Rate{{Currency: "USD", Rate: decimal.RequireFromString("1.3")}, {Currency: "BTC", Rate: decimal.RequireFromString("0.00003")}}, nil).Once()
+
+	s.store.EXPECT().Get(s.ctx, currency.ExchangeRateDateKey, new(currency.StoredValue)).Return(false, nil).Once()
+	s.store.EXPECT().Put(s.ctx, "USD", currency.NewStoredValue(decimal.RequireFromString("1.2"))).Return(nil).Once()
+	s.store.EXPECT().Put(s.ctx, "2021-05-27-USD", currency.NewStoredValue(decimal.RequireFromString("1.2"))).Return(nil).Once()
+	s.store.EXPECT().Put(s.ctx, "BTC", currency.NewStoredValue(decimal.RequireFromString("0.00003"))).Return(nil).Once()
+	s.store.EXPECT().Put(s.ctx, "2021-05-27-BTC", currency.NewStoredValue(decimal.RequireFromString("0.00003"))).Return(nil).Once()
+	s.store.EXPECT().Put(s.ctx, currency.ExchangeRateDateKey, currency.NewStoredValue(decimal.NewFromInt(s.clock.Now().Unix()))).Return(nil).Once()
+
+	updater := currency.NewUpdaterWithInterfaces(logMocks.NewLoggerMockedAll(), s.store, s.clock, first, second)
+
+	err := updater.EnsureRecentExchangeRates(s.ctx)
+	s.NoError(err)
+}
+
+func (s *providerTestSuite) TestUpdaterFallsBackToNextProvider() {
+	first := currencyMocks.NewRateProvider(s.T())
+	first.EXPECT().Name().Return("first")
+	first.EXPECT().GetLatestRates(s.ctx).Return(nil, fmt.Errorf("timeout")).Once()
+
+	second := currencyMocks.NewRateProvider(s.T())
+	second.EXPECT().GetLatestRates(s.ctx).Return([]currency.Rate{{Currency: "USD", Rate: decimal.RequireFromString("1.3")}}, nil).Once()
+
+	s.store.EXPECT().Get(s.ctx, currency.ExchangeRateDateKey, new(currency.StoredValue)).Return(false, nil).Once()
+	s.store.EXPECT().Put(s.ctx, "USD", currency.NewStoredValue(decimal.RequireFromString("1.3"))).Return(nil).Once()
+	s.store.EXPECT().Put(s.ctx, "2021-05-27-USD", currency.NewStoredValue(decimal.RequireFromString("1.3"))).Return(nil).Once()
+	s.store.EXPECT().Put(s.ctx, currency.ExchangeRateDateKey, currency.NewStoredValue(decimal.NewFromInt(s.clock.Now().Unix()))).Return(nil).Once()
+
+	updater := currency.NewUpdaterWithInterfaces(logMocks.NewLoggerMockedAll(), s.store, s.clock, first, second)
+
+	err := updater.EnsureRecentExchangeRates(s.ctx)
+	s.NoError(err)
+}
+
+func (s *providerTestSuite) TestUpdaterFailsIfAllProvidersFail() {
+	first := currencyMocks.NewRateProvider(s.T())
+	first.EXPECT().Name().Return("first")
+	first.EXPECT().GetHistoricalRates(s.ctx).Return(nil, fmt.Errorf("timeout")).Once()
+
+	s.store.EXPECT().Get(s.ctx, currency.HistoricalExchangeRateDateKey, new(currency.StoredValue)).Return(false, nil).Once()
+
+	updater := currency.NewUpdaterWithInterfaces(logMocks.NewLoggerMockedAll(), s.store, s.clock, first)
+
+	err := updater.EnsureHistoricalExchangeRates(s.ctx)
+	s.ErrorContains(err, "error getting historical currency exchange rates: all providers failed")
+	s.ErrorContains(err, "provider first: timeout")
+}
+
+func (s *providerTestSuite) TestUpdaterMergesHistoricalRates() {
+	first := currencyMocks.NewRateProvider(s.T())
+	first.EXPECT().GetHistoricalRates(s.ctx).Return([]currency.Content{
+		{Time: "2021-05-27", Rates: []currency.Rate{{Currency: "USD", Rate: decimal.RequireFromString("1.2")}}},
+		{Time: "2021-05-26", Rates: []currency.Rate{{Currency: "USD", Rate: decimal.RequireFromString("1.1")}}},
+	}, nil).Once()
+
+	second := currencyMocks.NewRateProvider(s.T())
+	second.EXPECT().GetHistoricalRates(s.ctx).Return([]currency.Content{
+		{Time: "2021-05-27", Rates: []currency.Rate{{Currency: "USD", Rate: decimal.RequireFromString("1.3")}, {Currency: "BTC", Rate: decimal.RequireFromString("0.00003")}}},
+	}, nil).Once()
+
+	s.store.EXPECT().Get(s.ctx, currency.HistoricalExchangeRateDateKey, new(currency.StoredValue)).Return(false, nil).Once()
+	s.store.EXPECT().PutBatch(s.ctx, map[string]currency.StoredValue{
+		"2021-05-27-USD": currency.NewStoredValue(decimal.RequireFromString("1.2")),
+		"2021-05-27-BTC": currency.NewStoredValue(decimal.RequireFromString("0.00003")),
+		"2021-05-26-USD": currency.NewStoredValue(decimal.RequireFromString("1.1")),
+	}).Return(nil).Once()
+	s.store.EXPECT().Put(s.ctx, currency.HistoricalExchangeRateDateKey, currency.NewStoredValue(decimal.NewFromInt(s.clock.Now().Unix()))).Return(nil).Once()
+
+	updater := currency.NewUpdaterWithInterfaces(logMocks.NewLoggerMockedAll(), s.store, s.clock, first, second)
+
+	err := updater.EnsureHistoricalExchangeRates(s.ctx)
+	s.NoError(err)
+}
+
+func formatRates(rates []currency.Rate) []string {
+	formatted := make([]string, 0, len(rates))
+
+	for _, rate := range rates {
+		formatted = append(formatted, fmt.Sprintf("%s %s", rate.Currency, rate.Rate.String()))
+	}
+
+	return formatted
+}
@@ -0,0 +1,61 @@
+package currency
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// divisionPrecision is the number of decimal places kept while dividing by an exchange rate before the result is
+// rounded as requested.
+const divisionPrecision = 32
+
+type RoundingMode int
+
+const (
+	// RoundingHalfUp rounds to the nearest value and away from zero on a tie, e.g. 2.5 -> 3 and -2.5 -> -3
+	RoundingHalfUp RoundingMode = iota
+	// RoundingHalfEven rounds to the nearest value and to the even value on a tie, e.g. 2.5 -> 2 and 3.5 -> 4
+	RoundingHalfEven
+	// RoundingDown rounds towards zero, e.g. 2.9 -> 2 and -2.9 -> -2
+	RoundingDown
+	// RoundingUp rounds away from zero, e.g. 2.1 -> 3 and -2.1 -> -3
+	RoundingUp
+	// RoundingNone keeps all places of the result
+	RoundingNone
+)
+
+// Rounding describes how the result of a conversion is rounded, e.g. to 2 places for most currencies.
+type Rounding struct {
+	Mode   RoundingMode
+	Places int32
+}
+
+func (r Rounding) Apply(value decimal.Decimal) (decimal.Decimal, error) {
+	switch r.Mode {
+	case RoundingHalfUp:
+		return value.Round(r.Places), nil
+	case RoundingHalfEven:
+		return value.RoundBank(r.Places), nil
+	case RoundingDown:
+		return value.Truncate(r.Places), nil
+	case RoundingUp:
+		truncated := value.Truncate(r.Places)
+
+		if truncated.Equal(value) {
+			return truncated, nil
+		}
+
+		step := decimal.New(1, -r.Places)
+
+		if value.Sign() < 0 {
+			return truncated.Sub(step), nil
+		}
+
+		return truncated.Add(step), nil
+	case RoundingNone:
+		return value, nil
+	default:
+		return decimal.Zero, fmt.Errorf("unknown rounding mode %d", r.Mode)
+	}
+}
@@ -0,0 +1,48 @@
+package currency_test
+
+import (
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/currency"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRounding_Apply(t *testing.T) {
+	tests := map[string]struct {
+		mode     currency.RoundingMode
+		places   int32
+		value    string
+		expected string
+	}{
+		"half_up_tie":             {mode: currency.RoundingHalfUp, places: 2, value: "2.345", expected: "2.35"},
+		"half_up_negative_tie":    {mode: currency.RoundingHalfUp, places: 2, value: "-2.345", expected: "-2.35"},
+		"half_even_tie_down":      {mode: currency.RoundingHalfEven, places: 2, value: "2.345", expected: "2.34"},
+		"half_even_tie_up":        {mode: currency.RoundingHalfEven, places: 2, value: "2.355", expected: "2.36"},
+		"down":                    {mode: currency.RoundingDown, places: 2, value: "2.349", expected: "2.34"},
+		"down_negative":           {mode: currency.RoundingDown, places: 2, value: "-2.349", expected: "-2.34"},
+		"up":                      {mode: currency.RoundingUp, places: 2, value: "2.341", expected: "2.35"},
+		"up_negative":             {mode: currency.RoundingUp, places: 2, value: "-2.341", expected: "-2.35"},
+		"up_exact":                {mode: currency.RoundingUp, places: 2, value: "2.34", expected: "2.34"},
+		"up_no_places":            {mode: currency.RoundingUp, places: 0, value: "2.01", expected: "3"},
+		"none":                    {mode: currency.RoundingNone, places: 2, value: "2.34567", expected: "2.34567"},
+		"half_up_crypto_8_places": {mode: currency.RoundingHalfUp, places: 8, value: "0.123456785", expected: "0.12345679"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rounding := currency.Rounding{Mode: test.mode, Places: test.places}
+
+			actual, err := rounding.Apply(decimal.RequireFromString(test.value))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual.String())
+		})
+	}
+}
+
+func TestRounding_ApplyUnknownMode(t *testing.T) {
+	rounding := currency.Rounding{Mode: currency.RoundingMode(42)}
+
+	_, err := rounding.Apply(decimal.NewFromInt(1))
+	assert.EqualError(t, err, "unknown rounding mode 42")
+}
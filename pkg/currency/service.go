@@ -10,6 +10,7 @@ import (
 	"github.com/justtrackio/gosoline/pkg/clock"
 	"github.com/justtrackio/gosoline/pkg/kvstore"
 	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/shopspring/decimal"
 )
 
 const (
@@ -27,15 +28,17 @@ type Service interface {
 	ToUsdAtDate(ctx context.Context, value float64, fromCurrency string, date time.Time) (float64, error)
 	ToCurrency(ctx context.Context, toCurrency string, value float64, fromCurrency string) (float64, error)
 	ToCurrencyAtDate(ctx context.Context, toCurrency string, value float64, fromCurrency string, date time.Time) (float64, error)
+	ToCurrencyDecimal(ctx context.Context, toCurrency string, value decimal.Decimal, fromCurrency string, rounding Rounding) (decimal.Decimal, error)
+	ToCurrencyDecimalAtDate(ctx context.Context, toCurrency string, value decimal.Decimal, fromCurrency string, date time.Time, rounding Rounding) (decimal.Decimal, error)
 }
 
 type currencyService struct {
-	store kvstore.KvStore[float64]
+	store kvstore.KvStore[StoredValue]
 	clock clock.Clock
 }
 
 func New(ctx context.Context, config cfg.Config, logger log.Logger) (Service, error) {
-	store, err := kvstore.ProvideConfigurableKvStore[float64](ctx, config, logger, kvStoreName)
+	store, err := kvstore.ProvideConfigurableKvStore[StoredValue](ctx, config, logger, kvStoreName)
 	if err != nil {
 		return nil, fmt.Errorf("can not create currency kvStore: %w", err)
 	}
@@ -43,7 +46,7 @@ func New(ctx context.Context, config cfg.Config, logger log.Logger) (Service, er
 	return NewWithInterfaces(store, clock.Provider), nil
 }
 
-func NewWithInterfaces(store kvstore.KvStore[float64], clock clock.Clock) Service {
+func NewWithInterfaces(store kvstore.KvStore[StoredValue], clock clock.Clock) Service {
 	return &currencyService{
 		store: store,
 		clock: clock,
@@ -95,7 +98,9 @@ func (s *currencyService) ToEur(ctx context.Context, value float64, fromCurrency
 		return 0, fmt.Errorf("CurrencyService: error parsing exchange rate for %s: %w", fromCurrency, err)
 	}
 
-	return value / exchangeRate, nil
+	rate, _ := exchangeRate.Float64()
+
+	return value / rate, nil
 }
 
 // ToEurAtDate returns the Euro value for a given value and currency at the given time.
@@ -110,7 +115,9 @@ func (s *currencyService) ToEurAtDate(ctx context.Context, value float64, fromCu
 		return 0, fmt.Errorf("CurrencyService: error parsing historic exchange rate for %s at %s: %w", fromCurrency, date.Format(YMDLayout), err)
 	}
 
-	return value / exchangeRate, nil
+	rate, _ := exchangeRate.Float64()
+
+	return value / rate, nil
 }
 
 // ToUsd returns the US dollar value for a given value and currency.
@@ -140,7 +147,9 @@ func (s *currencyService) ToCurrency(ctx context.Context, toCurrency string, val
 		return 0, fmt.Errorf("CurrencyService: error converting %s to EUR: %w", fromCurrency, err)
 	}
 
-	return eur * exchangeRate, nil
+	rate, _ := exchangeRate.Float64()
+
+	return eur * rate, nil
 }
 
 // ToCurrencyAtDate returns the value converted from one currency to another currency at the given time.
@@ -164,41 +173,96 @@ func (s *currencyService) ToCurrencyAtDate(ctx context.Context, toCurrency strin
 		return 0, fmt.Errorf("CurrencyService: error converting historic %s to EUR at %s: %w", fromCurrency, date.Format(YMDLayout), err)
 	}
 
-	return eur * exchangeRate, nil
+	rate, _ := exchangeRate.Float64()
+
+	return eur * rate, nil
+}
+
+// ToCurrencyDecimal returns the value converted from one currency to another currency using decimal math. Only the
+// result is rounded, so converting many values and summing them up afterwards is reproducible.
+func (s *currencyService) ToCurrencyDecimal(ctx context.Context, toCurrency string, value decimal.Decimal, fromCurrency string, rounding Rounding) (decimal.Decimal, error) {
+	if strings.EqualFold(fromCurrency, toCurrency) {
+		return rounding.Apply(value)
+	}
+
+	toRate, err := s.getExchangeRateToEur(ctx, toCurrency)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("CurrencyService: error parsing exchange rate for %s: %w", toCurrency, err)
+	}
+
+	fromRate, err := s.getExchangeRateToEur(ctx, fromCurrency)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("CurrencyService: error parsing exchange rate for %s: %w", fromCurrency, err)
+	}
+
+	return convertDecimal(value, fromRate, toRate, rounding)
+}
+
+// ToCurrencyDecimalAtDate returns the value converted from one currency to another currency at the given time using
+// decimal math. We might fall back to yesterday's data if today's data is not yet up to date.
+func (s *currencyService) ToCurrencyDecimalAtDate(ctx context.Context, toCurrency string, value decimal.Decimal, fromCurrency string, date time.Time, rounding Rounding) (decimal.Decimal, error) {
+	if strings.EqualFold(fromCurrency, toCurrency) {
+		return rounding.Apply(value)
+	}
+
+	if date.After(s.clock.Now().Add(maxClockSkew)) {
+		return decimal.Zero, fmt.Errorf("CurrencyService: requested date %s is in the future", date.Format(time.RFC3339))
+	}
+
+	toRate, err := s.getExchangeRateToEurAtDate(ctx, toCurrency, date)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("CurrencyService: error parsing historic exchange rate for %s at %s: %w", toCurrency, date.Format(YMDLayout), err)
+	}
+
+	fromRate, err := s.getExchangeRateToEurAtDate(ctx, fromCurrency, date)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("CurrencyService: error parsing historic exchange rate for %s at %s: %w", fromCurrency, date.Format(YMDLayout), err)
+	}
+
+	return convertDecimal(value, fromRate, toRate, rounding)
+}
+
+// convertDecimal converts the value with rates relative to EUR.
+func convertDecimal(value decimal.Decimal, fromRate decimal.Decimal, toRate decimal.Decimal, rounding Rounding) (decimal.Decimal, error) {
+	if fromRate.IsZero() {
+		return decimal.Zero, fmt.Errorf("CurrencyService: exchange rate is zero")
+	}
+
+	return rounding.Apply(value.Mul(toRate).DivRound(fromRate, divisionPrecision))
 }
 
 // getExchangeRateToEurAtDate looks up the exchange rate value for a given currency in the kvStore.
-func (s *currencyService) getExchangeRateToEur(ctx context.Context, currency string) (float64, error) {
+func (s *currencyService) getExchangeRateToEur(ctx context.Context, currency string) (decimal.Decimal, error) {
 	if strings.EqualFold(currency, Eur) {
-		return 1, nil
+		return decimal.NewFromInt(1), nil
 	}
 
-	var exchangeRate float64
+	var exchangeRate StoredValue
 	exists, err := s.store.Get(ctx, strings.ToUpper(currency), &exchangeRate)
 	if err != nil {
-		return 0, fmt.Errorf("CurrencyService: error getting exchange rate for %s: %w", currency, err)
+		return decimal.Zero, fmt.Errorf("CurrencyService: error getting exchange rate for %s: %w", currency, err)
 	}
 
 	if !exists {
-		return 0, fmt.Errorf("CurrencyService: currency %s not found", currency)
+		return decimal.Zero, fmt.Errorf("CurrencyService: currency %s not found", currency)
 	}
 
-	return exchangeRate, nil
+	return exchangeRate.Decimal, nil
 }
 
 // getExchangeRateToEurAtDate looks up the exchange rate value for a given currency at a given date in the kvStore.
 // We might fall back to yesterday's data if today's data is not yet up to date.
-func (s *currencyService) getExchangeRateToEurAtDate(ctx context.Context, currency string, date time.Time) (float64, error) {
+func (s *currencyService) getExchangeRateToEurAtDate(ctx context.Context, currency string, date time.Time) (decimal.Decimal, error) {
 	if strings.EqualFold(currency, Eur) {
-		return 1, nil
+		return decimal.NewFromInt(1), nil
 	}
 
-	var exchangeRate float64
+	var exchangeRate StoredValue
 	key := historicalRateKey(date, strings.ToUpper(currency))
 
 	exists, err := s.store.Get(ctx, key, &exchangeRate)
 	if err != nil {
-		return 0, fmt.Errorf("CurrencyService: error getting historic exchange rate for %s at %s: %w", currency, date.Format(YMDLayout), err)
+		return decimal.Zero, fmt.Errorf("CurrencyService: error getting historic exchange rate for %s at %s: %w", currency, date.Format(YMDLayout), err)
 	}
 
 	if !exists {
@@ -206,8 +270,8 @@ func (s *currencyService) getExchangeRateToEurAtDate(ctx context.Context, curren
 			return s.getExchangeRateToEurAtDate(ctx, currency, date.AddDate(0, 0, -1))
 		}
 
-		return 0, fmt.Errorf("CurrencyService: historic currency %s at %s not found", currency, date.Format(YMDLayout))
+		return decimal.Zero, fmt.Errorf("CurrencyService: historic currency %s at %s not found", currency, date.Format(YMDLayout))
 	}
 
-	return exchangeRate, nil
+	return exchangeRate.Decimal, nil
 }
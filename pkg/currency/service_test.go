@@ -8,9 +8,11 @@ import (
 
 	"github.com/justtrackio/gosoline/pkg/clock"
 	"github.com/justtrackio/gosoline/pkg/currency"
+	"github.com/justtrackio/gosoline/pkg/kvstore"
 	kvStoreMock "github.com/justtrackio/gosoline/pkg/kvstore/mocks"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
-	"github.com/justtrackio/gosoline/pkg/mdl"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -20,7 +22,7 @@ type serviceTestSuite struct {
 	ctx context.Context
 
 	logger *logMocks.Logger
-	store  *kvStoreMock.KvStore[float64]
+	store  *kvStoreMock.KvStore[currency.StoredValue]
 	clock  clock.FakeClock
 
 	service currency.Service
@@ -34,7 +36,7 @@ func (s *serviceTestSuite) SetupTest() {
 	s.ctx = context.Background()
 
 	s.logger = logMocks.NewLoggerMockedAll()
-	s.store = new(kvStoreMock.KvStore[float64])
+	s.store = new(kvStoreMock.KvStore[currency.StoredValue])
 	s.clock = clock.NewFakeClockAt(time.Date(2021, 1, 3, 4, 20, 33, 0, time.UTC))
 
 	s.service = currency.NewWithInterfaces(s.store, s.clock)
@@ -46,7 +48,7 @@ func (s *serviceTestSuite) TearDownTest() {
 }
 
 func (s *serviceTestSuite) TestToEur_Calculation() {
-	s.mockCurrencyStoreGet(currency.Usd, 1.09, true)
+	s.mockCurrencyStoreGet(currency.Usd, "1.09", true)
 
 	valueUsd := 1.09
 	valueEur := 1.0
@@ -58,7 +60,7 @@ func (s *serviceTestSuite) TestToEur_Calculation() {
 }
 
 func (s *serviceTestSuite) TestToUsd_Calculation() {
-	s.mockCurrencyStoreGet(currency.Usd, 1.09, true)
+	s.mockCurrencyStoreGet(currency.Usd, "1.09", true)
 
 	valueUsd := 1.09
 	valueEur := 1.0
@@ -106,7 +108,7 @@ func (s *serviceTestSuite) TestHasCurrencyAtDate_Error() {
 }
 
 func (s *serviceTestSuite) TestToEurAtDate_Calculation() {
-	s.mockCurrencyStoreGet("2021-01-02-USD", 1.09, true)
+	s.mockCurrencyStoreGet("2021-01-02-USD", "1.09", true)
 
 	valueUsd := 1.09
 	valueEur := 1.0
@@ -118,8 +120,8 @@ func (s *serviceTestSuite) TestToEurAtDate_Calculation() {
 }
 
 func (s *serviceTestSuite) TestToEurAtDate_FallbackToPreviousDay() {
-	s.mockCurrencyStoreGet("2021-01-03-USD", 0, false)
-	s.mockCurrencyStoreGet("2021-01-02-USD", 1.09, true)
+	s.mockCurrencyStoreGet("2021-01-03-USD", "0", false)
+	s.mockCurrencyStoreGet("2021-01-02-USD", "1.09", true)
 
 	valueUsd := 1.09
 	valueEur := 1.0
@@ -131,7 +133,7 @@ func (s *serviceTestSuite) TestToEurAtDate_FallbackToPreviousDay() {
 }
 
 func (s *serviceTestSuite) TestToEurAtDate_DontFallbackToPreviousDay() {
-	s.mockCurrencyStoreGet("2021-01-02-USD", 0, false)
+	s.mockCurrencyStoreGet("2021-01-02-USD", "0", false)
 
 	valueUsd := 1.09
 
@@ -148,7 +150,7 @@ func (s *serviceTestSuite) TestToEurAtDate_DateInFuture() {
 }
 
 func (s *serviceTestSuite) TestToUsdAtDate_Calculation() {
-	s.mockCurrencyStoreGet("2021-01-02-USD", 1.09, true)
+	s.mockCurrencyStoreGet("2021-01-02-USD", "1.09", true)
 
 	valueUsd := 1.09
 	valueEur := 1.0
@@ -160,8 +162,8 @@ func (s *serviceTestSuite) TestToUsdAtDate_Calculation() {
 }
 
 func (s *serviceTestSuite) TestToUsdAtDate_FallbackToPreviousDay() {
-	s.mockCurrencyStoreGet("2021-01-03-USD", 0, false)
-	s.mockCurrencyStoreGet("2021-01-02-USD", 1.09, true)
+	s.mockCurrencyStoreGet("2021-01-03-USD", "0", false)
+	s.mockCurrencyStoreGet("2021-01-02-USD", "1.09", true)
 
 	valueUsd := 1.09
 	valueEur := 1.0
@@ -173,7 +175,7 @@ func (s *serviceTestSuite) TestToUsdAtDate_FallbackToPreviousDay() {
 }
 
 func (s *serviceTestSuite) TestToUsdAtDate_ClockSkew() {
-	s.mockCurrencyStoreGet("2021-01-03-USD", 2, true)
+	s.mockCurrencyStoreGet("2021-01-03-USD", "2", true)
 
 	got, err := s.service.ToUsdAtDate(s.ctx, 3.5, currency.Usd, s.clock.Now().Add(26*time.Hour))
 	s.NoError(err)
@@ -188,9 +190,78 @@ func (s *serviceTestSuite) TestToUsdAtDate_ClockSkew() {
 	s.Equal(0.0, got)
 }
 
-func (s *serviceTestSuite) mockCurrencyStoreGet(key string, value float64, found bool) {
-	s.store.On("Get", s.ctx, key, mdl.Box(0.0)).Run(func(args mock.Arguments) {
-		f := args.Get(2).(*float64)
-		*f = value
+func (s *serviceTestSuite) TestToCurrencyDecimal_Calculation() {
+	s.mockCurrencyStoreGet(currency.Usd, "1.0847", true)
+	s.mockCurrencyStoreGet("GBP", "0.85473", true)
+
+	value := decimal.RequireFromString("1234.56")
+
+	converted, err := s.service.ToCurrencyDecimal(s.ctx, "GBP", value, currency.Usd, currency.Rounding{Mode: currency.RoundingNone})
+	s.NoError(err)
+	s.Equal("972.81780105098183829630312528809809", converted.String())
+}
+
+func (s *serviceTestSuite) TestToCurrencyDecimal_KeepsRatePrecision() {
+	// the rate has more significant digits than a float64 can hold
+	s.mockCurrencyStoreGet(currency.Usd, "1.12345678901234567891", true)
+
+	converted, err := s.service.ToCurrencyDecimal(s.ctx, currency.Usd, decimal.RequireFromString("100000000000000000000"), currency.Eur, currency.Rounding{Mode: currency.RoundingNone})
+	s.NoError(err)
+	s.Equal("112345678901234567891", converted.String())
+}
+
+func (s *serviceTestSuite) TestToCurrencyDecimal_Rounding() {
+	s.mockCurrencyStoreGet(currency.Usd, "1.0847", true)
+
+	converted, err := s.service.ToCurrencyDecimal(s.ctx, currency.Eur, decimal.RequireFromString("10.00"), currency.Usd, currency.Rounding{Mode: currency.RoundingHalfEven, Places: 2})
+	s.NoError(err)
+	s.Equal("9.22", converted.String())
+}
+
+func (s *serviceTestSuite) TestToCurrencyDecimal_SameCurrency() {
+	converted, err := s.service.ToCurrencyDecimal(s.ctx, currency.Usd, decimal.RequireFromString("10.005"), currency.Usd, currency.Rounding{Mode: currency.RoundingDown, Places: 2})
+	s.NoError(err)
+	s.Equal("10", converted.String())
+}
+
+func (s *serviceTestSuite) TestToCurrencyDecimalAtDate_FallbackToPreviousDay() {
+	s.mockCurrencyStoreGet("2021-01-03-BTC", "0", false)
+	s.mockCurrencyStoreGet("2021-01-02-BTC", "0.0000352", true)
+
+	converted, err := s.service.ToCurrencyDecimalAtDate(s.ctx, currency.Eur, decimal.RequireFromString("0.5"), "BTC", s.clock.Now(), currency.Rounding{Mode: currency.RoundingHalfUp, Places: 2})
+	s.NoError(err)
+	s.Equal("14204.55", converted.String())
+}
+
+func (s *serviceTestSuite) TestToCurrencyDecimalAtDate_Future() {
+	_, err := s.service.ToCurrencyDecimalAtDate(s.ctx, currency.Eur, decimal.NewFromInt(1), currency.Usd, s.clock.Now().Add(time.Hour), currency.Rounding{})
+	s.EqualError(err, "CurrencyService: requested date 2021-01-03T05:20:33Z is in the future")
+}
+
+func (s *serviceTestSuite) mockCurrencyStoreGet(key string, value string, found bool) {
+	s.store.On("Get", s.ctx, key, new(currency.StoredValue)).Run(func(args mock.Arguments) {
+		d := args.Get(2).(*currency.StoredValue)
+		*d = currency.NewStoredValue(decimal.RequireFromString(value))
 	}).Return(found, nil).Once()
 }
+
+func TestStoredValueJson(t *testing.T) {
+	value := currency.NewStoredValue(decimal.RequireFromString("1.08230000000000000001"))
+
+	bytes, err := kvstore.Marshal(value)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.08230000000000000001", string(bytes), "the value has to be stored as a json number")
+
+	// services reading the rates as float64 have to be able to share the store
+	var float float64
+	assert.NoError(t, kvstore.Unmarshal(bytes, &float))
+	assert.Equal(t, 1.0823, float)
+
+	var read currency.StoredValue
+	assert.NoError(t, kvstore.Unmarshal(bytes, &read))
+	assert.Equal(t, "1.08230000000000000001", read.String())
+
+	// values written by services storing float64 rates are readable as well
+	assert.NoError(t, kvstore.Unmarshal([]byte("1.25"), &read))
+	assert.Equal(t, "1.25", read.String())
+}
@@ -1,12 +1,30 @@
 package currency
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type Currency string
 
+// StoredValue is a decimal which is written to the kvstore as a plain json number instead of a quoted string. This way
+// services still reading the rates of the store as float64 keep working.
+type StoredValue struct {
+	decimal.Decimal
+}
+
+func NewStoredValue(value decimal.Decimal) StoredValue {
+	return StoredValue{Decimal: value}
+}
+
+func (v StoredValue) MarshalJSON() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
 type Rate struct {
-	Currency string  `xml:"currency,attr"`
-	Rate     float64 `xml:"rate,attr"`
+	Currency string          `xml:"currency,attr"`
+	Rate     decimal.Decimal `xml:"rate,attr"`
 }
 
 type Content struct {
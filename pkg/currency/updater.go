@@ -2,19 +2,20 @@ package currency
 
 import (
 	"context"
-	"encoding/xml"
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/clock"
-	"github.com/justtrackio/gosoline/pkg/http"
 	"github.com/justtrackio/gosoline/pkg/kvstore"
 	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/shopspring/decimal"
 )
 
 const (
-	ExchangeRateRefresh           = 8 * time.Hour
+	ExchangeRateRefresh = 8 * time.Hour
+	// ExchangeRateUrl and HistoricalExchangeRateUrl are the defaults of the ECB provider
 	ExchangeRateUrl               = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
 	HistoricalExchangeRateUrl     = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"
 	ExchangeRateDateKey           = "currency_exchange_last_refresh"
@@ -30,34 +31,37 @@ type UpdaterService interface {
 }
 
 type updaterService struct {
-	logger log.Logger
-	http   http.Client
-	store  kvstore.KvStore[float64]
-	clock  clock.Clock
+	logger    log.Logger
+	store     kvstore.KvStore[StoredValue]
+	clock     clock.Clock
+	providers []RateProvider
 }
 
 func NewUpdater(ctx context.Context, config cfg.Config, logger log.Logger) (UpdaterService, error) {
 	logger = logger.WithChannel("currency_updater_service")
 
-	store, err := kvstore.ProvideConfigurableKvStore[float64](ctx, config, logger, kvStoreName)
+	store, err := kvstore.ProvideConfigurableKvStore[StoredValue](ctx, config, logger, kvStoreName)
 	if err != nil {
 		return nil, fmt.Errorf("can not create kvStore: %w", err)
 	}
 
-	httpClient, err := http.ProvideHttpClient(ctx, config, logger, "currencyUpdater")
+	providers, err := NewRateProviders(ctx, config, logger)
 	if err != nil {
-		return nil, fmt.Errorf("can not create http client: %w", err)
+		return nil, fmt.Errorf("can not create rate providers: %w", err)
 	}
 
-	return NewUpdaterWithInterfaces(logger, store, httpClient, clock.Provider), nil
+	return NewUpdaterWithInterfaces(logger, store, clock.Provider, providers...), nil
 }
 
-func NewUpdaterWithInterfaces(logger log.Logger, store kvstore.KvStore[float64], httpClient http.Client, clock clock.Clock) UpdaterService {
+// NewUpdaterWithInterfaces creates an updater which merges the rates of all providers. If multiple providers know a
+// currency, the rate of the first one is used. A failing provider is skipped as long as another one succeeds. The
+// rates are stored as json numbers with all digits published by the providers.
+func NewUpdaterWithInterfaces(logger log.Logger, store kvstore.KvStore[StoredValue], clock clock.Clock, providers ...RateProvider) UpdaterService {
 	return &updaterService{
-		logger: logger,
-		store:  store,
-		http:   httpClient,
-		clock:  clock,
+		logger:    logger,
+		store:     store,
+		clock:     clock,
+		providers: providers,
 	}
 }
 
@@ -74,21 +78,21 @@ func (s *updaterService) EnsureRecentExchangeRates(ctx context.Context) error {
 
 	now := s.clock.Now()
 	for _, rate := range rates {
-		err := s.store.Put(ctx, rate.Currency, rate.Rate)
+		err := s.store.Put(ctx, rate.Currency, NewStoredValue(rate.Rate))
 		if err != nil {
 			return fmt.Errorf("error setting exchange rate: %w", err)
 		}
 
-		s.logger.Info("currency: %s, rate: %f", rate.Currency, rate.Rate)
+		s.logger.Info("currency: %s, rate: %s", rate.Currency, rate.Rate.String())
 
 		historicalRateKey := historicalRateKey(now, rate.Currency)
-		err = s.store.Put(ctx, historicalRateKey, rate.Rate)
+		err = s.store.Put(ctx, historicalRateKey, NewStoredValue(rate.Rate))
 		if err != nil {
 			return fmt.Errorf("error setting historical exchange rate, key: %s %w", historicalRateKey, err)
 		}
 	}
 
-	newTime := float64(s.clock.Now().Unix())
+	newTime := NewStoredValue(decimal.NewFromInt(s.clock.Now().Unix()))
 	err = s.store.Put(ctx, ExchangeRateDateKey, newTime)
 
 	if err != nil {
@@ -100,7 +104,7 @@ func (s *updaterService) EnsureRecentExchangeRates(ctx context.Context) error {
 }
 
 func (s *updaterService) needsRefresh(ctx context.Context) bool {
-	var dateUnix float64
+	var dateUnix StoredValue
 	exists, err := s.store.Get(ctx, ExchangeRateDateKey, &dateUnix)
 	if err != nil {
 		s.logger.Info("error fetching date")
@@ -116,7 +120,7 @@ func (s *updaterService) needsRefresh(ctx context.Context) bool {
 
 	comparisonDate := s.clock.Now().Add(-ExchangeRateRefresh)
 
-	date := time.Unix(int64(dateUnix), 0)
+	date := time.Unix(dateUnix.IntPart(), 0)
 
 	if date.Before(comparisonDate) {
 		s.logger.Info("comparison date was more than 8 hours ago")
@@ -128,21 +132,28 @@ func (s *updaterService) needsRefresh(ctx context.Context) bool {
 }
 
 func (s *updaterService) getCurrencyRates(ctx context.Context) ([]Rate, error) {
-	request := s.http.NewRequest().WithUrl(ExchangeRateUrl)
+	var err error
+	var errs error
 
-	response, err := s.http.Get(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("error requesting exchange rates: %w", err)
-	}
+	rateLists := make([][]Rate, len(s.providers))
+	succeeded := 0
 
-	exchangeRateResult := ExchangeResponse{}
-	err = xml.Unmarshal(response.Body, &exchangeRateResult)
+	for i, provider := range s.providers {
+		if rateLists[i], err = provider.GetLatestRates(ctx); err != nil {
+			s.logger.Warn("can not get exchange rates from provider %s: %s", provider.Name(), err.Error())
+			errs = multierror.Append(errs, fmt.Errorf("provider %s: %w", provider.Name(), err))
 
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling exchange rates: %w", err)
+			continue
+		}
+
+		succeeded++
+	}
+
+	if succeeded == 0 {
+		return nil, fmt.Errorf("all providers failed: %w", errs)
 	}
 
-	return exchangeRateResult.Body.Content.Rates, nil
+	return mergeRates(rateLists...), nil
 }
 
 func (s *updaterService) EnsureHistoricalExchangeRates(ctx context.Context) error {
@@ -170,7 +181,7 @@ func (s *updaterService) EnsureHistoricalExchangeRates(ctx context.Context) erro
 		return fmt.Errorf("error filling in gaps: %w", err)
 	}
 
-	keyValues := make(map[string]float64)
+	keyValues := make(map[string]StoredValue)
 	for _, dayRates := range rates {
 		date, err := dayRates.GetTime()
 		if err != nil {
@@ -179,7 +190,7 @@ func (s *updaterService) EnsureHistoricalExchangeRates(ctx context.Context) erro
 
 		for _, rate := range dayRates.Rates {
 			key := historicalRateKey(date, rate.Currency)
-			keyValues[key] = rate.Rate
+			keyValues[key] = NewStoredValue(rate.Rate)
 		}
 	}
 
@@ -188,7 +199,7 @@ func (s *updaterService) EnsureHistoricalExchangeRates(ctx context.Context) erro
 		return fmt.Errorf("error setting historical exchange rates: %w", err)
 	}
 
-	newTime := float64(s.clock.Now().Unix())
+	newTime := NewStoredValue(decimal.NewFromInt(s.clock.Now().Unix()))
 	err = s.store.Put(ctx, HistoricalExchangeRateDateKey, newTime)
 	if err != nil {
 		return fmt.Errorf("error setting historical refresh date %w", err)
@@ -199,7 +210,7 @@ func (s *updaterService) EnsureHistoricalExchangeRates(ctx context.Context) erro
 }
 
 func (s *updaterService) historicalRatesNeedRefresh(ctx context.Context) bool {
-	var dateUnix float64
+	var dateUnix StoredValue
 	exists, err := s.store.Get(ctx, HistoricalExchangeRateDateKey, &dateUnix)
 	if err != nil {
 		s.logger.Info("historicalRatesNeedRefresh error fetching date")
@@ -215,7 +226,7 @@ func (s *updaterService) historicalRatesNeedRefresh(ctx context.Context) bool {
 
 	comparisonDate := s.clock.Now().Add(-24 * time.Hour)
 
-	date := time.Unix(int64(dateUnix), 0)
+	date := time.Unix(dateUnix.IntPart(), 0)
 
 	if date.Before(comparisonDate) {
 		s.logger.Info("historicalRatesNeedRefresh comparison date was more than threshold")
@@ -227,21 +238,32 @@ func (s *updaterService) historicalRatesNeedRefresh(ctx context.Context) bool {
 }
 
 func (s *updaterService) fetchExchangeRates(ctx context.Context) ([]Content, error) {
-	request := s.http.NewRequest().WithUrl(HistoricalExchangeRateUrl)
+	var err error
+	var errs error
 
-	response, err := s.http.Get(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("error requesting historical exchange rates: %w", err)
+	contentLists := make([][]Content, len(s.providers))
+	succeeded := 0
+
+	for i, provider := range s.providers {
+		if contentLists[i], err = provider.GetHistoricalRates(ctx); err != nil {
+			s.logger.Warn("can not get historical exchange rates from provider %s: %s", provider.Name(), err.Error())
+			errs = multierror.Append(errs, fmt.Errorf("provider %s: %w", provider.Name(), err))
+
+			continue
+		}
+
+		succeeded++
 	}
 
-	exchangeRateResult := HistoricalExchangeResponse{}
-	err = xml.Unmarshal(response.Body, &exchangeRateResult)
+	if succeeded == 0 {
+		return nil, fmt.Errorf("all providers failed: %w", errs)
+	}
 
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling historical exchange rates: %w", err)
+	if len(s.providers) == 1 {
+		return contentLists[0], nil
 	}
 
-	return exchangeRateResult.Body.Content, nil
+	return mergeHistoricalRates(contentLists...), nil
 }
 
 func historicalRateKey(time time.Time, currency string) string {
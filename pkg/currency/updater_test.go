@@ -11,6 +11,7 @@ import (
 	httpMock "github.com/justtrackio/gosoline/pkg/http/mocks"
 	kvStoreMock "github.com/justtrackio/gosoline/pkg/kvstore/mocks"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -83,7 +84,7 @@ type updaterServiceTestSuite struct {
 	ctx context.Context
 
 	logger *logMocks.Logger
-	store  *kvStoreMock.KvStore[float64]
+	store  *kvStoreMock.KvStore[currency.StoredValue]
 	client *httpMock.Client
 	clock  clock.FakeClock
 
@@ -98,11 +99,16 @@ func (s *updaterServiceTestSuite) SetupTest() {
 	s.ctx = context.Background()
 
 	s.logger = logMocks.NewLoggerMockedAll()
-	s.store = new(kvStoreMock.KvStore[float64])
+	s.store = new(kvStoreMock.KvStore[currency.StoredValue])
 	s.client = new(httpMock.Client)
 	s.clock = clock.NewFakeClockAt(time.Date(2021, 5, 27, 0, 0, 0, 0, time.UTC))
 
-	s.updater = currency.NewUpdaterWithInterfaces(s.logger, s.store, s.client, s.clock)
+	provider := currency.NewEcbRateProviderWithInterfaces(s.client, &currency.EcbRateProviderSettings{
+		Url:           currency.ExchangeRateUrl,
+		HistoricalUrl: currency.HistoricalExchangeRateUrl,
+	})
+
+	s.updater = currency.NewUpdaterWithInterfaces(s.logger, s.store, s.clock, provider)
 }
 
 func (s *updaterServiceTestSuite) TearDownTest() {
@@ -113,8 +119,8 @@ func (s *updaterServiceTestSuite) TearDownTest() {
 
 func (s *updaterServiceTestSuite) TestEnsureRecentExchangeRates() {
 	s.mockCurrencyStoreGetTime(currency.ExchangeRateDateKey, s.clock.Now().AddDate(-1, 0, 0), true)
-	s.store.On("Put", s.ctx, currency.ExchangeRateDateKey, mock.AnythingOfType("float64")).Return(nil)
-	s.store.On("Put", s.ctx, mock.AnythingOfType("string"), mock.AnythingOfType("float64")).Return(nil)
+	s.store.On("Put", s.ctx, currency.ExchangeRateDateKey, mock.AnythingOfType("currency.StoredValue")).Return(nil)
+	s.store.On("Put", s.ctx, mock.AnythingOfType("string"), mock.AnythingOfType("currency.StoredValue")).Return(nil)
 
 	s.mockHttpRequest(response)
 
@@ -124,21 +130,21 @@ func (s *updaterServiceTestSuite) TestEnsureRecentExchangeRates() {
 }
 
 func (s *updaterServiceTestSuite) TestEnsureHistoricalExchangeRates() {
-	exchangeRates := map[string]float64{
-		"2021-05-27-USD": 1.2229,
-		"2021-05-27-BGN": 1.9558,
-		"2021-05-26-USD": 1.2229,
-		"2021-05-26-BGN": 1.9558,
-		"2021-05-25-USD": 1.2212,
-		"2021-05-25-JPY": 132.97,
-		"2021-05-24-USD": 1.2212,
-		"2021-05-24-JPY": 132.97,
-		"2021-05-23-USD": 1.2212,
-		"2021-05-23-JPY": 132.97,
+	exchangeRates := map[string]currency.StoredValue{
+		"2021-05-27-USD": currency.NewStoredValue(decimal.RequireFromString("1.2229")),
+		"2021-05-27-BGN": currency.NewStoredValue(decimal.RequireFromString("1.9558")),
+		"2021-05-26-USD": currency.NewStoredValue(decimal.RequireFromString("1.2229")),
+		"2021-05-26-BGN": currency.NewStoredValue(decimal.RequireFromString("1.9558")),
+		"2021-05-25-USD": currency.NewStoredValue(decimal.RequireFromString("1.2212")),
+		"2021-05-25-JPY": currency.NewStoredValue(decimal.RequireFromString("132.97")),
+		"2021-05-24-USD": currency.NewStoredValue(decimal.RequireFromString("1.2212")),
+		"2021-05-24-JPY": currency.NewStoredValue(decimal.RequireFromString("132.97")),
+		"2021-05-23-USD": currency.NewStoredValue(decimal.RequireFromString("1.2212")),
+		"2021-05-23-JPY": currency.NewStoredValue(decimal.RequireFromString("132.97")),
 	}
 	s.mockCurrencyStoreGetTime(currency.HistoricalExchangeRateDateKey, time.Time{}, false)
 	s.store.On("PutBatch", s.ctx, exchangeRates).Return(nil)
-	s.store.On("Put", s.ctx, currency.HistoricalExchangeRateDateKey, float64(s.clock.Now().Unix())).Return(nil)
+	s.store.On("Put", s.ctx, currency.HistoricalExchangeRateDateKey, currency.NewStoredValue(decimal.NewFromInt(s.clock.Now().Unix()))).Return(nil)
 
 	s.mockHttpRequest(historicalResponse)
 
@@ -150,23 +156,23 @@ func (s *updaterServiceTestSuite) TestEnsureHistoricalExchangeRates() {
 func (s *updaterServiceTestSuite) TestEnsureHistoricalExchangeRatesTwoGapDaysAtEnd() {
 	s.clock.Advance(time.Hour * 24)
 
-	exchangeRates := map[string]float64{
-		"2021-05-28-USD": 1.2229,
-		"2021-05-28-BGN": 1.9558,
-		"2021-05-27-USD": 1.2229,
-		"2021-05-27-BGN": 1.9558,
-		"2021-05-26-USD": 1.2229,
-		"2021-05-26-BGN": 1.9558,
-		"2021-05-25-USD": 1.2212,
-		"2021-05-25-JPY": 132.97,
-		"2021-05-24-USD": 1.2212,
-		"2021-05-24-JPY": 132.97,
-		"2021-05-23-USD": 1.2212,
-		"2021-05-23-JPY": 132.97,
+	exchangeRates := map[string]currency.StoredValue{
+		"2021-05-28-USD": currency.NewStoredValue(decimal.RequireFromString("1.2229")),
+		"2021-05-28-BGN": currency.NewStoredValue(decimal.RequireFromString("1.9558")),
+		"2021-05-27-USD": currency.NewStoredValue(decimal.RequireFromString("1.2229")),
+		"2021-05-27-BGN": currency.NewStoredValue(decimal.RequireFromString("1.9558")),
+		"2021-05-26-USD": currency.NewStoredValue(decimal.RequireFromString("1.2229")),
+		"2021-05-26-BGN": currency.NewStoredValue(decimal.RequireFromString("1.9558")),
+		"2021-05-25-USD": currency.NewStoredValue(decimal.RequireFromString("1.2212")),
+		"2021-05-25-JPY": currency.NewStoredValue(decimal.RequireFromString("132.97")),
+		"2021-05-24-USD": currency.NewStoredValue(decimal.RequireFromString("1.2212")),
+		"2021-05-24-JPY": currency.NewStoredValue(decimal.RequireFromString("132.97")),
+		"2021-05-23-USD": currency.NewStoredValue(decimal.RequireFromString("1.2212")),
+		"2021-05-23-JPY": currency.NewStoredValue(decimal.RequireFromString("132.97")),
 	}
 	s.mockCurrencyStoreGetTime(currency.HistoricalExchangeRateDateKey, time.Time{}, false)
 	s.store.On("PutBatch", s.ctx, exchangeRates).Return(nil)
-	s.store.On("Put", s.ctx, currency.HistoricalExchangeRateDateKey, float64(s.clock.Now().Unix())).Return(nil)
+	s.store.On("Put", s.ctx, currency.HistoricalExchangeRateDateKey, currency.NewStoredValue(decimal.NewFromInt(s.clock.Now().Unix()))).Return(nil)
 
 	s.mockHttpRequest(historicalResponse)
 
@@ -176,9 +182,9 @@ func (s *updaterServiceTestSuite) TestEnsureHistoricalExchangeRatesTwoGapDaysAtE
 }
 
 func (s *updaterServiceTestSuite) mockCurrencyStoreGetTime(key string, value time.Time, found bool) {
-	s.store.On("Get", s.ctx, key, new(float64)).Run(func(args mock.Arguments) {
-		f := args.Get(2).(*float64)
-		*f = float64(value.Unix())
+	s.store.On("Get", s.ctx, key, new(currency.StoredValue)).Run(func(args mock.Arguments) {
+		d := args.Get(2).(*currency.StoredValue)
+		*d = currency.NewStoredValue(decimal.NewFromInt(value.Unix()))
 	}).Return(found, nil).Once()
 }
 
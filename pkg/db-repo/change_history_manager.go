@@ -10,6 +10,7 @@ import (
 	"github.com/justtrackio/gosoline/pkg/appctx"
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/coffin"
+	"github.com/justtrackio/gosoline/pkg/db"
 	"github.com/justtrackio/gosoline/pkg/funk"
 	"github.com/justtrackio/gosoline/pkg/log"
 	"github.com/justtrackio/gosoline/pkg/mdl"
@@ -103,7 +104,12 @@ from (select TABLE_NAME, COLUMN_NAME, DATA_TYPE
               on original.COLUMN_NAME = history_entries.COLUMN_NAME
 where original.DATA_TYPE != coalesce(history_entries.DATA_TYPE, '');`
 
-	db := c.orm.Raw("select database();")
+	schemaQuery := "select database();"
+	if c.isPostgres() {
+		schemaQuery = "select current_schema();"
+	}
+
+	db := c.orm.Raw(schemaQuery)
 	if db.Error != nil {
 		return fmt.Errorf("unable to fetch database name: %w", db.Error)
 	}
@@ -202,6 +208,10 @@ func (c *ChangeHistoryManager) createHistoryTable(historyTable *tableMetadata) s
 }
 
 func (c *ChangeHistoryManager) dropHistoryTriggers(originalTable *tableMetadata, historyTable *tableMetadata) []string {
+	if c.isPostgres() {
+		return c.dropPostgresHistoryTriggers(originalTable, historyTable)
+	}
+
 	statements := make([]string, 0)
 	triggers := []string{
 		originalTable.tableName + "_ai",
@@ -218,6 +228,10 @@ func (c *ChangeHistoryManager) dropHistoryTriggers(originalTable *tableMetadata,
 }
 
 func (c *ChangeHistoryManager) createHistoryTriggers(originalTable *tableMetadata, historyTable *tableMetadata) []string {
+	if c.isPostgres() {
+		return c.createPostgresHistoryTriggers(originalTable, historyTable)
+	}
+
 	const NewRecord = "NEW"
 	const OldRecord = "OLD"
 
@@ -300,6 +314,10 @@ func (c *ChangeHistoryManager) rowUpdatedCondition(originalTable *tableMetadata)
 	return strings.Join(conditions, " OR ")
 }
 
+func (c *ChangeHistoryManager) isPostgres() bool {
+	return c.orm.Dialect().GetName() == db.DriverPostgres
+}
+
 func (c *ChangeHistoryManager) updateHistoryTable(historyTable *tableMetadata) (bool, string) {
 	for _, column := range historyTable.columns {
 		if column.exists {
@@ -0,0 +1,111 @@
+package db_repo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// postgres doesn't support statements in the body of a trigger, so every trigger executes a function with the same
+// name instead. The functions are replaced together with the triggers whenever the history table changes.
+type postgresTrigger struct {
+	name   string
+	timing string
+	table  string
+	body   string
+	record string
+}
+
+func (c *ChangeHistoryManager) dropPostgresHistoryTriggers(originalTable *tableMetadata, historyTable *tableMetadata) []string {
+	// triggers are scoped to their table in postgres, so we have to name the table when dropping them
+	return []string{
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS %s_ai ON %s`, originalTable.tableName, originalTable.tableNameQuoted),
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS %s_au ON %s`, originalTable.tableName, originalTable.tableNameQuoted),
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS %s_bd ON %s`, originalTable.tableName, originalTable.tableNameQuoted),
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS %s_revai ON %s`, historyTable.tableName, historyTable.tableNameQuoted),
+	}
+}
+
+func (c *ChangeHistoryManager) createPostgresHistoryTriggers(originalTable *tableMetadata, historyTable *tableMetadata) []string {
+	const NewRecord = "NEW"
+	const OldRecord = "OLD"
+
+	triggers := []postgresTrigger{
+		{
+			name:   originalTable.tableName + "_ai",
+			timing: "AFTER INSERT",
+			table:  originalTable.tableNameQuoted,
+			body: fmt.Sprintf("%s WHERE %s",
+				c.insertHistoryEntry(originalTable, historyTable, "insert", true),
+				c.primaryKeysMatchCondition(originalTable, NewRecord),
+			),
+			record: NewRecord,
+		},
+		{
+			name:   originalTable.tableName + "_au",
+			timing: "AFTER UPDATE",
+			table:  originalTable.tableNameQuoted,
+			body: fmt.Sprintf("%s WHERE %s AND (%s)",
+				c.insertHistoryEntry(originalTable, historyTable, "update", true),
+				c.primaryKeysMatchCondition(originalTable, NewRecord),
+				c.postgresRowUpdatedCondition(originalTable),
+			),
+			record: NewRecord,
+		},
+		{
+			name:   originalTable.tableName + "_bd",
+			timing: "BEFORE DELETE",
+			table:  originalTable.tableNameQuoted,
+			body: fmt.Sprintf("%s WHERE %s",
+				c.insertHistoryEntry(originalTable, historyTable, "delete", false),
+				c.primaryKeysMatchCondition(originalTable, OldRecord),
+			),
+			record: OldRecord,
+		},
+		{
+			name:   historyTable.tableName + "_revai",
+			timing: "BEFORE INSERT",
+			table:  historyTable.tableNameQuoted,
+			body: fmt.Sprintf("NEW.change_history_revision := (SELECT COALESCE(MAX(d.change_history_revision), 0) + 1 FROM %s AS d WHERE %s)",
+				historyTable.tableNameQuoted,
+				c.primaryKeysMatchCondition(originalTable, NewRecord),
+			),
+			record: NewRecord,
+		},
+	}
+
+	statements := make([]string, 0, len(triggers)*2)
+
+	for _, trigger := range triggers {
+		statements = append(statements,
+			fmt.Sprintf(`
+		CREATE OR REPLACE FUNCTION %s() RETURNS trigger LANGUAGE plpgsql AS $$
+		BEGIN
+			%s;
+			RETURN %s;
+		END
+		$$`,
+				trigger.name,
+				trigger.body,
+				trigger.record,
+			),
+			fmt.Sprintf(`CREATE TRIGGER %s %s ON %s FOR EACH ROW EXECUTE PROCEDURE %s()`,
+				trigger.name,
+				trigger.timing,
+				trigger.table,
+				trigger.name,
+			),
+		)
+	}
+
+	return statements
+}
+
+func (c *ChangeHistoryManager) postgresRowUpdatedCondition(originalTable *tableMetadata) string {
+	columnNames := originalTable.columnNamesQuotedExcludingValue(c.settings.ChangeAuthorField, ColumnUpdatedAt)
+	var conditions []string
+	for _, columnName := range columnNames {
+		condition := fmt.Sprintf("OLD.%s IS DISTINCT FROM NEW.%s", columnName, columnName)
+		conditions = append(conditions, condition)
+	}
+	return strings.Join(conditions, " OR ")
+}
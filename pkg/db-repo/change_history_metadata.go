@@ -92,6 +92,10 @@ func (m *tableMetadataBuilder) dataTypeOfField(field *gorm.StructField) string {
 	tag = strings.Replace(tag, "AUTO_INCREMENT", "", -1)
 	tag = strings.Replace(tag, "UNIQUE", "", -1)
 
+	// postgres models auto increments with serial types, the history table has to use the underlying integer types
+	tag = strings.Replace(tag, "bigserial", "bigint", -1)
+	tag = strings.Replace(tag, "serial", "integer", -1)
+
 	return tag
 }
 
@@ -1,6 +1,8 @@
 package db_repo
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -51,6 +53,46 @@ func NewOrmWithDbSettings(logger log.Logger, dbSettings db.Settings, application
 	return NewOrmWithInterfaces(dbClient, ormSettings)
 }
 
+// NewReadOrm creates an orm executing all statements on a healthy read replica of the default connection. It falls back
+// to the primary if there are no replicas configured or none of them is healthy.
+func NewReadOrm(config cfg.Config, logger log.Logger) (*gorm.DB, error) {
+	replicas, err := db.ProvideReplicas(config, logger, "default")
+	if err != nil {
+		return nil, fmt.Errorf("can not create db replicas: %w", err)
+	}
+
+	settings := OrmSettings{}
+	config.UnmarshalKey("db.default", &settings)
+
+	return NewReadOrmWithInterfaces(replicas, settings)
+}
+
+func NewReadOrmWithDbSettings(logger log.Logger, dbSettings db.Settings, application string) (*gorm.DB, error) {
+	primary, err := db.NewConnectionWithInterfaces(dbSettings)
+	if err != nil {
+		return nil, fmt.Errorf("can not connect to sql database: %w", err)
+	}
+
+	replicas, err := db.NewReplicasFromSettings(logger, dbSettings, primary)
+	if err != nil {
+		return nil, fmt.Errorf("can not create db replicas: %w", err)
+	}
+
+	ormSettings := OrmSettings{
+		Migrations: OrmMigrationSetting{
+			TablePrefixed: dbSettings.Migrations.PrefixedTables,
+		},
+		Driver:      dbSettings.Driver,
+		Application: application,
+	}
+
+	return NewReadOrmWithInterfaces(replicas, ormSettings)
+}
+
+func NewReadOrmWithInterfaces(replicas db.Replicas, settings OrmSettings) (*gorm.DB, error) {
+	return NewOrmWithInterfaces(&replicaConnection{replicas: replicas}, settings)
+}
+
 func NewOrmWithInterfaces(dbClient gorm.SQLCommon, settings OrmSettings) (*gorm.DB, error) {
 	orm, err := gorm.Open(settings.Driver, dbClient)
 	if err != nil {
@@ -78,3 +120,26 @@ func NewOrmWithInterfaces(dbClient gorm.SQLCommon, settings OrmSettings) (*gorm.
 
 	return orm, nil
 }
+
+// replicaConnection selects the connection for every statement gorm executes. gorm doesn't pass a context to its
+// connection, so primary reads can't be forced here - the repository uses the orm of the primary for that instead.
+// Exec and Prepare might be used to write, so they always go to the primary.
+type replicaConnection struct {
+	replicas db.Replicas
+}
+
+func (c *replicaConnection) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.replicas.Reader(db.WithPrimaryReads(context.Background())).Exec(query, args...)
+}
+
+func (c *replicaConnection) Prepare(query string) (*sql.Stmt, error) {
+	return c.replicas.Reader(db.WithPrimaryReads(context.Background())).Prepare(query)
+}
+
+func (c *replicaConnection) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.replicas.Reader(context.Background()).Query(query, args...)
+}
+
+func (c *replicaConnection) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.replicas.Reader(context.Background()).QueryRow(query, args...)
+}
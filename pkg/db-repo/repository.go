@@ -63,6 +63,7 @@ type repository struct {
 	logger   log.Logger
 	tracer   tracing.Tracer
 	orm      *gorm.DB
+	readOrm  *gorm.DB
 	clock    clock.Clock
 	metadata Metadata
 }
@@ -78,13 +79,18 @@ func New(config cfg.Config, logger log.Logger, s Settings) (*repository, error)
 		return nil, fmt.Errorf("can not create orm: %w", err)
 	}
 
+	readOrm, err := NewReadOrm(config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("can not create read orm: %w", err)
+	}
+
 	orm.Callback().
 		Update().
 		After("gorm:update_time_stamp").
 		Register("gosoline:ignore_created_at_if_needed", ignoreCreatedAtIfNeeded)
 	clk := clock.Provider
 
-	return NewWithInterfaces(logger, tracer, orm, clk, s.Metadata), nil
+	return NewWithInterfaces(logger, tracer, orm, readOrm, clk, s.Metadata), nil
 }
 
 func NewWithDbSettings(config cfg.Config, logger log.Logger, dbSettings db.Settings, repoSettings Settings) (*repository, error) {
@@ -98,6 +104,13 @@ func NewWithDbSettings(config cfg.Config, logger log.Logger, dbSettings db.Setti
 		return nil, fmt.Errorf("can not create orm: %w", err)
 	}
 
+	readOrm := orm
+	if len(dbSettings.Replicas.Uris) > 0 {
+		if readOrm, err = NewReadOrmWithDbSettings(logger, dbSettings, repoSettings.Application); err != nil {
+			return nil, fmt.Errorf("can not create read orm: %w", err)
+		}
+	}
+
 	orm.Callback().
 		Update().
 		After("gorm:update_time_stamp").
@@ -105,14 +118,15 @@ func NewWithDbSettings(config cfg.Config, logger log.Logger, dbSettings db.Setti
 
 	clk := clock.Provider
 
-	return NewWithInterfaces(logger, tracer, orm, clk, repoSettings.Metadata), nil
+	return NewWithInterfaces(logger, tracer, orm, readOrm, clk, repoSettings.Metadata), nil
 }
 
-func NewWithInterfaces(logger log.Logger, tracer tracing.Tracer, orm *gorm.DB, clock clock.Clock, metadata Metadata) *repository {
+func NewWithInterfaces(logger log.Logger, tracer tracing.Tracer, orm *gorm.DB, readOrm *gorm.DB, clock clock.Clock, metadata Metadata) *repository {
 	return &repository{
 		logger:   logger,
 		tracer:   tracer,
 		orm:      orm,
+		readOrm:  readOrm,
 		clock:    clock,
 		metadata: metadata,
 	}
@@ -156,7 +170,8 @@ func (r *repository) Create(ctx context.Context, value ModelBased) error {
 
 	logger.Info("created model of type %s with id %d", modelId, *value.GetId())
 
-	return r.Read(ctx, value.GetId(), value)
+	// the replicas might not know about our write yet
+	return r.Read(db.WithPrimaryReads(ctx), value.GetId(), value)
 }
 
 func (r *repository) Read(ctx context.Context, id *uint, out ModelBased) error {
@@ -168,7 +183,7 @@ func (r *repository) Read(ctx context.Context, id *uint, out ModelBased) error {
 	_, span := r.startSubSpan(ctx, "Get")
 	defer span.Finish()
 
-	err := r.orm.First(out, *id).Error
+	err := r.reader(ctx).First(out, *id).Error
 
 	if gorm.IsRecordNotFoundError(err) {
 		return NewRecordNotFoundError(*id, modelId, err)
@@ -214,7 +229,8 @@ func (r *repository) Update(ctx context.Context, value ModelBased) error {
 
 	logger.Info("updated model of type %s with id %d", modelId, *value.GetId())
 
-	return r.Read(ctx, value.GetId(), value)
+	// the replicas might not know about our write yet
+	return r.Read(db.WithPrimaryReads(ctx), value.GetId(), value)
 }
 
 func (r *repository) Delete(ctx context.Context, value ModelBased) error {
@@ -276,7 +292,7 @@ func (r *repository) Query(ctx context.Context, qb *QueryBuilder, result interfa
 	_, span := r.startSubSpan(ctx, "Query")
 	defer span.Finish()
 
-	db := r.orm.New()
+	db := r.reader(ctx).New()
 
 	for _, j := range qb.joins {
 		db = db.Joins(j)
@@ -327,7 +343,7 @@ func (r *repository) Count(ctx context.Context, qb *QueryBuilder, model ModelBas
 		Count int
 	}{}
 
-	db := r.orm.New()
+	db := r.reader(ctx).New()
 
 	for _, j := range qb.joins {
 		db = db.Joins(j)
@@ -347,6 +363,15 @@ func (r *repository) Count(ctx context.Context, qb *QueryBuilder, model ModelBas
 	return result.Count, err
 }
 
+// reader returns the orm to use for reads. Reads go to the replicas unless primary reads are forced for the context.
+func (r *repository) reader(ctx context.Context) *gorm.DB {
+	if r.readOrm == nil || db.IsPrimaryReadsForced(ctx) {
+		return r.orm
+	}
+
+	return r.readOrm
+}
+
 func (r *repository) refreshAssociations(model interface{}, op string) error {
 	typeReflection := reflect.TypeOf(model).Elem()
 	valueReflection := reflect.ValueOf(model).Elem()
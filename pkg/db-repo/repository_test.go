@@ -7,12 +7,16 @@ import (
 	"time"
 
 	goSqlMock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
 	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/db"
 	"github.com/justtrackio/gosoline/pkg/db-repo"
+	dbMocks "github.com/justtrackio/gosoline/pkg/db/mocks"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
 	"github.com/justtrackio/gosoline/pkg/mdl"
 	"github.com/justtrackio/gosoline/pkg/tracing"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 type MyTestModel struct {
@@ -795,6 +799,61 @@ func TestRepository_DeleteHasManyNoRelation(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRepository_ReadFromReplica(t *testing.T) {
+	now := time.Unix(1549964818, 0)
+	query := "SELECT \\* FROM `my_test_models` WHERE \\(`my_test_models`\\.`id` = 1\\) ORDER BY `my_test_models`\\.`id` ASC LIMIT 1"
+
+	primaryDb, primaryMock, _ := goSqlMock.New()
+	replicaDb, replicaMock, _ := goSqlMock.New()
+
+	primaryOrm, err := db_repo.NewOrmWithInterfaces(primaryDb, db_repo.OrmSettings{
+		Driver: "mysql",
+	})
+	assert.NoError(t, err)
+
+	replicaOrm, err := db_repo.NewOrmWithInterfaces(replicaDb, db_repo.OrmSettings{
+		Driver: "mysql",
+	})
+	assert.NoError(t, err)
+
+	repo := db_repo.NewWithInterfaces(logMocks.NewLoggerMockedAll(), tracing.NewNoopTracer(), primaryOrm, replicaOrm, clock.NewFakeClockAt(now), metadatas[myTestModel])
+
+	replicaMock.ExpectQuery(query).WillReturnRows(goSqlMock.NewRows([]string{"id", "updated_at", "created_at"}).AddRow(id1, &now, &now))
+	primaryMock.ExpectQuery(query).WillReturnRows(goSqlMock.NewRows([]string{"id", "updated_at", "created_at"}).AddRow(id1, &now, &now))
+
+	model := &MyTestModel{}
+	err = repo.Read(context.Background(), id1, model)
+	assert.NoError(t, err)
+	assert.Equal(t, id1, model.Id)
+
+	model = &MyTestModel{}
+	err = repo.Read(db.WithPrimaryReads(context.Background()), id1, model)
+	assert.NoError(t, err)
+	assert.Equal(t, id1, model.Id)
+
+	assert.NoError(t, replicaMock.ExpectationsWereMet())
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+}
+
+func TestReadOrm_ExecOnPrimary(t *testing.T) {
+	primaryDb, primaryMock, _ := goSqlMock.New()
+	primary := sqlx.NewDb(primaryDb, "sqlmock")
+
+	replicas := dbMocks.NewReplicas(t)
+	replicas.EXPECT().Reader(mock.MatchedBy(db.IsPrimaryReadsForced)).Return(primary).Once()
+
+	orm, err := db_repo.NewReadOrmWithInterfaces(replicas, db_repo.OrmSettings{
+		Driver: "mysql",
+	})
+	assert.NoError(t, err)
+
+	primaryMock.ExpectExec("UPDATE `my_test_models` SET `name` = \\?").WithArgs("foo").WillReturnResult(goSqlMock.NewResult(0, 1))
+
+	err = orm.Exec("UPDATE `my_test_models` SET `name` = ?", "foo").Error
+	assert.NoError(t, err)
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+}
+
 func getMocks(t *testing.T, whichMetadata string) (goSqlMock.Sqlmock, db_repo.Repository) {
 	logger := logMocks.NewLoggerMockedAll()
 	tracer := tracing.NewNoopTracer()
@@ -814,7 +873,7 @@ func getMocks(t *testing.T, whichMetadata string) (goSqlMock.Sqlmock, db_repo.Re
 		t.Errorf("couldn't find metadata named: %s", whichMetadata)
 	}
 
-	repo := db_repo.NewWithInterfaces(logger, tracer, orm, testClock, metadata)
+	repo := db_repo.NewWithInterfaces(logger, tracer, orm, orm, testClock, metadata)
 
 	return clientMock, repo
 }
@@ -839,7 +898,7 @@ func getTimedMocks(t *testing.T, time time.Time, whichMetadata string) (goSqlMoc
 		t.Errorf("couldn't find metadata named: %s", whichMetadata)
 	}
 
-	repo := db_repo.NewWithInterfaces(logger, tracer, orm, testClock, metadata)
+	repo := db_repo.NewWithInterfaces(logger, tracer, orm, orm, testClock, metadata)
 
 	return clientMock, repo
 }
@@ -44,8 +44,9 @@ type Client interface {
 }
 
 type ClientSqlx struct {
-	logger log.Logger
-	db     *sqlx.DB
+	logger   log.Logger
+	db       *sqlx.DB
+	replicas Replicas
 }
 
 func NewClient(config cfg.Config, logger log.Logger, name string) (Client, error) {
@@ -54,7 +55,12 @@ func NewClient(config cfg.Config, logger log.Logger, name string) (Client, error
 		return nil, fmt.Errorf("can not connect to sql database: %w", err)
 	}
 
-	return NewClientWithInterfaces(logger, db), nil
+	replicas, err := ProvideReplicas(config, logger, name)
+	if err != nil {
+		return nil, fmt.Errorf("can not connect to sql replicas: %w", err)
+	}
+
+	return NewClientWithReplicas(logger, db, replicas), nil
 }
 
 func NewClientWithSettings(logger log.Logger, settings Settings) (Client, error) {
@@ -63,13 +69,25 @@ func NewClientWithSettings(logger log.Logger, settings Settings) (Client, error)
 		return nil, fmt.Errorf("can not connect to sql database: %w", err)
 	}
 
-	return NewClientWithInterfaces(logger, db), nil
+	replicas, err := NewReplicasFromSettings(logger, settings, db)
+	if err != nil {
+		return nil, fmt.Errorf("can not connect to sql replicas: %w", err)
+	}
+
+	return NewClientWithReplicas(logger, db, replicas), nil
 }
 
 func NewClientWithInterfaces(logger log.Logger, db *sqlx.DB) Client {
+	return NewClientWithReplicas(logger, db, nil)
+}
+
+// NewClientWithReplicas creates a client executing Query, Queryx, QueryRow, Select and Get on the connection selected by
+// replicas. Everything else is executed on db, the primary. If replicas is nil, all queries go to the primary.
+func NewClientWithReplicas(logger log.Logger, db *sqlx.DB, replicas Replicas) Client {
 	return &ClientSqlx{
-		logger: logger,
-		db:     db,
+		logger:   logger,
+		db:       db,
+		replicas: replicas,
 	}
 }
 
@@ -153,29 +171,37 @@ func (c *ClientSqlx) Prepare(ctx context.Context, query string) (*sql.Stmt, erro
 func (c *ClientSqlx) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	c.logger.Debug("> %s %q", query, args)
 
-	return c.db.QueryContext(ctx, query, args...)
+	return c.reader(ctx).QueryContext(ctx, query, args...)
 }
 
 func (c *ClientSqlx) QueryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
-	return c.db.QueryRowContext(ctx, query, args...)
+	return c.reader(ctx).QueryRowContext(ctx, query, args...)
 }
 
 func (c *ClientSqlx) Queryx(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
 	c.logger.Debug("> %s %q", query, args)
 
-	return c.db.QueryxContext(ctx, query, args...)
+	return c.reader(ctx).QueryxContext(ctx, query, args...)
 }
 
 func (c *ClientSqlx) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
 	c.logger.Debug("> %s %q", query, args)
 
-	return c.db.SelectContext(ctx, dest, query, args...)
+	return c.reader(ctx).SelectContext(ctx, dest, query, args...)
 }
 
 func (c *ClientSqlx) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
 	c.logger.Debug("> %s %q", query, args)
 
-	return c.db.GetContext(ctx, dest, query, args...)
+	return c.reader(ctx).GetContext(ctx, dest, query, args...)
+}
+
+func (c *ClientSqlx) reader(ctx context.Context) *sqlx.DB {
+	if c.replicas == nil {
+		return c.db
+	}
+
+	return c.replicas.Reader(ctx)
 }
 
 func (c *ClientSqlx) BeginTx(ctx context.Context, ops *sql.TxOptions) (*sql.Tx, error) {
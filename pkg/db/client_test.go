@@ -7,6 +7,7 @@ import (
 	goSqlMock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/justtrackio/gosoline/pkg/db"
+	dbMocks "github.com/justtrackio/gosoline/pkg/db/mocks"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -126,6 +127,34 @@ func TestClient_Exec(t *testing.T) {
 	sqlMock.ExpectClose()
 }
 
+func TestReadsUseReplica(t *testing.T) {
+	ctx := context.Background()
+
+	primaryDb, primaryMock, _ := goSqlMock.New()
+	replicaDb, replicaMock, _ := goSqlMock.New()
+	primary := sqlx.NewDb(primaryDb, "sqlmock")
+	replica := sqlx.NewDb(replicaDb, "sqlmock")
+
+	replicas := dbMocks.NewReplicas(t)
+	replicas.EXPECT().Reader(ctx).Return(replica).Once()
+
+	client := db.NewClientWithReplicas(logMocks.NewLoggerMockedAll(), primary, replicas)
+
+	replicaMock.ExpectQuery("^SELECT name FROM TestTable").WillReturnRows(goSqlMock.NewRows([]string{"name"}).AddRow("foo"))
+	primaryMock.ExpectExec("^DELETE FROM TestTable").WillReturnResult(goSqlMock.NewResult(0, 1))
+
+	var names []string
+	err := client.Select(ctx, &names, "SELECT name FROM TestTable")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo"}, names)
+
+	_, err = client.Exec(ctx, "DELETE FROM TestTable")
+	assert.NoError(t, err)
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMock.ExpectationsWereMet())
+}
+
 func getMocks() (db.Client, goSqlMock.Sqlmock) {
 	dbMock, sqlMock, _ := goSqlMock.New()
 	loggerMock := logMocks.NewLoggerMockedAll()
@@ -26,25 +26,30 @@ type Settings struct {
 	MaxIdleConnections    int           `cfg:"max_idle_connections" default:"2"` // 0 or negative number=no idle connections, sql driver default=2
 	MaxOpenConnections    int           `cfg:"max_open_connections" default:"0"` // 0 or negative number=unlimited, sql driver default=0
 	ParseTime             bool          `cfg:"parse_time" default:"true"`
+	SslMode               string        `cfg:"ssl_mode" default:"require"` // only used by the postgres driver: disable, require, verify-ca or verify-full
 
 	Uri        Uri               `cfg:"uri"`
+	Replicas   ReplicaSettings   `cfg:"replicas"`
 	Migrations MigrationSettings `cfg:"migrations"`
 }
 
 var defaultConnections = struct {
 	lck       sync.Mutex
-	instances map[Settings]*sqlx.DB
-	errors    map[Settings]error
+	instances map[string]*sqlx.DB
+	errors    map[string]error
 }{
-	instances: make(map[Settings]*sqlx.DB),
-	errors:    make(map[Settings]error),
+	instances: make(map[string]*sqlx.DB),
+	errors:    make(map[string]error),
 }
 
 func ProvideConnection(config cfg.Config, logger log.Logger, configKey string) (*sqlx.DB, error) {
 	defaultConnections.lck.Lock()
 	defer defaultConnections.lck.Unlock()
 
-	key := createSettings(config, configKey)
+	// the settings contain the replica uris and are therefore not comparable anymore, so we use their string
+	// representation to share connections with the same settings
+	settings := createSettings(config, configKey)
+	key := fmt.Sprintf("%+v", settings)
 
 	if err := defaultConnections.errors[key]; err != nil {
 		return nil, err
@@ -54,7 +59,7 @@ func ProvideConnection(config cfg.Config, logger log.Logger, configKey string) (
 		return instance, nil
 	}
 
-	instance, err := NewConnectionFromSettings(logger, key)
+	instance, err := NewConnectionFromSettings(logger, settings)
 
 	defaultConnections.instances[key] = instance
 	defaultConnections.errors[key] = err
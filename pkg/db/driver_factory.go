@@ -1,10 +1,13 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/jmoiron/sqlx"
 )
 
 type DriverFactory interface {
@@ -12,6 +15,14 @@ type DriverFactory interface {
 	GetMigrationDriver(db *sql.DB, database string, migrationsTable string) (database.Driver, error)
 }
 
+// ReplicationLagProvider is implemented by driver factories which are able to tell how far a replica is behind its primary.
+// Replicas of drivers not implementing it are only checked for being reachable.
+//
+//go:generate mockery --name ReplicationLagProvider
+type ReplicationLagProvider interface {
+	GetReplicationLag(ctx context.Context, db *sqlx.DB) (time.Duration, error)
+}
+
 var connectionFactories = map[string]DriverFactory{}
 
 func GetDriverFactory(driverName string) (DriverFactory, error) {
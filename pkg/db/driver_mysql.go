@@ -1,14 +1,17 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/jmoiron/sqlx"
 )
 
 const DriverMysql = "mysql"
@@ -47,3 +50,38 @@ func (m *mysqlDriverFactory) GetMigrationDriver(db *sql.DB, database string, mig
 		MigrationsTable: migrationsTable,
 	})
 }
+
+func (m *mysqlDriverFactory) GetReplicationLag(ctx context.Context, db *sqlx.DB) (time.Duration, error) {
+	rows, err := db.QueryxContext(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, fmt.Errorf("can not get replica status: %w", err)
+	}
+	defer rows.Close()
+
+	// the server isn't a replica at all, so it can't lag behind
+	if !rows.Next() {
+		return 0, rows.Err()
+	}
+
+	status := make(map[string]interface{})
+	if err = rows.MapScan(status); err != nil {
+		return 0, fmt.Errorf("can not scan replica status: %w", err)
+	}
+
+	var seconds int64
+
+	switch value := status["Seconds_Behind_Master"].(type) {
+	case nil:
+		return 0, fmt.Errorf("replication is not running")
+	case int64:
+		seconds = value
+	case []byte:
+		if seconds, err = strconv.ParseInt(string(value), 10, 64); err != nil {
+			return 0, fmt.Errorf("can not parse replication lag %q: %w", value, err)
+		}
+	default:
+		return 0, fmt.Errorf("unexpected type %T of replication lag", value)
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+const DriverPostgres = "postgres"
+
+func init() {
+	connectionFactories[DriverPostgres] = NewPostgresDriverFactory()
+}
+
+func NewPostgresDriverFactory() DriverFactory {
+	return &postgresDriverFactory{}
+}
+
+type postgresDriverFactory struct{}
+
+func (p *postgresDriverFactory) GetDSN(settings Settings) string {
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(settings.Uri.User, settings.Uri.Password),
+		Host:   fmt.Sprintf("%s:%d", settings.Uri.Host, settings.Uri.Port),
+		Path:   settings.Uri.Database,
+	}
+
+	qry := dsn.Query()
+	qry.Set("sslmode", settings.SslMode)
+	dsn.RawQuery = qry.Encode()
+
+	return dsn.String()
+}
+
+func (p *postgresDriverFactory) GetMigrationDriver(db *sql.DB, database string, migrationsTable string) (database.Driver, error) {
+	return postgres.WithInstance(db, &postgres.Config{
+		DatabaseName:    database,
+		MigrationsTable: migrationsTable,
+	})
+}
+
+func (p *postgresDriverFactory) GetReplicationLag(ctx context.Context, db *sqlx.DB) (time.Duration, error) {
+	// the replay timestamp doesn't move while nothing is written to the primary, so we only count the time since the
+	// last replayed transaction as lag if the replica hasn't replayed everything it received yet
+	query := `SELECT CASE
+		WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`
+
+	var seconds float64
+	if err := db.GetContext(ctx, &seconds, query); err != nil {
+		return 0, fmt.Errorf("can not get replication lag: %w", err)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
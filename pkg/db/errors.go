@@ -6,8 +6,11 @@ import (
 
 	"github.com/VividCortex/mysqlerr"
 	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
 )
 
+const pqErrUniqueViolation = "23505"
+
 type DuplicateEntryError struct {
 	Err error
 }
@@ -43,5 +46,11 @@ func IsDuplicateEntryError(err error) bool {
 		return mysqlErr.Number == mysqlerr.ER_DUP_ENTRY
 	}
 
+	pqErr := &pq.Error{}
+
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqErrUniqueViolation
+	}
+
 	return errors.Is(err, &DuplicateEntryError{})
 }
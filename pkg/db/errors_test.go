@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/justtrackio/gosoline/pkg/db"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,6 +23,12 @@ func TestIsDuplicateEntryError(t *testing.T) {
 			Number: 1062,
 		}),
 		fmt.Errorf("error: %w", &db.DuplicateEntryError{}),
+		&pq.Error{
+			Code: "23505",
+		},
+		fmt.Errorf("error: %w", &pq.Error{
+			Code: "23505",
+		}),
 	}
 
 	invalid := []error{
@@ -30,6 +37,9 @@ func TestIsDuplicateEntryError(t *testing.T) {
 		&mysql.MySQLError{
 			Number: 42,
 		},
+		&pq.Error{
+			Code: "23503",
+		},
 	}
 
 	for _, validErr := range valid {
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/conc"
+	concDdb "github.com/justtrackio/gosoline/pkg/conc/ddb"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+type migrationModule struct {
+	kernel.BackgroundModule
+	kernel.EssentialStage
+
+	logger       log.Logger
+	lockProvider conc.DistributedLockProvider
+	migrator     Migrator
+	clock        clock.Clock
+	resource     string
+	lockTime     time.Duration
+
+	lck  sync.Mutex
+	done bool
+	err  error
+}
+
+// NewMigrationModule runs the migrations of the given db connection while holding a distributed lock, so only one
+// instance of the application migrates the db during a rolling deployment. The module lives in the essential stage and
+// only gets healthy after the migrations are done, so no other module starts before the schema is up-to-date.
+// Set db.<name>.migrations.enabled to false when using it, otherwise every connection migrates on its own again.
+func NewMigrationModule(name string) kernel.ModuleFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+		logger = logger.WithChannel("db-migrations")
+		settings := createSettings(config, name)
+
+		// the migrator closes its connection when done, so we can't share the default one here
+		connection, err := NewConnectionWithInterfaces(settings)
+		if err != nil {
+			return nil, fmt.Errorf("can not create connection: %w", err)
+		}
+
+		migrator, err := NewMigrator(logger, settings, connection)
+		if err != nil {
+			return nil, fmt.Errorf("can not create migrator: %w", err)
+		}
+
+		appId := cfg.AppId{}
+		appId.PadFromConfig(config)
+
+		lockProvider, err := concDdb.NewDdbLockProvider(ctx, config, logger, conc.DistributedLockSettings{
+			AppId:           appId,
+			DefaultLockTime: settings.Migrations.LockTime,
+			Domain:          "db-migrations",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("can not create lock provider: %w", err)
+		}
+
+		return NewMigrationModuleWithInterfaces(logger, lockProvider, migrator, clock.Provider, name, settings.Migrations.LockTime), nil
+	}
+}
+
+func NewMigrationModuleWithInterfaces(logger log.Logger, lockProvider conc.DistributedLockProvider, migrator Migrator, clock clock.Clock, name string, lockTime time.Duration) kernel.Module {
+	return &migrationModule{
+		logger:       logger,
+		lockProvider: lockProvider,
+		migrator:     migrator,
+		clock:        clock,
+		resource:     name,
+		lockTime:     lockTime,
+	}
+}
+
+func (m *migrationModule) IsHealthy(_ context.Context) (bool, error) {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	return m.done && m.err == nil, m.err
+}
+
+func (m *migrationModule) Run(ctx context.Context) error {
+	err := m.migrate(ctx)
+
+	if closeErr := m.migrator.Close(); closeErr != nil {
+		m.logger.Warn("can not close migrator: %s", closeErr.Error())
+	}
+
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	m.done = true
+	m.err = err
+
+	return err
+}
+
+func (m *migrationModule) migrate(ctx context.Context) error {
+	m.logger.Info("waiting for the migration lock of db %s", m.resource)
+
+	lock, err := m.lockProvider.Acquire(ctx, m.resource)
+	if err != nil {
+		return fmt.Errorf("can not acquire migration lock: %w", err)
+	}
+
+	defer func() {
+		if err := lock.Release(); err != nil {
+			m.logger.Warn("can not release migration lock: %s", err.Error())
+		}
+	}()
+
+	renewCtx, stopRenewing := context.WithCancel(ctx)
+	defer stopRenewing()
+
+	go m.renew(renewCtx, lock)
+
+	return m.migrator.Up(0)
+}
+
+// renew keeps the lock while the migrations are running, as they might take longer than the lock time.
+func (m *migrationModule) renew(ctx context.Context, lock conc.DistributedLock) {
+	ticker := m.clock.NewTicker(m.lockTime / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+			if err := lock.Renew(ctx, m.lockTime); err != nil && ctx.Err() == nil {
+				m.logger.Warn("can not renew migration lock: %s", err.Error())
+			}
+		}
+	}
+}
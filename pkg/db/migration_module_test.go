@@ -0,0 +1,79 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/clock"
+	concMocks "github.com/justtrackio/gosoline/pkg/conc/mocks"
+	"github.com/justtrackio/gosoline/pkg/db"
+	dbMocks "github.com/justtrackio/gosoline/pkg/db/mocks"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type migrationModuleTestSuite struct {
+	suite.Suite
+
+	ctx          context.Context
+	lockProvider *concMocks.DistributedLockProvider
+	lock         *concMocks.DistributedLock
+	migrator     *dbMocks.Migrator
+	module       kernel.Module
+}
+
+func TestMigrationModule(t *testing.T) {
+	suite.Run(t, new(migrationModuleTestSuite))
+}
+
+func (s *migrationModuleTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.lockProvider = concMocks.NewDistributedLockProvider(s.T())
+	s.lock = concMocks.NewDistributedLock(s.T())
+	s.migrator = dbMocks.NewMigrator(s.T())
+
+	s.module = db.NewMigrationModuleWithInterfaces(logMocks.NewLoggerMockedAll(), s.lockProvider, s.migrator, clock.NewFakeClock(), "default", time.Minute)
+}
+
+func (s *migrationModuleTestSuite) TestMigrate() {
+	s.lockProvider.EXPECT().Acquire(s.ctx, "default").Return(s.lock, nil).Once()
+	s.migrator.EXPECT().Up(0).Return(nil).Once()
+	s.lock.EXPECT().Release().Return(nil).Once()
+	s.migrator.EXPECT().Close().Return(nil).Once()
+
+	s.assertHealthy(false, nil)
+
+	err := s.module.Run(s.ctx)
+	s.NoError(err)
+
+	s.assertHealthy(true, nil)
+}
+
+func (s *migrationModuleTestSuite) TestMigrateFails() {
+	s.lockProvider.EXPECT().Acquire(s.ctx, "default").Return(s.lock, nil).Once()
+	s.migrator.EXPECT().Up(0).Return(fmt.Errorf("broken migration")).Once()
+	s.lock.EXPECT().Release().Return(nil).Once()
+	s.migrator.EXPECT().Close().Return(nil).Once()
+
+	err := s.module.Run(s.ctx)
+	s.EqualError(err, "broken migration")
+
+	s.assertHealthy(false, err)
+}
+
+func (s *migrationModuleTestSuite) TestAcquireFails() {
+	s.lockProvider.EXPECT().Acquire(s.ctx, "default").Return(nil, context.Canceled).Once()
+	s.migrator.EXPECT().Close().Return(nil).Once()
+
+	err := s.module.Run(s.ctx)
+	s.EqualError(err, "can not acquire migration lock: context canceled")
+}
+
+func (s *migrationModuleTestSuite) assertHealthy(expected bool, expectedErr error) {
+	healthy, err := s.module.(kernel.HealthCheckedModule).IsHealthy(s.ctx)
+	s.Equal(expected, healthy)
+	s.Equal(expectedErr, err)
+}
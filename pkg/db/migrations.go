@@ -1,31 +1,73 @@
 package db
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jmoiron/sqlx"
 	"github.com/justtrackio/gosoline/pkg/log"
 )
 
+const migrationVersionFormat = "20060102150405"
+
 type MigrationSettings struct {
 	Application    string `cfg:"application" default:"{app_name}"`
 	Path           string `cfg:"path"`
 	PrefixedTables bool   `cfg:"prefixed_tables" default:"false"`
 	Enabled        bool   `cfg:"enabled" default:"false"`
+	// LockTime is the duration the migration module holds its lock before it has to renew it
+	LockTime time.Duration `cfg:"lock_time" default:"1m"`
 }
 
-func runMigrations(logger log.Logger, settings Settings, db *sqlx.DB) error {
-	if !settings.Migrations.Enabled || settings.Migrations.Path == "" {
-		return nil
+type MigrationStatus struct {
+	// Version of the last applied migration, 0 if no migration was applied yet
+	Version uint
+	// Dirty is true if the last migration failed and the schema has to be fixed manually before forcing a version
+	Dirty bool
+	// Pending contains the versions of all migrations which are not applied yet
+	Pending []uint
+}
+
+//go:generate mockery --name Migrator
+type Migrator interface {
+	// Status returns the current version of the schema and the migrations still to apply.
+	Status() (*MigrationStatus, error)
+	// Up applies the next n migrations or all pending migrations if n is 0.
+	Up(n int) error
+	// Down reverts the last n migrations or all applied migrations if n is 0.
+	Down(n int) error
+	// Force sets the version of the schema without running any migrations and clears the dirty flag.
+	// Use it after fixing the schema by hand when a migration failed.
+	Force(version int) error
+	// Close closes the migrator together with the db connection it was created with.
+	Close() error
+}
+
+type migrator struct {
+	logger  log.Logger
+	migrate *migrate.Migrate
+	source  source.Driver
+}
+
+func NewMigrator(logger log.Logger, settings Settings, db *sqlx.DB) (Migrator, error) {
+	return newMigrator(logger, settings, db)
+}
+
+func newMigrator(logger log.Logger, settings Settings, db *sqlx.DB) (*migrator, error) {
+	if settings.Migrations.Path == "" {
+		return nil, fmt.Errorf("there is no migrations path configured")
 	}
 
 	driverFactory, err := GetDriverFactory(settings.Driver)
 	if err != nil {
-		return fmt.Errorf("could not get driver factory for %s: %w", settings.Driver, err)
+		return nil, fmt.Errorf("could not get driver factory for %s: %w", settings.Driver, err)
 	}
 
 	migrationsTable := "schema_migrations"
@@ -38,28 +80,180 @@ func runMigrations(logger log.Logger, settings Settings, db *sqlx.DB) error {
 
 	driver, err := driverFactory.GetMigrationDriver(db.DB, settings.Uri.Database, migrationsTable)
 	if err != nil {
-		return fmt.Errorf("could not get migration driver: %w", err)
+		return nil, fmt.Errorf("could not get migration driver: %w", err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(settings.Migrations.Path, settings.Driver, driver)
+	// the migrator doesn't expose its source, so we open it ourselves to be able to list the pending migrations
+	sourceDriver, err := source.Open(settings.Migrations.Path)
 	if err != nil {
-		return fmt.Errorf("could not initialize migrator for db migrations: %w", err)
+		return nil, fmt.Errorf("could not open migrations source %s: %w", settings.Migrations.Path, err)
 	}
 
-	start := time.Now()
+	m, err := migrate.NewWithInstance(settings.Migrations.Path, sourceDriver, settings.Driver, driver)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("could not initialize migrator for db migrations: %w", err), sourceDriver.Close())
+	}
+
+	return &migrator{
+		logger:  logger,
+		migrate: m,
+		source:  sourceDriver,
+	}, nil
+}
+
+// NewMigratorWithInterfaces creates a migrator reading the pending migrations from the source. The source has to be
+// the one the migrate instance was created with, as it is closed together with it.
+func NewMigratorWithInterfaces(logger log.Logger, migrate *migrate.Migrate, source source.Driver) Migrator {
+	return &migrator{
+		logger:  logger,
+		migrate: migrate,
+		source:  source,
+	}
+}
+
+func (m *migrator) Status() (*MigrationStatus, error) {
+	status := &MigrationStatus{
+		Pending: make([]uint, 0),
+	}
+
+	var err error
+
+	if status.Version, status.Dirty, err = m.migrate.Version(); err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("could not get the current schema version: %w", err)
+	}
+
+	version, err := m.source.First()
 
-	err = m.Up()
+	for ; err == nil; version, err = m.source.Next(version) {
+		if version > status.Version {
+			status.Pending = append(status.Pending, version)
+		}
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("could not read the migrations: %w", err)
+	}
 
-	if err == migrate.ErrNoChange {
-		logger.Info("no db migrations to run")
+	return status, nil
+}
+
+func (m *migrator) Up(n int) error {
+	return m.run("up", n, func() error {
+		if n <= 0 {
+			return m.migrate.Up()
+		}
+
+		return m.migrate.Steps(n)
+	})
+}
+
+func (m *migrator) Down(n int) error {
+	return m.run("down", n, func() error {
+		if n <= 0 {
+			return m.migrate.Down()
+		}
+
+		return m.migrate.Steps(-n)
+	})
+}
+
+func (m *migrator) Force(version int) error {
+	if err := m.migrate.Force(version); err != nil {
+		return fmt.Errorf("could not force version %d: %w", version, err)
+	}
+
+	m.logger.Info("forced db schema to version %d", version)
+
+	return nil
+}
+
+func (m *migrator) Close() error {
+	sourceErr, dbErr := m.migrate.Close()
+
+	return errors.Join(sourceErr, dbErr)
+}
+
+func (m *migrator) run(direction string, n int, do func() error) error {
+	start := time.Now()
+	err := do()
+
+	if errors.Is(err, migrate.ErrNoChange) {
+		m.logger.Info("no db migrations to run")
 		return nil
 	}
 
+	// migrating more steps than available still applies all migrations it can
+	var errShortLimit migrate.ErrShortLimit
+	if errors.As(err, &errShortLimit) {
+		m.logger.Warn("could only migrate %s %d of %d steps", direction, n-int(errShortLimit.Short), n)
+		err = nil
+	}
+
 	if err != nil {
-		return fmt.Errorf("could not run db migrations: %w", err)
+		return fmt.Errorf("could not run db migrations %s: %w", direction, err)
 	}
 
-	logger.Info("migrated db in %s", time.Since(start))
+	m.logger.Info("migrated db %s in %s", direction, time.Since(start))
 
 	return nil
 }
+
+// CreateMigrationFiles creates an empty up and down migration named by the given name in the directory of the
+// migrations path. The version of the migration is the timestamp of its creation, so migrations created in parallel
+// branches don't collide.
+func CreateMigrationFiles(path string, name string, now time.Time) ([]string, error) {
+	dir := strings.TrimPrefix(path, "file://")
+	if dir == "" {
+		return nil, fmt.Errorf("there is no migrations path configured")
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("the migration needs a name")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("could not create migrations directory %s: %w", dir, err)
+	}
+
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+	version := now.UTC().Format(migrationVersionFormat)
+	files := make([]string, 0, 2)
+
+	for _, direction := range []string{"up", "down"} {
+		file := filepath.Join(dir, fmt.Sprintf("%s_%s.%s.sql", version, name, direction))
+
+		// O_EXCL makes sure we never overwrite an existing migration
+		handle, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return files, fmt.Errorf("could not create migration file %s: %w", file, err)
+		}
+
+		if err = handle.Close(); err != nil {
+			return files, fmt.Errorf("could not close migration file %s: %w", file, err)
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func runMigrations(logger log.Logger, settings Settings, db *sqlx.DB) error {
+	if !settings.Migrations.Enabled || settings.Migrations.Path == "" {
+		return nil
+	}
+
+	m, err := newMigrator(logger, settings, db)
+	if err != nil {
+		return err
+	}
+
+	// closing the migrator would close the connection it was created with, which is still used by the application
+	defer func() {
+		if err := m.source.Close(); err != nil {
+			logger.Warn("could not close migrations source %s: %s", settings.Migrations.Path, err.Error())
+		}
+	}()
+
+	return m.Up(0)
+}
@@ -0,0 +1,108 @@
+package db_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/stub"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/justtrackio/gosoline/pkg/db"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type migratorTestSuite struct {
+	suite.Suite
+
+	dir      string
+	database *stub.Stub
+	migrator db.Migrator
+}
+
+func TestMigrator(t *testing.T) {
+	suite.Run(t, new(migratorTestSuite))
+}
+
+func (s *migratorTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+
+	for _, file := range []string{"1_first.up.sql", "1_first.down.sql", "2_second.up.sql", "2_second.down.sql", "3_third.up.sql", "3_third.down.sql"} {
+		err := os.WriteFile(filepath.Join(s.dir, file), []byte(file), 0o644)
+		s.NoError(err)
+	}
+
+	driver, err := stub.WithInstance(nil, &stub.Config{})
+	s.NoError(err)
+	s.database = driver.(*stub.Stub)
+
+	sourceDriver, err := source.Open("file://" + s.dir)
+	s.NoError(err)
+
+	m, err := migrate.NewWithInstance("file", sourceDriver, "stub", driver)
+	s.NoError(err)
+
+	s.migrator = db.NewMigratorWithInterfaces(logMocks.NewLoggerMockedAll(), m, sourceDriver)
+}
+
+func (s *migratorTestSuite) TestStatusWithoutMigrations() {
+	status, err := s.migrator.Status()
+	s.NoError(err)
+	s.Equal(&db.MigrationStatus{
+		Version: 0,
+		Dirty:   false,
+		Pending: []uint{1, 2, 3},
+	}, status)
+}
+
+func (s *migratorTestSuite) TestUpAndDown() {
+	err := s.migrator.Up(2)
+	s.NoError(err)
+	s.Equal([]string{"1_first.up.sql", "2_second.up.sql"}, s.database.MigrationSequence)
+
+	status, err := s.migrator.Status()
+	s.NoError(err)
+	s.Equal(uint(2), status.Version)
+	s.Equal([]uint{3}, status.Pending)
+
+	err = s.migrator.Down(1)
+	s.NoError(err)
+	s.Equal(1, s.database.CurrentVersion)
+
+	// migrating more steps than there are is fine
+	err = s.migrator.Up(5)
+	s.NoError(err)
+	s.Equal(3, s.database.CurrentVersion)
+
+	err = s.migrator.Up(0)
+	s.NoError(err, "no change is not an error")
+}
+
+func (s *migratorTestSuite) TestForce() {
+	err := s.migrator.Force(2)
+	s.NoError(err)
+
+	status, err := s.migrator.Status()
+	s.NoError(err)
+	s.Equal(uint(2), status.Version)
+	s.False(status.Dirty)
+	s.Equal([]uint{3}, status.Pending)
+}
+
+func TestCreateMigrationFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	now := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+
+	files, err := db.CreateMigrationFiles("file://"+dir, "Add Users", now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "20230405060708_add_users.up.sql"),
+		filepath.Join(dir, "20230405060708_add_users.down.sql"),
+	}, files)
+
+	_, err = db.CreateMigrationFiles("file://"+dir, "Add Users", now)
+	assert.Error(t, err, "existing migrations must not be overwritten")
+}
@@ -0,0 +1,248 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+type ReplicaSettings struct {
+	// Uris of the read replicas. Reads are spread over all healthy replicas, writes and transactions always use the primary.
+	Uris []Uri `cfg:"uris"`
+	// How often a replica is checked for being reachable and not lagging too far behind
+	HealthCheckInterval time.Duration `cfg:"health_check_interval" default:"10s"`
+	// Replicas lagging more than this behind the primary are not used for reads. 0 disables the lag check.
+	MaxLag time.Duration `cfg:"max_lag" default:"0s"`
+}
+
+type primaryReadsCtxKey struct{}
+
+// WithPrimaryReads forces all reads using the returned context to go to the primary. Use it after a write if you have to
+// read your own writes, as replicas might not have caught up yet.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsCtxKey{}, true)
+}
+
+func IsPrimaryReadsForced(ctx context.Context) bool {
+	forced, ok := ctx.Value(primaryReadsCtxKey{}).(bool)
+
+	return ok && forced
+}
+
+// Replicas selects the connection reads should be executed on.
+//
+//go:generate mockery --name Replicas
+type Replicas interface {
+	// Reader returns a healthy replica or the primary if there is no healthy replica or primary reads are forced for ctx.
+	Reader(ctx context.Context) *sqlx.DB
+}
+
+var defaultReplicas = struct {
+	lck       sync.Mutex
+	instances map[string]Replicas
+}{
+	instances: make(map[string]Replicas),
+}
+
+func ProvideReplicas(config cfg.Config, logger log.Logger, configKey string) (Replicas, error) {
+	primary, err := ProvideConnection(config, logger, configKey)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultReplicas.lck.Lock()
+	defer defaultReplicas.lck.Unlock()
+
+	settings := createSettings(config, configKey)
+	key := fmt.Sprintf("%+v", settings)
+
+	if instance := defaultReplicas.instances[key]; instance != nil {
+		return instance, nil
+	}
+
+	instance, err := NewReplicasFromSettings(logger, settings, primary)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultReplicas.instances[key] = instance
+
+	return instance, nil
+}
+
+func NewReplicasFromSettings(logger log.Logger, settings Settings, primary *sqlx.DB) (Replicas, error) {
+	connections := make([]*sqlx.DB, 0, len(settings.Replicas.Uris))
+
+	for _, uri := range settings.Replicas.Uris {
+		replicaSettings := settings
+		replicaSettings.Uri = uri
+		replicaSettings.Replicas = ReplicaSettings{}
+
+		connection, err := NewConnectionWithInterfaces(replicaSettings)
+		if err != nil {
+			return nil, fmt.Errorf("can not create connection to replica %s:%d: %w", uri.Host, uri.Port, err)
+		}
+
+		publishConnectionMetrics(connection)
+		connections = append(connections, connection)
+	}
+
+	var lagProvider ReplicationLagProvider
+
+	if driverFactory, err := GetDriverFactory(settings.Driver); err == nil {
+		lagProvider, _ = driverFactory.(ReplicationLagProvider)
+	}
+
+	return NewReplicasWithInterfaces(logger, clock.Provider, primary, connections, lagProvider, settings.Replicas), nil
+}
+
+func NewReplicasWithInterfaces(logger log.Logger, clock clock.Clock, primary *sqlx.DB, connections []*sqlx.DB, lagProvider ReplicationLagProvider, settings ReplicaSettings) Replicas {
+	members := make([]*replica, len(connections))
+
+	for i, connection := range connections {
+		members[i] = &replica{
+			db: connection,
+			logger: logger.WithFields(log.Fields{
+				"db_replica": i,
+			}),
+		}
+	}
+
+	replicas := &replicas{
+		clock:       clock,
+		primary:     primary,
+		members:     members,
+		lagProvider: lagProvider,
+		settings:    settings,
+	}
+
+	// the replicas are checked in the background, so a read never has to wait for a check of an unreachable replica.
+	// Until their first check succeeded, all reads go to the primary.
+	if len(members) > 0 {
+		go replicas.runChecks()
+	}
+
+	return replicas
+}
+
+type replicas struct {
+	clock       clock.Clock
+	primary     *sqlx.DB
+	members     []*replica
+	lagProvider ReplicationLagProvider
+	settings    ReplicaSettings
+	next        uint32
+}
+
+func (r *replicas) Reader(ctx context.Context) *sqlx.DB {
+	if len(r.members) == 0 || IsPrimaryReadsForced(ctx) {
+		return r.primary
+	}
+
+	start := int(atomic.AddUint32(&r.next, 1))
+
+	for i := range r.members {
+		member := r.members[(start+i)%len(r.members)]
+
+		if member.isHealthy() {
+			return member.db
+		}
+	}
+
+	return r.primary
+}
+
+func (r *replicas) runChecks() {
+	ticker := r.clock.NewTicker(r.settings.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		r.checkAll()
+
+		<-ticker.Chan()
+	}
+}
+
+func (r *replicas) checkAll() {
+	wg := &sync.WaitGroup{}
+
+	for _, member := range r.members {
+		wg.Add(1)
+
+		go func(member *replica) {
+			defer wg.Done()
+
+			r.updateHealth(member)
+		}(member)
+	}
+
+	wg.Wait()
+}
+
+func (r *replicas) updateHealth(member *replica) {
+	err := r.check(member)
+
+	switch {
+	case err != nil && member.isHealthy():
+		member.logger.Warn("replica became unhealthy, not using it for reads anymore: %s", err.Error())
+	case err != nil && !member.checked:
+		member.logger.Warn("replica is unhealthy, not using it for reads: %s", err.Error())
+	case err == nil && !member.isHealthy():
+		member.logger.Info("replica is healthy, using it for reads")
+	}
+
+	member.checked = true
+	member.setHealthy(err == nil)
+}
+
+func (r *replicas) check(member *replica) error {
+	// a check must not take longer than the interval, otherwise the next check would be delayed
+	ctx, cancel := context.WithTimeout(context.Background(), r.settings.HealthCheckInterval)
+	defer cancel()
+
+	if err := member.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("can not ping replica: %w", err)
+	}
+
+	if r.settings.MaxLag <= 0 || r.lagProvider == nil {
+		return nil
+	}
+
+	lag, err := r.lagProvider.GetReplicationLag(ctx, member.db)
+	if err != nil {
+		return err
+	}
+
+	if lag > r.settings.MaxLag {
+		return fmt.Errorf("replica lags %s behind the primary, which is more than the allowed %s", lag, r.settings.MaxLag)
+	}
+
+	return nil
+}
+
+type replica struct {
+	db      *sqlx.DB
+	logger  log.Logger
+	checked bool
+	healthy int32
+}
+
+func (r *replica) isHealthy() bool {
+	return atomic.LoadInt32(&r.healthy) == 1
+}
+
+func (r *replica) setHealthy(healthy bool) {
+	value := int32(0)
+	if healthy {
+		value = 1
+	}
+
+	atomic.StoreInt32(&r.healthy, value)
+}
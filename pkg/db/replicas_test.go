@@ -0,0 +1,158 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	goSqlMock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/db"
+	"github.com/justtrackio/gosoline/pkg/db/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type replicasTestSuite struct {
+	suite.Suite
+
+	ctx         context.Context
+	clock       clock.FakeClock
+	primary     *sqlx.DB
+	replica     *sqlx.DB
+	replicaMock goSqlMock.Sqlmock
+	lagProvider *mocks.ReplicationLagProvider
+	replicas    db.Replicas
+}
+
+func TestReplicas(t *testing.T) {
+	suite.Run(t, new(replicasTestSuite))
+}
+
+func (s *replicasTestSuite) SetupTest() {
+	primaryDb, _, err := goSqlMock.New()
+	s.NoError(err)
+
+	replicaDb, replicaMock, err := goSqlMock.New(goSqlMock.MonitorPingsOption(true))
+	s.NoError(err)
+
+	s.ctx = context.Background()
+	s.clock = clock.NewFakeClock()
+	s.primary = sqlx.NewDb(primaryDb, "sqlmock")
+	s.replica = sqlx.NewDb(replicaDb, "sqlmock")
+	s.replicaMock = replicaMock
+	s.lagProvider = mocks.NewReplicationLagProvider(s.T())
+}
+
+// setupReplicas has to be called after setting up the expectations for the first check, as it starts checking the replica
+func (s *replicasTestSuite) setupReplicas() {
+	s.replicas = db.NewReplicasWithInterfaces(logMocks.NewLoggerMockedAll(), s.clock, s.primary, []*sqlx.DB{s.replica}, s.lagProvider, db.ReplicaSettings{
+		HealthCheckInterval: time.Second * 10,
+		MaxLag:              time.Second * 5,
+	})
+}
+
+func (s *replicasTestSuite) TestHealthyReplica() {
+	s.replicaMock.ExpectPing()
+	s.lagProvider.EXPECT().GetReplicationLag(mock.Anything, s.replica).Return(time.Second, nil).Once()
+	s.setupReplicas()
+
+	s.Eventually(func() bool {
+		return s.replicas.Reader(s.ctx) == s.replica
+	}, time.Second, time.Millisecond)
+	// the replica is not checked again until the interval passed
+	s.Same(s.replica, s.replicas.Reader(s.ctx))
+	s.NoError(s.replicaMock.ExpectationsWereMet())
+}
+
+func (s *replicasTestSuite) TestPrimaryReadsForced() {
+	s.replicaMock.ExpectPing()
+	s.lagProvider.EXPECT().GetReplicationLag(mock.Anything, s.replica).Return(time.Second, nil).Once()
+	s.setupReplicas()
+
+	s.Eventually(func() bool {
+		return s.replicas.Reader(s.ctx) == s.replica
+	}, time.Second, time.Millisecond)
+	s.Same(s.primary, s.replicas.Reader(db.WithPrimaryReads(s.ctx)))
+}
+
+func (s *replicasTestSuite) TestReplicaNotReachable() {
+	s.replicaMock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+	s.setupReplicas()
+
+	s.Eventually(func() bool {
+		return s.replicaMock.ExpectationsWereMet() == nil
+	}, time.Second, time.Millisecond)
+	s.Same(s.primary, s.replicas.Reader(s.ctx))
+}
+
+func (s *replicasTestSuite) TestReplicaLagging() {
+	checked := make(chan struct{})
+
+	s.replicaMock.ExpectPing()
+	s.lagProvider.EXPECT().GetReplicationLag(mock.Anything, s.replica).Return(time.Minute, nil).Run(func(_ context.Context, _ *sqlx.DB) {
+		close(checked)
+	}).Once()
+	s.setupReplicas()
+
+	<-checked
+	s.Same(s.primary, s.replicas.Reader(s.ctx))
+
+	s.replicaMock.ExpectPing()
+	s.lagProvider.EXPECT().GetReplicationLag(mock.Anything, s.replica).Return(time.Second, nil).Once()
+
+	s.clock.BlockUntilTickers(1)
+	s.clock.Advance(time.Second * 10)
+
+	s.Eventually(func() bool {
+		return s.replicas.Reader(s.ctx) == s.replica
+	}, time.Second, time.Millisecond)
+	s.NoError(s.replicaMock.ExpectationsWereMet())
+}
+
+func (s *replicasTestSuite) TestReaderDoesNotWaitForCheck() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	s.replicaMock.ExpectPing()
+	s.lagProvider.EXPECT().GetReplicationLag(mock.Anything, s.replica).Return(time.Second, nil).Run(func(_ context.Context, _ *sqlx.DB) {
+		close(started)
+		<-release
+	}).Once()
+	s.setupReplicas()
+
+	<-started
+
+	// the check of the replica is still running, so reads have to go to the primary without waiting for it
+	done := make(chan *sqlx.DB)
+	go func() {
+		done <- s.replicas.Reader(s.ctx)
+	}()
+
+	select {
+	case reader := <-done:
+		s.Same(s.primary, reader)
+	case <-time.After(time.Second):
+		s.Fail("the reader blocked while the replica was checked")
+	}
+
+	close(release)
+
+	s.Eventually(func() bool {
+		return s.replicas.Reader(s.ctx) == s.replica
+	}, time.Second, time.Millisecond)
+}
+
+func TestReplicas_NoReplicas(t *testing.T) {
+	primaryDb, _, err := goSqlMock.New()
+	assert.NoError(t, err)
+
+	primary := sqlx.NewDb(primaryDb, "sqlmock")
+	replicas := db.NewReplicasWithInterfaces(logMocks.NewLoggerMockedAll(), clock.NewFakeClock(), primary, nil, nil, db.ReplicaSettings{})
+
+	assert.Same(t, primary, replicas.Reader(context.Background()))
+}
@@ -0,0 +1,190 @@
+package es
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+type (
+	// Clause is a single query clause like a term or range query, see Term, Terms, Match, Range, Exists and Bool.
+	Clause    map[string]interface{}
+	SortOrder string
+)
+
+type RangeBounds struct {
+	Gt  interface{}
+	Gte interface{}
+	Lt  interface{}
+	Lte interface{}
+}
+
+// Query builds the body of a search request. The clauses are combined by a bool query, a query without any clauses
+// matches all documents.
+//
+//	query := es.NewQuery().
+//		Filter(es.Term("status", "active"), es.Range("created_at", es.RangeBounds{Gte: "now-1d"})).
+//		Sort("created_at", es.SortDesc).
+//		Size(100)
+type Query struct {
+	must        []Clause
+	filter      []Clause
+	should      []Clause
+	mustNot     []Clause
+	sort        []map[string]SortOrder
+	size        *int
+	from        *int
+	searchAfter []interface{}
+}
+
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Must adds clauses every document has to match, contributing to the score.
+func (q *Query) Must(clauses ...Clause) *Query {
+	q.must = append(q.must, clauses...)
+
+	return q
+}
+
+// Filter adds clauses every document has to match without contributing to the score, which allows caching them.
+func (q *Query) Filter(clauses ...Clause) *Query {
+	q.filter = append(q.filter, clauses...)
+
+	return q
+}
+
+// Should adds clauses of which at least one has to match if there are no must or filter clauses.
+func (q *Query) Should(clauses ...Clause) *Query {
+	q.should = append(q.should, clauses...)
+
+	return q
+}
+
+// MustNot adds clauses no document is allowed to match.
+func (q *Query) MustNot(clauses ...Clause) *Query {
+	q.mustNot = append(q.mustNot, clauses...)
+
+	return q
+}
+
+func (q *Query) Sort(field string, order SortOrder) *Query {
+	q.sort = append(q.sort, map[string]SortOrder{field: order})
+
+	return q
+}
+
+func (q *Query) Size(size int) *Query {
+	q.size = &size
+
+	return q
+}
+
+func (q *Query) From(from int) *Query {
+	q.from = &from
+
+	return q
+}
+
+// SearchAfter returns the documents following the document with the given sort values, which is the efficient way to
+// page through many results.
+func (q *Query) SearchAfter(values ...interface{}) *Query {
+	q.searchAfter = values
+
+	return q
+}
+
+func (q *Query) HasSort() bool {
+	return len(q.sort) > 0
+}
+
+// Body returns the body of the search request for the query.
+func (q *Query) Body() map[string]interface{} {
+	body := map[string]interface{}{
+		"query": q.query(),
+	}
+
+	if len(q.sort) > 0 {
+		body["sort"] = q.sort
+	}
+
+	if q.size != nil {
+		body["size"] = *q.size
+	}
+
+	if q.from != nil {
+		body["from"] = *q.from
+	}
+
+	if len(q.searchAfter) > 0 {
+		body["search_after"] = q.searchAfter
+	}
+
+	return body
+}
+
+func (q *Query) query() Clause {
+	boolQuery := map[string]interface{}{}
+
+	for occur, clauses := range map[string][]Clause{
+		"must":     q.must,
+		"filter":   q.filter,
+		"should":   q.should,
+		"must_not": q.mustNot,
+	} {
+		if len(clauses) > 0 {
+			boolQuery[occur] = clauses
+		}
+	}
+
+	if len(boolQuery) == 0 {
+		return Clause{"match_all": map[string]interface{}{}}
+	}
+
+	return Clause{"bool": boolQuery}
+}
+
+// copy returns a shallow copy of the query, so paging doesn't modify the query of the caller.
+func (q *Query) copy() *Query {
+	c := *q
+
+	return &c
+}
+
+func Term(field string, value interface{}) Clause {
+	return Clause{"term": map[string]interface{}{field: value}}
+}
+
+func Terms(field string, values ...interface{}) Clause {
+	return Clause{"terms": map[string]interface{}{field: values}}
+}
+
+func Match(field string, value interface{}) Clause {
+	return Clause{"match": map[string]interface{}{field: value}}
+}
+
+func Exists(field string) Clause {
+	return Clause{"exists": map[string]interface{}{"field": field}}
+}
+
+func Range(field string, bounds RangeBounds) Clause {
+	values := map[string]interface{}{}
+
+	for op, value := range map[string]interface{}{
+		"gt":  bounds.Gt,
+		"gte": bounds.Gte,
+		"lt":  bounds.Lt,
+		"lte": bounds.Lte,
+	} {
+		if value != nil {
+			values[op] = value
+		}
+	}
+
+	return Clause{"range": map[string]interface{}{field: values}}
+}
+
+// Bool nests the clauses of the query, e.g. to combine multiple should clauses with a filter.
+func Bool(query *Query) Clause {
+	return query.query()
+}
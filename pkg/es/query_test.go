@@ -0,0 +1,51 @@
+package es_test
+
+import (
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/es"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery_BodyMatchAll(t *testing.T) {
+	body := es.NewQuery().Body()
+
+	assert.Equal(t, map[string]interface{}{
+		"query": es.Clause{"match_all": map[string]interface{}{}},
+	}, body)
+}
+
+func TestQuery_Body(t *testing.T) {
+	query := es.NewQuery().
+		Filter(es.Term("status", "active"), es.Range("created_at", es.RangeBounds{Gte: "now-1d", Lt: "now"})).
+		Must(es.Match("name", "foo")).
+		Should(es.Bool(es.NewQuery().MustNot(es.Exists("deleted_at")))).
+		Sort("created_at", es.SortDesc).
+		Sort("id", es.SortAsc).
+		Size(10).
+		SearchAfter(1234, "abc")
+
+	encoded, err := json.Marshal(query.Body())
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"query": {
+			"bool": {
+				"filter": [
+					{"term": {"status": "active"}},
+					{"range": {"created_at": {"gte": "now-1d", "lt": "now"}}}
+				],
+				"must": [
+					{"match": {"name": "foo"}}
+				],
+				"should": [
+					{"bool": {"must_not": [{"exists": {"field": "deleted_at"}}]}}
+				]
+			}
+		},
+		"sort": [{"created_at": "desc"}, {"id": "asc"}],
+		"size": 10,
+		"search_after": [1234, "abc"]
+	}`, string(encoded))
+}
@@ -0,0 +1,605 @@
+package es
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+const (
+	BulkActionIndex  = "index"
+	BulkActionDelete = "delete"
+)
+
+type RepositorySettings struct {
+	// ClientName selects the client configured by es_<client_name>_endpoint and es_<client_name>_type
+	ClientName string `cfg:"client_name" default:"default"`
+	// Index is the index or alias all documents are read from and written to
+	Index string `cfg:"index" validate:"required"`
+	// Refresh controls if writes wait for the documents to become visible for searches: false, true or wait_for
+	Refresh string `cfg:"refresh" default:"false"`
+	// PageSize is the number of documents fetched per request when iterating over search results
+	PageSize int `cfg:"page_size" default:"1000" validate:"min=1"`
+}
+
+// RawDocument is an already encoded json document, which allows writing documents without decoding them first.
+type RawDocument []byte
+
+func (d RawDocument) MarshalJSON() ([]byte, error) {
+	return d, nil
+}
+
+func (d *RawDocument) UnmarshalJSON(data []byte) error {
+	*d = append((*d)[0:0], data...)
+
+	return nil
+}
+
+type Hit[T any] struct {
+	Id     string
+	Index  string
+	Score  float64
+	Sort   []interface{}
+	Source T
+}
+
+type SearchResult[T any] struct {
+	Total int64
+	Hits  []Hit[T]
+}
+
+type BulkOperation[T any] struct {
+	Action string
+	// Id of the document, elasticsearch generates one when indexing a document without an id
+	Id       string
+	Document T
+}
+
+func BulkIndexOperation[T any](id string, document T) BulkOperation[T] {
+	return BulkOperation[T]{
+		Action:   BulkActionIndex,
+		Id:       id,
+		Document: document,
+	}
+}
+
+func BulkDeleteOperation[T any](id string) BulkOperation[T] {
+	return BulkOperation[T]{
+		Action: BulkActionDelete,
+		Id:     id,
+	}
+}
+
+// BulkError contains the reasons of all failed operations of a bulk request by the ids of their documents.
+type BulkError struct {
+	Failed map[string]string
+}
+
+func (e *BulkError) Error() string {
+	reasons := make([]string, 0, len(e.Failed))
+
+	for id, reason := range e.Failed {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", id, reason))
+	}
+
+	return fmt.Sprintf("%d bulk operations failed: %s", len(e.Failed), strings.Join(reasons, "; "))
+}
+
+// Repository reads and writes documents of type T in a single index or alias.
+//
+//go:generate mockery --name Repository
+type Repository[T any] interface {
+	Index(ctx context.Context, id string, document T) error
+	// Get returns an error for which IsNotFound is true if there is no document with the id.
+	Get(ctx context.Context, id string) (T, error)
+	Delete(ctx context.Context, id string) error
+	// Bulk executes all operations in a single request. Failed operations are returned as a *BulkError.
+	Bulk(ctx context.Context, operations ...BulkOperation[T]) error
+	// DeleteByQuery deletes all documents matching the query.
+	DeleteByQuery(ctx context.Context, query *Query) error
+	Search(ctx context.Context, query *Query) (*SearchResult[T], error)
+	// SearchAfter calls fn with every page of hits of the query, using the sort values of the last hit to fetch the
+	// next page. The query needs a sort with a unique tie-breaker field, otherwise hits with the same sort values get lost.
+	SearchAfter(ctx context.Context, query *Query, fn func([]Hit[T]) error) error
+	// Scroll calls fn with every page of hits of a consistent snapshot of the query results, which is kept alive for
+	// keepAlive between the pages.
+	Scroll(ctx context.Context, query *Query, keepAlive time.Duration, fn func([]Hit[T]) error) error
+	PutIndexTemplate(ctx context.Context, name string, template interface{}) error
+	CreateIndex(ctx context.Context, index string, body interface{}) error
+	// SwitchAlias points the alias to the index and removes it from all other indices in a single atomic request.
+	SwitchAlias(ctx context.Context, alias string, index string) error
+	// Refresh makes all writes to the index visible for searches.
+	Refresh(ctx context.Context) error
+}
+
+type repository[T any] struct {
+	logger   log.Logger
+	client   *ClientV7
+	settings *RepositorySettings
+}
+
+func ReadRepositorySettings(config cfg.Config, name string) *RepositorySettings {
+	key := fmt.Sprintf("es.repositories.%s", name)
+
+	settings := &RepositorySettings{}
+	config.UnmarshalKey(key, settings, cfg.UnmarshalWithDefaultForKey("index", name))
+
+	return settings
+}
+
+func NewRepository[T any](config cfg.Config, logger log.Logger, name string) (Repository[T], error) {
+	settings := ReadRepositorySettings(config, name)
+
+	client, err := ProvideClient(config, logger, settings.ClientName)
+	if err != nil {
+		return nil, fmt.Errorf("can not create es client %s: %w", settings.ClientName, err)
+	}
+
+	return NewRepositoryWithInterfaces[T](logger, client, settings), nil
+}
+
+func NewRepositoryWithInterfaces[T any](logger log.Logger, client *ClientV7, settings *RepositorySettings) Repository[T] {
+	return &repository[T]{
+		logger:   logger,
+		client:   client,
+		settings: settings,
+	}
+}
+
+func (r *repository[T]) Index(ctx context.Context, id string, document T) error {
+	body, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("can not marshal document %s: %w", id, err)
+	}
+
+	res, err := r.client.Index(r.settings.Index, bytes.NewReader(body),
+		r.client.Index.WithContext(ctx),
+		r.client.Index.WithDocumentID(id),
+		r.client.Index.WithRefresh(r.settings.Refresh),
+	)
+
+	return r.handle(res, err, nil, "can not index document %s", id)
+}
+
+func (r *repository[T]) Get(ctx context.Context, id string) (T, error) {
+	var document T
+
+	result := struct {
+		Source *T `json:"_source"`
+	}{
+		Source: &document,
+	}
+
+	res, err := r.client.Get(r.settings.Index, id, r.client.Get.WithContext(ctx))
+	err = r.handle(res, err, &result, "can not get document %s", id)
+
+	return document, err
+}
+
+func (r *repository[T]) Delete(ctx context.Context, id string) error {
+	res, err := r.client.Delete(r.settings.Index, id,
+		r.client.Delete.WithContext(ctx),
+		r.client.Delete.WithRefresh(r.settings.Refresh),
+	)
+
+	return r.handle(res, err, nil, "can not delete document %s", id)
+}
+
+func (r *repository[T]) Bulk(ctx context.Context, operations ...BulkOperation[T]) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+
+	for i, operation := range operations {
+		meta := map[string]interface{}{}
+		if operation.Id != "" {
+			meta["_id"] = operation.Id
+		}
+
+		if err := r.writeNdJson(buf, map[string]interface{}{operation.Action: meta}); err != nil {
+			return fmt.Errorf("can not encode bulk operation %d: %w", i, err)
+		}
+
+		if operation.Action == BulkActionDelete {
+			continue
+		}
+
+		if err := r.writeNdJson(buf, operation.Document); err != nil {
+			return fmt.Errorf("can not encode document of bulk operation %d: %w", i, err)
+		}
+	}
+
+	result := struct {
+		Errors bool                                `json:"errors"`
+		Items  []map[string]bulkResponseItemResult `json:"items"`
+	}{}
+
+	res, err := r.client.Bulk(buf,
+		r.client.Bulk.WithContext(ctx),
+		r.client.Bulk.WithIndex(r.settings.Index),
+		r.client.Bulk.WithRefresh(r.settings.Refresh),
+	)
+
+	if err = r.handle(res, err, &result, "can not execute bulk request with %d operations", len(operations)); err != nil {
+		return err
+	}
+
+	if !result.Errors {
+		return nil
+	}
+
+	bulkErr := &BulkError{
+		Failed: make(map[string]string),
+	}
+
+	for i, item := range result.Items {
+		for action, itemResult := range item {
+			// deleting a missing document is no error for us, the document is gone after all
+			if itemResult.Error == nil || (action == BulkActionDelete && itemResult.Status == http.StatusNotFound) {
+				continue
+			}
+
+			id := itemResult.Id
+			if id == "" {
+				id = fmt.Sprintf("operation %d", i)
+			}
+
+			bulkErr.Failed[id] = fmt.Sprintf("%s: %s", itemResult.Error.Type, itemResult.Error.Reason)
+		}
+	}
+
+	if len(bulkErr.Failed) == 0 {
+		return nil
+	}
+
+	return bulkErr
+}
+
+func (r *repository[T]) DeleteByQuery(ctx context.Context, query *Query) error {
+	body, err := r.encode(map[string]interface{}{
+		"query": query.query(),
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.DeleteByQuery([]string{r.settings.Index}, body,
+		r.client.DeleteByQuery.WithContext(ctx),
+		r.client.DeleteByQuery.WithConflicts("proceed"),
+		r.client.DeleteByQuery.WithRefresh(r.settings.Refresh != "false"),
+	)
+
+	return r.handle(res, err, nil, "can not delete documents by query")
+}
+
+func (r *repository[T]) Search(ctx context.Context, query *Query) (*SearchResult[T], error) {
+	body, err := r.encode(query.Body())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithContext(ctx),
+		r.client.Search.WithIndex(r.settings.Index),
+		r.client.Search.WithBody(body),
+	)
+
+	result, err := r.searchResult(res, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SearchResult, nil
+}
+
+func (r *repository[T]) SearchAfter(ctx context.Context, query *Query, fn func([]Hit[T]) error) error {
+	if !query.HasSort() {
+		return fmt.Errorf("search after needs a query with a sort")
+	}
+
+	query = query.copy()
+
+	if query.size == nil {
+		query.Size(r.settings.PageSize)
+	}
+
+	for {
+		result, err := r.Search(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		if len(result.Hits) == 0 {
+			return nil
+		}
+
+		if err = fn(result.Hits); err != nil {
+			return err
+		}
+
+		if len(result.Hits) < *query.size {
+			return nil
+		}
+
+		query.SearchAfter(result.Hits[len(result.Hits)-1].Sort...)
+	}
+}
+
+func (r *repository[T]) Scroll(ctx context.Context, query *Query, keepAlive time.Duration, fn func([]Hit[T]) error) error {
+	query = query.copy()
+
+	if query.size == nil {
+		query.Size(r.settings.PageSize)
+	}
+
+	body, err := r.encode(query.Body())
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithContext(ctx),
+		r.client.Search.WithIndex(r.settings.Index),
+		r.client.Search.WithBody(body),
+		r.client.Search.WithScroll(keepAlive),
+	)
+
+	result, err := r.searchResult(res, err)
+	if err != nil {
+		return err
+	}
+
+	// every page might come with a new scroll id, only the latest one is still alive when we are done
+	scrollId := result.scrollId
+	defer func() {
+		r.clearScroll(scrollId)
+	}()
+
+	for len(result.Hits) > 0 {
+		if err = fn(result.Hits); err != nil {
+			return err
+		}
+
+		res, err = r.client.Scroll(
+			r.client.Scroll.WithContext(ctx),
+			r.client.Scroll.WithScrollID(scrollId),
+			r.client.Scroll.WithScroll(keepAlive),
+		)
+
+		if result, err = r.searchResult(res, err); err != nil {
+			return err
+		}
+
+		if result.scrollId != "" {
+			scrollId = result.scrollId
+		}
+	}
+
+	return nil
+}
+
+func (r *repository[T]) PutIndexTemplate(ctx context.Context, name string, template interface{}) error {
+	body, err := r.encode(template)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Indices.PutTemplate(name, body, r.client.Indices.PutTemplate.WithContext(ctx))
+
+	return r.handle(res, err, nil, "can not put index template %s", name)
+}
+
+func (r *repository[T]) CreateIndex(ctx context.Context, index string, body interface{}) error {
+	reader, err := r.encode(body)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Indices.Create(index,
+		r.client.Indices.Create.WithContext(ctx),
+		r.client.Indices.Create.WithBody(reader),
+	)
+
+	return r.handle(res, err, nil, "can not create index %s", index)
+}
+
+func (r *repository[T]) SwitchAlias(ctx context.Context, alias string, index string) error {
+	current := map[string]interface{}{}
+
+	res, err := r.client.Indices.GetAlias(
+		r.client.Indices.GetAlias.WithContext(ctx),
+		r.client.Indices.GetAlias.WithName(alias),
+	)
+
+	if err = r.handle(res, err, &current, "can not get indices of alias %s", alias); err != nil && !IsNotFound(err) {
+		return err
+	}
+
+	actions := []map[string]interface{}{
+		{"add": map[string]string{"index": index, "alias": alias}},
+	}
+
+	for oldIndex := range current {
+		if oldIndex == index {
+			continue
+		}
+
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]string{"index": oldIndex, "alias": alias},
+		})
+	}
+
+	body, err := r.encode(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return err
+	}
+
+	res, err = r.client.Indices.UpdateAliases(body, r.client.Indices.UpdateAliases.WithContext(ctx))
+
+	return r.handle(res, err, nil, "can not switch alias %s to index %s", alias, index)
+}
+
+func (r *repository[T]) Refresh(ctx context.Context) error {
+	res, err := r.client.Indices.Refresh(
+		r.client.Indices.Refresh.WithContext(ctx),
+		r.client.Indices.Refresh.WithIndex(r.settings.Index),
+	)
+
+	return r.handle(res, err, nil, "can not refresh index %s", r.settings.Index)
+}
+
+type bulkResponseItemResult struct {
+	Id     string `json:"_id"`
+	Status int    `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+type searchResult[T any] struct {
+	*SearchResult[T]
+	scrollId string
+}
+
+func (r *repository[T]) searchResult(res *esapi.Response, err error) (*searchResult[T], error) {
+	response := struct {
+		ScrollId string `json:"_scroll_id"`
+		Hits     struct {
+			// elasticsearch 7 returns an object with the total, but older versions and some proxies a plain number
+			Total interface{} `json:"total"`
+			Hits  []struct {
+				Id     string        `json:"_id"`
+				Index  string        `json:"_index"`
+				Score  float64       `json:"_score"`
+				Sort   []interface{} `json:"sort"`
+				Source T             `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}{}
+
+	if err = r.handle(res, err, &response, "can not search index %s", r.settings.Index); err != nil {
+		return nil, err
+	}
+
+	result := &searchResult[T]{
+		SearchResult: &SearchResult[T]{
+			Total: r.total(response.Hits.Total),
+			Hits:  make([]Hit[T], len(response.Hits.Hits)),
+		},
+		scrollId: response.ScrollId,
+	}
+
+	for i, hit := range response.Hits.Hits {
+		result.Hits[i] = Hit[T]{
+			Id:     hit.Id,
+			Index:  hit.Index,
+			Score:  hit.Score,
+			Sort:   hit.Sort,
+			Source: hit.Source,
+		}
+	}
+
+	return result, nil
+}
+
+func (r *repository[T]) total(total interface{}) int64 {
+	switch value := total.(type) {
+	case map[string]interface{}:
+		return r.total(value["value"])
+	case float64:
+		return int64(value)
+	default:
+		return 0
+	}
+}
+
+func (r *repository[T]) clearScroll(scrollId string) {
+	if scrollId == "" {
+		return
+	}
+
+	// the scroll expires anyway after the keep alive, so there is no need to fail if we can't clear it
+	res, err := r.client.ClearScroll(r.client.ClearScroll.WithScrollID(scrollId))
+	if err = r.handle(res, err, nil, "can not clear scroll"); err != nil {
+		r.logger.Warn("%s", err.Error())
+	}
+}
+
+// handle closes the body of the response, turns error responses into an *Error and decodes the body into result.
+func (r *repository[T]) handle(res *esapi.Response, err error, result interface{}, format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			r.logger.Warn("can not close response body: %s", err.Error())
+		}
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("%s: can not read response: %w", msg, err)
+	}
+
+	if res.IsError() {
+		esErr := NewError(fmt.Sprintf("%s: %s: %s", msg, res.Status(), string(body)))
+		esErr.Status = res.StatusCode
+
+		return esErr
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err = json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("%s: can not decode response: %w", msg, err)
+	}
+
+	return nil
+}
+
+func (r *repository[T]) encode(body interface{}) (io.Reader, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("can not encode request body: %w", err)
+	}
+
+	return bytes.NewReader(encoded), nil
+}
+
+func (r *repository[T]) writeNdJson(buf *bytes.Buffer, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(encoded)
+	buf.WriteByte('\n')
+
+	return nil
+}
+
+// IsNotFound returns true if the error is an error response of elasticsearch with status 404, e.g. because the
+// document or index doesn't exist.
+func IsNotFound(err error) bool {
+	var esErr *Error
+
+	return errors.As(err, &esErr) && esErr.Status == http.StatusNotFound
+}
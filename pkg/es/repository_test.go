@@ -0,0 +1,223 @@
+package es_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/es"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type document struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type request struct {
+	method string
+	path   string
+	query  string
+	body   string
+}
+
+type response struct {
+	status int
+	body   string
+}
+
+type RepositoryTestSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	server     *httptest.Server
+	requests   []request
+	responses  []response
+	repository es.Repository[document]
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.requests = nil
+	s.responses = nil
+
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.requests = append(s.requests, request{
+			method: r.Method,
+			path:   r.URL.Path,
+			query:  r.URL.RawQuery,
+			body:   string(body),
+		})
+
+		if len(s.responses) == 0 {
+			s.FailNow("unexpected request", "%s %s", r.Method, r.URL.String())
+		}
+
+		res := s.responses[0]
+		s.responses = s.responses[1:]
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(res.status)
+		_, _ = w.Write([]byte(res.body))
+	}))
+
+	logger := logMocks.NewLoggerMockedAll()
+
+	client, err := es.NewSimpleClient(logger, s.server.URL, "default")
+	s.NoError(err)
+
+	s.repository = es.NewRepositoryWithInterfaces[document](logger, client, &es.RepositorySettings{
+		Index:    "documents",
+		Refresh:  "wait_for",
+		PageSize: 2,
+	})
+}
+
+func (s *RepositoryTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *RepositoryTestSuite) respond(status int, body string) {
+	s.responses = append(s.responses, response{status: status, body: body})
+}
+
+func (s *RepositoryTestSuite) TestIndex() {
+	s.respond(http.StatusCreated, `{"result":"created"}`)
+
+	err := s.repository.Index(s.ctx, "1", document{Id: 1, Name: "foo"})
+	s.NoError(err)
+
+	s.Len(s.requests, 1)
+	s.Equal("PUT", s.requests[0].method)
+	s.Equal("/documents/_doc/1", s.requests[0].path)
+	s.Equal("refresh=wait_for", s.requests[0].query)
+	s.JSONEq(`{"id":1,"name":"foo"}`, s.requests[0].body)
+}
+
+func (s *RepositoryTestSuite) TestGet() {
+	s.respond(http.StatusOK, `{"_id":"1","found":true,"_source":{"id":1,"name":"foo"}}`)
+
+	doc, err := s.repository.Get(s.ctx, "1")
+	s.NoError(err)
+	s.Equal(document{Id: 1, Name: "foo"}, doc)
+	s.Equal("/documents/_doc/1", s.requests[0].path)
+}
+
+func (s *RepositoryTestSuite) TestGetNotFound() {
+	s.respond(http.StatusNotFound, `{"_id":"1","found":false}`)
+
+	_, err := s.repository.Get(s.ctx, "1")
+	s.Error(err)
+	s.True(es.IsNotFound(err))
+}
+
+func (s *RepositoryTestSuite) TestBulk() {
+	s.respond(http.StatusOK, `{"errors":true,"items":[
+		{"index":{"_id":"1","status":201}},
+		{"delete":{"_id":"2","status":404,"error":{"type":"not_found","reason":"missing"}}},
+		{"index":{"_id":"3","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}
+	]}`)
+
+	err := s.repository.Bulk(s.ctx,
+		es.BulkIndexOperation("1", document{Id: 1, Name: "foo"}),
+		es.BulkDeleteOperation[document]("2"),
+		es.BulkIndexOperation("3", document{Id: 3}),
+	)
+
+	s.Equal(&es.BulkError{
+		Failed: map[string]string{
+			"3": "mapper_parsing_exception: failed to parse",
+		},
+	}, err)
+
+	s.Equal("/documents/_bulk", s.requests[0].path)
+	s.Equal(`{"index":{"_id":"1"}}
+{"id":1,"name":"foo"}
+{"delete":{"_id":"2"}}
+{"index":{"_id":"3"}}
+{"id":3,"name":""}
+`, s.requests[0].body)
+}
+
+func (s *RepositoryTestSuite) TestSearchAfter() {
+	s.respond(http.StatusOK, `{"hits":{"total":{"value":3},"hits":[
+		{"_id":"1","_source":{"id":1},"sort":[1]},
+		{"_id":"2","_source":{"id":2},"sort":[2]}
+	]}}`)
+	s.respond(http.StatusOK, `{"hits":{"total":{"value":3},"hits":[
+		{"_id":"3","_source":{"id":3},"sort":[3]}
+	]}}`)
+
+	ids := make([]int, 0)
+
+	err := s.repository.SearchAfter(s.ctx, es.NewQuery().Sort("id", es.SortAsc), func(hits []es.Hit[document]) error {
+		for _, hit := range hits {
+			ids = append(ids, hit.Source.Id)
+		}
+
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal([]int{1, 2, 3}, ids)
+	s.Len(s.requests, 2)
+	s.Equal("/documents/_search", s.requests[1].path)
+	s.JSONEq(`{"query":{"match_all":{}},"sort":[{"id":"asc"}],"size":2,"search_after":[2]}`, s.requests[1].body)
+}
+
+func (s *RepositoryTestSuite) TestSearchAfterWithoutSort() {
+	err := s.repository.SearchAfter(s.ctx, es.NewQuery(), func(hits []es.Hit[document]) error {
+		return nil
+	})
+
+	s.EqualError(err, "search after needs a query with a sort")
+}
+
+func (s *RepositoryTestSuite) TestScroll() {
+	s.respond(http.StatusOK, `{"_scroll_id":"scroll-1","hits":{"total":{"value":2},"hits":[{"_id":"1","_source":{"id":1}}]}}`)
+	s.respond(http.StatusOK, `{"_scroll_id":"scroll-2","hits":{"total":{"value":2},"hits":[{"_id":"2","_source":{"id":2}}]}}`)
+	s.respond(http.StatusOK, `{"_scroll_id":"scroll-3","hits":{"total":{"value":2},"hits":[]}}`)
+	s.respond(http.StatusOK, `{"succeeded":true}`)
+
+	ids := make([]int, 0)
+
+	err := s.repository.Scroll(s.ctx, es.NewQuery(), 0, func(hits []es.Hit[document]) error {
+		for _, hit := range hits {
+			ids = append(ids, hit.Source.Id)
+		}
+
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal([]int{1, 2}, ids)
+	s.Len(s.requests, 4)
+	s.Equal("scroll_id=scroll-1", s.requests[1].query)
+	s.Equal("scroll_id=scroll-2", s.requests[2].query)
+	s.Equal("DELETE", s.requests[3].method)
+	s.Equal("/_search/scroll/scroll-3", s.requests[3].path)
+}
+
+func (s *RepositoryTestSuite) TestSwitchAlias() {
+	s.respond(http.StatusOK, `{"documents-1":{"aliases":{"documents":{}}}}`)
+	s.respond(http.StatusOK, `{"acknowledged":true}`)
+
+	err := s.repository.SwitchAlias(s.ctx, "documents", "documents-2")
+	s.NoError(err)
+
+	s.Equal("/_alias/documents", s.requests[0].path)
+	s.Equal("/_aliases", s.requests[1].path)
+	s.JSONEq(`{"actions":[
+		{"add":{"index":"documents-2","alias":"documents"}},
+		{"remove":{"index":"documents-1","alias":"documents"}}
+	]}`, s.requests[1].body)
+}
+
+func TestRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
@@ -0,0 +1,39 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/db"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+// postgres can't disable foreign key checks for a session without superuser privileges, so we truncate all tables
+// referencing the purged table, too. Identities are restarted to get the same ids on every run of the fixtures.
+const truncateTableCascadeStatement = `TRUNCATE TABLE %s RESTART IDENTITY CASCADE;`
+
+type postgresPurger struct {
+	client    db.Client
+	logger    log.Logger
+	tableName string
+}
+
+func newPostgresPurger(config cfg.Config, logger log.Logger, tableName string) (*postgresPurger, error) {
+	client, err := db.NewClient(config, logger, "default")
+	if err != nil {
+		return nil, fmt.Errorf("can not create db client: %w", err)
+	}
+
+	return &postgresPurger{client: client, logger: logger, tableName: tableName}, nil
+}
+
+func (p *postgresPurger) purgePostgres(ctx context.Context) error {
+	_, err := p.client.Exec(ctx, fmt.Sprintf(truncateTableCascadeStatement, p.tableName))
+	if err != nil {
+		p.logger.Error("error truncating table %s: %w", p.tableName, err)
+		return err
+	}
+
+	return nil
+}
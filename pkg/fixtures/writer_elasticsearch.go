@@ -0,0 +1,81 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/es"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+type ElasticsearchFixture struct {
+	// Id of the document, elasticsearch generates one if it is empty
+	Id       string
+	Document interface{}
+}
+
+type elasticsearchFixtureWriter[T any] struct {
+	logger     log.Logger
+	repository es.Repository[T]
+}
+
+// ElasticsearchFixtureWriterFactory writes fixtures of type *ElasticsearchFixture with documents of type T to the
+// repository configured at es.repositories.<name>.
+func ElasticsearchFixtureWriterFactory[T any](name string) FixtureWriterFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (FixtureWriter, error) {
+		repository, err := es.NewRepository[T](config, logger, name)
+		if err != nil {
+			return nil, fmt.Errorf("can not create es repository: %w", err)
+		}
+
+		return NewElasticsearchFixtureWriterWithInterfaces[T](logger, repository), nil
+	}
+}
+
+func NewElasticsearchFixtureWriterWithInterfaces[T any](logger log.Logger, repository es.Repository[T]) FixtureWriter {
+	return &elasticsearchFixtureWriter[T]{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+func (d *elasticsearchFixtureWriter[T]) Purge(ctx context.Context) error {
+	if err := d.repository.DeleteByQuery(ctx, es.NewQuery()); err != nil {
+		return fmt.Errorf("can not purge documents: %w", err)
+	}
+
+	return nil
+}
+
+func (d *elasticsearchFixtureWriter[T]) Write(ctx context.Context, fs *FixtureSet) error {
+	if len(fs.Fixtures) == 0 {
+		return nil
+	}
+
+	operations := make([]es.BulkOperation[T], 0, len(fs.Fixtures))
+
+	for _, item := range fs.Fixtures {
+		fixture := item.(*ElasticsearchFixture)
+
+		document, ok := fixture.Document.(T)
+		if !ok {
+			return fmt.Errorf("the document of the fixture with id %q is of type %T, expected %T", fixture.Id, fixture.Document, document)
+		}
+
+		operations = append(operations, es.BulkIndexOperation(fixture.Id, document))
+	}
+
+	if err := d.repository.Bulk(ctx, operations...); err != nil {
+		return fmt.Errorf("can not write documents: %w", err)
+	}
+
+	// fixtures are usually queried right after loading them, so make them visible for searches
+	if err := d.repository.Refresh(ctx); err != nil {
+		return fmt.Errorf("can not refresh index: %w", err)
+	}
+
+	d.logger.Info("loaded %d elasticsearch fixtures", len(fs.Fixtures))
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/db-repo"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+type postgresOrmFixtureWriter struct {
+	logger   log.Logger
+	metadata *db_repo.Metadata
+	repo     db_repo.Repository
+	purger   *postgresPurger
+}
+
+func PostgresOrmFixtureWriterFactory(metadata *db_repo.Metadata) FixtureWriterFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (FixtureWriter, error) {
+		metadata.ModelId.PadFromConfig(config)
+
+		settings := db_repo.Settings{
+			AppId:    cfg.GetAppIdFromConfig(config),
+			Metadata: *metadata,
+		}
+
+		repo, err := db_repo.New(config, logger, settings)
+		if err != nil {
+			return nil, fmt.Errorf("can not create repo: %w", err)
+		}
+
+		purger, err := newPostgresPurger(config, logger, metadata.TableName)
+		if err != nil {
+			return nil, fmt.Errorf("can not create purger: %w", err)
+		}
+
+		return NewPostgresOrmFixtureWriterWithInterfaces(logger, metadata, repo, purger), nil
+	}
+}
+
+func NewPostgresOrmFixtureWriterWithInterfaces(logger log.Logger, metadata *db_repo.Metadata, repo db_repo.Repository, purger *postgresPurger) FixtureWriter {
+	return &postgresOrmFixtureWriter{
+		logger:   logger,
+		metadata: metadata,
+		repo:     repo,
+		purger:   purger,
+	}
+}
+
+func (m *postgresOrmFixtureWriter) Purge(ctx context.Context) error {
+	err := m.purger.purgePostgres(ctx)
+	if err != nil {
+		m.logger.Error("error occured during purging of table %s in orm postgres fixture loader: %w", m.metadata.TableName, err)
+
+		return err
+	}
+
+	m.logger.Info("purged table for orm postgres fixtureSets")
+
+	return nil
+}
+
+func (m *postgresOrmFixtureWriter) Write(ctx context.Context, fs *FixtureSet) error {
+	for _, item := range fs.Fixtures {
+		model := item.(db_repo.ModelBased)
+
+		err := m.repo.Update(ctx, model)
+		if err != nil {
+			return err
+		}
+	}
+
+	m.logger.Info("loaded %d postgres fixtures", len(fs.Fixtures))
+
+	return nil
+}
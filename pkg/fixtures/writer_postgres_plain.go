@@ -0,0 +1,123 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/db"
+	"github.com/justtrackio/gosoline/pkg/funk"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+type PostgresPlainFixtureValues []interface{}
+
+type PostgresPlainMetaData struct {
+	TableName string
+	Columns   []string
+	// PrimaryKeys are used to replace existing rows (like REPLACE for mysql). Without them, the fixtures are just inserted.
+	PrimaryKeys []string
+}
+
+type postgresPlainFixtureWriter struct {
+	logger   log.Logger
+	client   db.Client
+	metadata *PostgresPlainMetaData
+	purger   *postgresPurger
+}
+
+func PostgresPlainFixtureWriterFactory(metadata *PostgresPlainMetaData) FixtureWriterFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (FixtureWriter, error) {
+		dbClient, err := db.NewClient(config, logger, "default")
+		if err != nil {
+			return nil, fmt.Errorf("can not create dbClient: %w", err)
+		}
+
+		purger, err := newPostgresPurger(config, logger, metadata.TableName)
+		if err != nil {
+			return nil, fmt.Errorf("can not create purger: %w", err)
+		}
+
+		return NewPostgresPlainFixtureWriterWithInterfaces(logger, dbClient, metadata, purger), nil
+	}
+}
+
+func NewPostgresPlainFixtureWriterWithInterfaces(logger log.Logger, client db.Client, metadata *PostgresPlainMetaData, purger *postgresPurger) FixtureWriter {
+	return &postgresPlainFixtureWriter{
+		logger:   logger,
+		client:   client,
+		metadata: metadata,
+		purger:   purger,
+	}
+}
+
+func (m *postgresPlainFixtureWriter) Purge(ctx context.Context) error {
+	err := m.purger.purgePostgres(ctx)
+	if err != nil {
+		m.logger.Error("error occured during purging of table %s in plain postgres fixture loader: %w", m.metadata.TableName, err)
+
+		return err
+	}
+
+	m.logger.Info("purged table %s for plain postgres fixtureSets", m.metadata.TableName)
+
+	return nil
+}
+
+func (m *postgresPlainFixtureWriter) Write(ctx context.Context, fs *FixtureSet) error {
+	for _, item := range fs.Fixtures {
+		fixture := item.(PostgresPlainFixtureValues)
+
+		sql, args, err := m.buildSql(fixture)
+		if err != nil {
+			return err
+		}
+
+		res, err := m.client.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		ar, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		m.logger.Debug(fmt.Sprintf("affected rows while fixture loading: %d", ar))
+	}
+
+	m.logger.Info("loaded %d plain postgres fixtures", len(fs.Fixtures))
+
+	return nil
+}
+
+func (m *postgresPlainFixtureWriter) buildSql(values PostgresPlainFixtureValues) (string, []interface{}, error) {
+	insertBuilder := squirrel.Insert(m.metadata.TableName).
+		PlaceholderFormat(squirrel.Dollar).
+		Columns(m.metadata.Columns...).
+		Values(values...)
+
+	if len(m.metadata.PrimaryKeys) > 0 {
+		insertBuilder = insertBuilder.Suffix(m.buildUpsertSuffix())
+	}
+
+	return insertBuilder.ToSql()
+}
+
+func (m *postgresPlainFixtureWriter) buildUpsertSuffix() string {
+	columns := funk.Filter(m.metadata.Columns, func(column string) bool {
+		return !funk.Contains(m.metadata.PrimaryKeys, column)
+	})
+
+	if len(columns) == 0 {
+		return fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", strings.Join(m.metadata.PrimaryKeys, ","))
+	}
+
+	updates := funk.Map(columns, func(column string) string {
+		return fmt.Sprintf("%s = EXCLUDED.%s", column, column)
+	})
+
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(m.metadata.PrimaryKeys, ","), strings.Join(updates, ","))
+}
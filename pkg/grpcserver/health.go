@@ -6,10 +6,19 @@ import (
 
 	protobuf "github.com/justtrackio/gosoline/pkg/grpcserver/proto/health/v1"
 	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/metric"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	MetricGrpcHealthStatus      = "GrpcHealthStatus"
+	MetricGrpcHealthServing     = "GrpcHealthServing"
+	MetricDimensionService      = "service"
+	MetricDimensionHealthStatus = "status"
+	metricServiceNameServerWide = "server"
+)
+
 // HealthCheckCallback the signature of the HealthCheckCallback.
 type HealthCheckCallback func(ctx context.Context) protobuf.HealthCheckResponse_ServingStatus
 
@@ -23,8 +32,9 @@ type ServiceHealthCallback struct {
 type healthServer struct {
 	protobuf.UnimplementedHealthServer
 
-	logger     log.Logger
-	cancelFunc context.CancelFunc
+	logger       log.Logger
+	metricWriter metric.Writer
+	cancelFunc   context.CancelFunc
 
 	mu sync.RWMutex
 	// If shutdown is true, it's expected all serving status is NOT_SERVING, and
@@ -38,12 +48,17 @@ type healthServer struct {
 
 // NewHealthServer returns a new HealthServer.
 func NewHealthServer(logger log.Logger, cancelFunc context.CancelFunc) *healthServer {
+	return NewHealthServerWithInterfaces(logger, metric.NewWriter(), cancelFunc)
+}
+
+func NewHealthServerWithInterfaces(logger log.Logger, metricWriter metric.Writer, cancelFunc context.CancelFunc) *healthServer {
 	return &healthServer{
-		logger:     logger,
-		cancelFunc: cancelFunc,
-		statusMap:  map[string]protobuf.HealthCheckResponse_ServingStatus{"": protobuf.HealthCheckResponse_SERVING},
-		updates:    map[string]map[protobuf.Health_WatchServer]chan protobuf.HealthCheckResponse_ServingStatus{},
-		callbacks:  []ServiceHealthCallback{},
+		logger:       logger,
+		metricWriter: metricWriter,
+		cancelFunc:   cancelFunc,
+		statusMap:    map[string]protobuf.HealthCheckResponse_ServingStatus{"": protobuf.HealthCheckResponse_SERVING},
+		updates:      map[string]map[protobuf.Health_WatchServer]chan protobuf.HealthCheckResponse_ServingStatus{},
+		callbacks:    []ServiceHealthCallback{},
 	}
 }
 
@@ -186,6 +201,10 @@ func (s *healthServer) Resume() {
 }
 
 func (s *healthServer) setServingStatusLocked(service string, servingStatus protobuf.HealthCheckResponse_ServingStatus) {
+	if previous, ok := s.statusMap[service]; !ok || previous != servingStatus {
+		s.writeTransitionMetrics(service, servingStatus)
+	}
+
 	s.statusMap[service] = servingStatus
 	for _, update := range s.updates[service] {
 		// Clears previous updates, that are not sent to the client, from the channel.
@@ -198,3 +217,36 @@ func (s *healthServer) setServingStatusLocked(service string, servingStatus prot
 		update <- servingStatus
 	}
 }
+
+func (s *healthServer) writeTransitionMetrics(service string, servingStatus protobuf.HealthCheckResponse_ServingStatus) {
+	if service == "" {
+		service = metricServiceNameServerWide
+	}
+
+	serving := 0.0
+	if servingStatus == protobuf.HealthCheckResponse_SERVING {
+		serving = 1.0
+	}
+
+	s.metricWriter.Write(metric.Data{
+		{
+			Priority:   metric.PriorityHigh,
+			MetricName: MetricGrpcHealthStatus,
+			Dimensions: metric.Dimensions{
+				MetricDimensionService:      service,
+				MetricDimensionHealthStatus: servingStatus.String(),
+			},
+			Value: 1.0,
+			Unit:  metric.UnitCount,
+		},
+		{
+			Priority:   metric.PriorityHigh,
+			MetricName: MetricGrpcHealthServing,
+			Dimensions: metric.Dimensions{
+				MetricDimensionService: service,
+			},
+			Value: serving,
+			Unit:  metric.UnitCountMinimum,
+		},
+	})
+}
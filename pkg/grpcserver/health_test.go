@@ -8,6 +8,8 @@ import (
 	"github.com/justtrackio/gosoline/pkg/grpcserver"
 	protobuf "github.com/justtrackio/gosoline/pkg/grpcserver/proto/health/v1"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/metric"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
 )
 
 func Test_healthServer_Check(t *testing.T) {
@@ -75,3 +77,36 @@ func Test_healthServer_Check(t *testing.T) {
 		})
 	}
 }
+
+func Test_healthServer_TransitionMetrics(t *testing.T) {
+	logger := logMocks.NewLoggerMockedAll()
+	writer := new(metricMocks.Writer)
+	writer.On("Write", metric.Data{
+		{
+			Priority:   metric.PriorityHigh,
+			MetricName: grpcserver.MetricGrpcHealthStatus,
+			Dimensions: metric.Dimensions{
+				grpcserver.MetricDimensionService:      "server",
+				grpcserver.MetricDimensionHealthStatus: "NOT_SERVING",
+			},
+			Value: 1.0,
+			Unit:  metric.UnitCount,
+		},
+		{
+			Priority:   metric.PriorityHigh,
+			MetricName: grpcserver.MetricGrpcHealthServing,
+			Dimensions: metric.Dimensions{
+				grpcserver.MetricDimensionService: "server",
+			},
+			Value: 0.0,
+			Unit:  metric.UnitCountMinimum,
+		},
+	}).Once()
+	defer writer.AssertExpectations(t)
+
+	hs := grpcserver.NewHealthServerWithInterfaces(logger, writer, func() {})
+
+	// only transitions are reported
+	hs.Shutdown()
+	hs.Shutdown()
+}
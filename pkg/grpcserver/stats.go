@@ -37,9 +37,25 @@ type statsHolder struct {
 	OutPayload                interface{}
 	OutData                   []byte
 	OutHeaders                *sync.Map
+	InMessages                int
+	OutMessages               int
 	Error                     error
 }
 
+// StreamType classifies the RPC by the direction of its streams.
+func (s *statsHolder) StreamType() string {
+	switch {
+	case s.IsClientStream && s.IsServerStream:
+		return StreamTypeBidirectionalStreaming
+	case s.IsClientStream:
+		return StreamTypeClientStream
+	case s.IsServerStream:
+		return StreamTypeServerStream
+	default:
+		return StreamTypeUnary
+	}
+}
+
 func (s *statsHolder) GetLoggerFields() log.Fields {
 	fields := log.Fields{
 		"start_time":                   s.BeginTime,
@@ -67,6 +83,9 @@ func (s *statsHolder) GetLoggerFields() log.Fields {
 		"out_payload_wire_length":      s.OutPayloadWireLength,
 		"out_payload":                  s.OutPayload,
 		"out_data":                     s.OutData,
+		"in_messages":                  s.InMessages,
+		"out_messages":                 s.OutMessages,
+		"stream_type":                  s.StreamType(),
 		"error":                        s.Error,
 	}
 
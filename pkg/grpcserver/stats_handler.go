@@ -2,12 +2,17 @@ package grpcserver
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/justtrackio/gosoline/pkg/log"
 	"github.com/justtrackio/gosoline/pkg/metric"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
 )
 
 type key int
@@ -15,20 +20,35 @@ type key int
 const (
 	contextKey key = 0
 
-	MetricApiRequestCount        = "ApiRequestCount"
-	MetricApiRequestResponseTime = "ApiRequestResponseTime"
-	MetricDimensionFullMethod    = "full_method"
+	MetricApiRequestCount            = "ApiRequestCount"
+	MetricApiRequestResponseTime     = "ApiRequestResponseTime"
+	MetricApiRequestsInFlight        = "ApiRequestsInFlight"
+	MetricApiStreamMessagesReceived  = "ApiStreamMessagesReceived"
+	MetricApiStreamMessagesSent      = "ApiStreamMessagesSent"
+	MetricApiStatusFormat            = "ApiStatus%dXX"
+	MetricGrpcStatusFormat           = "GrpcStatus%s"
+	MetricDimensionFullMethod        = "full_method"
+	MetricDimensionStreamType        = "stream_type"
+	StreamTypeUnary                  = "unary"
+	StreamTypeClientStream           = "client_stream"
+	StreamTypeServerStream           = "server_stream"
+	StreamTypeBidirectionalStreaming = "bidi_stream"
 )
 
 type statsHandler struct {
 	logger       log.Logger
 	metricWriter metric.Writer
 	settings     *Settings
+	inFlight     sync.Map
 }
 
 func NewStatsHandler(logger log.Logger, settings *Settings) *statsHandler {
 	writer := metric.NewWriter()
 
+	return NewStatsHandlerWithInterfaces(logger, settings, writer)
+}
+
+func NewStatsHandlerWithInterfaces(logger log.Logger, settings *Settings, writer metric.Writer) *statsHandler {
 	return &statsHandler{
 		logger:       logger,
 		metricWriter: writer,
@@ -36,8 +56,14 @@ func NewStatsHandler(logger log.Logger, settings *Settings) *statsHandler {
 	}
 }
 
-func (s *statsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
-	return ctx
+// TagRPC attaches a statsHolder to every RPC. Multiple RPCs can be multiplexed over the same connection, so the
+// holder can not be attached to the connection.
+func (s *statsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, contextKey, &statsHolder{
+		FullMethod: info.FullMethodName,
+		InHeaders:  &sync.Map{},
+		OutHeaders: &sync.Map{},
+	})
 }
 
 func (s *statsHandler) HandleRPC(ctx context.Context, st stats.RPCStats) {
@@ -51,6 +77,8 @@ func (s *statsHandler) HandleRPC(ctx context.Context, st stats.RPCStats) {
 		holder.IsServerStream = v.IsServerStream
 		holder.IsTransparentRetryAttempt = v.IsTransparentRetryAttempt
 
+		s.writeInFlight(holder.FullMethod, 1)
+
 	case *stats.InHeader:
 		holder.InHeaderWireLength = v.WireLength
 		holder.InCompression = v.Compression
@@ -66,6 +94,7 @@ func (s *statsHandler) HandleRPC(ctx context.Context, st stats.RPCStats) {
 		}
 
 	case *stats.InPayload:
+		holder.InMessages++
 		holder.InPayloadLength = v.Length
 		holder.InPayloadWireLength = v.WireLength
 		holder.RecvTime = v.RecvTime
@@ -89,6 +118,7 @@ func (s *statsHandler) HandleRPC(ctx context.Context, st stats.RPCStats) {
 		}
 
 	case *stats.OutPayload:
+		holder.OutMessages++
 		if s.settings.Stats.LogPayload {
 			holder.OutPayload = v.Payload
 		}
@@ -104,16 +134,14 @@ func (s *statsHandler) HandleRPC(ctx context.Context, st stats.RPCStats) {
 		holder.Error = v.Error
 		holder.TotalTime = v.EndTime.Sub(v.BeginTime).Nanoseconds()
 
+		s.writeInFlight(holder.FullMethod, -1)
 		s.writeLog(ctx, holder)
 		s.writeMetrics(holder)
 	}
 }
 
 func (s *statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
-	return context.WithValue(ctx, contextKey, &statsHolder{
-		InHeaders:  &sync.Map{},
-		OutHeaders: &sync.Map{},
-	})
+	return ctx
 }
 
 func (s *statsHandler) HandleConn(_ context.Context, _ stats.ConnStats) {
@@ -154,4 +182,98 @@ func (s *statsHandler) writeMetrics(holder *statsHolder) {
 		Value: 1.0,
 		Unit:  metric.UnitCount,
 	})
+
+	code := status.Code(holder.Error)
+
+	// the status classes of the http status equivalents are shared with the apiserver
+	s.metricWriter.WriteOne(&metric.Datum{
+		Priority:   metric.PriorityHigh,
+		MetricName: fmt.Sprintf(MetricApiStatusFormat, httpStatusFromCode(code)/100),
+		Dimensions: metric.Dimensions{
+			MetricDimensionFullMethod: holder.FullMethod,
+		},
+		Value: 1.0,
+		Unit:  metric.UnitCount,
+	})
+
+	s.metricWriter.WriteOne(&metric.Datum{
+		Priority:   metric.PriorityHigh,
+		MetricName: fmt.Sprintf(MetricGrpcStatusFormat, code.String()),
+		Dimensions: metric.Dimensions{
+			MetricDimensionFullMethod: holder.FullMethod,
+		},
+		Value: 1.0,
+		Unit:  metric.UnitCount,
+	})
+
+	if !holder.IsClientStream && !holder.IsServerStream {
+		return
+	}
+
+	dimensions := metric.Dimensions{
+		MetricDimensionFullMethod: holder.FullMethod,
+		MetricDimensionStreamType: holder.StreamType(),
+	}
+
+	s.metricWriter.WriteOne(&metric.Datum{
+		Priority:   metric.PriorityHigh,
+		MetricName: MetricApiStreamMessagesReceived,
+		Dimensions: dimensions,
+		Value:      float64(holder.InMessages),
+		Unit:       metric.UnitCount,
+	})
+
+	s.metricWriter.WriteOne(&metric.Datum{
+		Priority:   metric.PriorityHigh,
+		MetricName: MetricApiStreamMessagesSent,
+		Dimensions: dimensions,
+		Value:      float64(holder.OutMessages),
+		Unit:       metric.UnitCount,
+	})
+}
+
+func (s *statsHandler) writeInFlight(fullMethod string, delta int64) {
+	counter, _ := s.inFlight.LoadOrStore(fullMethod, new(int64))
+	inFlight := atomic.AddInt64(counter.(*int64), delta)
+
+	s.metricWriter.WriteOne(&metric.Datum{
+		Priority:   metric.PriorityHigh,
+		MetricName: MetricApiRequestsInFlight,
+		Dimensions: metric.Dimensions{
+			MetricDimensionFullMethod: fullMethod,
+		},
+		Value: float64(inFlight),
+		Unit:  metric.UnitCountMaximum,
+	})
+}
+
+// httpStatusFromCode maps a gRPC status code to its http equivalent as defined by
+// https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
 }
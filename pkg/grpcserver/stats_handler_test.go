@@ -0,0 +1,86 @@
+package grpcserver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/grpcserver"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/metric"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatsHandler_StreamMetrics(t *testing.T) {
+	written := map[string]*metric.Datum{}
+
+	writer := new(metricMocks.Writer)
+	writer.On("WriteOne", mock.AnythingOfType("*metric.Datum")).Run(func(args mock.Arguments) {
+		datum := args.Get(0).(*metric.Datum)
+		written[datum.MetricName] = datum
+	})
+
+	handler := grpcserver.NewStatsHandlerWithInterfaces(logMocks.NewLoggerMockedAll(), &grpcserver.Settings{
+		Stats: grpcserver.Stats{LogLevel: "debug"},
+	}, writer)
+
+	ctx := handler.TagConn(context.Background(), &stats.ConnTagInfo{})
+	ctx = handler.TagRPC(ctx, &stats.RPCTagInfo{FullMethodName: "/test.Service/Chat"})
+
+	begin := time.Now()
+	handler.HandleRPC(ctx, &stats.Begin{BeginTime: begin, IsClientStream: true, IsServerStream: true})
+
+	assert.Equal(t, 1.0, written[grpcserver.MetricApiRequestsInFlight].Value)
+
+	handler.HandleRPC(ctx, &stats.InPayload{})
+	handler.HandleRPC(ctx, &stats.InPayload{})
+	handler.HandleRPC(ctx, &stats.OutPayload{})
+	handler.HandleRPC(ctx, &stats.End{BeginTime: begin, EndTime: begin.Add(time.Second), Error: status.Error(codes.NotFound, "not found")})
+
+	dimensions := metric.Dimensions{
+		grpcserver.MetricDimensionFullMethod: "/test.Service/Chat",
+	}
+	streamDimensions := metric.Dimensions{
+		grpcserver.MetricDimensionFullMethod: "/test.Service/Chat",
+		grpcserver.MetricDimensionStreamType: grpcserver.StreamTypeBidirectionalStreaming,
+	}
+
+	assert.Equal(t, 0.0, written[grpcserver.MetricApiRequestsInFlight].Value)
+	assert.Equal(t, 1000.0, written[grpcserver.MetricApiRequestResponseTime].Value)
+	assert.Equal(t, dimensions, written[grpcserver.MetricApiRequestCount].Dimensions)
+	assert.Equal(t, dimensions, written["ApiStatus4XX"].Dimensions)
+	assert.Equal(t, dimensions, written["GrpcStatusNotFound"].Dimensions)
+	assert.Equal(t, 2.0, written[grpcserver.MetricApiStreamMessagesReceived].Value)
+	assert.Equal(t, streamDimensions, written[grpcserver.MetricApiStreamMessagesReceived].Dimensions)
+	assert.Equal(t, 1.0, written[grpcserver.MetricApiStreamMessagesSent].Value)
+}
+
+func TestStatsHandler_Unary(t *testing.T) {
+	written := map[string]*metric.Datum{}
+
+	writer := new(metricMocks.Writer)
+	writer.On("WriteOne", mock.AnythingOfType("*metric.Datum")).Run(func(args mock.Arguments) {
+		datum := args.Get(0).(*metric.Datum)
+		written[datum.MetricName] = datum
+	})
+
+	handler := grpcserver.NewStatsHandlerWithInterfaces(logMocks.NewLoggerMockedAll(), &grpcserver.Settings{
+		Stats: grpcserver.Stats{LogLevel: "debug"},
+	}, writer)
+
+	ctx := handler.TagRPC(context.Background(), &stats.RPCTagInfo{FullMethodName: "/test.Service/Get"})
+
+	begin := time.Now()
+	handler.HandleRPC(ctx, &stats.Begin{BeginTime: begin})
+	handler.HandleRPC(ctx, &stats.End{BeginTime: begin, EndTime: begin})
+
+	assert.Contains(t, written, "ApiStatus2XX")
+	assert.Contains(t, written, "GrpcStatusOK")
+	assert.NotContains(t, written, grpcserver.MetricApiStreamMessagesReceived)
+	assert.NotContains(t, written, grpcserver.MetricApiStreamMessagesSent)
+}
@@ -3,6 +3,7 @@ package guard
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/log"
@@ -27,19 +28,82 @@ type Manager interface {
 	ladon.Manager
 }
 
+const (
+	ManagerTypeSql    = "sql"
+	ManagerTypeMemory = "memory"
+	ManagerTypeDdb    = "ddb"
+	ManagerTypeFile   = "file"
+)
+
+type ManagerFactory func(ctx context.Context, config cfg.Config, logger log.Logger) (Manager, error)
+
+var managerFactories = map[string]ManagerFactory{
+	ManagerTypeSql: func(_ context.Context, config cfg.Config, logger log.Logger) (Manager, error) {
+		return NewSqlManager(config, logger)
+	},
+	ManagerTypeMemory: ProvideMemoryManager,
+	ManagerTypeDdb:    NewDdbManager,
+	ManagerTypeFile:   NewFileManager,
+}
+
+type ManagerSettings struct {
+	Type string              `cfg:"type" default:"sql" validate:"oneof=sql memory ddb file"`
+	Ddb  DdbManagerSettings  `cfg:"ddb"`
+	File FileManagerSettings `cfg:"file"`
+}
+
+type CacheSettings struct {
+	// Enabled is true by default for the ddb manager
+	Enabled bool          `cfg:"enabled" default:"false"`
+	Ttl     time.Duration `cfg:"ttl" default:"1m"`
+	Size    int64         `cfg:"size" default:"1000"`
+}
+
 type LadonGuard struct {
 	warden *ladon.Ladon
 }
 
-func NewGuard(config cfg.Config, logger log.Logger) (*LadonGuard, error) {
-	sqlManager, err := NewSqlManager(config, logger)
+func NewGuard(ctx context.Context, config cfg.Config, logger log.Logger) (*LadonGuard, error) {
+	settings := readManagerSettings(config)
+
+	factory, ok := managerFactories[settings.Type]
+	if !ok {
+		return nil, fmt.Errorf("there is no policy manager of type %s", settings.Type)
+	}
+
+	manager, err := factory(ctx, config, logger)
 	if err != nil {
-		return nil, fmt.Errorf("can not create sqlManager: %w", err)
+		return nil, fmt.Errorf("can not create %s manager: %w", settings.Type, err)
+	}
+
+	cacheSettings := readCacheSettings(config, settings.Type)
+
+	if cacheSettings.Enabled {
+		manager = NewCachedManager(manager, cacheSettings)
 	}
 
 	auditLogger := NewAuditLogger(config, logger)
 
-	return NewGuardWithInterfaces(sqlManager, auditLogger), nil
+	return NewGuardWithInterfaces(manager, auditLogger), nil
+}
+
+// readCacheSettings enables the cache for the ddb manager unless it is disabled explicitly, as the ddb manager scans the
+// whole policy table on every access check.
+func readCacheSettings(config cfg.Config, managerType string) *CacheSettings {
+	enabledByDefault := managerType == ManagerTypeDdb && !config.IsSet("guard.cache.enabled")
+
+	settings := &CacheSettings{}
+	config.UnmarshalKey("guard.cache", settings)
+	settings.Enabled = settings.Enabled || enabledByDefault
+
+	return settings
+}
+
+func readManagerSettings(config cfg.Config) *ManagerSettings {
+	settings := &ManagerSettings{}
+	config.UnmarshalKey("guard.manager", settings)
+
+	return settings
 }
 
 func NewGuardWithInterfaces(manager Manager, logger AuditLogger) *LadonGuard {
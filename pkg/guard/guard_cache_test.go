@@ -0,0 +1,46 @@
+package guard
+
+import (
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCacheSettings(t *testing.T) {
+	for name, test := range map[string]struct {
+		managerType string
+		settings    map[string]interface{}
+		enabled     bool
+	}{
+		"sql default": {
+			managerType: ManagerTypeSql,
+			enabled:     false,
+		},
+		"ddb default": {
+			managerType: ManagerTypeDdb,
+			enabled:     true,
+		},
+		"ddb disabled": {
+			managerType: ManagerTypeDdb,
+			settings:    map[string]interface{}{"guard.cache.enabled": false},
+			enabled:     false,
+		},
+		"sql enabled": {
+			managerType: ManagerTypeSql,
+			settings:    map[string]interface{}{"guard.cache.enabled": true},
+			enabled:     true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := cfg.New()
+
+			for key, value := range test.settings {
+				assert.NoError(t, config.Option(cfg.WithConfigSetting(key, value)))
+			}
+
+			settings := readCacheSettings(config, test.managerType)
+			assert.Equal(t, test.enabled, settings.Enabled)
+		})
+	}
+}
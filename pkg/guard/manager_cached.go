@@ -0,0 +1,77 @@
+package guard
+
+import (
+	"context"
+	"sync"
+
+	"github.com/justtrackio/gosoline/pkg/cache"
+	"github.com/selm0/ladon"
+)
+
+// cachedManager caches the policies of a subject, which are looked up by the warden on every access check. As the
+// subjects of a policy can contain patterns, any change of a policy drops the whole cache. Changes done by other
+// instances of the application are picked up once the ttl of the cached policies expires.
+type cachedManager struct {
+	Manager
+	lck      sync.RWMutex
+	cache    cache.Cache[ladon.Policies]
+	settings *CacheSettings
+}
+
+func NewCachedManager(manager Manager, settings *CacheSettings) Manager {
+	return &cachedManager{
+		Manager:  manager,
+		cache:    newPolicyCache(settings),
+		settings: settings,
+	}
+}
+
+func (m *cachedManager) Create(ctx context.Context, pol ladon.Policy) error {
+	defer m.invalidate()
+
+	return m.Manager.Create(ctx, pol)
+}
+
+func (m *cachedManager) Update(ctx context.Context, pol ladon.Policy) error {
+	defer m.invalidate()
+
+	return m.Manager.Update(ctx, pol)
+}
+
+func (m *cachedManager) Delete(ctx context.Context, id string) error {
+	defer m.invalidate()
+
+	return m.Manager.Delete(ctx, id)
+}
+
+func (m *cachedManager) FindRequestCandidates(ctx context.Context, r *ladon.Request) (ladon.Policies, error) {
+	return m.currentCache().ProvideWithError("candidates:"+r.Subject, func() (ladon.Policies, error) {
+		return m.Manager.FindRequestCandidates(ctx, r)
+	})
+}
+
+func (m *cachedManager) FindPoliciesForSubject(ctx context.Context, subject string) (ladon.Policies, error) {
+	return m.currentCache().ProvideWithError("subject:"+subject, func() (ladon.Policies, error) {
+		return m.Manager.FindPoliciesForSubject(ctx, subject)
+	})
+}
+
+// currentCache returns the cache a lookup has to be stored in. A lookup running while the cache gets invalidated
+// stores its result in the dropped cache, so it can't bring back outdated policies.
+func (m *cachedManager) currentCache() cache.Cache[ladon.Policies] {
+	m.lck.RLock()
+	defer m.lck.RUnlock()
+
+	return m.cache
+}
+
+func (m *cachedManager) invalidate() {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	m.cache = newPolicyCache(m.settings)
+}
+
+func newPolicyCache(settings *CacheSettings) cache.Cache[ladon.Policies] {
+	return cache.New[ladon.Policies](settings.Size, 10, settings.Ttl)
+}
@@ -0,0 +1,45 @@
+package guard_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/guard"
+	"github.com/justtrackio/gosoline/pkg/guard/mocks"
+	"github.com/selm0/ladon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedManager(t *testing.T) {
+	ctx := context.Background()
+	base := new(mocks.Manager)
+	manager := guard.NewCachedManager(base, &guard.CacheSettings{
+		Enabled: true,
+		Ttl:     time.Minute,
+		Size:    100,
+	})
+
+	pol := &ladon.DefaultPolicy{ID: "a", Subjects: []string{"user:1"}}
+	updated := &ladon.DefaultPolicy{ID: "a", Subjects: []string{"user:1"}, Description: "updated"}
+
+	base.On("FindPoliciesForSubject", ctx, "user:1").Return(ladon.Policies{pol}, nil).Once()
+
+	for i := 0; i < 3; i++ {
+		policies, err := manager.FindPoliciesForSubject(ctx, "user:1")
+		require.NoError(t, err)
+		assert.Equal(t, ladon.Policies{pol}, policies)
+	}
+
+	base.On("Update", ctx, updated).Return(nil).Once()
+	base.On("FindPoliciesForSubject", ctx, "user:1").Return(ladon.Policies{updated}, nil).Once()
+
+	require.NoError(t, manager.Update(ctx, updated))
+
+	policies, err := manager.FindPoliciesForSubject(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, ladon.Policies{updated}, policies)
+
+	base.AssertExpectations(t)
+}
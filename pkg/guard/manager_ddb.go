@@ -0,0 +1,193 @@
+package guard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/ddb"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/mdl"
+	"github.com/selm0/ladon"
+)
+
+type DdbManagerSettings struct {
+	ClientName string `cfg:"client_name" default:"default"`
+}
+
+type DdbPolicyItem struct {
+	Id     string `json:"id" ddb:"key=hash"`
+	Policy string `json:"policy"`
+}
+
+// DdbManager stores the policies in a dynamodb table. As dynamodb can't query the subjects or resources of the
+// policies, the table is scanned and filtered for these lookups, so consider enabling the cache of the guard.
+type DdbManager struct {
+	logger     log.Logger
+	repository ddb.Repository
+}
+
+func NewDdbManager(ctx context.Context, config cfg.Config, logger log.Logger) (Manager, error) {
+	settings := readManagerSettings(config)
+
+	repository, err := ddb.NewRepository(ctx, config, logger, &ddb.Settings{
+		ModelId: mdl.ModelId{
+			Name: "guardPolicies",
+		},
+		Main: ddb.MainSettings{
+			Model:              DdbPolicyItem{},
+			ReadCapacityUnits:  5,
+			WriteCapacityUnits: 5,
+		},
+		ClientName: settings.Ddb.ClientName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can not create ddb repository: %w", err)
+	}
+
+	return NewDdbManagerWithInterfaces(logger, repository), nil
+}
+
+func NewDdbManagerWithInterfaces(logger log.Logger, repository ddb.Repository) *DdbManager {
+	return &DdbManager{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+func (m *DdbManager) Create(ctx context.Context, pol ladon.Policy) error {
+	item, err := buildDdbPolicyItem(pol)
+	if err != nil {
+		return err
+	}
+
+	// same as the sql manager, creating an existing policy is ignored
+	qb := m.repository.PutItemBuilder().WithCondition(expression.AttributeNotExists(expression.Name("id")))
+
+	if _, err = m.repository.PutItem(ctx, qb, item); err != nil {
+		return fmt.Errorf("can not put policy %s: %w", pol.GetID(), err)
+	}
+
+	return nil
+}
+
+func (m *DdbManager) Update(ctx context.Context, pol ladon.Policy) error {
+	item, err := buildDdbPolicyItem(pol)
+	if err != nil {
+		return err
+	}
+
+	qb := m.repository.PutItemBuilder().WithCondition(expression.AttributeExists(expression.Name("id")))
+
+	result, err := m.repository.PutItem(ctx, qb, item)
+	if err != nil {
+		return fmt.Errorf("can not put policy %s: %w", pol.GetID(), err)
+	}
+
+	if result.ConditionalCheckFailed {
+		return fmt.Errorf("can not update policy %s: %w", pol.GetID(), ladon.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (m *DdbManager) Get(ctx context.Context, id string) (ladon.Policy, error) {
+	item := &DdbPolicyItem{}
+
+	result, err := m.repository.GetItem(ctx, m.repository.GetItemBuilder().WithHash(id), item)
+	if err != nil {
+		return nil, fmt.Errorf("can not get policy %s: %w", id, err)
+	}
+
+	if !result.IsFound {
+		return nil, fmt.Errorf("can not get policy %s: %w", id, ladon.ErrNotFound)
+	}
+
+	return item.policy()
+}
+
+func (m *DdbManager) Delete(ctx context.Context, id string) error {
+	if _, err := m.repository.DeleteItem(ctx, m.repository.DeleteItemBuilder().WithHash(id), &DdbPolicyItem{}); err != nil {
+		return fmt.Errorf("can not delete policy %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func (m *DdbManager) GetAll(ctx context.Context, limit, offset int64) (ladon.Policies, error) {
+	policies, err := m.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginatePolicies(policies, limit, offset), nil
+}
+
+func (m *DdbManager) FindRequestCandidates(ctx context.Context, r *ladon.Request) (ladon.Policies, error) {
+	return m.FindPoliciesForSubject(ctx, r.Subject)
+}
+
+func (m *DdbManager) FindPoliciesForSubject(ctx context.Context, subject string) (ladon.Policies, error) {
+	policies, err := m.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterPolicies(policies, ladon.Policy.GetSubjects, subject)
+}
+
+func (m *DdbManager) FindPoliciesForResource(ctx context.Context, resource string) (ladon.Policies, error) {
+	policies, err := m.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterPolicies(policies, ladon.Policy.GetResources, resource)
+}
+
+func (m *DdbManager) all(ctx context.Context) (ladon.Policies, error) {
+	items := make([]DdbPolicyItem, 0)
+
+	if _, err := m.repository.Scan(ctx, m.repository.ScanBuilder(), &items); err != nil {
+		return nil, fmt.Errorf("can not scan policies: %w", err)
+	}
+
+	policies := make(ladon.Policies, 0, len(items))
+
+	for _, item := range items {
+		pol, err := item.policy()
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, pol)
+	}
+
+	return sortPolicies(policies), nil
+}
+
+func (i *DdbPolicyItem) policy() (ladon.Policy, error) {
+	var pol ladon.DefaultPolicy
+
+	if err := json.Unmarshal([]byte(i.Policy), &pol); err != nil {
+		return nil, fmt.Errorf("can not unmarshal the policy %s: %w", i.Id, err)
+	}
+
+	pol.ID = i.Id
+
+	return &pol, nil
+}
+
+func buildDdbPolicyItem(pol ladon.Policy) (*DdbPolicyItem, error) {
+	policy, err := buildPolicy(pol)
+	if err != nil {
+		return nil, fmt.Errorf("can not marshal the policy: %w", err)
+	}
+
+	return &DdbPolicyItem{
+		Id:     pol.GetID(),
+		Policy: string(policy),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package guard_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/ddb"
+	ddbMocks "github.com/justtrackio/gosoline/pkg/ddb/mocks"
+	"github.com/justtrackio/gosoline/pkg/guard"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/selm0/ladon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDdbManager_Update_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repository := ddbMocks.NewRepository(t)
+	putItemBuilder := ddbMocks.NewPutItemBuilder(t)
+	manager := guard.NewDdbManagerWithInterfaces(logMocks.NewLoggerMockedAll(), repository)
+
+	item := &guard.DdbPolicyItem{
+		Id:     "a",
+		Policy: `{"description":"","effect":"","conditions":{},"subjects":["user:1"],"resources":null,"actions":null}`,
+	}
+
+	repository.EXPECT().PutItemBuilder().Return(putItemBuilder).Once()
+	putItemBuilder.EXPECT().WithCondition(mock.Anything).Return(putItemBuilder).Once()
+	repository.EXPECT().PutItem(ctx, putItemBuilder, item).Return(&ddb.PutItemResult{ConditionalCheckFailed: true}, nil).Once()
+
+	err := manager.Update(ctx, &ladon.DefaultPolicy{ID: "a", Subjects: []string{"user:1"}, Conditions: ladon.Conditions{}})
+	assert.ErrorIs(t, err, ladon.ErrNotFound)
+}
+
+func TestDdbManager_FindPoliciesForSubject(t *testing.T) {
+	ctx := context.Background()
+	repository := ddbMocks.NewRepository(t)
+	scanBuilder := ddbMocks.NewScanBuilder(t)
+	manager := guard.NewDdbManagerWithInterfaces(logMocks.NewLoggerMockedAll(), repository)
+
+	repository.EXPECT().ScanBuilder().Return(scanBuilder).Once()
+	repository.EXPECT().Scan(ctx, scanBuilder, mock.AnythingOfType("*[]guard.DdbPolicyItem")).Run(func(_ context.Context, _ ddb.ScanBuilder, result interface{}) {
+		*result.(*[]guard.DdbPolicyItem) = []guard.DdbPolicyItem{
+			{Id: "b", Policy: `{"subjects":["user:<[0-9]+>"],"effect":"allow","resources":["reports"],"actions":["read"]}`},
+			{Id: "a", Policy: `{"subjects":["team:finance"],"effect":"allow","resources":["invoices"],"actions":["read"]}`},
+		}
+	}).Return(&ddb.ScanResult{}, nil).Once()
+
+	policies, err := manager.FindPoliciesForSubject(ctx, "user:42")
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+
+	assert.Equal(t, "b", policies[0].GetID())
+	assert.Equal(t, []string{"reports"}, policies[0].GetResources())
+}
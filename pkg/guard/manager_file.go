@@ -0,0 +1,97 @@
+package guard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/selm0/ladon"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrManagerReadOnly = errors.New("the policies of the manager are read only")
+
+type FileManagerSettings struct {
+	// Path of a json or yaml file containing a list of ladon policies
+	Path string `cfg:"path"`
+}
+
+// fileManager serves the policies of a file, they can't be changed at runtime.
+type fileManager struct {
+	*MemoryManager
+}
+
+func NewFileManager(_ context.Context, config cfg.Config, _ log.Logger) (Manager, error) {
+	settings := readManagerSettings(config)
+
+	return NewFileManagerWithSettings(&settings.File)
+}
+
+func NewFileManagerWithSettings(settings *FileManagerSettings) (Manager, error) {
+	policies, err := readPolicyFile(settings.Path)
+	if err != nil {
+		return nil, fmt.Errorf("can not read policies from file %s: %w", settings.Path, err)
+	}
+
+	memoryManager := NewMemoryManager()
+
+	for _, pol := range policies {
+		if err = memoryManager.Create(context.Background(), pol); err != nil {
+			return nil, fmt.Errorf("can not add policy %s: %w", pol.GetID(), err)
+		}
+	}
+
+	return &fileManager{
+		MemoryManager: memoryManager,
+	}, nil
+}
+
+func (m *fileManager) Create(_ context.Context, pol ladon.Policy) error {
+	return fmt.Errorf("can not create policy %s: %w", pol.GetID(), ErrManagerReadOnly)
+}
+
+func (m *fileManager) Update(_ context.Context, pol ladon.Policy) error {
+	return fmt.Errorf("can not update policy %s: %w", pol.GetID(), ErrManagerReadOnly)
+}
+
+func (m *fileManager) Delete(_ context.Context, id string) error {
+	return fmt.Errorf("can not delete policy %s: %w", id, ErrManagerReadOnly)
+}
+
+func readPolicyFile(path string) ([]*ladon.DefaultPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the conditions of the policies can only be unmarshalled from json, so yaml files are converted first
+	if ext := filepath.Ext(path); ext == ".yml" || ext == ".yaml" {
+		var raw []map[string]interface{}
+
+		if err = yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("can not unmarshal yaml: %w", err)
+		}
+
+		if data, err = json.Marshal(raw); err != nil {
+			return nil, fmt.Errorf("can not convert yaml to json: %w", err)
+		}
+	}
+
+	policies := make([]*ladon.DefaultPolicy, 0)
+	if err = json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("can not unmarshal policies: %w", err)
+	}
+
+	for _, pol := range policies {
+		if pol.ID == "" {
+			return nil, fmt.Errorf("there is a policy without id")
+		}
+	}
+
+	return policies, nil
+}
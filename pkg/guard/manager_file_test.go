@@ -0,0 +1,52 @@
+package guard_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/guard"
+	"github.com/selm0/ladon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileManager(t *testing.T) {
+	ctx := context.Background()
+
+	manager, err := guard.NewFileManagerWithSettings(&guard.FileManagerSettings{
+		Path: "testdata/policies.yml",
+	})
+	require.NoError(t, err)
+
+	policies, err := manager.FindPoliciesForSubject(ctx, "team:finance")
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "reports:delete", policies[0].GetID())
+	assert.Equal(t, "reports:read", policies[1].GetID())
+
+	warden := &ladon.Ladon{Manager: manager}
+
+	err = warden.IsAllowed(ctx, &ladon.Request{Subject: "team:analytics", Resource: "reports:daily", Action: "read"})
+	assert.NoError(t, err)
+
+	err = warden.IsAllowed(ctx, &ladon.Request{
+		Subject:  "team:finance",
+		Resource: "reports:daily",
+		Action:   "delete",
+		Context:  ladon.Context{"clientIp": "10.1.2.3"},
+	})
+	assert.ErrorIs(t, err, ladon.ErrRequestForcefullyDenied)
+
+	err = manager.Create(ctx, &ladon.DefaultPolicy{ID: "new"})
+	assert.ErrorIs(t, err, guard.ErrManagerReadOnly)
+
+	err = manager.Delete(ctx, "reports:read")
+	assert.ErrorIs(t, err, guard.ErrManagerReadOnly)
+}
+
+func TestFileManager_MissingFile(t *testing.T) {
+	_, err := guard.NewFileManagerWithSettings(&guard.FileManagerSettings{
+		Path: "testdata/missing.json",
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package guard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/justtrackio/gosoline/pkg/appctx"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/selm0/ladon"
+)
+
+type MemoryManager struct {
+	lck      sync.RWMutex
+	policies map[string]ladon.Policy
+}
+
+type memoryManagerCtxKey struct{}
+
+// ProvideMemoryManager returns the memory manager shared by all guards of the application.
+func ProvideMemoryManager(ctx context.Context, _ cfg.Config, _ log.Logger) (Manager, error) {
+	return appctx.Provide(ctx, memoryManagerCtxKey{}, func() (Manager, error) {
+		return NewMemoryManager(), nil
+	})
+}
+
+func NewMemoryManager() *MemoryManager {
+	return &MemoryManager{
+		policies: make(map[string]ladon.Policy),
+	}
+}
+
+func (m *MemoryManager) Create(_ context.Context, pol ladon.Policy) error {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	// same as the sql manager, creating an existing policy is ignored
+	if _, ok := m.policies[pol.GetID()]; ok {
+		return nil
+	}
+
+	m.policies[pol.GetID()] = pol
+
+	return nil
+}
+
+func (m *MemoryManager) Update(_ context.Context, pol ladon.Policy) error {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	if _, ok := m.policies[pol.GetID()]; !ok {
+		return fmt.Errorf("can not update policy %s: %w", pol.GetID(), ladon.ErrNotFound)
+	}
+
+	m.policies[pol.GetID()] = pol
+
+	return nil
+}
+
+func (m *MemoryManager) Get(_ context.Context, id string) (ladon.Policy, error) {
+	m.lck.RLock()
+	defer m.lck.RUnlock()
+
+	pol, ok := m.policies[id]
+	if !ok {
+		return nil, fmt.Errorf("can not get policy %s: %w", id, ladon.ErrNotFound)
+	}
+
+	return pol, nil
+}
+
+func (m *MemoryManager) Delete(_ context.Context, id string) error {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	delete(m.policies, id)
+
+	return nil
+}
+
+func (m *MemoryManager) GetAll(_ context.Context, limit, offset int64) (ladon.Policies, error) {
+	return paginatePolicies(m.all(), limit, offset), nil
+}
+
+func (m *MemoryManager) FindRequestCandidates(ctx context.Context, r *ladon.Request) (ladon.Policies, error) {
+	return m.FindPoliciesForSubject(ctx, r.Subject)
+}
+
+func (m *MemoryManager) FindPoliciesForSubject(_ context.Context, subject string) (ladon.Policies, error) {
+	return filterPolicies(m.all(), ladon.Policy.GetSubjects, subject)
+}
+
+func (m *MemoryManager) FindPoliciesForResource(_ context.Context, resource string) (ladon.Policies, error) {
+	return filterPolicies(m.all(), ladon.Policy.GetResources, resource)
+}
+
+func (m *MemoryManager) all() ladon.Policies {
+	m.lck.RLock()
+	defer m.lck.RUnlock()
+
+	policies := make(ladon.Policies, 0, len(m.policies))
+	for _, pol := range m.policies {
+		policies = append(policies, pol)
+	}
+
+	return sortPolicies(policies)
+}
+
+// sortPolicies orders the policies by id, so the pagination of GetAll is stable.
+func sortPolicies(policies ladon.Policies) ladon.Policies {
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].GetID() < policies[j].GetID()
+	})
+
+	return policies
+}
+
+func paginatePolicies(policies ladon.Policies, limit, offset int64) ladon.Policies {
+	if offset >= int64(len(policies)) {
+		return ladon.Policies{}
+	}
+
+	end := min(offset+limit, int64(len(policies)))
+
+	return policies[offset:end]
+}
+
+// filterPolicies returns the policies where one of the values matches the given one. Values can contain regular
+// expressions enclosed in <>, which are matched the same way as the warden does.
+func filterPolicies(policies ladon.Policies, values func(ladon.Policy) []string, value string) (ladon.Policies, error) {
+	result := make(ladon.Policies, 0)
+
+	for _, pol := range policies {
+		matches, err := ladon.DefaultMatcher.Matches(pol, values(pol), value)
+		if err != nil {
+			return nil, fmt.Errorf("can not match policy %s: %w", pol.GetID(), err)
+		}
+
+		if matches {
+			result = append(result, pol)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package guard_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/guard"
+	"github.com/selm0/ladon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryManager(t *testing.T) {
+	ctx := context.Background()
+	manager := guard.NewMemoryManager()
+
+	polA := &ladon.DefaultPolicy{ID: "a", Subjects: []string{"user:<[0-9]+>"}, Resources: []string{"reports"}}
+	polB := &ladon.DefaultPolicy{ID: "b", Subjects: []string{"team:finance"}, Resources: []string{"invoices"}}
+
+	require.NoError(t, manager.Create(ctx, polB))
+	require.NoError(t, manager.Create(ctx, polA))
+	require.NoError(t, manager.Create(ctx, &ladon.DefaultPolicy{ID: "a"}), "creating an existing policy should be ignored")
+
+	pol, err := manager.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, polA, pol)
+
+	all, err := manager.GetAll(ctx, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, ladon.Policies{polA}, all)
+
+	all, err = manager.GetAll(ctx, 10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, ladon.Policies{polB}, all)
+
+	all, err = manager.GetAll(ctx, 10, 2)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	found, err := manager.FindPoliciesForSubject(ctx, "user:42")
+	require.NoError(t, err)
+	assert.Equal(t, ladon.Policies{polA}, found)
+
+	found, err = manager.FindRequestCandidates(ctx, &ladon.Request{Subject: "team:finance"})
+	require.NoError(t, err)
+	assert.Equal(t, ladon.Policies{polB}, found)
+
+	found, err = manager.FindPoliciesForResource(ctx, "invoices")
+	require.NoError(t, err)
+	assert.Equal(t, ladon.Policies{polB}, found)
+
+	err = manager.Update(ctx, &ladon.DefaultPolicy{ID: "c"})
+	assert.ErrorIs(t, err, ladon.ErrNotFound)
+
+	require.NoError(t, manager.Delete(ctx, "a"))
+
+	_, err = manager.Get(ctx, "a")
+	assert.ErrorIs(t, err, ladon.ErrNotFound)
+}
@@ -0,0 +1,120 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/justtrackio/gosoline/pkg/apiserver/auth"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/selm0/ladon"
+)
+
+// ResourceResolver returns the resource and action a request is checked against.
+type ResourceResolver func(ginCtx *gin.Context) (resource string, action string)
+
+// RouteResource uses the path of the route as resource and the http method as action.
+func RouteResource(ginCtx *gin.Context) (string, string) {
+	return ginCtx.FullPath(), ginCtx.Request.Method
+}
+
+func StaticResource(resource string, action string) ResourceResolver {
+	return func(_ *gin.Context) (string, string) {
+		return resource, action
+	}
+}
+
+func NewGuardHandler(ctx context.Context, config cfg.Config, logger log.Logger, resolver ResourceResolver) (gin.HandlerFunc, error) {
+	guard, err := NewGuard(ctx, config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("can not create guard: %w", err)
+	}
+
+	return NewGuardHandlerWithInterfaces(logger, guard, resolver), nil
+}
+
+// NewGuardHandlerWithInterfaces checks the subject of an authenticated request against the policies of the guard. The
+// roles of the subject are checked as subjects role:<name>, too. The request is allowed if any of them is allowed and
+// none of them is explicitly denied. Subjects whose name starts with role: are rejected. The handler has to run after
+// the authentication handlers.
+func NewGuardHandlerWithInterfaces(logger log.Logger, guard Guard, resolver ResourceResolver) gin.HandlerFunc {
+	logger = logger.WithChannel("guard_handler")
+
+	return func(ginCtx *gin.Context) {
+		ctx := ginCtx.Request.Context()
+
+		subject, ok := auth.LookupSubject(ctx)
+		if !ok || subject.Anonymous {
+			ginCtx.JSON(http.StatusUnauthorized, gin.H{"err": "the request is not authenticated"})
+			ginCtx.Abort()
+
+			return
+		}
+
+		// the role subjects are reserved for the roles of a subject, otherwise a token for a subject named like a role
+		// would get all permissions of the role
+		if strings.HasPrefix(subject.Name, RoleSubjectPrefix) {
+			logger.WithContext(ctx).Warn("rejecting request of subject %s as it uses the reserved prefix %s", subject.Name, RoleSubjectPrefix)
+
+			ginCtx.JSON(http.StatusForbidden, gin.H{"err": fmt.Sprintf("the subject %s uses the reserved prefix %s", subject.Name, RoleSubjectPrefix)})
+			ginCtx.Abort()
+
+			return
+		}
+
+		resource, action := resolver(ginCtx)
+
+		allowed, err := isSubjectAllowed(ctx, guard, subject, &ladon.Request{
+			Resource: resource,
+			Action:   action,
+			Context: ladon.Context{
+				"clientIp": ginCtx.ClientIP(),
+			},
+		})
+
+		if err != nil {
+			logger.WithContext(ctx).Error("can not check access of %s to %s %s: %w", subject.Name, action, resource, err)
+
+			ginCtx.JSON(http.StatusInternalServerError, gin.H{"err": "can not check access"})
+			ginCtx.Abort()
+
+			return
+		}
+
+		if !allowed {
+			ginCtx.JSON(http.StatusForbidden, gin.H{"err": fmt.Sprintf("%s is not allowed to %s %s", subject.Name, action, resource)})
+			ginCtx.Abort()
+		}
+	}
+}
+
+func isSubjectAllowed(ctx context.Context, guard Guard, subject *auth.Subject, request *ladon.Request) (bool, error) {
+	subjects := []string{subject.Name}
+	for _, role := range subject.Roles() {
+		subjects = append(subjects, RoleSubject(role))
+	}
+
+	allowed := false
+
+	for _, name := range subjects {
+		request.Subject = name
+		err := guard.IsAllowed(ctx, request)
+
+		switch {
+		case err == nil:
+			allowed = true
+		case errors.Is(err, ladon.ErrRequestForcefullyDenied):
+			return false, nil
+		case errors.Is(err, ladon.ErrRequestDenied):
+			continue
+		default:
+			return false, err
+		}
+	}
+
+	return allowed, nil
+}
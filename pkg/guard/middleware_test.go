@@ -0,0 +1,126 @@
+package guard_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/justtrackio/gosoline/pkg/apiserver/auth"
+	"github.com/justtrackio/gosoline/pkg/guard"
+	"github.com/justtrackio/gosoline/pkg/guard/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/selm0/ladon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type GuardHandlerTestSuite struct {
+	suite.Suite
+
+	guard   *mocks.Guard
+	subject *auth.Subject
+	router  *gin.Engine
+}
+
+func TestGuardHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(GuardHandlerTestSuite))
+}
+
+func (s *GuardHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	s.guard = mocks.NewGuard(s.T())
+	s.subject = &auth.Subject{
+		Name:       "user:1",
+		Attributes: map[string]interface{}{auth.AttributeRoles: []string{"editor"}},
+	}
+
+	s.router = gin.New()
+	s.router.Use(func(ginCtx *gin.Context) {
+		if s.subject != nil {
+			auth.RequestWithSubject(ginCtx, s.subject)
+		}
+	})
+	s.router.Use(guard.NewGuardHandlerWithInterfaces(logMocks.NewLoggerMockedAll(), s.guard, guard.RouteResource))
+	s.router.GET("/reports/:id", func(ginCtx *gin.Context) {
+		ginCtx.Status(http.StatusOK)
+	})
+}
+
+func (s *GuardHandlerTestSuite) expectIsAllowed(subject string, err error) {
+	s.guard.EXPECT().IsAllowed(mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(request *ladon.Request) bool {
+		return request.Subject == subject
+	})).Run(func(_ context.Context, request *ladon.Request) {
+		s.Equal("/reports/:id", request.Resource)
+		s.Equal(http.MethodGet, request.Action)
+		s.Contains(request.Context, "clientIp")
+	}).Return(err).Once()
+}
+
+func (s *GuardHandlerTestSuite) serve() int {
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/reports/1", nil)
+
+	s.router.ServeHTTP(response, request)
+
+	return response.Code
+}
+
+func (s *GuardHandlerTestSuite) TestAllowedBySubject() {
+	s.expectIsAllowed("user:1", nil)
+	s.expectIsAllowed("role:editor", ladon.ErrRequestDenied)
+
+	s.Equal(http.StatusOK, s.serve())
+}
+
+func (s *GuardHandlerTestSuite) TestAllowedByRole() {
+	s.expectIsAllowed("user:1", ladon.ErrRequestDenied)
+	s.expectIsAllowed("role:editor", nil)
+
+	s.Equal(http.StatusOK, s.serve())
+}
+
+func (s *GuardHandlerTestSuite) TestForcefullyDeniedRole() {
+	s.expectIsAllowed("user:1", nil)
+	s.expectIsAllowed("role:editor", ladon.ErrRequestForcefullyDenied)
+
+	s.Equal(http.StatusForbidden, s.serve())
+}
+
+func (s *GuardHandlerTestSuite) TestDenied() {
+	s.expectIsAllowed("user:1", ladon.ErrRequestDenied)
+	s.expectIsAllowed("role:editor", ladon.ErrRequestDenied)
+
+	s.Equal(http.StatusForbidden, s.serve())
+}
+
+func (s *GuardHandlerTestSuite) TestGuardError() {
+	s.expectIsAllowed("user:1", fmt.Errorf("database is gone"))
+
+	s.Equal(http.StatusInternalServerError, s.serve())
+}
+
+func (s *GuardHandlerTestSuite) TestRoleSubjectRejected() {
+	s.subject = &auth.Subject{
+		Name: "role:admin",
+	}
+
+	s.Equal(http.StatusForbidden, s.serve())
+}
+
+func (s *GuardHandlerTestSuite) TestUnauthenticated() {
+	s.subject = nil
+
+	s.Equal(http.StatusUnauthorized, s.serve())
+}
+
+func TestStaticResource(t *testing.T) {
+	resource, action := guard.StaticResource("reports", "read")(nil)
+
+	assert.Equal(t, "reports", resource)
+	assert.Equal(t, "read", action)
+}
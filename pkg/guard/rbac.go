@@ -0,0 +1,263 @@
+package guard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/selm0/ladon"
+)
+
+const (
+	RbacPolicyPrefix  = "rbac:"
+	RoleSubjectPrefix = "role:"
+)
+
+type Permission struct {
+	Resources []string `cfg:"resources" json:"resources"`
+	Actions   []string `cfg:"actions" json:"actions"`
+	// Effect is either allow or deny, an empty effect allows the access
+	Effect string `cfg:"effect" json:"effect"`
+}
+
+type Role struct {
+	Name        string       `cfg:"name" json:"name"`
+	Description string       `cfg:"description" json:"description"`
+	Permissions []Permission `cfg:"permissions" json:"permissions"`
+	// Inherits contains the names of the roles whose permissions are granted by this role, too
+	Inherits []string `cfg:"inherits" json:"inherits"`
+}
+
+type RoleBinding struct {
+	Name     string   `cfg:"name" json:"name"`
+	Role     string   `cfg:"role" json:"role"`
+	Subjects []string `cfg:"subjects" json:"subjects"`
+}
+
+// RbacModel describes roles and the subjects they are bound to. Memberships map a subject to the subjects it is a
+// member of, e.g. a user to its teams. A subject gets all roles bound to the subjects it is a member of, directly or
+// transitively. Every role can also be used as subject role:<name>, which is how the route guard handler checks the
+// roles of a token.
+type RbacModel struct {
+	Roles       []Role              `cfg:"roles" json:"roles"`
+	Bindings    []RoleBinding       `cfg:"bindings" json:"bindings"`
+	Memberships map[string][]string `cfg:"memberships" json:"memberships"`
+}
+
+func ReadRbacModel(config cfg.Config) *RbacModel {
+	model := &RbacModel{}
+	config.UnmarshalKey("guard.rbac", model)
+
+	return model
+}
+
+func RoleSubject(role string) string {
+	return RoleSubjectPrefix + role
+}
+
+// CompileRbac converts the model to ladon policies. Every permission of a role and every permission of a bound role
+// results in its own policy, the ids of the policies are prefixed with rbac: to tell them apart from other policies.
+func CompileRbac(model *RbacModel) (ladon.Policies, error) {
+	roles := make(map[string]Role, len(model.Roles))
+
+	for _, role := range model.Roles {
+		if role.Name == "" {
+			return nil, fmt.Errorf("there is a role without name")
+		}
+
+		if _, ok := roles[role.Name]; ok {
+			return nil, fmt.Errorf("role %s is defined twice", role.Name)
+		}
+
+		roles[role.Name] = role
+	}
+
+	members := make(map[string][]string)
+	for subject, groups := range model.Memberships {
+		for _, group := range groups {
+			members[group] = append(members[group], subject)
+		}
+	}
+
+	policies := make(ladon.Policies, 0)
+
+	for _, role := range model.Roles {
+		permissions, err := resolvePermissions(roles, role.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		subjects := expandSubjects(members, []string{RoleSubject(role.Name)})
+		description := fmt.Sprintf("permissions of role %s", role.Name)
+
+		rolePolicies, err := buildRbacPolicies("role:"+role.Name, description, subjects, permissions)
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, rolePolicies...)
+	}
+
+	for _, binding := range model.Bindings {
+		if binding.Name == "" {
+			return nil, fmt.Errorf("there is a binding without name")
+		}
+
+		if _, ok := roles[binding.Role]; !ok {
+			return nil, fmt.Errorf("binding %s references unknown role %s", binding.Name, binding.Role)
+		}
+
+		permissions, err := resolvePermissions(roles, binding.Role, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		subjects := expandSubjects(members, binding.Subjects)
+		description := fmt.Sprintf("role %s bound by %s", binding.Role, binding.Name)
+
+		bindingPolicies, err := buildRbacPolicies("binding:"+binding.Name, description, subjects, permissions)
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, bindingPolicies...)
+	}
+
+	return policies, nil
+}
+
+// SyncRbac writes the compiled policies of the model to the guard. Policies created by an earlier version of the
+// model which don't exist anymore are deleted, all other policies are left untouched.
+func SyncRbac(ctx context.Context, guard Guard, model *RbacModel) error {
+	policies, err := CompileRbac(model)
+	if err != nil {
+		return fmt.Errorf("can not compile rbac model: %w", err)
+	}
+
+	existing, err := guard.GetPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("can not get existing policies: %w", err)
+	}
+
+	existingIds := make(map[string]bool, len(existing))
+	for _, pol := range existing {
+		existingIds[pol.GetID()] = true
+	}
+
+	compiledIds := make(map[string]bool, len(policies))
+
+	for _, pol := range policies {
+		compiledIds[pol.GetID()] = true
+
+		if existingIds[pol.GetID()] {
+			err = guard.UpdatePolicy(ctx, pol)
+		} else {
+			err = guard.CreatePolicy(ctx, pol)
+		}
+
+		if err != nil {
+			return fmt.Errorf("can not write policy %s: %w", pol.GetID(), err)
+		}
+	}
+
+	for _, pol := range existing {
+		if !strings.HasPrefix(pol.GetID(), RbacPolicyPrefix) || compiledIds[pol.GetID()] {
+			continue
+		}
+
+		if err = guard.DeletePolicy(ctx, pol); err != nil {
+			return fmt.Errorf("can not delete stale policy %s: %w", pol.GetID(), err)
+		}
+	}
+
+	return nil
+}
+
+func resolvePermissions(roles map[string]Role, name string, path []string) ([]Permission, error) {
+	for _, visited := range path {
+		if visited == name {
+			return nil, fmt.Errorf("roles inherit each other in a cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+	}
+
+	role, ok := roles[name]
+	if !ok {
+		return nil, fmt.Errorf("role %s inherits unknown role %s", path[len(path)-1], name)
+	}
+
+	permissions := append([]Permission{}, role.Permissions...)
+
+	for _, inherited := range role.Inherits {
+		inheritedPermissions, err := resolvePermissions(roles, inherited, append(path, name))
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, inheritedPermissions...)
+	}
+
+	return permissions, nil
+}
+
+// expandSubjects adds all subjects which are members of the given subjects, directly or transitively.
+func expandSubjects(members map[string][]string, subjects []string) []string {
+	seen := make(map[string]bool)
+	queue := append([]string{}, subjects...)
+
+	for len(queue) > 0 {
+		subject := queue[0]
+		queue = queue[1:]
+
+		if seen[subject] {
+			continue
+		}
+
+		seen[subject] = true
+		queue = append(queue, members[subject]...)
+	}
+
+	result := make([]string, 0, len(seen))
+	for subject := range seen {
+		result = append(result, subject)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func buildRbacPolicies(name string, description string, subjects []string, permissions []Permission) (ladon.Policies, error) {
+	policies := make(ladon.Policies, 0, len(permissions))
+
+	for i, permission := range permissions {
+		effect, err := rbacEffect(permission.Effect)
+		if err != nil {
+			return nil, fmt.Errorf("invalid permission of %s: %w", name, err)
+		}
+
+		policies = append(policies, &ladon.DefaultPolicy{
+			ID:          fmt.Sprintf("%s%s:%d", RbacPolicyPrefix, name, i),
+			Description: description,
+			Subjects:    subjects,
+			Effect:      effect,
+			Resources:   permission.Resources,
+			Actions:     permission.Actions,
+			Conditions:  ladon.Conditions{},
+		})
+	}
+
+	return policies, nil
+}
+
+func rbacEffect(effect string) (string, error) {
+	switch effect {
+	case "", ladon.AllowAccess:
+		return ladon.AllowAccess, nil
+	case ladon.DenyAccess:
+		return ladon.DenyAccess, nil
+	default:
+		return "", fmt.Errorf("unknown effect %s", effect)
+	}
+}
@@ -0,0 +1,178 @@
+package guard_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/guard"
+	"github.com/justtrackio/gosoline/pkg/guard/mocks"
+	"github.com/selm0/ladon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildRbacModel() *guard.RbacModel {
+	return &guard.RbacModel{
+		Roles: []guard.Role{
+			{
+				Name: "viewer",
+				Permissions: []guard.Permission{
+					{Resources: []string{"reports:<.*>"}, Actions: []string{"read"}},
+				},
+			},
+			{
+				Name:     "editor",
+				Inherits: []string{"viewer"},
+				Permissions: []guard.Permission{
+					{Resources: []string{"reports:<.*>"}, Actions: []string{"write"}},
+					{Resources: []string{"reports:locked"}, Actions: []string{"write"}, Effect: ladon.DenyAccess},
+				},
+			},
+		},
+		Bindings: []guard.RoleBinding{
+			{Name: "finance-editors", Role: "editor", Subjects: []string{"team:finance"}},
+		},
+		Memberships: map[string][]string{
+			"user:alice":       {"team:finance"},
+			"user:bob":         {"team:controlling"},
+			"team:controlling": {"team:finance"},
+		},
+	}
+}
+
+func TestCompileRbac(t *testing.T) {
+	policies, err := guard.CompileRbac(buildRbacModel())
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(policies))
+	for _, pol := range policies {
+		ids = append(ids, pol.GetID())
+	}
+
+	assert.Equal(t, []string{
+		"rbac:role:viewer:0",
+		"rbac:role:editor:0",
+		"rbac:role:editor:1",
+		"rbac:role:editor:2",
+		"rbac:binding:finance-editors:0",
+		"rbac:binding:finance-editors:1",
+		"rbac:binding:finance-editors:2",
+	}, ids)
+
+	assert.Equal(t, []string{"role:viewer"}, policies[0].GetSubjects())
+	assert.Equal(t, []string{"read"}, policies[3].GetActions(), "the permissions of the inherited role should be appended")
+	assert.Equal(t, []string{"team:controlling", "team:finance", "user:alice", "user:bob"}, policies[4].GetSubjects())
+
+	warden := &ladon.Ladon{Manager: guard.NewMemoryManager()}
+	for _, pol := range policies {
+		require.NoError(t, warden.Manager.Create(context.Background(), pol))
+	}
+
+	assert.NoError(t, warden.IsAllowed(context.Background(), &ladon.Request{Subject: "user:bob", Resource: "reports:daily", Action: "write"}))
+	assert.NoError(t, warden.IsAllowed(context.Background(), &ladon.Request{Subject: "role:viewer", Resource: "reports:daily", Action: "read"}))
+	assert.ErrorIs(t, warden.IsAllowed(context.Background(), &ladon.Request{Subject: "role:viewer", Resource: "reports:daily", Action: "write"}), ladon.ErrRequestDenied)
+	assert.ErrorIs(t, warden.IsAllowed(context.Background(), &ladon.Request{Subject: "user:alice", Resource: "reports:locked", Action: "write"}), ladon.ErrRequestForcefullyDenied)
+}
+
+func TestCompileRbac_Invalid(t *testing.T) {
+	tests := map[string]*guard.RbacModel{
+		"unknown role": {
+			Bindings: []guard.RoleBinding{{Name: "b", Role: "missing"}},
+		},
+		"unknown inherited role": {
+			Roles: []guard.Role{{Name: "a", Inherits: []string{"missing"}}},
+		},
+		"cycle": {
+			Roles: []guard.Role{
+				{Name: "a", Inherits: []string{"b"}},
+				{Name: "b", Inherits: []string{"a"}},
+			},
+		},
+		"invalid effect": {
+			Roles: []guard.Role{{Name: "a", Permissions: []guard.Permission{{Effect: "maybe"}}}},
+		},
+		"duplicate role": {
+			Roles: []guard.Role{{Name: "a"}, {Name: "a"}},
+		},
+	}
+
+	for name, model := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := guard.CompileRbac(model)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSyncRbac(t *testing.T) {
+	ctx := context.Background()
+	g := mocks.NewGuard(t)
+
+	model := &guard.RbacModel{
+		Roles: []guard.Role{
+			{Name: "viewer", Permissions: []guard.Permission{{Resources: []string{"reports"}, Actions: []string{"read"}}}},
+		},
+		Bindings: []guard.RoleBinding{
+			{Name: "everyone", Role: "viewer", Subjects: []string{"user:<.*>"}},
+		},
+	}
+
+	policies, err := guard.CompileRbac(model)
+	require.NoError(t, err)
+
+	stale := &ladon.DefaultPolicy{ID: "rbac:binding:removed:0"}
+	other := &ladon.DefaultPolicy{ID: "share:1"}
+
+	g.EXPECT().GetPolicies(ctx).Return(ladon.Policies{other, stale, &ladon.DefaultPolicy{ID: "rbac:role:viewer:0"}}, nil).Once()
+	g.EXPECT().UpdatePolicy(ctx, policies[0]).Return(nil).Once()
+	g.EXPECT().CreatePolicy(ctx, policies[1]).Return(nil).Once()
+	g.EXPECT().DeletePolicy(ctx, stale).Return(nil).Once()
+
+	err = guard.SyncRbac(ctx, g, model)
+	assert.NoError(t, err)
+}
+
+func TestReadRbacModel(t *testing.T) {
+	config := cfg.New()
+	err := config.Option(cfg.WithConfigMap(map[string]interface{}{
+		"guard": map[string]interface{}{
+			"rbac": map[string]interface{}{
+				"roles": []interface{}{
+					map[string]interface{}{
+						"name":     "editor",
+						"inherits": []interface{}{"viewer"},
+						"permissions": []interface{}{
+							map[string]interface{}{"resources": []interface{}{"reports"}, "actions": []interface{}{"write"}},
+						},
+					},
+				},
+				"bindings": []interface{}{
+					map[string]interface{}{"name": "finance", "role": "editor", "subjects": []interface{}{"team:finance"}},
+				},
+				"memberships": map[string]interface{}{
+					"user:alice": []interface{}{"team:finance"},
+				},
+			},
+		},
+	}))
+	require.NoError(t, err)
+
+	model := guard.ReadRbacModel(config)
+
+	assert.Equal(t, &guard.RbacModel{
+		Roles: []guard.Role{
+			{
+				Name:        "editor",
+				Inherits:    []string{"viewer"},
+				Permissions: []guard.Permission{{Resources: []string{"reports"}, Actions: []string{"write"}}},
+			},
+		},
+		Bindings: []guard.RoleBinding{
+			{Name: "finance", Role: "editor", Subjects: []string{"team:finance"}},
+		},
+		Memberships: map[string][]string{
+			"user:alice": {"team:finance"},
+		},
+	}, model)
+}
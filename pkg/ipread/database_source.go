@@ -0,0 +1,117 @@
+package ipread
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	gosoS3 "github.com/justtrackio/gosoline/pkg/cloud/aws/s3"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+// A DatabaseSource provides the content of a database file, either from the local file system or from s3.
+//
+//go:generate mockery --name DatabaseSource
+type DatabaseSource interface {
+	// Version returns an identifier which changes whenever the database changes, e.g. the etag of the s3 object
+	Version(ctx context.Context) (string, error)
+	Read(ctx context.Context) ([]byte, error)
+}
+
+type fileDatabaseSource struct {
+	path string
+}
+
+type s3DatabaseSource struct {
+	client gosoS3.Client
+	bucket string
+	key    string
+}
+
+// NewDatabaseSource creates a source for paths like s3://bucket/key or a path in the local file system.
+func NewDatabaseSource(ctx context.Context, config cfg.Config, logger log.Logger, path string, s3ClientName string) (DatabaseSource, error) {
+	if !strings.HasPrefix(path, "s3://") {
+		return NewFileDatabaseSource(path), nil
+	}
+
+	location, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("can not parse s3 location %s: %w", path, err)
+	}
+
+	client, err := gosoS3.ProvideClient(ctx, config, logger, s3ClientName)
+	if err != nil {
+		return nil, fmt.Errorf("can not create s3 client %s: %w", s3ClientName, err)
+	}
+
+	return NewS3DatabaseSourceWithInterfaces(client, location.Host, strings.TrimPrefix(location.Path, "/")), nil
+}
+
+func NewFileDatabaseSource(path string) DatabaseSource {
+	return &fileDatabaseSource{
+		path: path,
+	}
+}
+
+func NewS3DatabaseSourceWithInterfaces(client gosoS3.Client, bucket string, key string) DatabaseSource {
+	return &s3DatabaseSource{
+		client: client,
+		bucket: bucket,
+		key:    key,
+	}
+}
+
+func (s *fileDatabaseSource) Version(_ context.Context) (string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", fmt.Errorf("can not stat database file %s: %w", s.path, err)
+	}
+
+	return fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size()), nil
+}
+
+func (s *fileDatabaseSource) Read(_ context.Context) ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("can not read database file %s: %w", s.path, err)
+	}
+
+	return data, nil
+}
+
+func (s *s3DatabaseSource) Version(ctx context.Context) (string, error) {
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key),
+	})
+	if err != nil {
+		return "", fmt.Errorf("can not head database s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+
+	return aws.ToString(out.ETag), nil
+}
+
+func (s *s3DatabaseSource) Read(ctx context.Context) ([]byte, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can not get database s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("can not read database s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,36 @@
+package ipread_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/ipread"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileDatabaseSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "city.mmdb")
+	source := ipread.NewFileDatabaseSource(path)
+
+	_, err := source.Version(context.Background())
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+	version, err := source.Version(context.Background())
+	assert.NoError(t, err)
+
+	data, err := source.Read(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), data)
+
+	assert.NoError(t, os.WriteFile(path, []byte("v2 with more data"), 0o600))
+	assert.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+	changedVersion, err := source.Version(context.Background())
+	assert.NoError(t, err)
+	assert.NotEqual(t, version, changedVersion)
+}
@@ -1,17 +1,32 @@
 package ipread
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
+	"sync/atomic"
+	"time"
 
+	"github.com/justtrackio/gosoline/pkg/appctx"
+	"github.com/justtrackio/gosoline/pkg/cache"
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/metric"
+)
+
+const (
+	lookupCity        = "City"
+	lookupAsn         = "Asn"
+	lookupAnonymousIp = "AnonymousIp"
 )
 
 var (
 	ErrIpParseFailed = errors.New("failed to parse geo ip")
 	ErrIpNotFound    = errors.New("ip not found")
+	// ErrLookupNotSupported is returned if the provider has no data for this kind of lookup, e.g. because no asn
+	// database is configured.
+	ErrLookupNotSupported = errors.New("lookup not supported by provider")
 )
 
 type GeoCity struct {
@@ -21,59 +36,303 @@ type GeoCity struct {
 	TimeZone    string `json:"timeZone"`
 }
 
+type GeoAsn struct {
+	Ip                           string `json:"ip"`
+	AutonomousSystemNumber       uint   `json:"autonomousSystemNumber"`
+	AutonomousSystemOrganization string `json:"autonomousSystemOrganization"`
+	// Isp and Organization are only available with an isp database
+	Isp          string `json:"isp,omitempty"`
+	Organization string `json:"organization,omitempty"`
+}
+
+type GeoAnonymousIp struct {
+	Ip                 string `json:"ip"`
+	IsAnonymous        bool   `json:"isAnonymous"`
+	IsAnonymousVpn     bool   `json:"isAnonymousVpn"`
+	IsHostingProvider  bool   `json:"isHostingProvider"`
+	IsPublicProxy      bool   `json:"isPublicProxy"`
+	IsResidentialProxy bool   `json:"isResidentialProxy"`
+	IsTorExitNode      bool   `json:"isTorExitNode"`
+}
+
+// Record contains the results of all lookups supported by the provider.
+type Record struct {
+	Ip          string          `json:"ip"`
+	City        *GeoCity        `json:"city,omitempty"`
+	Asn         *GeoAsn         `json:"asn,omitempty"`
+	AnonymousIp *GeoAnonymousIp `json:"anonymousIp,omitempty"`
+}
+
+type LookupResult struct {
+	Ip     string
+	Record *Record
+	Err    error
+}
+
 type ReaderSettings struct {
-	Provider string `cfg:"provider" default:"maxmind"`
+	Provider string          `cfg:"provider" default:"maxmind"`
+	Cache    CacheSettings   `cfg:"cache"`
+	Refresh  RefreshSettings `cfg:"refresh"`
+}
+
+type CacheSettings struct {
+	// Enabled caches the results of the lookups in a lru cache with up to Size entries per kind of lookup
+	Enabled bool          `cfg:"enabled" default:"false"`
+	Size    int64         `cfg:"size" default:"10000" validate:"min=1"`
+	Ttl     time.Duration `cfg:"ttl" default:"1h"`
 }
 
 //go:generate mockery --name Reader
 type Reader interface {
 	City(ipString string) (*GeoCity, error)
+	Asn(ipString string) (*GeoAsn, error)
+	AnonymousIp(ipString string) (*GeoAnonymousIp, error)
+	// Lookup runs all lookups supported by the provider
+	Lookup(ipString string) (*Record, error)
+	// LookupBatch runs Lookup for every ip and returns the results in the same order
+	LookupBatch(ipStrings []string) []LookupResult
+	// Refresh reloads the data of the provider if it changed
+	Refresh(ctx context.Context) error
+}
+
+type readerCaches struct {
+	city        cache.Cache[*GeoCity]
+	asn         cache.Cache[*GeoAsn]
+	anonymousIp cache.Cache[*GeoAnonymousIp]
 }
 
 type reader struct {
-	provider Provider
+	logger       log.Logger
+	metricWriter metric.Writer
+	provider     Provider
+	name         string
+	settings     *ReaderSettings
+	caches       atomic.Pointer[readerCaches]
 }
 
-func NewReader(config cfg.Config, logger log.Logger, name string) (*reader, error) {
-	key := fmt.Sprintf("ipread.%s", name)
-	settings := &ReaderSettings{}
-	config.UnmarshalKey(key, settings)
+type readerCtxKey string
+
+// ProvideReader returns the reader with the given name, which is shared with the refresh module of the reader.
+func ProvideReader(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Reader, error) {
+	return appctx.Provide(ctx, readerCtxKey(name), func() (Reader, error) {
+		return NewReader(ctx, config, logger, name)
+	})
+}
+
+func NewReader(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Reader, error) {
+	settings := readReaderSettings(config, name)
 
 	var ok bool
 	var factory ProviderFactory
-
 	if factory, ok = providers[settings.Provider]; !ok {
 		return nil, fmt.Errorf("provider %s not found", settings.Provider)
 	}
 
-	provider, err := factory(config, logger, name)
+	provider, err := factory(ctx, config, logger, name)
 	if err != nil {
 		return nil, fmt.Errorf("can not create ip reader provider: %w", err)
 	}
 
-	reader := &reader{
-		provider: provider,
+	metricWriter := metric.NewWriter(getCacheDefaultMetrics(name)...)
+
+	return NewReaderWithInterfaces(logger, metricWriter, provider, name, settings), nil
+}
+
+func NewReaderWithInterfaces(logger log.Logger, metricWriter metric.Writer, provider Provider, name string, settings *ReaderSettings) Reader {
+	r := &reader{
+		logger:       logger.WithChannel("ipread"),
+		metricWriter: metricWriter,
+		provider:     provider,
+		name:         name,
+		settings:     settings,
 	}
 
-	return reader, nil
+	r.resetCaches()
+
+	return r
 }
 
-func (r reader) City(ipString string) (*GeoCity, error) {
-	ip := net.ParseIP(ipString)
+func (r *reader) City(ipString string) (*GeoCity, error) {
+	return lookup(r, r.caches.Load().city, lookupCity, ipString, func(ip net.IP) (*GeoCity, error) {
+		record, err := r.provider.City(ip)
+		if err != nil {
+			return nil, err
+		}
+
+		return &GeoCity{
+			Ip:          ipString,
+			CountryCode: record.Country.IsoCode,
+			City:        record.City.Names["en"],
+			TimeZone:    record.Location.TimeZone,
+		}, nil
+	})
+}
+
+func (r *reader) Asn(ipString string) (*GeoAsn, error) {
+	return lookup(r, r.caches.Load().asn, lookupAsn, ipString, func(ip net.IP) (*GeoAsn, error) {
+		record, err := r.provider.Asn(ip)
+		if err != nil {
+			return nil, err
+		}
+
+		return &GeoAsn{
+			Ip:                           ipString,
+			AutonomousSystemNumber:       record.AutonomousSystemNumber,
+			AutonomousSystemOrganization: record.AutonomousSystemOrganization,
+			Isp:                          record.ISP,
+			Organization:                 record.Organization,
+		}, nil
+	})
+}
+
+func (r *reader) AnonymousIp(ipString string) (*GeoAnonymousIp, error) {
+	return lookup(r, r.caches.Load().anonymousIp, lookupAnonymousIp, ipString, func(ip net.IP) (*GeoAnonymousIp, error) {
+		record, err := r.provider.AnonymousIp(ip)
+		if err != nil {
+			return nil, err
+		}
+
+		return &GeoAnonymousIp{
+			Ip:                 ipString,
+			IsAnonymous:        record.IsAnonymous,
+			IsAnonymousVpn:     record.IsAnonymousVPN,
+			IsHostingProvider:  record.IsHostingProvider,
+			IsPublicProxy:      record.IsPublicProxy,
+			IsResidentialProxy: record.IsResidentialProxy,
+			IsTorExitNode:      record.IsTorExitNode,
+		}, nil
+	})
+}
+
+func (r *reader) Lookup(ipString string) (*Record, error) {
+	var err error
+	record := &Record{
+		Ip: ipString,
+	}
+
+	if record.City, err = r.City(ipString); ignoreUnsupported(err) != nil {
+		return nil, fmt.Errorf("can not lookup city: %w", err)
+	}
+
+	if record.Asn, err = r.Asn(ipString); ignoreUnsupported(err) != nil {
+		return nil, fmt.Errorf("can not lookup asn: %w", err)
+	}
+
+	if record.AnonymousIp, err = r.AnonymousIp(ipString); ignoreUnsupported(err) != nil {
+		return nil, fmt.Errorf("can not lookup anonymous ip: %w", err)
+	}
+
+	if record.City == nil && record.Asn == nil && record.AnonymousIp == nil {
+		return nil, ErrIpNotFound
+	}
+
+	return record, nil
+}
+
+func (r *reader) LookupBatch(ipStrings []string) []LookupResult {
+	results := make([]LookupResult, len(ipStrings))
+
+	for i, ipString := range ipStrings {
+		record, err := r.Lookup(ipString)
+
+		results[i] = LookupResult{
+			Ip:     ipString,
+			Record: record,
+			Err:    err,
+		}
+	}
+
+	return results
+}
+
+func (r *reader) Refresh(ctx context.Context) error {
+	refreshable, ok := r.provider.(RefreshableProvider)
+	if !ok {
+		return nil
+	}
+
+	changed, err := refreshable.Refresh(ctx)
+	if err != nil {
+		return fmt.Errorf("can not refresh provider %s: %w", r.settings.Provider, err)
+	}
 
+	if changed {
+		r.resetCaches()
+		r.logger.Info("refreshed the data of ip reader %s", r.name)
+	}
+
+	return nil
+}
+
+// resetCaches replaces the caches, so no results of outdated data are returned after a refresh.
+func (r *reader) resetCaches() {
+	if !r.settings.Cache.Enabled {
+		r.caches.Store(&readerCaches{})
+
+		return
+	}
+
+	size := r.settings.Cache.Size
+	pruneCount := uint32(max(size/100, 1))
+
+	r.caches.Store(&readerCaches{
+		city:        cache.New[*GeoCity](size, pruneCount, r.settings.Cache.Ttl),
+		asn:         cache.New[*GeoAsn](size, pruneCount, r.settings.Cache.Ttl),
+		anonymousIp: cache.New[*GeoAnonymousIp](size, pruneCount, r.settings.Cache.Ttl),
+	})
+}
+
+// lookup parses the ip and runs the lookup if the result isn't cached yet. Ips which aren't found are cached as nil.
+func lookup[T any](r *reader, c cache.Cache[*T], lookupName string, ipString string, lookupFunc func(ip net.IP) (*T, error)) (*T, error) {
+	ip := net.ParseIP(ipString)
 	if ip == nil {
 		return nil, ErrIpParseFailed
 	}
 
-	record, err := r.provider.City(ip)
-	if err != nil {
+	if c == nil {
+		return lookupFunc(ip)
+	}
+
+	if result, ok := c.Get(ipString); ok {
+		r.writeCacheMetric(metricNameCacheHit, lookupName)
+
+		if result == nil {
+			return nil, ErrIpNotFound
+		}
+
+		return result, nil
+	}
+
+	r.writeCacheMetric(metricNameCacheMiss, lookupName)
+
+	result, err := lookupFunc(ip)
+
+	switch {
+	case errors.Is(err, ErrIpNotFound):
+		c.Set(ipString, nil)
+
+		return nil, err
+	case err != nil:
 		return nil, err
 	}
 
-	return &GeoCity{
-		Ip:          ipString,
-		CountryCode: record.Country.IsoCode,
-		City:        record.City.Names["en"],
-		TimeZone:    record.Location.TimeZone,
-	}, nil
+	c.Set(ipString, result)
+
+	return result, nil
+}
+
+func ignoreUnsupported(err error) error {
+	if errors.Is(err, ErrLookupNotSupported) || errors.Is(err, ErrIpNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func readReaderSettings(config cfg.Config, name string) *ReaderSettings {
+	key := fmt.Sprintf("ipread.%s", name)
+	settings := &ReaderSettings{}
+	config.UnmarshalKey(key, settings)
+
+	return settings
 }
@@ -0,0 +1,188 @@
+package ipread_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/ipread"
+	"github.com/justtrackio/gosoline/pkg/ipread/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/metric"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/oschwald/geoip2-golang"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestReaderTestSuite(t *testing.T) {
+	suite.Run(t, new(ReaderTestSuite))
+}
+
+type ReaderTestSuite struct {
+	suite.Suite
+
+	metricWriter *metricMocks.Writer
+	reader       ipread.Reader
+}
+
+func (s *ReaderTestSuite) SetupTest() {
+	provider := ipread.ProvideMemoryProvider("reader-test")
+	provider.AddRecord("1.2.3.4", ipread.MemoryRecord{
+		CountryIso:     "DE",
+		CityName:       "Berlin",
+		TimeZone:       "Europe/Berlin",
+		AsNumber:       3320,
+		AsOrganization: "Deutsche Telekom AG",
+	})
+	provider.AddRecord("5.6.7.8", ipread.MemoryRecord{
+		CountryIso:     "NL",
+		IsAnonymous:    true,
+		IsAnonymousVpn: true,
+	})
+
+	s.metricWriter = metricMocks.NewWriter(s.T())
+	s.reader = ipread.NewReaderWithInterfaces(logMocks.NewLoggerMockedAll(), s.metricWriter, provider, "reader-test", &ipread.ReaderSettings{
+		Provider: "memory",
+		Cache: ipread.CacheSettings{
+			Enabled: true,
+			Size:    100,
+			Ttl:     time.Hour,
+		},
+	})
+}
+
+func (s *ReaderTestSuite) TestCity() {
+	s.expectCacheMetric("IpReadCacheMiss", "City", 1)
+	s.expectCacheMetric("IpReadCacheHit", "City", 1)
+
+	expected := &ipread.GeoCity{
+		City:        "Berlin",
+		CountryCode: "DE",
+		Ip:          "1.2.3.4",
+		TimeZone:    "Europe/Berlin",
+	}
+
+	city, err := s.reader.City("1.2.3.4")
+	s.NoError(err)
+	s.Equal(expected, city)
+
+	city, err = s.reader.City("1.2.3.4")
+	s.NoError(err)
+	s.Equal(expected, city)
+}
+
+func (s *ReaderTestSuite) TestNotFoundIsCached() {
+	s.expectCacheMetric("IpReadCacheMiss", "Asn", 1)
+	s.expectCacheMetric("IpReadCacheHit", "Asn", 1)
+
+	_, err := s.reader.Asn("5.6.7.8")
+	s.ErrorIs(err, ipread.ErrIpNotFound)
+
+	_, err = s.reader.Asn("5.6.7.8")
+	s.ErrorIs(err, ipread.ErrIpNotFound)
+}
+
+func (s *ReaderTestSuite) TestParseFailed() {
+	_, err := s.reader.City("not an ip")
+	s.ErrorIs(err, ipread.ErrIpParseFailed)
+}
+
+func (s *ReaderTestSuite) TestLookupBatch() {
+	s.expectCacheMetric("IpReadCacheMiss", "City", 3)
+	s.expectCacheMetric("IpReadCacheMiss", "Asn", 3)
+	s.expectCacheMetric("IpReadCacheMiss", "AnonymousIp", 3)
+
+	results := s.reader.LookupBatch([]string{"1.2.3.4", "5.6.7.8", "9.9.9.9", "invalid"})
+
+	s.Len(results, 4)
+
+	s.Equal(ipread.LookupResult{
+		Ip: "1.2.3.4",
+		Record: &ipread.Record{
+			Ip:          "1.2.3.4",
+			City:        &ipread.GeoCity{City: "Berlin", CountryCode: "DE", Ip: "1.2.3.4", TimeZone: "Europe/Berlin"},
+			Asn:         &ipread.GeoAsn{Ip: "1.2.3.4", AutonomousSystemNumber: 3320, AutonomousSystemOrganization: "Deutsche Telekom AG"},
+			AnonymousIp: &ipread.GeoAnonymousIp{Ip: "1.2.3.4"},
+		},
+	}, results[0])
+
+	s.Equal(ipread.LookupResult{
+		Ip: "5.6.7.8",
+		Record: &ipread.Record{
+			Ip:          "5.6.7.8",
+			City:        &ipread.GeoCity{CountryCode: "NL", Ip: "5.6.7.8"},
+			AnonymousIp: &ipread.GeoAnonymousIp{Ip: "5.6.7.8", IsAnonymous: true, IsAnonymousVpn: true},
+		},
+	}, results[1])
+
+	s.Equal("9.9.9.9", results[2].Ip)
+	s.ErrorIs(results[2].Err, ipread.ErrIpNotFound)
+
+	s.Equal("invalid", results[3].Ip)
+	s.ErrorIs(results[3].Err, ipread.ErrIpParseFailed)
+}
+
+func (s *ReaderTestSuite) TestLookupUnsupported() {
+	provider := mocks.NewProvider(s.T())
+	provider.EXPECT().City(net.ParseIP("1.2.3.4")).Return(&geoip2.City{}, nil).Once()
+	provider.EXPECT().Asn(net.ParseIP("1.2.3.4")).Return(nil, ipread.ErrLookupNotSupported).Once()
+	provider.EXPECT().AnonymousIp(net.ParseIP("1.2.3.4")).Return(nil, ipread.ErrLookupNotSupported).Once()
+
+	reader := ipread.NewReaderWithInterfaces(logMocks.NewLoggerMockedAll(), s.metricWriter, provider, "reader-test", &ipread.ReaderSettings{})
+
+	record, err := reader.Lookup("1.2.3.4")
+	s.NoError(err)
+	s.Equal(&ipread.Record{
+		Ip:   "1.2.3.4",
+		City: &ipread.GeoCity{Ip: "1.2.3.4"},
+	}, record)
+}
+
+func (s *ReaderTestSuite) TestRefreshResetsCache() {
+	provider := mocks.NewRefreshableProvider(s.T())
+	provider.EXPECT().City(net.ParseIP("1.2.3.4")).Return(&geoip2.City{}, nil).Twice()
+	provider.EXPECT().Refresh(context.Background()).Return(false, nil).Once()
+	provider.EXPECT().Refresh(context.Background()).Return(true, nil).Once()
+	provider.EXPECT().Refresh(context.Background()).Return(false, fmt.Errorf("s3 not reachable")).Once()
+
+	s.expectCacheMetric("IpReadCacheMiss", "City", 2)
+	s.expectCacheMetric("IpReadCacheHit", "City", 1)
+
+	reader := ipread.NewReaderWithInterfaces(logMocks.NewLoggerMockedAll(), s.metricWriter, provider, "reader-test", &ipread.ReaderSettings{
+		Provider: "maxmind",
+		Cache: ipread.CacheSettings{
+			Enabled: true,
+			Size:    100,
+			Ttl:     time.Hour,
+		},
+	})
+
+	_, err := reader.City("1.2.3.4")
+	s.NoError(err)
+
+	// nothing changed, so the cached result is used
+	s.NoError(reader.Refresh(context.Background()))
+	_, err = reader.City("1.2.3.4")
+	s.NoError(err)
+
+	// the data changed, so the cache is empty again
+	s.NoError(reader.Refresh(context.Background()))
+	_, err = reader.City("1.2.3.4")
+	s.NoError(err)
+
+	s.EqualError(reader.Refresh(context.Background()), "can not refresh provider maxmind: s3 not reachable")
+}
+
+func (s *ReaderTestSuite) expectCacheMetric(name string, lookup string, times int) {
+	s.metricWriter.EXPECT().WriteOne(&metric.Datum{
+		MetricName: name,
+		Dimensions: map[string]string{
+			"Reader": "reader-test",
+			"Lookup": lookup,
+		},
+		Unit:  metric.UnitCount,
+		Value: 1.0,
+	}).Times(times)
+}
@@ -0,0 +1,45 @@
+package ipread
+
+import (
+	"github.com/justtrackio/gosoline/pkg/metric"
+)
+
+const (
+	// number of lookups answered by the cache
+	metricNameCacheHit = "IpReadCacheHit"
+	// number of lookups which had to be done by the provider
+	metricNameCacheMiss = "IpReadCacheMiss"
+)
+
+func (r *reader) writeCacheMetric(metricName string, lookupName string) {
+	r.metricWriter.WriteOne(&metric.Datum{
+		MetricName: metricName,
+		Dimensions: map[string]string{
+			"Reader": r.name,
+			"Lookup": lookupName,
+		},
+		Unit:  metric.UnitCount,
+		Value: 1.0,
+	})
+}
+
+func getCacheDefaultMetrics(name string) metric.Data {
+	defaults := make(metric.Data, 0)
+
+	for _, metricName := range []string{metricNameCacheHit, metricNameCacheMiss} {
+		for _, lookupName := range []string{lookupCity, lookupAsn, lookupAnonymousIp} {
+			defaults = append(defaults, &metric.Datum{
+				Priority:   metric.PriorityHigh,
+				MetricName: metricName,
+				Dimensions: map[string]string{
+					"Reader": name,
+					"Lookup": lookupName,
+				},
+				Unit:  metric.UnitCount,
+				Value: 0.0,
+			})
+		}
+	}
+
+	return defaults
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	ipread "github.com/justtrackio/gosoline/pkg/ipread"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,6 +22,114 @@ func (_m *Reader) EXPECT() *Reader_Expecter {
 	return &Reader_Expecter{mock: &_m.Mock}
 }
 
+// AnonymousIp provides a mock function with given fields: ipString
+func (_m *Reader) AnonymousIp(ipString string) (*ipread.GeoAnonymousIp, error) {
+	ret := _m.Called(ipString)
+
+	var r0 *ipread.GeoAnonymousIp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*ipread.GeoAnonymousIp, error)); ok {
+		return rf(ipString)
+	}
+	if rf, ok := ret.Get(0).(func(string) *ipread.GeoAnonymousIp); ok {
+		r0 = rf(ipString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ipread.GeoAnonymousIp)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ipString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reader_AnonymousIp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymousIp'
+type Reader_AnonymousIp_Call struct {
+	*mock.Call
+}
+
+// AnonymousIp is a helper method to define mock.On call
+//   - ipString string
+func (_e *Reader_Expecter) AnonymousIp(ipString interface{}) *Reader_AnonymousIp_Call {
+	return &Reader_AnonymousIp_Call{Call: _e.mock.On("AnonymousIp", ipString)}
+}
+
+func (_c *Reader_AnonymousIp_Call) Run(run func(ipString string)) *Reader_AnonymousIp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Reader_AnonymousIp_Call) Return(_a0 *ipread.GeoAnonymousIp, _a1 error) *Reader_AnonymousIp_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Reader_AnonymousIp_Call) RunAndReturn(run func(string) (*ipread.GeoAnonymousIp, error)) *Reader_AnonymousIp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Asn provides a mock function with given fields: ipString
+func (_m *Reader) Asn(ipString string) (*ipread.GeoAsn, error) {
+	ret := _m.Called(ipString)
+
+	var r0 *ipread.GeoAsn
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*ipread.GeoAsn, error)); ok {
+		return rf(ipString)
+	}
+	if rf, ok := ret.Get(0).(func(string) *ipread.GeoAsn); ok {
+		r0 = rf(ipString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ipread.GeoAsn)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ipString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reader_Asn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Asn'
+type Reader_Asn_Call struct {
+	*mock.Call
+}
+
+// Asn is a helper method to define mock.On call
+//   - ipString string
+func (_e *Reader_Expecter) Asn(ipString interface{}) *Reader_Asn_Call {
+	return &Reader_Asn_Call{Call: _e.mock.On("Asn", ipString)}
+}
+
+func (_c *Reader_Asn_Call) Run(run func(ipString string)) *Reader_Asn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Reader_Asn_Call) Return(_a0 *ipread.GeoAsn, _a1 error) *Reader_Asn_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Reader_Asn_Call) RunAndReturn(run func(string) (*ipread.GeoAsn, error)) *Reader_Asn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // City provides a mock function with given fields: ipString
 func (_m *Reader) City(ipString string) (*ipread.GeoCity, error) {
 	ret := _m.Called(ipString)
@@ -74,6 +184,146 @@ func (_c *Reader_City_Call) RunAndReturn(run func(string) (*ipread.GeoCity, erro
 	return _c
 }
 
+// Lookup provides a mock function with given fields: ipString
+func (_m *Reader) Lookup(ipString string) (*ipread.Record, error) {
+	ret := _m.Called(ipString)
+
+	var r0 *ipread.Record
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*ipread.Record, error)); ok {
+		return rf(ipString)
+	}
+	if rf, ok := ret.Get(0).(func(string) *ipread.Record); ok {
+		r0 = rf(ipString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ipread.Record)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ipString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reader_Lookup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lookup'
+type Reader_Lookup_Call struct {
+	*mock.Call
+}
+
+// Lookup is a helper method to define mock.On call
+//   - ipString string
+func (_e *Reader_Expecter) Lookup(ipString interface{}) *Reader_Lookup_Call {
+	return &Reader_Lookup_Call{Call: _e.mock.On("Lookup", ipString)}
+}
+
+func (_c *Reader_Lookup_Call) Run(run func(ipString string)) *Reader_Lookup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Reader_Lookup_Call) Return(_a0 *ipread.Record, _a1 error) *Reader_Lookup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Reader_Lookup_Call) RunAndReturn(run func(string) (*ipread.Record, error)) *Reader_Lookup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupBatch provides a mock function with given fields: ipStrings
+func (_m *Reader) LookupBatch(ipStrings []string) []ipread.LookupResult {
+	ret := _m.Called(ipStrings)
+
+	var r0 []ipread.LookupResult
+	if rf, ok := ret.Get(0).(func([]string) []ipread.LookupResult); ok {
+		r0 = rf(ipStrings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ipread.LookupResult)
+		}
+	}
+
+	return r0
+}
+
+// Reader_LookupBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupBatch'
+type Reader_LookupBatch_Call struct {
+	*mock.Call
+}
+
+// LookupBatch is a helper method to define mock.On call
+//   - ipStrings []string
+func (_e *Reader_Expecter) LookupBatch(ipStrings interface{}) *Reader_LookupBatch_Call {
+	return &Reader_LookupBatch_Call{Call: _e.mock.On("LookupBatch", ipStrings)}
+}
+
+func (_c *Reader_LookupBatch_Call) Run(run func(ipStrings []string)) *Reader_LookupBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Reader_LookupBatch_Call) Return(_a0 []ipread.LookupResult) *Reader_LookupBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Reader_LookupBatch_Call) RunAndReturn(run func([]string) []ipread.LookupResult) *Reader_LookupBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx
+func (_m *Reader) Refresh(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reader_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type Reader_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Reader_Expecter) Refresh(ctx interface{}) *Reader_Refresh_Call {
+	return &Reader_Refresh_Call{Call: _e.mock.On("Refresh", ctx)}
+}
+
+func (_c *Reader_Refresh_Call) Run(run func(ctx context.Context)) *Reader_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Reader_Refresh_Call) Return(_a0 error) *Reader_Refresh_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Reader_Refresh_Call) RunAndReturn(run func(context.Context) error) *Reader_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewReader interface {
 	mock.TestingT
 	Cleanup(func())
@@ -1,6 +1,7 @@
 package ipread
 
 import (
+	"context"
 	"net"
 
 	"github.com/justtrackio/gosoline/pkg/cfg"
@@ -8,11 +9,24 @@ import (
 	"github.com/oschwald/geoip2-golang"
 )
 
+//go:generate mockery --name Provider
 type Provider interface {
 	City(ipAddress net.IP) (*geoip2.City, error)
+	// Asn returns the data of an isp database or, if only the asn is known, the asn and its organization
+	Asn(ipAddress net.IP) (*geoip2.ISP, error)
+	AnonymousIp(ipAddress net.IP) (*geoip2.AnonymousIP, error)
 }
 
-type ProviderFactory func(config cfg.Config, logger log.Logger, name string) (Provider, error)
+// A RefreshableProvider can reload its data while the application is running.
+//
+//go:generate mockery --name RefreshableProvider
+type RefreshableProvider interface {
+	Provider
+	// Refresh reloads the data if it changed and reports if it did so
+	Refresh(ctx context.Context) (bool, error)
+}
+
+type ProviderFactory func(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Provider, error)
 
 var providers = map[string]ProviderFactory{
 	"maxmind": NewMaxmindProvider,
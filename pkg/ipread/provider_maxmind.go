@@ -1,20 +1,196 @@
 package ipread
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
 
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/log"
 	"github.com/oschwald/geoip2-golang"
 )
 
-func NewMaxmindProvider(config cfg.Config, _ log.Logger, name string) (Provider, error) {
-	key := fmt.Sprintf("ipread.%s.maxmind.database", name)
-	database := config.GetString(key)
-	geoIpReader, err := geoip2.Open(database)
-	if err != nil {
+// MaxmindSettings configure the databases of the maxmind provider. Every database can either be a local file or an
+// object in s3 (s3://bucket/key), only the lookups of the configured databases are supported.
+type MaxmindSettings struct {
+	// Database is a city database used for City lookups
+	Database            string `cfg:"database"`
+	AsnDatabase         string `cfg:"asn_database"`
+	IspDatabase         string `cfg:"isp_database"`
+	AnonymousIpDatabase string `cfg:"anonymous_ip_database"`
+	S3ClientName        string `cfg:"s3_client_name" default:"default"`
+}
+
+type maxmindDatabase struct {
+	path    string
+	source  DatabaseSource
+	version string
+	reader  atomic.Pointer[geoip2.Reader]
+}
+
+type maxmindProvider struct {
+	logger      log.Logger
+	lck         sync.Mutex
+	city        *maxmindDatabase
+	asn         *maxmindDatabase
+	isp         *maxmindDatabase
+	anonymousIp *maxmindDatabase
+}
+
+func NewMaxmindProvider(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Provider, error) {
+	key := fmt.Sprintf("ipread.%s.maxmind", name)
+	settings := &MaxmindSettings{}
+	config.UnmarshalKey(key, settings)
+
+	sources := make(map[string]DatabaseSource)
+
+	for _, path := range []string{settings.Database, settings.AsnDatabase, settings.IspDatabase, settings.AnonymousIpDatabase} {
+		if path == "" {
+			continue
+		}
+
+		source, err := NewDatabaseSource(ctx, config, logger, path, settings.S3ClientName)
+		if err != nil {
+			return nil, fmt.Errorf("can not create source for database %s: %w", path, err)
+		}
+
+		sources[path] = source
+	}
+
+	return NewMaxmindProviderWithInterfaces(ctx, logger, settings, sources)
+}
+
+// NewMaxmindProviderWithInterfaces loads the databases configured in the settings from the sources, which are
+// keyed by the path of the database.
+func NewMaxmindProviderWithInterfaces(ctx context.Context, logger log.Logger, settings *MaxmindSettings, sources map[string]DatabaseSource) (*maxmindProvider, error) {
+	provider := &maxmindProvider{
+		logger:      logger.WithChannel("ipread-maxmind"),
+		city:        newMaxmindDatabase(settings.Database, sources),
+		asn:         newMaxmindDatabase(settings.AsnDatabase, sources),
+		isp:         newMaxmindDatabase(settings.IspDatabase, sources),
+		anonymousIp: newMaxmindDatabase(settings.AnonymousIpDatabase, sources),
+	}
+
+	if len(provider.databases()) == 0 {
+		return nil, fmt.Errorf("no maxmind database configured")
+	}
+
+	if _, err := provider.Refresh(ctx); err != nil {
 		return nil, fmt.Errorf("could not open geo db: %w", err)
 	}
 
-	return geoIpReader, nil
+	return provider, nil
+}
+
+func newMaxmindDatabase(path string, sources map[string]DatabaseSource) *maxmindDatabase {
+	if path == "" {
+		return nil
+	}
+
+	return &maxmindDatabase{
+		path:   path,
+		source: sources[path],
+	}
+}
+
+func (p *maxmindProvider) City(ipAddress net.IP) (*geoip2.City, error) {
+	if p.city == nil {
+		return nil, ErrLookupNotSupported
+	}
+
+	return p.city.reader.Load().City(ipAddress)
+}
+
+func (p *maxmindProvider) Asn(ipAddress net.IP) (*geoip2.ISP, error) {
+	if p.isp != nil {
+		return p.isp.reader.Load().ISP(ipAddress)
+	}
+
+	if p.asn == nil {
+		return nil, ErrLookupNotSupported
+	}
+
+	record, err := p.asn.reader.Load().ASN(ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geoip2.ISP{
+		AutonomousSystemNumber:       record.AutonomousSystemNumber,
+		AutonomousSystemOrganization: record.AutonomousSystemOrganization,
+	}, nil
+}
+
+func (p *maxmindProvider) AnonymousIp(ipAddress net.IP) (*geoip2.AnonymousIP, error) {
+	if p.anonymousIp == nil {
+		return nil, ErrLookupNotSupported
+	}
+
+	return p.anonymousIp.reader.Load().AnonymousIP(ipAddress)
+}
+
+// Refresh reloads every database whose version changed. The new database replaces the old one only after it was
+// read completely, lookups keep using the old one until then.
+func (p *maxmindProvider) Refresh(ctx context.Context) (bool, error) {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	changed := false
+
+	for _, database := range p.databases() {
+		reloaded, err := p.reload(ctx, database)
+		if err != nil {
+			return changed, fmt.Errorf("can not reload database %s: %w", database.path, err)
+		}
+
+		changed = changed || reloaded
+	}
+
+	return changed, nil
+}
+
+func (p *maxmindProvider) reload(ctx context.Context, database *maxmindDatabase) (bool, error) {
+	var err error
+	var version string
+	var data []byte
+	var reader *geoip2.Reader
+
+	if version, err = database.source.Version(ctx); err != nil {
+		return false, err
+	}
+
+	if version == database.version {
+		return false, nil
+	}
+
+	if data, err = database.source.Read(ctx); err != nil {
+		return false, err
+	}
+
+	if reader, err = geoip2.FromBytes(data); err != nil {
+		return false, fmt.Errorf("can not open database: %w", err)
+	}
+
+	// readers created from bytes don't hold any resources, so the old one doesn't have to be closed and can still be
+	// used by running lookups
+	database.reader.Store(reader)
+	database.version = version
+
+	p.logger.Info("loaded database %s with version %s built at %d", database.path, version, reader.Metadata().BuildEpoch)
+
+	return true, nil
+}
+
+func (p *maxmindProvider) databases() []*maxmindDatabase {
+	databases := make([]*maxmindDatabase, 0, 4)
+
+	for _, database := range []*maxmindDatabase{p.city, p.asn, p.isp, p.anonymousIp} {
+		if database != nil {
+			databases = append(databases, database)
+		}
+	}
+
+	return databases
 }
@@ -0,0 +1,51 @@
+package ipread_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/ipread"
+	"github.com/justtrackio/gosoline/pkg/ipread/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxmindProvider_NoDatabase(t *testing.T) {
+	_, err := ipread.NewMaxmindProviderWithInterfaces(context.Background(), logMocks.NewLoggerMockedAll(), &ipread.MaxmindSettings{}, map[string]ipread.DatabaseSource{})
+
+	assert.EqualError(t, err, "no maxmind database configured")
+}
+
+func TestMaxmindProvider_InvalidDatabase(t *testing.T) {
+	source := mocks.NewDatabaseSource(t)
+	source.EXPECT().Version(context.Background()).Return("v1", nil).Once()
+	source.EXPECT().Read(context.Background()).Return([]byte("not a database"), nil).Once()
+
+	settings := &ipread.MaxmindSettings{
+		Database: "s3://bucket/city.mmdb",
+	}
+	sources := map[string]ipread.DatabaseSource{
+		"s3://bucket/city.mmdb": source,
+	}
+
+	_, err := ipread.NewMaxmindProviderWithInterfaces(context.Background(), logMocks.NewLoggerMockedAll(), settings, sources)
+
+	assert.ErrorContains(t, err, "could not open geo db: can not reload database s3://bucket/city.mmdb: can not open database")
+}
+
+func TestMaxmindProvider_SourceFailed(t *testing.T) {
+	source := mocks.NewDatabaseSource(t)
+	source.EXPECT().Version(context.Background()).Return("", fmt.Errorf("access denied")).Once()
+
+	settings := &ipread.MaxmindSettings{
+		AnonymousIpDatabase: "/data/anonymous-ip.mmdb",
+	}
+	sources := map[string]ipread.DatabaseSource{
+		"/data/anonymous-ip.mmdb": source,
+	}
+
+	_, err := ipread.NewMaxmindProviderWithInterfaces(context.Background(), logMocks.NewLoggerMockedAll(), settings, sources)
+
+	assert.EqualError(t, err, "could not open geo db: can not reload database /data/anonymous-ip.mmdb: access denied")
+}
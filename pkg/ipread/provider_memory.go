@@ -1,6 +1,7 @@
 package ipread
 
 import (
+	"context"
 	"net"
 
 	"github.com/justtrackio/gosoline/pkg/cfg"
@@ -12,10 +13,26 @@ type MemoryRecord struct {
 	CountryIso string `cfg:"country_iso"`
 	CityName   string `cfg:"city_name"`
 	TimeZone   string `cfg:"time_zone"`
+	// the asn of the ip is only known if the number or the organization is set
+	AsNumber       uint   `cfg:"as_number"`
+	AsOrganization string `cfg:"as_organization"`
+	Isp            string `cfg:"isp"`
+	Organization   string `cfg:"organization"`
+	IsAnonymous    bool   `cfg:"is_anonymous"`
+	IsAnonymousVpn bool   `cfg:"is_anonymous_vpn"`
+	IsHosting      bool   `cfg:"is_hosting"`
+	IsPublicProxy  bool   `cfg:"is_public_proxy"`
+	IsTorExitNode  bool   `cfg:"is_tor_exit_node"`
+}
+
+type memoryEntry struct {
+	city        *geoip2.City
+	asn         *geoip2.ISP
+	anonymousIp *geoip2.AnonymousIP
 }
 
 type memoryProvider struct {
-	records map[string]*geoip2.City
+	records map[string]*memoryEntry
 }
 
 var memoryProviderContainer = make(map[string]*memoryProvider)
@@ -26,28 +43,64 @@ func ProvideMemoryProvider(name string) *memoryProvider {
 	}
 
 	memoryProviderContainer[name] = &memoryProvider{
-		records: make(map[string]*geoip2.City),
+		records: make(map[string]*memoryEntry),
 	}
 
 	return memoryProviderContainer[name]
 }
 
-func NewMemoryProvider(_ cfg.Config, _ log.Logger, name string) (Provider, error) {
+func NewMemoryProvider(_ context.Context, _ cfg.Config, _ log.Logger, name string) (Provider, error) {
 	return ProvideMemoryProvider(name), nil
 }
 
 func (p memoryProvider) City(ipAddress net.IP) (*geoip2.City, error) {
-	ipString := ipAddress.String()
+	entry, ok := p.records[ipAddress.String()]
+	if !ok {
+		return nil, ErrIpNotFound
+	}
 
-	if _, ok := p.records[ipString]; !ok {
+	return entry.city, nil
+}
+
+func (p memoryProvider) Asn(ipAddress net.IP) (*geoip2.ISP, error) {
+	entry, ok := p.records[ipAddress.String()]
+	if !ok || entry.asn == nil {
 		return nil, ErrIpNotFound
 	}
 
-	return p.records[ipString], nil
+	return entry.asn, nil
+}
+
+func (p memoryProvider) AnonymousIp(ipAddress net.IP) (*geoip2.AnonymousIP, error) {
+	entry, ok := p.records[ipAddress.String()]
+	if !ok {
+		return nil, ErrIpNotFound
+	}
+
+	return entry.anonymousIp, nil
 }
 
 func (p memoryProvider) AddRecord(ipString string, record MemoryRecord) {
-	p.records[ipString] = &geoip2.City{
+	entry := &memoryEntry{
+		anonymousIp: &geoip2.AnonymousIP{
+			IsAnonymous:       record.IsAnonymous,
+			IsAnonymousVPN:    record.IsAnonymousVpn,
+			IsHostingProvider: record.IsHosting,
+			IsPublicProxy:     record.IsPublicProxy,
+			IsTorExitNode:     record.IsTorExitNode,
+		},
+	}
+
+	if record.AsNumber != 0 || record.AsOrganization != "" {
+		entry.asn = &geoip2.ISP{
+			AutonomousSystemNumber:       record.AsNumber,
+			AutonomousSystemOrganization: record.AsOrganization,
+			ISP:                          record.Isp,
+			Organization:                 record.Organization,
+		}
+	}
+
+	entry.city = &geoip2.City{
 		City: struct {
 			GeoNameID uint              `maxminddb:"geoname_id"`
 			Names     map[string]string `maxminddb:"names"`
@@ -74,4 +127,6 @@ func (p memoryProvider) AddRecord(ipString string, record MemoryRecord) {
 			TimeZone: record.TimeZone,
 		},
 	}
+
+	p.records[ipString] = entry
 }
@@ -0,0 +1,68 @@
+package ipread
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+type RefreshSettings struct {
+	// Interval in which the refresh module checks if the data of the provider changed
+	Interval time.Duration `cfg:"interval" default:"1h" validate:"min=1000000000"`
+}
+
+type refreshModule struct {
+	kernel.BackgroundModule
+	kernel.ServiceStage
+
+	logger   log.Logger
+	clock    clock.Clock
+	reader   Reader
+	interval time.Duration
+}
+
+// NewRefreshModule keeps the data of the reader with the given name up to date, e.g. by loading new versions of the
+// maxmind databases from s3. Get the refreshed reader with ProvideReader.
+func NewRefreshModule(name string) kernel.ModuleFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+		reader, err := ProvideReader(ctx, config, logger, name)
+		if err != nil {
+			return nil, fmt.Errorf("can not create ip reader %s: %w", name, err)
+		}
+
+		settings := readReaderSettings(config, name)
+
+		return NewRefreshModuleWithInterfaces(logger, clock.Provider, reader, settings.Refresh.Interval), nil
+	}
+}
+
+func NewRefreshModuleWithInterfaces(logger log.Logger, clock clock.Clock, reader Reader, interval time.Duration) kernel.Module {
+	return &refreshModule{
+		logger:   logger.WithChannel("ipread-refresh"),
+		clock:    clock,
+		reader:   reader,
+		interval: interval,
+	}
+}
+
+func (m *refreshModule) Run(ctx context.Context) error {
+	ticker := m.clock.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.Chan():
+			// a failed refresh keeps the old data, so we only log the error and try again later
+			if err := m.reader.Refresh(ctx); err != nil {
+				m.logger.Error("can not refresh ip reader: %w", err)
+			}
+		}
+	}
+}
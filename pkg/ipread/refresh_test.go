@@ -0,0 +1,48 @@
+package ipread_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/ipread"
+	"github.com/justtrackio/gosoline/pkg/ipread/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRefreshModule_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fakeClock := clock.NewFakeClock()
+	refreshed := make(chan struct{})
+
+	reader := mocks.NewReader(t)
+	reader.EXPECT().Refresh(mock.Anything).Run(func(ctx context.Context) {
+		refreshed <- struct{}{}
+	}).Return(fmt.Errorf("s3 not reachable")).Once()
+	reader.EXPECT().Refresh(mock.Anything).Run(func(ctx context.Context) {
+		refreshed <- struct{}{}
+	}).Return(nil).Once()
+
+	module := ipread.NewRefreshModuleWithInterfaces(logMocks.NewLoggerMockedAll(), fakeClock, reader, time.Hour)
+
+	done := make(chan error)
+	go func() {
+		done <- module.Run(ctx)
+	}()
+
+	fakeClock.BlockUntilTickers(1)
+	fakeClock.Advance(time.Hour)
+	<-refreshed
+
+	// a failed refresh doesn't stop the module
+	fakeClock.Advance(time.Hour)
+	<-refreshed
+
+	cancel()
+
+	assert.NoError(t, <-done)
+}
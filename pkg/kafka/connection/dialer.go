@@ -19,20 +19,36 @@ const (
 
 // NewDialer is a dialer factory.
 func NewDialer(conf *Settings) (*kafka.Dialer, error) {
+	dialer := &kafka.Dialer{
+		DualStack:       true,
+		TLS:             NewTLSConfig(conf),
+		KeepAlive:       DefaultKeepAlive,
+		Timeout:         DefaultDialerTimeout,
+		TransactionalID: uuid.New().String(),
+	}
+
+	if conf.Username == "" {
+		return dialer, nil
+	}
+
 	mechanism, err := scram.Mechanism(scram.SHA512, conf.Username, conf.Password)
 	if err != nil {
 		return nil, err
 	}
 
-	return &kafka.Dialer{
-		DualStack: true,
-		TLS: &tls.Config{
-			InsecureSkipVerify: conf.InsecureSkipVerify,
-			MinVersion:         tls.VersionTLS12,
-		},
-		SASLMechanism:   mechanism,
-		KeepAlive:       DefaultKeepAlive,
-		Timeout:         DefaultDialerTimeout,
-		TransactionalID: uuid.New().String(),
-	}, nil
+	dialer.SASLMechanism = mechanism
+
+	return dialer, nil
+}
+
+// NewTLSConfig returns the tls config to connect to the brokers or nil if tls is disabled.
+func NewTLSConfig(conf *Settings) *tls.Config {
+	if conf.DisableTLS {
+		return nil
+	}
+
+	return &tls.Config{
+		InsecureSkipVerify: conf.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
 }
@@ -24,3 +24,14 @@ func TestNewDialer(t *testing.T) {
 	assert.NotNil(t, dialer.SASLMechanism)
 	assert.NotEmpty(t, dialer.TransactionalID)
 }
+
+func TestNewDialerPlaintext(t *testing.T) {
+	dialer, err := NewDialer(
+		&Settings{
+			DisableTLS: true,
+		})
+
+	assert.Nil(t, err)
+	assert.Nil(t, dialer.TLS)
+	assert.Nil(t, dialer.SASLMechanism)
+}
@@ -10,6 +10,8 @@ type Settings struct {
 	// Connection.
 	Bootstrap          []string `cfg:"bootstrap" validate:"required"`
 	InsecureSkipVerify bool     `cfg:"insecure_skip_verify"`
+	// DisableTLS connects in plaintext, e.g. to a local broker.
+	DisableTLS bool `cfg:"disable_tls"`
 
 	// Credentials.
 	Username string `cfg:"username"`
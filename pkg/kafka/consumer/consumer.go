@@ -9,25 +9,44 @@ import (
 	"github.com/justtrackio/gosoline/pkg/kafka/connection"
 	"github.com/justtrackio/gosoline/pkg/kafka/logging"
 	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/metric"
 	"github.com/segmentio/kafka-go"
 )
 
 type Offset struct {
+	Topic     string
 	Partition int
 	Index     int64
 }
 
+// GroupGeneration identifies the membership of a consumer in a generation of its consumer group.
+type GroupGeneration struct {
+	GroupID      string
+	GenerationID int32
+	MemberID     string
+}
+
+//go:generate mockery --name GroupMember
+type GroupMember interface {
+	Generation() (GroupGeneration, error)
+	Lag() map[TopicPartition]int64
+}
+
 type Consumer struct {
 	logger   log.Logger
 	settings *Settings
 
-	pool    coffin.Coffin
-	backlog chan kafka.Message
-	manager OffsetManager
+	pool         coffin.Coffin
+	backlog      chan kafka.Message
+	manager      OffsetManager
+	member       GroupMember
+	metricWriter metric.Writer
 }
 
+// NewConsumer creates a consumer for all topics configured at the given key. The listeners are notified about
+// every partition assignment and revocation of the consumer group after the in-flight messages have been flushed.
 func NewConsumer(
-	ctx context.Context, conf cfg.Config, logger log.Logger, key string,
+	ctx context.Context, conf cfg.Config, logger log.Logger, key string, listeners ...RebalanceListener,
 ) (*Consumer, error) {
 	settings := ParseSettings(conf, key)
 
@@ -37,18 +56,35 @@ func NewConsumer(
 		return nil, fmt.Errorf("kafka: failed to get dialer: %w", err)
 	}
 
+	// Topics.
+	topics, err := ResolveTopics(ctx, dialer, settings)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to resolve topics: %w", err)
+	}
+
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("kafka: no topics to consume configured at %s", key)
+	}
+
 	// Reader.
-	reader, err := NewReader(logger, dialer, settings, getOptions(settings)...)
+	reader, err := NewGroupReader(logger, dialer, settings, topics)
 	if err != nil {
 		return nil, fmt.Errorf("kafka: failed to get reader: %w", err)
 	}
 
 	manager := NewOffsetManager(logger, reader, settings.BatchSize, settings.BatchTimeout)
 
-	return NewConsumerWithInterfaces(settings, logger, manager)
+	reader.AddRebalanceListener(manager)
+	for _, listener := range listeners {
+		reader.AddRebalanceListener(listener)
+	}
+
+	mw := metric.NewWriter(getConsumerDefaultMetrics(settings, topics)...)
+
+	return NewConsumerWithInterfaces(settings, logger, manager, reader, mw)
 }
 
-func NewConsumerWithInterfaces(settings *Settings, logger log.Logger, manager OffsetManager) (*Consumer, error) {
+func NewConsumerWithInterfaces(settings *Settings, logger log.Logger, manager OffsetManager, member GroupMember, mw metric.Writer) (*Consumer, error) {
 	logger = logger.WithFields(
 		log.Fields{
 			"kafka_topic":          settings.FQTopic,
@@ -59,11 +95,13 @@ func NewConsumerWithInterfaces(settings *Settings, logger log.Logger, manager Of
 	)
 
 	return &Consumer{
-		settings: settings,
-		logger:   logging.NewKafkaLogger(logger),
-		pool:     coffin.New(),
-		backlog:  make(chan kafka.Message, settings.BatchSize),
-		manager:  manager,
+		settings:     settings,
+		logger:       logging.NewKafkaLogger(logger),
+		pool:         coffin.New(),
+		backlog:      make(chan kafka.Message, settings.BatchSize),
+		manager:      manager,
+		member:       member,
+		metricWriter: mw,
 	}, nil
 }
 
@@ -75,6 +113,10 @@ func (c *Consumer) Run(ctx context.Context) error {
 	return c.pool.Wait()
 }
 
+func (c *Consumer) Settings() *Settings {
+	return c.settings
+}
+
 func (c *Consumer) Data() chan kafka.Message {
 	return c.backlog
 }
@@ -83,9 +125,19 @@ func (c *Consumer) Commit(ctx context.Context, msgs ...kafka.Message) error {
 	return c.manager.Commit(ctx, msgs...)
 }
 
+// Generation returns the current consumer group generation, which is needed to commit offsets as part of a
+// producer transaction.
+func (c *Consumer) Generation() (GroupGeneration, error) {
+	return c.member.Generation()
+}
+
 func (c *Consumer) run(ctx context.Context) error {
 	c.pool.GoWithContext(ctx, c.manager.Start)
 
+	if c.settings.LagInterval > 0 {
+		c.pool.GoWithContext(ctx, c.runLagMetrics)
+	}
+
 	for {
 		for _, msg := range c.manager.Batch(ctx) {
 			select {
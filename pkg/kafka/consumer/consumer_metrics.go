@@ -0,0 +1,70 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/metric"
+)
+
+const (
+	metricNameConsumerLag = "KafkaConsumerLag"
+)
+
+func getConsumerDefaultMetrics(settings *Settings, topics []string) metric.Data {
+	data := make(metric.Data, 0, len(topics))
+
+	for _, topic := range topics {
+		data = append(data, &metric.Datum{
+			Priority:   metric.PriorityHigh,
+			MetricName: metricNameConsumerLag,
+			Dimensions: map[string]string{
+				"ConsumerGroup": settings.FQGroupID,
+				"Topic":         topic,
+			},
+			Unit:  metric.UnitCountMaximum,
+			Value: 0.0,
+		})
+	}
+
+	return data
+}
+
+func (c *Consumer) runLagMetrics(ctx context.Context) error {
+	ticker := time.NewTicker(c.settings.LagInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.writeLagMetrics()
+		}
+	}
+}
+
+func (c *Consumer) writeLagMetrics() {
+	lagPerTopic := map[string]int64{}
+
+	for tp, lag := range c.member.Lag() {
+		lagPerTopic[tp.Topic] += lag
+	}
+
+	data := make(metric.Data, 0, len(lagPerTopic))
+
+	for topic, lag := range lagPerTopic {
+		data = append(data, &metric.Datum{
+			Priority:   metric.PriorityHigh,
+			MetricName: metricNameConsumerLag,
+			Dimensions: map[string]string{
+				"ConsumerGroup": c.settings.FQGroupID,
+				"Topic":         topic,
+			},
+			Unit:  metric.UnitCountMaximum,
+			Value: float64(lag),
+		})
+	}
+
+	c.metricWriter.Write(data)
+}
@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -9,7 +10,12 @@ import (
 	"github.com/segmentio/kafka-go"
 )
 
-var _ OffsetManager = &offsetManager{}
+const revokePollInterval = 100 * time.Millisecond
+
+var (
+	_ OffsetManager     = &offsetManager{}
+	_ RebalanceListener = &offsetManager{}
+)
 
 //go:generate mockery --name OffsetManager
 type OffsetManager interface {
@@ -87,7 +93,7 @@ func (m *offsetManager) Batch(ctx context.Context) []kafka.Message {
 	m.readLock.Lock()
 	defer m.readLock.Unlock()
 	for _, msg := range batch {
-		m.uncomitted[Offset{Partition: msg.Partition, Index: msg.Offset}] = msg.Offset
+		m.uncomitted[Offset{Topic: msg.Topic, Partition: msg.Partition, Index: msg.Offset}] = msg.Offset
 	}
 
 	return batch
@@ -104,7 +110,7 @@ func (m *offsetManager) Commit(ctx context.Context, msgs ...kafka.Message) error
 	m.readLock.Lock()
 	defer m.readLock.Unlock()
 	for _, msg := range msgs {
-		key := Offset{Partition: msg.Partition, Index: msg.Offset}
+		key := Offset{Topic: msg.Topic, Partition: msg.Partition, Index: msg.Offset}
 		if _, exists := m.uncomitted[key]; !exists {
 			m.logger.WithFields(log.Fields{
 				"kafka_partition": msg.Partition,
@@ -127,6 +133,49 @@ func (m *offsetManager) Commit(ctx context.Context, msgs ...kafka.Message) error
 	return m.reader.CommitMessages(ctx, msgs...)
 }
 
+func (m *offsetManager) OnAssigned(_ context.Context, _ []TopicPartition) error {
+	return nil
+}
+
+// OnRevoked blocks until all in-flight messages of the revoked partitions got committed, so their offsets are
+// stored before another consumer takes over the partitions.
+func (m *offsetManager) OnRevoked(ctx context.Context, partitions []TopicPartition) error {
+	ticker := time.NewTicker(revokePollInterval)
+	defer ticker.Stop()
+
+	for {
+		pending := m.pending(partitions)
+		if pending == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d in-flight messages of revoked partitions were not committed: %w", pending, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *offsetManager) pending(partitions []TopicPartition) int {
+	m.readLock.Lock()
+	defer m.readLock.Unlock()
+
+	revoked := make(map[TopicPartition]bool, len(partitions))
+	for _, tp := range partitions {
+		revoked[tp] = true
+	}
+
+	pending := 0
+	for offset := range m.uncomitted {
+		if revoked[TopicPartition{Topic: offset.Topic, Partition: offset.Partition}] {
+			pending++
+		}
+	}
+
+	return pending
+}
+
 func (m *offsetManager) Flush() error {
 	m.logger.Info("flushing messages")
 	defer m.logger.Info("flushed messages")
@@ -294,6 +294,53 @@ func TestOffsetManager_FlushErrors(t *testing.T) {
 	assert.ErrorIs(t, manager.Start(ctx), readerErr)
 }
 
+func TestOffsetManager_OnRevoked(t *testing.T) {
+	var (
+		pool        = coffin.New()
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	)
+	defer cancel()
+
+	var (
+		readerFetches = 0
+		reader        = &mocks.Reader{}
+	)
+	reader.On("FetchMessage", mock.AnythingOfType("*context.timerCtx")).Return(
+		func(ctx context.Context) kafka.Message {
+			defer func() {
+				time.Sleep(time.Millisecond)
+				readerFetches += 1
+			}()
+
+			return OnFetch(ctx, readerFetches)
+		},
+		func(ctx context.Context) error {
+			return nil
+		},
+	)
+	reader.On("CommitMessages", mock.AnythingOfType("*context.timerCtx"), kafka.Message{Partition: 1, Offset: 1}).Return(nil).Times(1)
+	reader.On("Close").Times(1).Return(nil)
+	defer reader.AssertExpectations(t)
+
+	manager := consumer.NewOffsetManager(logMocks.NewLoggerMockedAll(), reader, 2, time.Second)
+	pool.GoWithContext(ctx, manager.Start)
+
+	assert.Len(t, manager.Batch(ctx), 2)
+
+	// partition 3 has no in-flight messages and can be revoked right away.
+	assert.NoError(t, manager.OnRevoked(ctx, []consumer.TopicPartition{{Partition: 3}}))
+
+	// partition 1 can not be revoked as long as its message has not been committed.
+	revokeCtx, revokeCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer revokeCancel()
+	assert.ErrorIs(t, manager.OnRevoked(revokeCtx, []consumer.TopicPartition{{Partition: 1}}), context.DeadlineExceeded)
+
+	assert.Nil(t, manager.Commit(ctx, kafka.Message{Partition: 1, Offset: 1}))
+	assert.NoError(t, manager.OnRevoked(ctx, []consumer.TopicPartition{{Partition: 1}}))
+
+	_ = pool.Wait()
+}
+
 func OnFetch(ctx context.Context, call int) kafka.Message {
 	return kafka.Message{
 		Partition: call + 1,
@@ -9,6 +9,7 @@ import (
 	"github.com/justtrackio/gosoline/pkg/kafka/consumer"
 	"github.com/justtrackio/gosoline/pkg/kafka/consumer/mocks"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
 	"github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -48,6 +49,8 @@ func TestConsumer_Manager_Batch_Commit(t *testing.T) {
 		},
 		logMocks.NewLoggerMockedAll(),
 		manager,
+		&mocks.GroupMember{},
+		metricMocks.NewWriterMockedAll(),
 	)
 	assert.Nil(t, err)
 
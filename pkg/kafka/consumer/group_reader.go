@@ -0,0 +1,397 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/justtrackio/gosoline/pkg/kafka/logging"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/segmentio/kafka-go"
+)
+
+var (
+	_ Reader      = &GroupReader{}
+	_ GroupMember = &GroupReader{}
+
+	ErrNoGeneration = errors.New("kafka: consumer is not part of an active consumer group generation")
+)
+
+// GroupReader consumes one or more topics as member of a consumer group. Compared to a plain kafka.Reader it
+// exposes the partition assignments of each generation to RebalanceListeners and applies the configured seek
+// settings the first time a partition gets assigned.
+type GroupReader struct {
+	logger    log.Logger
+	dialer    *kafka.Dialer
+	settings  *Settings
+	topics    []string
+	group     *kafka.ConsumerGroup
+	listeners []RebalanceListener
+
+	messages chan kafka.Message
+	runError chan error
+	cancel   context.CancelFunc
+	done     chan struct{}
+	once     sync.Once
+
+	lck        sync.RWMutex
+	generation *kafka.Generation
+	readers    map[TopicPartition]*kafka.Reader
+	seeked     map[TopicPartition]bool
+	rebalances int64
+}
+
+func NewGroupReader(logger log.Logger, dialer *kafka.Dialer, settings *Settings, topics []string) (*GroupReader, error) {
+	group, err := kafka.NewConsumerGroup(kafka.ConsumerGroupConfig{
+		ID:                    settings.FQGroupID,
+		Brokers:               settings.Connection().Bootstrap,
+		Dialer:                dialer,
+		Topics:                topics,
+		WatchPartitionChanges: true,
+		RetentionTime:         DefaultConsumerGroupRetentionTime,
+		StartOffset:           kafka.FirstOffset,
+		Logger:                logging.NewKafkaLogger(logger).DebugLogger(),
+		ErrorLogger:           logging.NewKafkaLogger(logger).ErrorLogger(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can not create consumer group: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	reader := &GroupReader{
+		logger:   logger,
+		dialer:   dialer,
+		settings: settings,
+		topics:   topics,
+		group:    group,
+		messages: make(chan kafka.Message),
+		runError: make(chan error, 1),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		readers:  map[TopicPartition]*kafka.Reader{},
+		seeked:   map[TopicPartition]bool{},
+	}
+
+	go reader.run(ctx)
+
+	return reader, nil
+}
+
+// AddRebalanceListener registers a listener which gets notified about all following partition assignments and
+// revocations.
+func (r *GroupReader) AddRebalanceListener(listener RebalanceListener) {
+	r.lck.Lock()
+	defer r.lck.Unlock()
+
+	r.listeners = append(r.listeners, listener)
+}
+
+func (r *GroupReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case msg := <-r.messages:
+		return msg, nil
+	case err := <-r.runError:
+		return kafka.Message{}, err
+	case <-r.done:
+		return kafka.Message{}, io.EOF
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (r *GroupReader) ReadMessage(ctx context.Context) (kafka.Message, error) {
+	msg, err := r.FetchMessage(ctx)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, r.CommitMessages(ctx, msg)
+}
+
+// CommitMessages commits the offsets of the given messages within the current generation. Messages of partitions
+// which are no longer assigned to this consumer are skipped, as their offsets are owned by another member by now.
+func (r *GroupReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	r.lck.RLock()
+	defer r.lck.RUnlock()
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	if r.generation == nil {
+		return ErrNoGeneration
+	}
+
+	offsets := map[string]map[int]int64{}
+
+	for _, msg := range msgs {
+		tp := TopicPartition{Topic: msg.Topic, Partition: msg.Partition}
+
+		if _, ok := r.readers[tp]; !ok {
+			r.logger.WithFields(log.Fields{
+				"kafka_topic":     msg.Topic,
+				"kafka_partition": msg.Partition,
+				"kafka_offset":    msg.Offset,
+			}).Warn("skipping commit of message from revoked partition")
+
+			continue
+		}
+
+		if _, ok := offsets[msg.Topic]; !ok {
+			offsets[msg.Topic] = map[int]int64{}
+		}
+
+		// the committed offset is the offset of the next message to consume
+		if current, ok := offsets[msg.Topic][msg.Partition]; !ok || current < msg.Offset+1 {
+			offsets[msg.Topic][msg.Partition] = msg.Offset + 1
+		}
+	}
+
+	return r.generation.CommitOffsets(offsets)
+}
+
+func (r *GroupReader) Generation() (GroupGeneration, error) {
+	r.lck.RLock()
+	defer r.lck.RUnlock()
+
+	if r.generation == nil {
+		return GroupGeneration{}, ErrNoGeneration
+	}
+
+	return GroupGeneration{
+		GroupID:      r.generation.GroupID,
+		GenerationID: r.generation.ID,
+		MemberID:     r.generation.MemberID,
+	}, nil
+}
+
+// Lag returns the current lag of every assigned partition.
+func (r *GroupReader) Lag() map[TopicPartition]int64 {
+	r.lck.RLock()
+	defer r.lck.RUnlock()
+
+	lag := make(map[TopicPartition]int64, len(r.readers))
+	for tp, reader := range r.readers {
+		lag[tp] = reader.Lag()
+	}
+
+	return lag
+}
+
+func (r *GroupReader) Stats() kafka.ReaderStats {
+	r.lck.RLock()
+	defer r.lck.RUnlock()
+
+	stats := kafka.ReaderStats{
+		ClientID:   r.dialer.ClientID,
+		Rebalances: r.rebalances,
+	}
+
+	for _, reader := range r.readers {
+		partitionStats := reader.Stats()
+
+		stats.Dials += partitionStats.Dials
+		stats.Fetches += partitionStats.Fetches
+		stats.Messages += partitionStats.Messages
+		stats.Bytes += partitionStats.Bytes
+		stats.Timeouts += partitionStats.Timeouts
+		stats.Errors += partitionStats.Errors
+		stats.Lag += partitionStats.Lag
+	}
+
+	return stats
+}
+
+func (r *GroupReader) Close() error {
+	var err error
+
+	r.once.Do(func() {
+		r.cancel()
+		err = r.group.Close()
+		<-r.done
+	})
+
+	return err
+}
+
+func (r *GroupReader) run(ctx context.Context) {
+	defer close(r.done)
+
+	for {
+		generation, err := r.group.Next(ctx)
+
+		if errors.Is(err, kafka.ErrGroupClosed) || ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			r.logger.Warn("can not join next consumer group generation: %s", err)
+
+			continue
+		}
+
+		if err = r.startGeneration(ctx, generation); err != nil {
+			r.runError <- fmt.Errorf("can not start consumer group generation %d: %w", generation.ID, err)
+
+			return
+		}
+	}
+}
+
+func (r *GroupReader) startGeneration(ctx context.Context, generation *kafka.Generation) error {
+	partitions := make([]TopicPartition, 0)
+	readers := make(map[TopicPartition]*kafka.Reader)
+
+	for topic, assignments := range generation.Assignments {
+		for _, assignment := range assignments {
+			tp := TopicPartition{Topic: topic, Partition: assignment.ID}
+
+			reader, err := r.newPartitionReader(ctx, tp, assignment.Offset)
+			if err != nil {
+				closePartitionReaders(readers)
+
+				return fmt.Errorf("can not create reader for partition %s: %w", tp, err)
+			}
+
+			partitions = append(partitions, tp)
+			readers[tp] = reader
+		}
+	}
+
+	r.lck.Lock()
+	r.generation = generation
+	r.readers = readers
+	r.rebalances++
+	listeners := r.listeners
+	r.lck.Unlock()
+
+	r.logger.WithFields(log.Fields{
+		"kafka_generation": generation.ID,
+		"kafka_partitions": fmt.Sprint(partitions),
+	}).Info("partitions assigned")
+
+	for _, listener := range listeners {
+		if err := listener.OnAssigned(ctx, partitions); err != nil {
+			r.logger.Error("rebalance listener failed on partition assignment: %w", err)
+		}
+	}
+
+	for tp, reader := range readers {
+		tp, reader := tp, reader
+
+		generation.Start(func(genCtx context.Context) {
+			r.pump(genCtx, tp, reader)
+		})
+	}
+
+	generation.Start(func(genCtx context.Context) {
+		select {
+		case <-genCtx.Done():
+		case <-ctx.Done():
+		}
+
+		r.revokeGeneration(generation, partitions, listeners)
+	})
+
+	return nil
+}
+
+func (r *GroupReader) revokeGeneration(generation *kafka.Generation, partitions []TopicPartition, listeners []RebalanceListener) {
+	r.logger.WithFields(log.Fields{
+		"kafka_generation": generation.ID,
+		"kafka_partitions": fmt.Sprint(partitions),
+	}).Info("partitions revoked")
+
+	// the listeners still get the chance to commit in-flight messages with the ending generation
+	ctx, cancel := context.WithTimeout(context.Background(), r.settings.FlushTimeout)
+	defer cancel()
+
+	for _, listener := range listeners {
+		if err := listener.OnRevoked(ctx, partitions); err != nil {
+			r.logger.Error("rebalance listener failed on partition revocation: %w", err)
+		}
+	}
+
+	r.lck.Lock()
+	defer r.lck.Unlock()
+
+	closePartitionReaders(r.readers)
+
+	r.generation = nil
+	r.readers = map[TopicPartition]*kafka.Reader{}
+}
+
+func (r *GroupReader) pump(ctx context.Context, tp TopicPartition, reader *kafka.Reader) {
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Warn("can not fetch message from partition %s: %s", tp, err)
+			}
+
+			return
+		}
+
+		select {
+		case r.messages <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *GroupReader) newPartitionReader(ctx context.Context, tp TopicPartition, committed int64) (*kafka.Reader, error) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        r.settings.Connection().Bootstrap,
+		Dialer:         r.dialer,
+		Topic:          tp.Topic,
+		Partition:      tp.Partition,
+		MinBytes:       1,
+		MaxBytes:       MaxBatchBytes,
+		MaxWait:        DefaultMaxWait,
+		MaxAttempts:    DefaultMaxRetryAttempts,
+		IsolationLevel: kafka.ReadCommitted,
+		Logger:         logging.NewKafkaLogger(r.logger).DebugLogger(),
+		ErrorLogger:    logging.NewKafkaLogger(r.logger).ErrorLogger(),
+	})
+
+	var err error
+
+	r.lck.Lock()
+	seeked := r.seeked[tp]
+	r.seeked[tp] = true
+	r.lck.Unlock()
+
+	// seeking only happens once per partition, later rebalances continue from the committed offsets
+	switch mode := r.settings.Seek.Mode; {
+	case seeked || mode == SeekModeCommitted || mode == "":
+		err = reader.SetOffset(committed)
+	case mode == SeekModeEarliest:
+		err = reader.SetOffset(kafka.FirstOffset)
+	case mode == SeekModeLatest:
+		err = reader.SetOffset(kafka.LastOffset)
+	case mode == SeekModeOffset:
+		err = reader.SetOffset(r.settings.Seek.Offset)
+	case mode == SeekModeTimestamp:
+		err = reader.SetOffsetAt(ctx, r.settings.Seek.Timestamp)
+	default:
+		err = fmt.Errorf("unknown seek mode %q", mode)
+	}
+
+	if err != nil {
+		_ = reader.Close()
+
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+func closePartitionReaders(readers map[TopicPartition]*kafka.Reader) {
+	for _, reader := range readers {
+		_ = reader.Close()
+	}
+}
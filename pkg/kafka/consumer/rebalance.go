@@ -0,0 +1,48 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+)
+
+type TopicPartition struct {
+	Topic     string
+	Partition int
+}
+
+func (p TopicPartition) String() string {
+	return fmt.Sprintf("%s/%d", p.Topic, p.Partition)
+}
+
+// RebalanceListener is notified whenever the consumer group assigns partitions to this consumer or revokes them
+// again. OnRevoked is called before the partitions are handed over to another consumer, so offsets of in-flight
+// messages can still be committed.
+//
+//go:generate mockery --name RebalanceListener
+type RebalanceListener interface {
+	OnAssigned(ctx context.Context, partitions []TopicPartition) error
+	OnRevoked(ctx context.Context, partitions []TopicPartition) error
+}
+
+type RebalanceCallbacks struct {
+	Assigned func(ctx context.Context, partitions []TopicPartition) error
+	Revoked  func(ctx context.Context, partitions []TopicPartition) error
+}
+
+var _ RebalanceListener = RebalanceCallbacks{}
+
+func (c RebalanceCallbacks) OnAssigned(ctx context.Context, partitions []TopicPartition) error {
+	if c.Assigned == nil {
+		return nil
+	}
+
+	return c.Assigned(ctx, partitions)
+}
+
+func (c RebalanceCallbacks) OnRevoked(ctx context.Context, partitions []TopicPartition) error {
+	if c.Revoked == nil {
+		return nil
+	}
+
+	return c.Revoked(ctx, partitions)
+}
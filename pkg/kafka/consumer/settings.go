@@ -9,18 +9,50 @@ import (
 	"github.com/justtrackio/gosoline/pkg/kafka/connection"
 )
 
+const (
+	// SeekModeCommitted resumes from the committed offsets of the consumer group.
+	SeekModeCommitted = "committed"
+	// SeekModeEarliest starts from the first offset still retained by the broker.
+	SeekModeEarliest = "earliest"
+	// SeekModeLatest starts from the end of each partition, skipping the backlog.
+	SeekModeLatest = "latest"
+	// SeekModeTimestamp starts from the first offset with a timestamp greater or equal to the configured one.
+	SeekModeTimestamp = "timestamp"
+	// SeekModeOffset starts from the configured offset in every partition.
+	SeekModeOffset = "offset"
+)
+
 type Settings struct {
 	ConnectionName string `cfg:"connection" validate:"required"`
 	connection     *connection.Settings
 
-	Topic   string `cfg:"topic" validate:"required"`
-	GroupID string `cfg:"group_id"`
+	Topic string `cfg:"topic"`
+	// Topics are additional topic ids to consume in the same consumer group.
+	Topics []string `cfg:"topics"`
+	// TopicPatterns are regular expressions matched against the fully-qualified topic names of the cluster on startup.
+	TopicPatterns []string `cfg:"topic_patterns"`
+	GroupID       string   `cfg:"group_id"`
 	// FQTopic is the fully-qualified topic name (with prefix).
 	FQTopic string
+	// FQTopics are the fully-qualified names of Topic and Topics (with prefix).
+	FQTopics []string
 	// FQGroupID is the fully-qualified group id (with prefix).
 	FQGroupID    string
 	BatchSize    int           `cfg:"batch_size" default:"1"`
 	BatchTimeout time.Duration `cfg:"idle_timeout" default:"1s"`
+	// FlushTimeout is how long to wait for in-flight messages to be committed when partitions get revoked.
+	FlushTimeout time.Duration `cfg:"flush_timeout" default:"10s"`
+	// LagInterval is the interval in which the consumer lag is published as metric.
+	LagInterval time.Duration `cfg:"lag_interval" default:"1m"`
+	Seek        SeekSettings  `cfg:"seek"`
+	// SchemaRegistry is the name of the schema registry connection to decode messages in its wire format with.
+	SchemaRegistry string `cfg:"schema_registry"`
+}
+
+type SeekSettings struct {
+	Mode      string    `cfg:"mode" default:"committed" validate:"oneof=committed earliest latest timestamp offset"`
+	Timestamp time.Time `cfg:"timestamp"`
+	Offset    int64     `cfg:"offset"`
 }
 
 func (s *Settings) Connection() *connection.Settings {
@@ -39,7 +71,15 @@ func ParseSettings(config cfg.Config, key string) *Settings {
 	appID := cfg.GetAppIdFromConfig(config)
 	settings.connection = connection.ParseSettings(config, settings.ConnectionName)
 	settings.FQGroupID = kafka.FQGroupId(config, appID, settings.GroupID)
-	settings.FQTopic = kafka.FQTopicName(config, appID, settings.Topic)
+
+	if settings.Topic != "" {
+		settings.FQTopic = kafka.FQTopicName(config, appID, settings.Topic)
+		settings.FQTopics = append(settings.FQTopics, settings.FQTopic)
+	}
+
+	for _, topic := range settings.Topics {
+		settings.FQTopics = append(settings.FQTopics, kafka.FQTopicName(config, appID, topic))
+	}
 
 	return settings
 }
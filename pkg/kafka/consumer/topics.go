@@ -0,0 +1,88 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/justtrackio/gosoline/pkg/funk"
+	"github.com/segmentio/kafka-go"
+)
+
+// ResolveTopics returns the configured fully-qualified topics together with all topics of the cluster matching
+// one of the configured topic patterns.
+func ResolveTopics(ctx context.Context, dialer *kafka.Dialer, settings *Settings) ([]string, error) {
+	topics := funk.Uniq(settings.FQTopics)
+
+	if len(settings.TopicPatterns) == 0 {
+		return topics, nil
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(settings.TopicPatterns))
+	for _, pattern := range settings.TopicPatterns {
+		exp, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid topic pattern %q: %w", pattern, err)
+		}
+
+		patterns = append(patterns, exp)
+	}
+
+	available, err := listTopics(ctx, dialer, settings.Connection().Bootstrap)
+	if err != nil {
+		return nil, fmt.Errorf("can not list topics: %w", err)
+	}
+
+	return MatchTopics(topics, available, patterns), nil
+}
+
+// MatchTopics appends every topic of available which is matched by one of the patterns to topics.
+func MatchTopics(topics []string, available []string, patterns []*regexp.Regexp) []string {
+	for _, topic := range available {
+		for _, pattern := range patterns {
+			if pattern.MatchString(topic) {
+				topics = append(topics, topic)
+				break
+			}
+		}
+	}
+
+	topics = funk.Uniq(topics)
+	sort.Strings(topics)
+
+	return topics
+}
+
+func listTopics(ctx context.Context, dialer *kafka.Dialer, bootstrap []string) ([]string, error) {
+	var err error
+	var conn *kafka.Conn
+
+	if len(bootstrap) == 0 {
+		return nil, fmt.Errorf("no bootstrap brokers configured")
+	}
+
+	for _, broker := range bootstrap {
+		if conn, err = dialer.DialContext(ctx, "tcp", broker); err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions()
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make([]string, 0, len(partitions))
+	for _, partition := range partitions {
+		topics = append(topics, partition.Topic)
+	}
+
+	return funk.Uniq(topics), nil
+}
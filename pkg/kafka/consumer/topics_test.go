@@ -0,0 +1,25 @@
+package consumer_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/kafka/consumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTopics(t *testing.T) {
+	topics := consumer.MatchTopics(
+		[]string{"test-orders", "test-users"},
+		[]string{"test-events-click", "test-events-view", "prod-events-click", "test-users"},
+		[]*regexp.Regexp{regexp.MustCompile("^test-events-.*$"), regexp.MustCompile("^test-users$")},
+	)
+
+	assert.Equal(t, []string{"test-events-click", "test-events-view", "test-orders", "test-users"}, topics)
+}
+
+func TestMatchTopicsWithoutPatterns(t *testing.T) {
+	topics := consumer.MatchTopics([]string{"test-orders"}, []string{"test-users"}, nil)
+
+	assert.Equal(t, []string{"test-orders"}, topics)
+}
@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+var _ kgo.Logger = &KgoLogger{}
+
+// KgoLogger adapts the kafka logger to the logger interface of the franz-go client.
+type KgoLogger struct {
+	logger *KafkaLogger
+}
+
+func (l *KafkaLogger) KgoLogger() *KgoLogger {
+	return &KgoLogger{logger: l}
+}
+
+func (l *KgoLogger) Level() kgo.LogLevel {
+	return kgo.LogLevelInfo
+}
+
+func (l *KgoLogger) Log(level kgo.LogLevel, msg string, keyvals ...any) {
+	fields := log.Fields{}
+
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if key, ok := keyvals[i].(string); ok {
+			fields[key] = keyvals[i+1]
+		}
+	}
+
+	logger := l.logger.WithFields(fields)
+
+	switch level {
+	case kgo.LogLevelError:
+		logger.Error(msg)
+	case kgo.LogLevelWarn:
+		logger.Warn(msg)
+	case kgo.LogLevelInfo:
+		logger.Info(msg)
+	default:
+		logger.Debug(msg)
+	}
+}
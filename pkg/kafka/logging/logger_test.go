@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/justtrackio/gosoline/pkg/kafka/logging"
+	"github.com/justtrackio/gosoline/pkg/log"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/twmb/franz-go/pkg/kgo"
 )
 
 func TestKafkaLogger(t *testing.T) {
@@ -24,3 +26,21 @@ func TestKafkaLogger(t *testing.T) {
 	kLogger.DebugLogger().Printf("debug message")
 	kLogger.ErrorLogger().Printf("error message")
 }
+
+func TestKgoLogger(t *testing.T) {
+	var (
+		logger            = new(logMocks.Logger)
+		loggerWithChannel = new(logMocks.Logger)
+		loggerWithFields  = new(logMocks.Logger)
+	)
+	defer logger.AssertExpectations(t)
+	defer loggerWithChannel.AssertExpectations(t)
+	defer loggerWithFields.AssertExpectations(t)
+
+	logger.On("WithChannel", "stream.kafka").Return(loggerWithChannel).Once()
+	loggerWithChannel.On("WithFields", log.Fields{"broker": "1"}).Return(loggerWithFields).Once()
+	loggerWithFields.On("Warn", "connection lost").Once()
+
+	kLogger := logging.NewKafkaLogger(logger).KgoLogger()
+	kLogger.Log(kgo.LogLevelWarn, "connection lost", "broker", "1")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/coffin"
 	"github.com/justtrackio/gosoline/pkg/kafka/connection"
+	"github.com/justtrackio/gosoline/pkg/kafka/consumer"
 	"github.com/justtrackio/gosoline/pkg/kafka/logging"
 	"github.com/justtrackio/gosoline/pkg/log"
 	"github.com/segmentio/kafka-go"
@@ -33,7 +34,14 @@ func NewProducer(ctx context.Context, conf cfg.Config, logger log.Logger, name s
 	}
 
 	// Writer.
-	writer, err := NewWriter(logger, dialer, settings.Connection().Bootstrap, getOptions(settings)...)
+	var writer Writer
+
+	if settings.Idempotent || settings.Transactional() {
+		writer, err = NewIdempotentWriter(logger, settings)
+	} else {
+		writer, err = NewWriter(logger, dialer, settings.Connection().Bootstrap, getOptions(settings)...)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("kafka: failed to get writer: %w", err)
 	}
@@ -76,6 +84,55 @@ func (p *Producer) Write(ctx context.Context, ms ...kafka.Message) error {
 	return p.write(ctx, ms...)
 }
 
+// BeginTransaction starts a new transaction. All messages written until the transaction is committed or aborted
+// become visible to read committed consumers atomically.
+func (p *Producer) BeginTransaction() error {
+	writer, err := p.transactionalWriter()
+	if err != nil {
+		return err
+	}
+
+	return writer.BeginTransaction()
+}
+
+func (p *Producer) CommitTransaction(ctx context.Context) error {
+	writer, err := p.transactionalWriter()
+	if err != nil {
+		return err
+	}
+
+	return writer.CommitTransaction(ctx)
+}
+
+func (p *Producer) AbortTransaction(ctx context.Context) error {
+	writer, err := p.transactionalWriter()
+	if err != nil {
+		return err
+	}
+
+	return writer.AbortTransaction(ctx)
+}
+
+// SendOffsetsToTransaction commits the offsets of the consumed messages together with the current transaction,
+// which makes a consume-transform-produce cycle exactly-once.
+func (p *Producer) SendOffsetsToTransaction(ctx context.Context, generation consumer.GroupGeneration, msgs ...kafka.Message) error {
+	writer, err := p.transactionalWriter()
+	if err != nil {
+		return err
+	}
+
+	return writer.SendOffsetsToTransaction(ctx, generation, msgs...)
+}
+
+func (p *Producer) transactionalWriter() (TransactionalWriter, error) {
+	writer, ok := p.Writer.(TransactionalWriter)
+	if !ok || !p.Settings.Transactional() {
+		return nil, ErrNotTransactional
+	}
+
+	return writer, nil
+}
+
 func (p *Producer) write(ctx context.Context, ms ...kafka.Message) error {
 	ctx, cancel := context.WithTimeout(ctx, DefaultWriterWriteTimeout)
 	defer cancel()
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/justtrackio/gosoline/pkg/kafka/consumer"
 	"github.com/justtrackio/gosoline/pkg/kafka/producer"
 	producerMocks "github.com/justtrackio/gosoline/pkg/kafka/producer/mocks"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
@@ -121,3 +122,48 @@ func Test_Write_WriteOne(t *testing.T) {
 	cancel()
 	time.Sleep(time.Second)
 }
+
+func Test_Transaction(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		logger     = logMocks.NewLoggerMockedAll()
+		writer     = &producerMocks.TransactionalWriter{}
+		generation = consumer.GroupGeneration{GroupID: "group", GenerationID: 3, MemberID: "member"}
+		consumed   = []kafka.Message{{Topic: "in", Partition: 1, Offset: 41}}
+		conf       = &producer.Settings{
+			FQTopic:         "fq-topic",
+			TransactionalID: "txn",
+		}
+	)
+	defer writer.AssertExpectations(t)
+
+	writer.On("BeginTransaction").Return(nil).Once()
+	writer.On("WriteMessages", mock.Anything, mock.Anything).Return(nil).Once()
+	writer.On("SendOffsetsToTransaction", ctx, generation, consumed).Return(nil).Once()
+	writer.On("CommitTransaction", ctx).Return(nil).Once()
+
+	prod, err := producer.NewProducerWithInterfaces(conf, logger, writer)
+	assert.NoError(t, err)
+
+	assert.NoError(t, prod.BeginTransaction())
+	assert.NoError(t, prod.WriteOne(ctx, kafka.Message{Value: []byte("out")}))
+	assert.NoError(t, prod.SendOffsetsToTransaction(ctx, generation, consumed...))
+	assert.NoError(t, prod.CommitTransaction(ctx))
+}
+
+func Test_Transaction_NotTransactional(t *testing.T) {
+	var (
+		logger = logMocks.NewLoggerMockedAll()
+		writer = &producerMocks.Writer{}
+		conf   = &producer.Settings{
+			FQTopic: "fq-topic",
+		}
+	)
+	defer writer.AssertExpectations(t)
+
+	prod, err := producer.NewProducerWithInterfaces(conf, logger, writer)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, prod.BeginTransaction(), producer.ErrNotTransactional)
+	assert.ErrorIs(t, prod.AbortTransaction(context.Background()), producer.ErrNotTransactional)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/justtrackio/gosoline/pkg/kafka"
 	"github.com/justtrackio/gosoline/pkg/kafka/connection"
+	"github.com/justtrackio/gosoline/pkg/kafka/schemaregistry"
 )
 
 type Settings struct {
@@ -16,14 +17,28 @@ type Settings struct {
 	FQTopic      string
 	BatchSize    int           `cfg:"batch_size"`
 	BatchTimeout time.Duration `cfg:"idle_timeout"`
-	AsyncWrites  bool          `cfg:"async_writes"`
-	connection   *connection.Settings
+	// AsyncWrites can't be combined with Idempotent or TransactionalID
+	AsyncWrites bool `cfg:"async_writes"`
+	// Idempotent makes the brokers deduplicate retried writes, so every message is written exactly once per partition.
+	Idempotent bool `cfg:"idempotent"`
+	// TransactionalID enables transactional writes (and implies Idempotent). It has to be unique per producer
+	// instance and stable across restarts, so the brokers can fence off zombie instances.
+	TransactionalID    string        `cfg:"transactional_id"`
+	TransactionTimeout time.Duration `cfg:"transaction_timeout" default:"1m"`
+	// Schema enables the wire format of a schema registry for the written messages.
+	Schema     schemaregistry.SchemaSettings `cfg:"schema"`
+	connection *connection.Settings
 }
 
 func (s *Settings) Connection() *connection.Settings {
 	return s.connection
 }
 
+// Transactional reports whether the producer is configured to write within transactions.
+func (s *Settings) Transactional() bool {
+	return s.TransactionalID != ""
+}
+
 func (s *Settings) WithConnection(conn *connection.Settings) *Settings {
 	s.connection = conn
 	return s
@@ -108,3 +108,31 @@ func TestWithAsyncWrites(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, writer.Async)
 }
+
+func TestNewIdempotentWriter(t *testing.T) {
+	conf := (&producer.Settings{
+		FQTopic:         "test-my-topic",
+		TransactionalID: "my-producer",
+	}).WithConnection(&connection.Settings{
+		Bootstrap:  []string{"kafka.domain.tld:9094"},
+		DisableTLS: true,
+	})
+
+	writer, err := producer.NewIdempotentWriter(logMocks.NewLoggerMockedAll(), conf)
+	assert.NoError(t, err)
+	assert.Equal(t, kafka.WriterStats{}, writer.Stats())
+}
+
+func TestNewIdempotentWriterAsyncWrites(t *testing.T) {
+	conf := (&producer.Settings{
+		FQTopic:     "test-my-topic",
+		AsyncWrites: true,
+		Idempotent:  true,
+	}).WithConnection(&connection.Settings{
+		Bootstrap:  []string{"kafka.domain.tld:9094"},
+		DisableTLS: true,
+	})
+
+	_, err := producer.NewIdempotentWriter(logMocks.NewLoggerMockedAll(), conf)
+	assert.EqualError(t, err, "async writes can not be used with the idempotent or transactional producer")
+}
@@ -0,0 +1,324 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/justtrackio/gosoline/pkg/kafka/connection"
+	"github.com/justtrackio/gosoline/pkg/kafka/consumer"
+	"github.com/justtrackio/gosoline/pkg/kafka/logging"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/segmentio/kafka-go"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+var ErrNotTransactional = errors.New("kafka: producer is not configured for transactions")
+
+//go:generate mockery --name TransactionalWriter --unroll-variadic=False --with-expecter=False
+type TransactionalWriter interface {
+	Writer
+	BeginTransaction() error
+	CommitTransaction(ctx context.Context) error
+	AbortTransaction(ctx context.Context) error
+	SendOffsetsToTransaction(ctx context.Context, generation consumer.GroupGeneration, msgs ...kafka.Message) error
+}
+
+// idempotentWriter writes with an idempotent (and optionally transactional) producer. kafka-go can not assign
+// producer ids and sequence numbers to record batches, so these writes are done with the franz-go client instead.
+type idempotentWriter struct {
+	client          *kgo.Client
+	transactionalID string
+
+	lck          sync.Mutex
+	produced     bool
+	offsetsAdded bool
+
+	writes   atomic.Int64
+	messages atomic.Int64
+	errors   atomic.Int64
+}
+
+// NewIdempotentWriter returns a writer for the idempotent producer mode. If settings.TransactionalID is set, the
+// writer additionally supports transactions. Async writes aren't supported, as a write has to report whether the
+// brokers accepted it to be retried without duplicates and to be part of a transaction.
+func NewIdempotentWriter(logger log.Logger, settings *Settings) (TransactionalWriter, error) {
+	if settings.AsyncWrites {
+		return nil, fmt.Errorf("async writes can not be used with the idempotent or transactional producer")
+	}
+
+	conn := settings.Connection()
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(conn.Bootstrap...),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.RecordRetries(DefaultMaxRetryAttempts),
+		kgo.ProduceRequestTimeout(DefaultWriterWriteTimeout),
+		kgo.ProducerBatchCompression(kgo.SnappyCompression()),
+		kgo.RecordPartitioner(kgo.StickyKeyPartitioner(kafkaGoHasher)),
+		kgo.WithLogger(logging.NewKafkaLogger(logger).KgoLogger()),
+	}
+
+	if settings.BatchTimeout > 0 {
+		opts = append(opts, kgo.ProducerLinger(settings.BatchTimeout))
+	}
+
+	if tlsConfig := connection.NewTLSConfig(conn); tlsConfig != nil {
+		dialer := &net.Dialer{Timeout: connection.DefaultDialerTimeout, KeepAlive: connection.DefaultKeepAlive}
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig), kgo.Dialer(dialer.DialContext))
+	}
+
+	if conn.Username != "" {
+		auth := scram.Auth{User: conn.Username, Pass: conn.Password}
+		opts = append(opts, kgo.SASL(auth.AsSha512Mechanism()))
+	}
+
+	if settings.Transactional() {
+		opts = append(
+			opts,
+			kgo.TransactionalID(settings.TransactionalID),
+			kgo.TransactionTimeout(settings.TransactionTimeout),
+		)
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("can not create idempotent kafka client: %w", err)
+	}
+
+	return &idempotentWriter{
+		client:          client,
+		transactionalID: settings.TransactionalID,
+	}, nil
+}
+
+// kafkaGoHasher assigns keys to the same partitions as the kafka.Hash balancer of the regular writer does.
+func kafkaGoHasher(key []byte, n int) int {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(key)
+
+	partition := int32(hasher.Sum32()) % int32(n)
+	if partition < 0 {
+		partition = -partition
+	}
+
+	return int(partition)
+}
+
+func (w *idempotentWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	records := make([]*kgo.Record, 0, len(msgs))
+
+	for _, msg := range msgs {
+		record := &kgo.Record{
+			Topic:     msg.Topic,
+			Key:       msg.Key,
+			Value:     msg.Value,
+			Timestamp: msg.Time,
+		}
+
+		for _, header := range msg.Headers {
+			record.Headers = append(record.Headers, kgo.RecordHeader{Key: header.Key, Value: header.Value})
+		}
+
+		records = append(records, record)
+	}
+
+	w.writes.Add(1)
+	w.messages.Add(int64(len(records)))
+
+	if err := w.client.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		w.errors.Add(1)
+
+		return err
+	}
+
+	w.lck.Lock()
+	w.produced = true
+	w.lck.Unlock()
+
+	return nil
+}
+
+func (w *idempotentWriter) Stats() kafka.WriterStats {
+	return kafka.WriterStats{
+		Writes:   w.writes.Load(),
+		Messages: w.messages.Load(),
+		Errors:   w.errors.Load(),
+	}
+}
+
+func (w *idempotentWriter) Close() error {
+	defer w.client.Close()
+
+	return w.client.Flush(context.Background())
+}
+
+func (w *idempotentWriter) BeginTransaction() error {
+	if w.transactionalID == "" {
+		return ErrNotTransactional
+	}
+
+	w.lck.Lock()
+	defer w.lck.Unlock()
+
+	w.produced = false
+	w.offsetsAdded = false
+
+	return w.client.BeginTransaction()
+}
+
+func (w *idempotentWriter) CommitTransaction(ctx context.Context) error {
+	if err := w.client.Flush(ctx); err != nil {
+		return fmt.Errorf("can not flush records of transaction: %w", err)
+	}
+
+	return w.endTransaction(ctx, kgo.TryCommit)
+}
+
+func (w *idempotentWriter) AbortTransaction(ctx context.Context) error {
+	if err := w.client.AbortBufferedRecords(ctx); err != nil {
+		return fmt.Errorf("can not abort buffered records of transaction: %w", err)
+	}
+
+	return w.endTransaction(ctx, kgo.TryAbort)
+}
+
+// SendOffsetsToTransaction adds the offsets of the consumed messages to the current transaction. The offsets get
+// committed for the consumer group of the generation if and only if the transaction gets committed.
+func (w *idempotentWriter) SendOffsetsToTransaction(ctx context.Context, generation consumer.GroupGeneration, msgs ...kafka.Message) error {
+	if w.transactionalID == "" {
+		return ErrNotTransactional
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	producerID, epoch, err := w.client.ProducerID(ctx)
+	if err != nil {
+		return fmt.Errorf("can not get producer id: %w", err)
+	}
+
+	addReq := kmsg.NewPtrAddOffsetsToTxnRequest()
+	addReq.TransactionalID = w.transactionalID
+	addReq.ProducerID = producerID
+	addReq.ProducerEpoch = epoch
+	addReq.Group = generation.GroupID
+
+	addRes, err := addReq.RequestWith(ctx, w.client)
+	if err != nil {
+		return fmt.Errorf("can not add offsets to transaction: %w", err)
+	}
+
+	if err = kerr.ErrorForCode(addRes.ErrorCode); err != nil {
+		return fmt.Errorf("can not add offsets to transaction: %w", err)
+	}
+
+	commitReq := kmsg.NewPtrTxnOffsetCommitRequest()
+	commitReq.TransactionalID = w.transactionalID
+	commitReq.Group = generation.GroupID
+	commitReq.ProducerID = producerID
+	commitReq.ProducerEpoch = epoch
+	commitReq.Generation = generation.GenerationID
+	commitReq.MemberID = generation.MemberID
+	commitReq.Topics = buildTxnOffsetCommitTopics(msgs)
+
+	commitRes, err := commitReq.RequestWith(ctx, w.client)
+	if err != nil {
+		return fmt.Errorf("can not commit offsets in transaction: %w", err)
+	}
+
+	for _, topic := range commitRes.Topics {
+		for _, partition := range topic.Partitions {
+			if err = kerr.ErrorForCode(partition.ErrorCode); err != nil {
+				return fmt.Errorf("can not commit offset of partition %s/%d in transaction: %w", topic.Topic, partition.Partition, err)
+			}
+		}
+	}
+
+	w.lck.Lock()
+	w.offsetsAdded = true
+	w.lck.Unlock()
+
+	return nil
+}
+
+func (w *idempotentWriter) endTransaction(ctx context.Context, commit kgo.TransactionEndTry) error {
+	if w.transactionalID == "" {
+		return ErrNotTransactional
+	}
+
+	w.lck.Lock()
+	defer w.lck.Unlock()
+
+	if err := w.client.EndTransaction(ctx, commit); err != nil {
+		return fmt.Errorf("can not end transaction: %w", err)
+	}
+
+	// franz-go only ends transactions it added partitions to by itself. If we only committed offsets of an external
+	// consumer group, we have to end the transaction on our own.
+	if !w.offsetsAdded || w.produced {
+		return nil
+	}
+
+	producerID, epoch, err := w.client.ProducerID(ctx)
+	if err != nil {
+		return fmt.Errorf("can not get producer id: %w", err)
+	}
+
+	req := kmsg.NewPtrEndTxnRequest()
+	req.TransactionalID = w.transactionalID
+	req.ProducerID = producerID
+	req.ProducerEpoch = epoch
+	req.Commit = bool(commit)
+
+	res, err := req.RequestWith(ctx, w.client)
+	if err != nil {
+		return fmt.Errorf("can not end offset only transaction: %w", err)
+	}
+
+	return kerr.ErrorForCode(res.ErrorCode)
+}
+
+func buildTxnOffsetCommitTopics(msgs []kafka.Message) []kmsg.TxnOffsetCommitRequestTopic {
+	offsets := map[string]map[int32]int64{}
+
+	for _, msg := range msgs {
+		if _, ok := offsets[msg.Topic]; !ok {
+			offsets[msg.Topic] = map[int32]int64{}
+		}
+
+		// the committed offset is the offset of the next message to consume
+		partition := int32(msg.Partition)
+		if current, ok := offsets[msg.Topic][partition]; !ok || current < msg.Offset+1 {
+			offsets[msg.Topic][partition] = msg.Offset + 1
+		}
+	}
+
+	topics := make([]kmsg.TxnOffsetCommitRequestTopic, 0, len(offsets))
+
+	for topic, partitions := range offsets {
+		reqTopic := kmsg.NewTxnOffsetCommitRequestTopic()
+		reqTopic.Topic = topic
+
+		for partition, offset := range partitions {
+			reqPartition := kmsg.NewTxnOffsetCommitRequestTopicPartition()
+			reqPartition.Partition = partition
+			reqPartition.Offset = offset
+			reqPartition.LeaderEpoch = -1
+
+			reqTopic.Partitions = append(reqTopic.Partitions, reqPartition)
+		}
+
+		topics = append(topics, reqTopic)
+	}
+
+	return topics
+}
@@ -0,0 +1,237 @@
+package schemaregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	netHttp "net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/justtrackio/gosoline/pkg/appctx"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/http"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+const (
+	errorCodeSubjectNotFound = 40401
+	errorCodeVersionNotFound = 40402
+	errorCodeSchemaNotFound  = 40403
+)
+
+var ErrSchemaNotFound = errors.New("schema registry: schema not found")
+
+type Schema struct {
+	Schema     string      `json:"schema"`
+	SchemaType SchemaType  `json:"schemaType,omitempty"`
+	References []Reference `json:"references,omitempty"`
+}
+
+type Reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// Type returns the type of the schema, the registry omits it for avro schemas.
+func (s Schema) Type() SchemaType {
+	if s.SchemaType == "" {
+		return SchemaTypeAvro
+	}
+
+	return s.SchemaType
+}
+
+type Error struct {
+	StatusCode int    `json:"-"`
+	ErrorCode  int    `json:"error_code"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("schema registry: %s (status %d, error code %d)", e.Message, e.StatusCode, e.ErrorCode)
+}
+
+func (e *Error) Is(target error) bool {
+	return target == ErrSchemaNotFound && (e.ErrorCode == errorCodeSubjectNotFound || e.ErrorCode == errorCodeVersionNotFound || e.ErrorCode == errorCodeSchemaNotFound)
+}
+
+//go:generate mockery --name Client
+type Client interface {
+	// GetSchemaByID returns the schema with the given globally unique id.
+	GetSchemaByID(ctx context.Context, id int) (*Schema, error)
+	// LookupSchema returns the id of a schema which is already registered under the subject.
+	LookupSchema(ctx context.Context, subject string, schema Schema) (int, error)
+	// RegisterSchema registers the schema as new version of the subject, if it is not registered yet, and returns its id.
+	RegisterSchema(ctx context.Context, subject string, schema Schema) (int, error)
+	// CheckCompatibility checks the schema against the latest version of the subject with the compatibility level
+	// configured at the registry. A subject without any versions is compatible to every schema.
+	CheckCompatibility(ctx context.Context, subject string, schema Schema) (bool, error)
+}
+
+type client struct {
+	http     http.Client
+	settings *ClientSettings
+
+	lck          sync.RWMutex
+	schemasByID  map[int]*Schema
+	idsBySubject map[string]map[string]int
+}
+
+type clientAppCtxKey string
+
+// ProvideClient returns the client of the named registry. The client is shared within the application, so all
+// producers and consumers make use of the same schema cache.
+func ProvideClient(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Client, error) {
+	return appctx.Provide(ctx, clientAppCtxKey(name), func() (Client, error) {
+		return NewClient(ctx, config, logger, name)
+	})
+}
+
+func NewClient(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Client, error) {
+	settings := ParseClientSettings(config, name)
+
+	httpClient, err := http.ProvideHttpClient(ctx, config, logger, fmt.Sprintf("schema_registry_%s", name))
+	if err != nil {
+		return nil, fmt.Errorf("can not create http client: %w", err)
+	}
+
+	return NewClientWithInterfaces(httpClient, settings), nil
+}
+
+func NewClientWithInterfaces(httpClient http.Client, settings *ClientSettings) Client {
+	return &client{
+		http:         httpClient,
+		settings:     settings,
+		schemasByID:  map[int]*Schema{},
+		idsBySubject: map[string]map[string]int{},
+	}
+}
+
+func (c *client) GetSchemaByID(ctx context.Context, id int) (*Schema, error) {
+	c.lck.RLock()
+	schema, ok := c.schemasByID[id]
+	c.lck.RUnlock()
+
+	if ok {
+		return schema, nil
+	}
+
+	schema = &Schema{}
+	if err := c.do(ctx, netHttp.MethodGet, fmt.Sprintf("/schemas/ids/%d", id), nil, schema); err != nil {
+		return nil, fmt.Errorf("can not get schema %d: %w", id, err)
+	}
+
+	// schema ids are immutable, so they can be cached forever
+	c.lck.Lock()
+	c.schemasByID[id] = schema
+	c.lck.Unlock()
+
+	return schema, nil
+}
+
+func (c *client) LookupSchema(ctx context.Context, subject string, schema Schema) (int, error) {
+	return c.getOrFetchID(ctx, subject, schema, fmt.Sprintf("/subjects/%s", url.PathEscape(subject)))
+}
+
+func (c *client) RegisterSchema(ctx context.Context, subject string, schema Schema) (int, error) {
+	return c.getOrFetchID(ctx, subject, schema, fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject)))
+}
+
+func (c *client) CheckCompatibility(ctx context.Context, subject string, schema Schema) (bool, error) {
+	result := &struct {
+		IsCompatible bool `json:"is_compatible"`
+	}{}
+
+	path := fmt.Sprintf("/compatibility/subjects/%s/versions/latest", url.PathEscape(subject))
+	err := c.do(ctx, netHttp.MethodPost, path, schema, result)
+
+	if errors.Is(err, ErrSchemaNotFound) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("can not check compatibility of schema for subject %s: %w", subject, err)
+	}
+
+	return result.IsCompatible, nil
+}
+
+func (c *client) getOrFetchID(ctx context.Context, subject string, schema Schema, path string) (int, error) {
+	c.lck.RLock()
+	id, ok := c.idsBySubject[subject][schema.Schema]
+	c.lck.RUnlock()
+
+	if ok {
+		return id, nil
+	}
+
+	result := &struct {
+		Id int `json:"id"`
+	}{}
+
+	if err := c.do(ctx, netHttp.MethodPost, path, schema, result); err != nil {
+		return 0, fmt.Errorf("can not get id of schema for subject %s: %w", subject, err)
+	}
+
+	c.lck.Lock()
+	defer c.lck.Unlock()
+
+	if _, ok := c.idsBySubject[subject]; !ok {
+		c.idsBySubject[subject] = map[string]int{}
+	}
+
+	c.idsBySubject[subject][schema.Schema] = result.Id
+	c.schemasByID[result.Id] = &schema
+
+	return result.Id, nil
+}
+
+func (c *client) do(ctx context.Context, method string, path string, body interface{}, result interface{}) error {
+	request := c.http.NewRequest().
+		WithUrl(strings.TrimSuffix(c.settings.Url, "/")+path).
+		WithHeader("Accept", contentType)
+
+	if c.settings.Username != "" {
+		request = request.WithBasicAuth(c.settings.Username, c.settings.Password)
+	}
+
+	if body != nil {
+		request = request.WithHeader("Content-Type", contentType).WithBody(body)
+	}
+
+	var err error
+	var response *http.Response
+
+	switch method {
+	case netHttp.MethodGet:
+		response, err = c.http.Get(ctx, request)
+	default:
+		response, err = c.http.Post(ctx, request)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode >= netHttp.StatusBadRequest {
+		registryErr := &Error{}
+		if err = json.Unmarshal(response.Body, registryErr); err != nil {
+			registryErr.Message = string(response.Body)
+		}
+		registryErr.StatusCode = response.StatusCode
+
+		return registryErr
+	}
+
+	if err = json.Unmarshal(response.Body, result); err != nil {
+		return fmt.Errorf("can not unmarshal response: %w", err)
+	}
+
+	return nil
+}
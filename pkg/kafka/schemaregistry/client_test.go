@@ -0,0 +1,102 @@
+package schemaregistry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/http"
+	httpMocks "github.com/justtrackio/gosoline/pkg/http/mocks"
+	"github.com/justtrackio/gosoline/pkg/kafka/schemaregistry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func matchUrl(url string) interface{} {
+	return mock.MatchedBy(func(request *http.Request) bool {
+		return request.GetUrl() == url
+	})
+}
+
+func TestClientGetSchemaByID(t *testing.T) {
+	ctx := context.Background()
+
+	httpClient := new(httpMocks.Client)
+	httpClient.On("NewRequest").Return(http.NewRequest(nil)).Once()
+	httpClient.On("Get", ctx, matchUrl("http://registry/schemas/ids/1")).Return(&http.Response{
+		StatusCode: 200,
+		Body:       []byte(`{"schema":"{\"type\":\"string\"}"}`),
+	}, nil).Once()
+	defer httpClient.AssertExpectations(t)
+
+	client := schemaregistry.NewClientWithInterfaces(httpClient, &schemaregistry.ClientSettings{Url: "http://registry/"})
+
+	for i := 0; i < 2; i++ {
+		schema, err := client.GetSchemaByID(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"string"}`, schema.Schema)
+		assert.Equal(t, schemaregistry.SchemaTypeAvro, schema.Type())
+	}
+}
+
+func TestClientRegisterSchema(t *testing.T) {
+	ctx := context.Background()
+	schema := schemaregistry.Schema{Schema: `{"type":"string"}`}
+
+	httpClient := new(httpMocks.Client)
+	httpClient.On("NewRequest").Return(http.NewRequest(nil)).Once()
+	httpClient.On("Post", ctx, matchUrl("http://registry/subjects/topic-value/versions")).Return(&http.Response{
+		StatusCode: 200,
+		Body:       []byte(`{"id":12}`),
+	}, nil).Once()
+	defer httpClient.AssertExpectations(t)
+
+	client := schemaregistry.NewClientWithInterfaces(httpClient, &schemaregistry.ClientSettings{Url: "http://registry"})
+
+	for i := 0; i < 2; i++ {
+		id, err := client.RegisterSchema(ctx, "topic-value", schema)
+		assert.NoError(t, err)
+		assert.Equal(t, 12, id)
+	}
+
+	cached, err := client.GetSchemaByID(ctx, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, schema, *cached)
+}
+
+func TestClientCheckCompatibility(t *testing.T) {
+	ctx := context.Background()
+	schema := schemaregistry.Schema{Schema: `{"type":"string"}`}
+
+	httpClient := new(httpMocks.Client)
+	httpClient.On("NewRequest").Return(http.NewRequest(nil)).Once()
+	httpClient.On("Post", ctx, matchUrl("http://registry/compatibility/subjects/new-value/versions/latest")).Return(&http.Response{
+		StatusCode: 404,
+		Body:       []byte(`{"error_code":40401,"message":"Subject 'new-value' not found."}`),
+	}, nil).Once()
+	httpClient.On("NewRequest").Return(http.NewRequest(nil)).Once()
+	httpClient.On("Post", ctx, matchUrl("http://registry/compatibility/subjects/old-value/versions/latest")).Return(&http.Response{
+		StatusCode: 200,
+		Body:       []byte(`{"is_compatible":false}`),
+	}, nil).Once()
+	httpClient.On("NewRequest").Return(http.NewRequest(nil)).Once()
+	httpClient.On("Post", ctx, matchUrl("http://registry/compatibility/subjects/broken-value/versions/latest")).Return(&http.Response{
+		StatusCode: 500,
+		Body:       []byte(`{"error_code":50001,"message":"Error in the backend data store"}`),
+	}, nil).Once()
+	defer httpClient.AssertExpectations(t)
+
+	client := schemaregistry.NewClientWithInterfaces(httpClient, &schemaregistry.ClientSettings{Url: "http://registry"})
+
+	compatible, err := client.CheckCompatibility(ctx, "new-value", schema)
+	assert.NoError(t, err)
+	assert.True(t, compatible)
+
+	compatible, err = client.CheckCompatibility(ctx, "old-value", schema)
+	assert.NoError(t, err)
+	assert.False(t, compatible)
+
+	_, err = client.CheckCompatibility(ctx, "broken-value", schema)
+	registryErr := &schemaregistry.Error{}
+	assert.ErrorAs(t, err, &registryErr)
+	assert.Equal(t, 50001, registryErr.ErrorCode)
+}
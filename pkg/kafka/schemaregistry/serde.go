@@ -0,0 +1,279 @@
+package schemaregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/encoding/base64"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/linkedin/goavro/v2"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var ErrIncompatibleSchema = errors.New("schema registry: schema is not compatible with the latest version of the subject")
+
+//go:generate mockery --name Serializer
+type Serializer interface {
+	// Serialize converts a message body into the wire format of the registry.
+	Serialize(body []byte) ([]byte, error)
+}
+
+//go:generate mockery --name Deserializer
+type Deserializer interface {
+	// Deserialize converts a message in the wire format of the registry back into a message body.
+	Deserialize(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// codec converts between the body of a stream message and the payload of the wire format. Avro payloads are
+// converted from and to json bodies, protobuf bodies are base64 encoded by the stream encoder and json bodies are
+// validated against their schema.
+type codec interface {
+	fromBody(body []byte) ([]byte, error)
+	toBody(payload []byte) ([]byte, error)
+}
+
+type serializer struct {
+	schemaID       int
+	schemaType     SchemaType
+	messageIndexes []int
+	codec          codec
+}
+
+// NewSerializer resolves the id of the configured schema for the topic. If the compatibility check is enabled, the
+// producer fails to start if the schema is not compatible with the latest version registered for the subject.
+func NewSerializer(ctx context.Context, config cfg.Config, logger log.Logger, settings *SchemaSettings, fqTopic string) (Serializer, error) {
+	client, err := ProvideClient(ctx, config, logger, settings.Registry)
+	if err != nil {
+		return nil, fmt.Errorf("can not create schema registry client: %w", err)
+	}
+
+	return NewSerializerWithInterfaces(ctx, logger, client, settings, fqTopic)
+}
+
+func NewSerializerWithInterfaces(ctx context.Context, logger log.Logger, client Client, settings *SchemaSettings, fqTopic string) (Serializer, error) {
+	var err error
+	var id int
+	var compatible bool
+
+	subject := settings.Subject
+	if subject == "" {
+		subject = fmt.Sprintf("%s-value", fqTopic)
+	}
+
+	schema := Schema{
+		Schema:     settings.Schema,
+		SchemaType: settings.Type,
+	}
+
+	if schema.Type() == SchemaTypeAvro {
+		// avro is the default of the registry and the type has to be omitted for older versions
+		schema.SchemaType = ""
+	}
+
+	codec, err := newCodec(schema)
+	if err != nil {
+		return nil, fmt.Errorf("can not parse schema for subject %s: %w", subject, err)
+	}
+
+	if settings.CompatibilityCheck {
+		if compatible, err = client.CheckCompatibility(ctx, subject, schema); err != nil {
+			return nil, err
+		}
+
+		if !compatible {
+			return nil, fmt.Errorf("%w: subject %s", ErrIncompatibleSchema, subject)
+		}
+	}
+
+	if settings.AutoRegister {
+		id, err = client.RegisterSchema(ctx, subject, schema)
+	} else {
+		id, err = client.LookupSchema(ctx, subject, schema)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WithFields(log.Fields{
+		"schema_subject": subject,
+		"schema_id":      id,
+	}).Info("using schema %d of subject %s", id, subject)
+
+	return &serializer{
+		schemaID:       id,
+		schemaType:     schema.Type(),
+		messageIndexes: settings.MessageIndexes,
+		codec:          codec,
+	}, nil
+}
+
+func (s *serializer) Serialize(body []byte) ([]byte, error) {
+	payload, err := s.codec.fromBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("can not encode body with schema %d: %w", s.schemaID, err)
+	}
+
+	if s.schemaType == SchemaTypeProtobuf {
+		payload = EncodeMessageIndexes(s.messageIndexes, payload)
+	}
+
+	return EncodeWireFormat(s.schemaID, payload), nil
+}
+
+type deserializer struct {
+	client Client
+
+	lck    sync.RWMutex
+	codecs map[int]codec
+}
+
+func NewDeserializer(ctx context.Context, config cfg.Config, logger log.Logger, registry string) (Deserializer, error) {
+	client, err := ProvideClient(ctx, config, logger, registry)
+	if err != nil {
+		return nil, fmt.Errorf("can not create schema registry client: %w", err)
+	}
+
+	return NewDeserializerWithInterfaces(client), nil
+}
+
+func NewDeserializerWithInterfaces(client Client) Deserializer {
+	return &deserializer{
+		client: client,
+		codecs: map[int]codec{},
+	}
+}
+
+func (d *deserializer) Deserialize(ctx context.Context, data []byte) ([]byte, error) {
+	id, payload, err := DecodeWireFormat(data)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := d.client.GetSchemaByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.Type() == SchemaTypeProtobuf {
+		if _, payload, err = DecodeMessageIndexes(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	codec, err := d.getCodec(id, *schema)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := codec.toBody(payload)
+	if err != nil {
+		return nil, fmt.Errorf("can not decode payload with schema %d: %w", id, err)
+	}
+
+	return body, nil
+}
+
+func (d *deserializer) getCodec(id int, schema Schema) (codec, error) {
+	d.lck.RLock()
+	c, ok := d.codecs[id]
+	d.lck.RUnlock()
+
+	if ok {
+		return c, nil
+	}
+
+	c, err := newCodec(schema)
+	if err != nil {
+		return nil, fmt.Errorf("can not parse schema %d: %w", id, err)
+	}
+
+	d.lck.Lock()
+	d.codecs[id] = c
+	d.lck.Unlock()
+
+	return c, nil
+}
+
+func newCodec(schema Schema) (codec, error) {
+	switch schema.Type() {
+	case SchemaTypeAvro:
+		avroCodec, err := goavro.NewCodec(schema.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		return &avroBodyCodec{codec: avroCodec}, nil
+	case SchemaTypeJson:
+		jsonSchema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema.Schema))
+		if err != nil {
+			return nil, err
+		}
+
+		return &jsonBodyCodec{schema: jsonSchema}, nil
+	case SchemaTypeProtobuf:
+		return &protobufBodyCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown schema type %s", schema.SchemaType)
+	}
+}
+
+type avroBodyCodec struct {
+	codec *goavro.Codec
+}
+
+func (c *avroBodyCodec) fromBody(body []byte) ([]byte, error) {
+	native, _, err := c.codec.NativeFromTextual(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.codec.BinaryFromNative(nil, native)
+}
+
+func (c *avroBodyCodec) toBody(payload []byte) ([]byte, error) {
+	native, _, err := c.codec.NativeFromBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.codec.TextualFromNative(nil, native)
+}
+
+type jsonBodyCodec struct {
+	schema *gojsonschema.Schema
+}
+
+func (c *jsonBodyCodec) fromBody(body []byte) ([]byte, error) {
+	return body, c.validate(body)
+}
+
+func (c *jsonBodyCodec) toBody(payload []byte) ([]byte, error) {
+	return payload, c.validate(payload)
+}
+
+func (c *jsonBodyCodec) validate(data []byte) error {
+	result, err := c.schema.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return err
+	}
+
+	if !result.Valid() {
+		return fmt.Errorf("json does not match schema: %v", result.Errors())
+	}
+
+	return nil
+}
+
+type protobufBodyCodec struct{}
+
+func (c *protobufBodyCodec) fromBody(body []byte) ([]byte, error) {
+	return base64.Decode(body)
+}
+
+func (c *protobufBodyCodec) toBody(payload []byte) ([]byte, error) {
+	return base64.Encode(payload), nil
+}
@@ -0,0 +1,124 @@
+package schemaregistry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/kafka/schemaregistry"
+	"github.com/justtrackio/gosoline/pkg/kafka/schemaregistry/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const avroSchema = `{"type":"record","name":"Event","fields":[{"name":"id","type":"int"},{"name":"name","type":"string"}]}`
+
+func TestSerdeAvro(t *testing.T) {
+	ctx := context.Background()
+	schema := schemaregistry.Schema{Schema: avroSchema}
+
+	client := new(mocks.Client)
+	client.On("CheckCompatibility", ctx, "test-topic-value", schema).Return(true, nil).Once()
+	client.On("RegisterSchema", ctx, "test-topic-value", schema).Return(7, nil).Once()
+	client.On("GetSchemaByID", ctx, 7).Return(&schema, nil).Once()
+	defer client.AssertExpectations(t)
+
+	serializer, err := schemaregistry.NewSerializerWithInterfaces(ctx, logMocks.NewLoggerMockedAll(), client, &schemaregistry.SchemaSettings{
+		Registry:           "default",
+		Type:               schemaregistry.SchemaTypeAvro,
+		Schema:             avroSchema,
+		AutoRegister:       true,
+		CompatibilityCheck: true,
+	}, "test-topic")
+	assert.NoError(t, err)
+
+	data, err := serializer.Serialize([]byte(`{"id":1,"name":"foo"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 7, 2, 6, 'f', 'o', 'o'}, data)
+
+	deserializer := schemaregistry.NewDeserializerWithInterfaces(client)
+
+	body, err := deserializer.Deserialize(ctx, data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"name":"foo"}`, string(body))
+
+	_, err = serializer.Serialize([]byte(`{"id":"1"}`))
+	assert.Error(t, err)
+}
+
+func TestSerdeJson(t *testing.T) {
+	ctx := context.Background()
+	schema := schemaregistry.Schema{
+		Schema:     `{"type":"object","properties":{"id":{"type":"integer"}},"required":["id"]}`,
+		SchemaType: schemaregistry.SchemaTypeJson,
+	}
+
+	client := new(mocks.Client)
+	client.On("LookupSchema", ctx, "subject", schema).Return(3, nil).Once()
+	client.On("GetSchemaByID", ctx, 3).Return(&schema, nil).Once()
+	defer client.AssertExpectations(t)
+
+	serializer, err := schemaregistry.NewSerializerWithInterfaces(ctx, logMocks.NewLoggerMockedAll(), client, &schemaregistry.SchemaSettings{
+		Registry: "default",
+		Subject:  "subject",
+		Type:     schemaregistry.SchemaTypeJson,
+		Schema:   schema.Schema,
+	}, "test-topic")
+	assert.NoError(t, err)
+
+	data, err := serializer.Serialize([]byte(`{"id":1}`))
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{0, 0, 0, 0, 3}, []byte(`{"id":1}`)...), data)
+
+	body, err := schemaregistry.NewDeserializerWithInterfaces(client).Deserialize(ctx, data)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, string(body))
+
+	_, err = serializer.Serialize([]byte(`{"name":"foo"}`))
+	assert.Error(t, err)
+}
+
+func TestSerdeProtobuf(t *testing.T) {
+	ctx := context.Background()
+	schema := schemaregistry.Schema{
+		Schema:     `syntax = "proto3"; message Event { int32 id = 1; }`,
+		SchemaType: schemaregistry.SchemaTypeProtobuf,
+	}
+
+	client := new(mocks.Client)
+	client.On("LookupSchema", ctx, "test-topic-value", schema).Return(5, nil).Once()
+	client.On("GetSchemaByID", ctx, 5).Return(&schema, nil).Once()
+	defer client.AssertExpectations(t)
+
+	serializer, err := schemaregistry.NewSerializerWithInterfaces(ctx, logMocks.NewLoggerMockedAll(), client, &schemaregistry.SchemaSettings{
+		Registry: "default",
+		Type:     schemaregistry.SchemaTypeProtobuf,
+		Schema:   schema.Schema,
+	}, "test-topic")
+	assert.NoError(t, err)
+
+	// the stream protobuf encoder writes base64 encoded bodies
+	data, err := serializer.Serialize([]byte("CAE="))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 5, 0, 8, 1}, data)
+
+	body, err := schemaregistry.NewDeserializerWithInterfaces(client).Deserialize(ctx, data)
+	assert.NoError(t, err)
+	assert.Equal(t, "CAE=", string(body))
+}
+
+func TestSerializerIncompatible(t *testing.T) {
+	ctx := context.Background()
+	schema := schemaregistry.Schema{Schema: avroSchema}
+
+	client := new(mocks.Client)
+	client.On("CheckCompatibility", ctx, "test-topic-value", schema).Return(false, nil).Once()
+	defer client.AssertExpectations(t)
+
+	_, err := schemaregistry.NewSerializerWithInterfaces(ctx, logMocks.NewLoggerMockedAll(), client, &schemaregistry.SchemaSettings{
+		Registry:           "default",
+		Type:               schemaregistry.SchemaTypeAvro,
+		Schema:             avroSchema,
+		CompatibilityCheck: true,
+	}, "test-topic")
+	assert.ErrorIs(t, err, schemaregistry.ErrIncompatibleSchema)
+}
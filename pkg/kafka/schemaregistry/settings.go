@@ -0,0 +1,48 @@
+package schemaregistry
+
+import (
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+)
+
+const (
+	SchemaTypeAvro     SchemaType = "AVRO"
+	SchemaTypeProtobuf SchemaType = "PROTOBUF"
+	SchemaTypeJson     SchemaType = "JSON"
+)
+
+type SchemaType string
+
+// ClientSettings configure the connection to a registry, they are read from kafka.schema_registry.<name>.
+type ClientSettings struct {
+	Url      string `cfg:"url" validate:"required"`
+	Username string `cfg:"username"`
+	Password string `cfg:"password"`
+}
+
+// SchemaSettings describe the schema the messages of a producer are written with.
+type SchemaSettings struct {
+	// Registry is the name of the registry connection. Messages are written without wire format if it is empty.
+	Registry string `cfg:"registry"`
+	// Subject defaults to the topic name strategy, i.e. "<fq topic>-value".
+	Subject string     `cfg:"subject"`
+	Type    SchemaType `cfg:"type" default:"AVRO" validate:"oneof=AVRO PROTOBUF JSON"`
+	Schema  string     `cfg:"schema"`
+	// MessageIndexes address the message type within a protobuf schema, the default [0] is the first message.
+	MessageIndexes []int `cfg:"message_indexes"`
+	// AutoRegister registers the schema on startup, otherwise it has to be registered already.
+	AutoRegister       bool `cfg:"auto_register" default:"false"`
+	CompatibilityCheck bool `cfg:"compatibility_check" default:"true"`
+}
+
+func (s *SchemaSettings) Enabled() bool {
+	return s.Registry != ""
+}
+
+func ParseClientSettings(config cfg.Config, name string) *ClientSettings {
+	settings := &ClientSettings{}
+	config.UnmarshalKey(fmt.Sprintf("kafka.schema_registry.%s", name), settings)
+
+	return settings
+}
@@ -0,0 +1,74 @@
+package schemaregistry
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// MagicByte is the first byte of every message in the wire format of the confluent schema registry. It is followed
+// by the schema id as 4 byte big endian integer and the encoded payload.
+const MagicByte byte = 0x0
+
+const wireFormatHeaderLength = 5
+
+var ErrInvalidWireFormat = errors.New("schema registry: message is not in wire format")
+
+func EncodeWireFormat(schemaID int, payload []byte) []byte {
+	data := make([]byte, wireFormatHeaderLength, wireFormatHeaderLength+len(payload))
+	data[0] = MagicByte
+	binary.BigEndian.PutUint32(data[1:wireFormatHeaderLength], uint32(schemaID))
+
+	return append(data, payload...)
+}
+
+func DecodeWireFormat(data []byte) (schemaID int, payload []byte, err error) {
+	if len(data) < wireFormatHeaderLength || data[0] != MagicByte {
+		return 0, nil, ErrInvalidWireFormat
+	}
+
+	schemaID = int(binary.BigEndian.Uint32(data[1:wireFormatHeaderLength]))
+
+	return schemaID, data[wireFormatHeaderLength:], nil
+}
+
+// EncodeMessageIndexes prefixes a protobuf payload with the indexes of its message type within the schema. The
+// common case of the first message is shortened to a single zero byte.
+func EncodeMessageIndexes(indexes []int, payload []byte) []byte {
+	if len(indexes) == 0 || (len(indexes) == 1 && indexes[0] == 0) {
+		return append([]byte{0}, payload...)
+	}
+
+	data := binary.AppendVarint(nil, int64(len(indexes)))
+	for _, index := range indexes {
+		data = binary.AppendVarint(data, int64(index))
+	}
+
+	return append(data, payload...)
+}
+
+func DecodeMessageIndexes(data []byte) (indexes []int, payload []byte, err error) {
+	count, n := binary.Varint(data)
+	if n <= 0 || count < 0 {
+		return nil, nil, fmt.Errorf("%w: invalid protobuf message index count", ErrInvalidWireFormat)
+	}
+	data = data[n:]
+
+	if count == 0 {
+		return []int{0}, data, nil
+	}
+
+	indexes = make([]int, 0, count)
+
+	for i := int64(0); i < count; i++ {
+		index, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, nil, fmt.Errorf("%w: invalid protobuf message index", ErrInvalidWireFormat)
+		}
+
+		indexes = append(indexes, int(index))
+		data = data[n:]
+	}
+
+	return indexes, data, nil
+}
@@ -0,0 +1,60 @@
+package schemaregistry_test
+
+import (
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/kafka/schemaregistry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWireFormat(t *testing.T) {
+	data := schemaregistry.EncodeWireFormat(258, []byte("payload"))
+	assert.Equal(t, []byte{0, 0, 0, 1, 2, 'p', 'a', 'y', 'l', 'o', 'a', 'd'}, data)
+
+	id, payload, err := schemaregistry.DecodeWireFormat(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 258, id)
+	assert.Equal(t, []byte("payload"), payload)
+}
+
+func TestWireFormatInvalid(t *testing.T) {
+	_, _, err := schemaregistry.DecodeWireFormat([]byte{0, 1})
+	assert.ErrorIs(t, err, schemaregistry.ErrInvalidWireFormat)
+
+	_, _, err = schemaregistry.DecodeWireFormat([]byte(`{"id":1}`))
+	assert.ErrorIs(t, err, schemaregistry.ErrInvalidWireFormat)
+}
+
+func TestMessageIndexes(t *testing.T) {
+	for name, test := range map[string]struct {
+		indexes  []int
+		expected []int
+		encoded  []byte
+	}{
+		"default": {
+			indexes:  nil,
+			expected: []int{0},
+			encoded:  []byte{0},
+		},
+		"first": {
+			indexes:  []int{0},
+			expected: []int{0},
+			encoded:  []byte{0},
+		},
+		"nested": {
+			indexes:  []int{1, 2},
+			expected: []int{1, 2},
+			encoded:  []byte{4, 2, 4},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data := schemaregistry.EncodeMessageIndexes(test.indexes, []byte{42})
+			assert.Equal(t, append(test.encoded, 42), data)
+
+			indexes, payload, err := schemaregistry.DecodeMessageIndexes(data)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, indexes)
+			assert.Equal(t, []byte{42}, payload)
+		})
+	}
+}
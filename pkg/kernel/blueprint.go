@@ -65,6 +65,22 @@ func WithKillTimeout(killTimeout time.Duration) Option {
 	}
 }
 
+func WithDrainDelay(drainDelay time.Duration) Option {
+	return func(bp *blueprint) {
+		bp.kernelOptions = append(bp.kernelOptions, func(k *kernel) {
+			k.drainDelay = drainDelay
+		})
+	}
+}
+
+func WithRestartListener(listener RestartListener) Option {
+	return func(bp *blueprint) {
+		bp.kernelOptions = append(bp.kernelOptions, func(k *kernel) {
+			k.restartListeners = append(k.restartListeners, listener)
+		})
+	}
+}
+
 func WithExitHandler(handler func(code int)) Option {
 	return func(bp *blueprint) {
 		bp.kernelOptions = append(bp.kernelOptions, func(k *kernel) {
@@ -100,7 +100,11 @@ func (f *factory) buildModuleFactory(name string, factory ModuleFactory, opts ..
 		return fmt.Errorf("can not build module %s: %w", name, err)
 	}
 
-	if err = f.addModuleToStage(name, module, opts); err != nil {
+	rebuild := func() (Module, error) {
+		return factory(f.ctx, f.config, f.logger)
+	}
+
+	if err = f.addModuleToStage(name, module, rebuild, opts); err != nil {
 		return fmt.Errorf("can not add module to stage: %w", err)
 	}
 
@@ -141,9 +145,10 @@ func (f *factory) buildMiddleware(middlewareFactory MiddlewareFactory, position
 	return nil
 }
 
-func (f *factory) addModuleToStage(name string, module Module, opts []ModuleOption) error {
+func (f *factory) addModuleToStage(name string, module Module, factory func() (Module, error), opts []ModuleOption) error {
 	ms := &moduleState{
 		module:    module,
+		factory:   factory,
 		config:    getModuleConfig(module),
 		isRunning: 0,
 		err:       nil,
@@ -151,6 +156,13 @@ func (f *factory) addModuleToStage(name string, module Module, opts []ModuleOpti
 
 	MergeOptions(opts)(&ms.config)
 
+	restartKey := fmt.Sprintf("kernel.modules.%s.restart", name)
+	f.config.UnmarshalKey(restartKey, &ms.config.restart)
+
+	if ms.config.restartPolicy != "" && !f.config.IsSet(fmt.Sprintf("%s.policy", restartKey)) {
+		ms.config.restart.Policy = ms.config.restartPolicy
+	}
+
 	var ok bool
 	var stage *stage
 
@@ -19,6 +19,8 @@ type ModuleHealthCheckResult struct {
 	StageIndex int
 	Name       string
 	Healthy    bool
+	// Restarting is true while the module waits to be restarted after it stopped
+	Restarting bool
 	Err        error
 }
 
@@ -30,13 +30,18 @@ const (
 type ExitHandler func(code int)
 
 type Settings struct {
-	KillTimeout time.Duration       `cfg:"kill_timeout" default:"10s"`
+	KillTimeout time.Duration `cfg:"kill_timeout" default:"10s"`
+	// DrainDelay is the time between flipping the readiness to false and stopping the stages after the kernel was told
+	// to stop, e.g. by a signal. It gives load balancers and the like the chance to stop sending new work to the
+	// application. There is no delay if the kernel stops because its modules finished or failed.
+	DrainDelay  time.Duration       `cfg:"drain_delay" default:"0s"`
 	HealthCheck HealthCheckSettings `cfg:"health_check"`
 }
 
 //go:generate mockery --name Kernel
 type Kernel interface {
 	HealthCheck() HealthCheckResult
+	ReadinessCheck() ReadinessCheckResult
 	Running() <-chan struct{}
 	Run()
 	Stop(reason string)
@@ -50,12 +55,15 @@ type kernel struct {
 	logger log.Logger
 
 	middlewares       []Middleware
+	restartListeners  []RestartListener
 	stages            stages
 	running           chan struct{}
 	stopOnce          sync.Once
+	draining          atomic.Bool
 	foregroundModules int32
 
 	killTimeout time.Duration
+	drainDelay  time.Duration
 	exitCode    int
 	exitOnce    sync.Once
 	exitHandler ExitHandler
@@ -73,15 +81,24 @@ func newKernel(ctx context.Context, config cfg.Config, logger log.Logger) (*kern
 		running: make(chan struct{}),
 
 		killTimeout: settings.KillTimeout,
+		drainDelay:  settings.DrainDelay,
 		exitCode:    ExitCodeErr,
 		exitHandler: os.Exit,
 	}
 
-	_, err := appctx.Provide(ctx, healthCheckerKey, func() (HealthChecker, error) {
+	if _, err := appctx.Provide(ctx, healthCheckerKey, func() (HealthChecker, error) {
 		return k.HealthCheck, nil
-	})
+	}); err != nil {
+		return nil, err
+	}
 
-	return k, err
+	if _, err := appctx.Provide(ctx, readinessCheckerKey, func() (ReadinessChecker, error) {
+		return k.ReadinessCheck, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return k, nil
 }
 
 func (k *kernel) init(middlewares []Middleware, stages map[int]*stage) {
@@ -111,14 +128,14 @@ func (k *kernel) Run() {
 	runHandler := func() {
 		if err := k.runStages(); err != nil {
 			reason := fmt.Sprintf("error during running all stages: %s", err)
-			k.Stop(reason)
+			k.stop(reason, false)
 		}
 
 		k.logger.Info("kernel up and running")
 		close(k.running)
 
 		<-k.waitAllStagesDone().Channel()
-		k.Stop("context done")
+		k.stop("context done", false)
 
 		k.waitStopped()
 
@@ -141,10 +158,25 @@ func (k *kernel) Run() {
 	runHandler()
 }
 
+// Stop stops the kernel after the drain delay, as it was told to stop from the outside, e.g. by a signal.
 func (k *kernel) Stop(reason string) {
+	k.stop(reason, true)
+}
+
+// stop stops the kernel. The drain delay is only needed if the kernel was told to stop from the outside. If it stops
+// because its modules finished or failed, there is no work left which would need to be drained.
+func (k *kernel) stop(reason string, drain bool) {
 	k.stopOnce.Do(func() {
+		k.draining.Store(true)
+
 		go func() {
 			k.logger.Info("stopping kernel due to: %s", reason)
+
+			if drain && k.drainDelay > 0 {
+				k.logger.Info("draining for %s before stopping the stages", k.drainDelay)
+				time.Sleep(k.drainDelay)
+			}
+
 			indices := k.stages.getIndices()
 
 			for i := len(indices) - 1; i >= 0; i-- {
@@ -176,6 +208,18 @@ func (k *kernel) HealthCheck() HealthCheckResult {
 	return result
 }
 
+func (k *kernel) ReadinessCheck() ReadinessCheckResult {
+	result := ReadinessCheckResult{
+		Draining: k.draining.Load(),
+	}
+
+	for _, stageIndex := range k.stages.getIndices() {
+		result.Stages = append(result.Stages, k.stages[stageIndex].readinesscheck())
+	}
+
+	return result
+}
+
 func (k *kernel) exit() {
 	k.exitOnce.Do(func() {
 		k.logger.Info("leaving kernel with exit code %d", k.exitCode)
@@ -235,7 +279,7 @@ func (k *kernel) runModule(ctx context.Context, name string, ms *moduleState) (m
 		moduleErr = ms.err
 	}(ms)
 
-	ms.err = ms.module.Run(ctx)
+	ms.err = newModuleSupervisor(k, name, ms).run(ctx)
 
 	return ms.err
 }
@@ -244,14 +288,14 @@ func (k *kernel) essentialModuleExited(name string) {
 	// actually we would need to decrement k.foregroundModules here, too
 	// however, as we are stopping in any case, we don't have to
 	reason := fmt.Sprintf("the essential module [%s] has stopped running", name)
-	k.Stop(reason)
+	k.stop(reason, false)
 }
 
 func (k *kernel) foregroundModuleExited() {
 	remaining := atomic.AddInt32(&k.foregroundModules, -1)
 
 	if remaining == 0 {
-		k.Stop("no more foreground modules in running state")
+		k.stop("no more foreground modules in running state", false)
 	}
 }
 
@@ -12,10 +12,15 @@ import (
 	cfgMocks "github.com/justtrackio/gosoline/pkg/cfg/mocks"
 	"github.com/justtrackio/gosoline/pkg/coffin"
 	"github.com/justtrackio/gosoline/pkg/conc"
+	"github.com/justtrackio/gosoline/pkg/exec"
 	"github.com/justtrackio/gosoline/pkg/kernel"
 	kernelMocks "github.com/justtrackio/gosoline/pkg/kernel/mocks"
 	"github.com/justtrackio/gosoline/pkg/log"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/justtrackio/gosoline/pkg/stream"
+	"github.com/justtrackio/gosoline/pkg/tracing"
+	"github.com/justtrackio/gosoline/pkg/uuid"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -40,11 +45,17 @@ type KernelTestSuite struct {
 	config *cfgMocks.Config
 	logger *logMocks.Logger
 	module *kernelMocks.FullModule
+
+	restartSettings kernel.RestartSettings
 }
 
 func (s *KernelTestSuite) SetupTest() {
 	s.ctx = appctx.WithContainer(context.Background())
 
+	s.restartSettings = kernel.RestartSettings{
+		Policy: kernel.RestartPolicyNever,
+	}
+
 	s.config = new(cfgMocks.Config)
 	s.config.On("AllSettings").Return(map[string]interface{}{})
 	s.config.On("UnmarshalKey", "kernel", mock.AnythingOfType("*kernel.Settings")).Run(func(args mock.Arguments) {
@@ -53,6 +64,11 @@ func (s *KernelTestSuite) SetupTest() {
 		settings.HealthCheck.Timeout = time.Second
 		settings.HealthCheck.WaitInterval = time.Second
 	})
+	s.config.On("UnmarshalKey", mock.AnythingOfType("string"), mock.AnythingOfType("*kernel.RestartSettings")).Run(func(args mock.Arguments) {
+		settings := args[1].(*kernel.RestartSettings)
+		*settings = s.restartSettings
+	})
+	s.config.On("IsSet", mock.AnythingOfType("string")).Return(false)
 
 	s.logger = new(logMocks.Logger)
 	s.logger.On("WithChannel", mock.Anything).Return(s.logger)
@@ -494,12 +510,326 @@ func (s *KernelTestSuite) TestModuleFastShutdown() {
 	k.Run()
 }
 
+func (s *KernelTestSuite) TestReadinessAndDrain() {
+	var err error
+	var k kernel.Kernel
+
+	module := &readyModule{
+		ready:   true,
+		stopped: make(chan struct{}),
+	}
+
+	k, err = kernel.BuildKernel(s.ctx, s.config, s.logger, []kernel.Option{
+		kernel.WithModuleFactory("ready", func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+			return module, nil
+		}),
+		kernel.WithKillTimeout(time.Second),
+		kernel.WithDrainDelay(100 * time.Millisecond),
+		s.mockExitHandler(kernel.ExitCodeOk),
+	})
+	s.NoError(err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		k.Run()
+	}()
+
+	<-k.Running()
+
+	result := k.ReadinessCheck()
+	s.True(result.IsReady())
+	s.Equal(kernel.ReadinessCheckResult{
+		Draining: false,
+		Stages: []kernel.StageReadinessCheckResult{
+			{
+				Index:   kernel.StageApplication,
+				Running: true,
+				Modules: []kernel.ModuleReadinessCheckResult{
+					{StageIndex: kernel.StageApplication, Name: "ready", Ready: true},
+				},
+			},
+		},
+	}, result)
+
+	k.Stop("test done")
+
+	result = k.ReadinessCheck()
+	s.True(result.Draining)
+	s.False(result.IsReady())
+
+	select {
+	case <-module.stopped:
+		s.Fail("the module should still be running while draining")
+	default:
+	}
+
+	<-done
+	<-module.stopped
+}
+
+func (s *KernelTestSuite) TestNoDrainAfterModulesFinished() {
+	k, err := kernel.BuildKernel(s.ctx, s.config, s.logger, []kernel.Option{
+		kernel.WithModuleFactory("finishing", func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+			return FunctionModule(func(ctx context.Context) error {
+				return nil
+			}), nil
+		}),
+		kernel.WithKillTimeout(time.Second),
+		kernel.WithDrainDelay(time.Hour),
+		s.mockExitHandler(kernel.ExitCodeOk),
+	})
+	s.NoError(err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		k.Run()
+	}()
+
+	// nothing is left to drain once all foreground modules finished, so the kernel has to stop without the delay
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		s.Fail("the kernel waited for the drain delay after all modules finished")
+	}
+}
+
+func (s *KernelTestSuite) TestRestartOnFailure() {
+	s.logger.On("Warn", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.restartSettings = kernel.RestartSettings{
+		Policy: kernel.RestartPolicyOnFailure,
+		Backoff: exec.BackoffSettings{
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond * 10,
+		},
+		MaxRestarts: 5,
+		Window:      time.Minute,
+	}
+
+	runs := 0
+	module := FunctionModule(func(ctx context.Context) error {
+		runs++
+
+		if runs < 3 {
+			return fmt.Errorf("connection lost")
+		}
+
+		return nil
+	})
+
+	listener := kernelMocks.NewRestartListener(s.T())
+	listener.EXPECT().ModuleRestarted(kernel.StageApplication, "module", 1, fmt.Errorf("connection lost")).Once()
+	listener.EXPECT().ModuleRestarted(kernel.StageApplication, "module", 2, fmt.Errorf("connection lost")).Once()
+
+	k, err := kernel.BuildKernel(s.ctx, s.config, s.logger, []kernel.Option{
+		kernel.WithModuleFactory("module", func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+			return module, nil
+		}),
+		kernel.WithRestartListener(listener),
+		kernel.WithKillTimeout(time.Second),
+		s.mockExitHandler(kernel.ExitCodeOk),
+	})
+	s.NoError(err)
+
+	k.Run()
+
+	s.Equal(3, runs)
+}
+
+func (s *KernelTestSuite) TestRestartBudgetExceeded() {
+	s.logger.On("Warn", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.logger.On("Error", "error running %s module %s: %w", "foreground", "module", mock.Anything)
+	s.logger.On("Error", "error during the execution of stage %d: %w", kernel.StageApplication, mock.Anything)
+	s.restartSettings = kernel.RestartSettings{
+		Policy: kernel.RestartPolicyAlways,
+		Backoff: exec.BackoffSettings{
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond,
+		},
+		MaxRestarts: 2,
+		Window:      time.Minute,
+	}
+
+	runs := 0
+	module := FunctionModule(func(ctx context.Context) error {
+		runs++
+
+		return nil
+	})
+
+	k, err := kernel.BuildKernel(s.ctx, s.config, s.logger, []kernel.Option{
+		kernel.WithModuleFactory("module", func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+			return module, nil
+		}),
+		kernel.WithKillTimeout(time.Second),
+		s.mockExitHandler(kernel.ExitCodeErr),
+	})
+	s.NoError(err)
+
+	k.Run()
+
+	s.Equal(3, runs)
+	s.logger.AssertCalled(s.T(), "Error", "error running %s module %s: %w", "foreground", "module", fmt.Errorf("module module exceeded its budget of 2 restarts in 1m0s"))
+}
+
+func (s *KernelTestSuite) TestRestartingModuleIsUnhealthy() {
+	s.logger.On("Warn", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.restartSettings = kernel.RestartSettings{
+		Policy: kernel.RestartPolicyOnFailure,
+		Backoff: exec.BackoffSettings{
+			InitialInterval: time.Hour,
+			MaxInterval:     time.Hour,
+		},
+		MaxRestarts: 5,
+		Window:      time.Minute,
+	}
+
+	failed := make(chan struct{})
+	foreground := FunctionModule(func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return nil
+	})
+
+	failing := FunctionModule(func(ctx context.Context) error {
+		close(failed)
+
+		return fmt.Errorf("connection lost")
+	})
+
+	k, err := kernel.BuildKernel(s.ctx, s.config, s.logger, []kernel.Option{
+		kernel.WithModuleFactory("failing", func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+			return failing, nil
+		}, kernel.ModuleType(kernel.TypeBackground)),
+		kernel.WithModuleFactory("foreground", func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+			return foreground, nil
+		}),
+		kernel.WithKillTimeout(time.Second),
+		s.mockExitHandler(kernel.ExitCodeOk),
+	})
+	s.NoError(err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		k.Run()
+	}()
+
+	<-failed
+	<-k.Running()
+
+	s.Eventually(func() bool {
+		return !k.HealthCheck().IsHealthy()
+	}, time.Second, time.Millisecond)
+
+	result := k.HealthCheck()
+	s.Len(result, 1)
+	s.Equal("failing", result[0].Name)
+	s.True(result[0].Restarting)
+	s.EqualError(result[0].Err, "restarting (restart 1): connection lost")
+
+	k.Stop("test done")
+	<-done
+}
+
+func (s *KernelTestSuite) TestRestartConsumer() {
+	s.restartSettings = kernel.RestartSettings{
+		Policy: kernel.RestartPolicyOnFailure,
+		Backoff: exec.BackoffSettings{
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond * 10,
+		},
+		MaxRestarts: 5,
+		Window:      time.Minute,
+	}
+
+	logger := logMocks.NewLoggerMockedAll()
+	consumed := make(chan string)
+	builds := 0
+
+	// every build gets its own input, the first consumer fails like it lost the connection to its broker
+	consumerFactory := func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+		builds++
+
+		input := stream.NewInMemoryInput(&stream.InMemorySettings{Size: 1})
+		callback := &restartingConsumerCallback{
+			failing:  builds == 1,
+			consumed: consumed,
+		}
+
+		if !callback.failing {
+			input.Publish(stream.NewJsonMessage(`"foo"`))
+		}
+
+		settings := &stream.ConsumerSettings{
+			Input:       "test",
+			RunnerCount: 1,
+			IdleTimeout: time.Second,
+		}
+
+		base := stream.NewBaseConsumerWithInterfaces(uuid.New(), logger, metricMocks.NewWriterMockedAll(), tracing.NewNoopTracer(), input, stream.NewMessageEncoder(&stream.MessageEncoderSettings{}), stream.NewNoopInput(), stream.NewRetryHandlerNoopWithInterfaces(), callback, settings, "test", cfg.AppId{})
+
+		return stream.NewConsumerWithInterfaces(base, callback), nil
+	}
+
+	k, err := kernel.BuildKernel(s.ctx, s.config, logger, []kernel.Option{
+		kernel.WithModuleFactory("consumer", consumerFactory),
+		kernel.WithKillTimeout(time.Second),
+		s.mockExitHandler(kernel.ExitCodeOk),
+	})
+	s.NoError(err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		k.Run()
+	}()
+
+	select {
+	case model := <-consumed:
+		s.Equal("foo", model)
+	case <-time.After(time.Second * 3):
+		s.FailNow("the restarted consumer didn't consume the message")
+	}
+
+	k.Stop("test done")
+	<-done
+
+	s.Equal(2, builds)
+}
+
 func (s *KernelTestSuite) mockExitHandler(expectedCode int) kernel.Option {
 	return kernel.WithExitHandler(func(actualCode int) {
 		s.Equal(expectedCode, actualCode, "exit code does not match")
 	})
 }
 
+type restartingConsumerCallback struct {
+	failing  bool
+	consumed chan string
+}
+
+func (c *restartingConsumerCallback) GetModel(_ map[string]string) interface{} {
+	return new(string)
+}
+
+func (c *restartingConsumerCallback) Consume(_ context.Context, model interface{}, _ map[string]string) (bool, error) {
+	c.consumed <- *model.(*string)
+
+	return true, nil
+}
+
+func (c *restartingConsumerCallback) Run(ctx context.Context) error {
+	if c.failing {
+		return fmt.Errorf("connection lost")
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
 type fakeModule struct{}
 
 func (m *fakeModule) Run(_ context.Context) error {
@@ -560,3 +890,19 @@ func (s *slowExitModule) Run(_ context.Context) error {
 
 	return nil
 }
+
+type readyModule struct {
+	ready   bool
+	stopped chan struct{}
+}
+
+func (m *readyModule) Run(ctx context.Context) error {
+	<-ctx.Done()
+	close(m.stopped)
+
+	return nil
+}
+
+func (m *readyModule) IsReady(_ context.Context) (bool, error) {
+	return m.ready, nil
+}
@@ -40,6 +40,7 @@ func (s *MiddleWareTestSuite) SetupTest() {
 		settings.HealthCheck.Timeout = time.Second
 		settings.HealthCheck.WaitInterval = time.Second
 	})
+	s.config.On("UnmarshalKey", mock.AnythingOfType("string"), mock.AnythingOfType("*kernel.RestartSettings"))
 
 	s.logger = new(logMocks.Logger)
 	s.logger.On("WithChannel", mock.Anything).Return(s.logger)
@@ -63,6 +63,47 @@ func (_c *Kernel_HealthCheck_Call) RunAndReturn(run func() kernel.HealthCheckRes
 	return _c
 }
 
+// ReadinessCheck provides a mock function with given fields:
+func (_m *Kernel) ReadinessCheck() kernel.ReadinessCheckResult {
+	ret := _m.Called()
+
+	var r0 kernel.ReadinessCheckResult
+	if rf, ok := ret.Get(0).(func() kernel.ReadinessCheckResult); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(kernel.ReadinessCheckResult)
+	}
+
+	return r0
+}
+
+// Kernel_ReadinessCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadinessCheck'
+type Kernel_ReadinessCheck_Call struct {
+	*mock.Call
+}
+
+// ReadinessCheck is a helper method to define mock.On call
+func (_e *Kernel_Expecter) ReadinessCheck() *Kernel_ReadinessCheck_Call {
+	return &Kernel_ReadinessCheck_Call{Call: _e.mock.On("ReadinessCheck")}
+}
+
+func (_c *Kernel_ReadinessCheck_Call) Run(run func()) *Kernel_ReadinessCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Kernel_ReadinessCheck_Call) Return(_a0 kernel.ReadinessCheckResult) *Kernel_ReadinessCheck_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Kernel_ReadinessCheck_Call) RunAndReturn(run func() kernel.ReadinessCheckResult) *Kernel_ReadinessCheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Run provides a mock function with given fields:
 func (_m *Kernel) Run() {
 	_m.Called()
@@ -2,6 +2,8 @@ package kernel
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/kernel/common"
@@ -61,14 +63,41 @@ func getModuleConfig(m Module) moduleConfig {
 
 // moduleState stores data needed for module management.
 type moduleState struct {
-	// module stores the core functionality of this module.
+	lck sync.RWMutex
+	// module stores the core functionality of this module. It is replaced on every restart, use getModule to access it.
 	module Module
+	// factory builds a new instance of the module when it is restarted.
+	factory func() (Module, error)
 	// config information regarding starting and stopping this module.
 	config moduleConfig
 	// isRunning is 1 if the module is still running, 0 otherwise. Access with atomic reads
 	isRunning int32
 	// Error obtained by running this module.
 	err error
+	// restarting is set while the module waits to be restarted by its supervisor.
+	restarting atomic.Pointer[restartStatus]
+}
+
+func (ms *moduleState) getModule() Module {
+	ms.lck.RLock()
+	defer ms.lck.RUnlock()
+
+	return ms.module
+}
+
+// rebuild replaces the module by a new instance built by its factory.
+func (ms *moduleState) rebuild() (Module, error) {
+	module, err := ms.factory()
+	if err != nil {
+		return nil, err
+	}
+
+	ms.lck.Lock()
+	defer ms.lck.Unlock()
+
+	ms.module = module
+
+	return module, nil
 }
 
 // moduleConfig stores attributes used in starting and stopping a module.
@@ -79,6 +108,10 @@ type moduleConfig struct {
 	background bool
 	// stage in which this module will be started.
 	stage int
+	// restartPolicy is the policy used if it isn't configured in the restart settings.
+	restartPolicy string
+	// restart configures if and how often the module is restarted after it stopped.
+	restart RestartSettings
 }
 
 func (mc moduleConfig) GetType() string {
@@ -155,6 +188,15 @@ type HealthCheckedModule interface {
 	IsHealthy(ctx context.Context) (bool, error)
 }
 
+// A ReadinessCheckedModule provides an interface to report if a module is able to do its work, e.g. it finished
+// warming its caches, running migrations or connecting its inputs. In contrast to the health check, a module
+// which is not ready doesn't prevent the application from booting, it is only reported by the readiness check.
+//
+//go:generate mockery --name ReadinessCheckedModule
+type ReadinessCheckedModule interface {
+	IsReady(ctx context.Context) (bool, error)
+}
+
 // A FullModule provides all the methods a module can have and thus never relies on defaults.
 //
 //go:generate mockery --name FullModule
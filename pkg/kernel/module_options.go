@@ -29,6 +29,16 @@ func ModuleStage(moduleStage int) ModuleOption {
 	}
 }
 
+// Restart a module after it stopped, e.g. a consumer which lost the connection to its broker. The policy is one of
+// RestartPolicyNever, RestartPolicyOnFailure and RestartPolicyAlways. The backoff and restart budget as well as the
+// policy itself can be configured at kernel.modules.<name>.restart. Every restart builds a new instance of the module
+// with its factory, as most modules (e.g. consumers and their inputs) can only be run once.
+func ModuleRestartPolicy(policy string) ModuleOption {
+	return func(ms *moduleConfig) {
+		ms.restartPolicy = policy
+	}
+}
+
 // Combine a list of options by applying them in order.
 func MergeOptions(options []ModuleOption) ModuleOption {
 	return func(ms *moduleConfig) {
@@ -0,0 +1,82 @@
+package kernel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/justtrackio/gosoline/pkg/appctx"
+)
+
+type ModuleReadinessCheckResult struct {
+	StageIndex int
+	Name       string
+	Ready      bool
+	Err        error
+}
+
+type StageReadinessCheckResult struct {
+	Index int
+	// Running is true as soon as all modules of the stage were started and got healthy
+	Running bool
+	Modules []ModuleReadinessCheckResult
+}
+
+func (r StageReadinessCheckResult) IsReady() bool {
+	if !r.Running {
+		return false
+	}
+
+	for _, m := range r.Modules {
+		if !m.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ReadinessCheckResult struct {
+	// Draining is true after the kernel was told to stop, the application should not receive any new work anymore
+	Draining bool
+	Stages   []StageReadinessCheckResult
+}
+
+func (r ReadinessCheckResult) IsReady() bool {
+	if r.Draining {
+		return false
+	}
+
+	for _, stage := range r.Stages {
+		if !stage.IsReady() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r ReadinessCheckResult) Err() error {
+	var err error
+
+	for _, stage := range r.Stages {
+		for _, m := range stage.Modules {
+			if m.Err != nil {
+				err = multierror.Append(err, fmt.Errorf("error during readiness check in module %s: %w", m.Name, m.Err))
+			}
+		}
+	}
+
+	return err
+}
+
+type (
+	ReadinessChecker        func() ReadinessCheckResult
+	readinessCheckerKeyType int
+)
+
+var readinessCheckerKey = readinessCheckerKeyType(0)
+
+func GetReadinessChecker(ctx context.Context) (ReadinessChecker, error) {
+	return appctx.Get[ReadinessChecker](ctx, readinessCheckerKey)
+}
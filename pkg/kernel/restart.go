@@ -0,0 +1,190 @@
+package kernel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/justtrackio/gosoline/pkg/coffin"
+	"github.com/justtrackio/gosoline/pkg/exec"
+)
+
+const (
+	RestartPolicyNever     = "never"
+	RestartPolicyOnFailure = "on_failure"
+	RestartPolicyAlways    = "always"
+)
+
+// RestartSettings configure the supervision of a module. They are read from kernel.modules.<name>.restart, the
+// policy can be provided with the ModuleRestartPolicy option as well.
+type RestartSettings struct {
+	// Policy decides if a module is restarted after it stopped: never, on_failure if it returned an error or panicked
+	// and always, even if it returned without an error
+	Policy string `cfg:"policy" default:"never" validate:"oneof=never on_failure always"`
+	// Backoff is used to wait between restarts. It is reset after the module ran for longer than its max interval.
+	Backoff exec.BackoffSettings `cfg:"backoff"`
+	// MaxRestarts is the number of restarts allowed in the Window before the module finally fails
+	MaxRestarts int           `cfg:"max_restarts" default:"5"`
+	Window      time.Duration `cfg:"window" default:"10m"`
+}
+
+// A RestartListener is notified whenever the kernel restarts a module.
+//
+//go:generate mockery --name RestartListener
+type RestartListener interface {
+	ModuleRestarted(stageIndex int, name string, restart int, err error)
+}
+
+type restartStatus struct {
+	restart int
+	err     error
+}
+
+type moduleSupervisor struct {
+	kernel   *kernel
+	name     string
+	ms       *moduleState
+	settings RestartSettings
+	backoff  *backoff.ExponentialBackOff
+	restarts []time.Time
+}
+
+func newModuleSupervisor(k *kernel, name string, ms *moduleState) *moduleSupervisor {
+	settings := ms.config.restart
+	expBackoff := exec.NewExponentialBackOff(&settings.Backoff)
+	expBackoff.Reset()
+
+	return &moduleSupervisor{
+		kernel:   k,
+		name:     name,
+		ms:       ms,
+		settings: settings,
+		backoff:  expBackoff,
+	}
+}
+
+func (s *moduleSupervisor) run(ctx context.Context) error {
+	for restarted := false; ; restarted = true {
+		started := time.Now()
+		err := s.runOnce(ctx, restarted)
+
+		if !s.shouldRestart(ctx, err) {
+			return err
+		}
+
+		if time.Since(started) > s.settings.Backoff.MaxInterval {
+			s.backoff.Reset()
+		}
+
+		if budgetErr := s.checkBudget(); budgetErr != nil {
+			return s.wrapErr(err, budgetErr)
+		}
+
+		wait := s.backoff.NextBackOff()
+		if wait == backoff.Stop {
+			return s.wrapErr(err, fmt.Errorf("module %s is restarting for longer than %s", s.name, s.settings.Backoff.MaxElapsedTime))
+		}
+
+		restart := len(s.restarts)
+		s.ms.restarting.Store(&restartStatus{
+			restart: restart,
+			err:     err,
+		})
+
+		s.kernel.logger.Warn("restarting %s module %s in %s (restart %d of %d in %s) after it stopped: %v", s.ms.config.GetType(), s.name, wait, restart, s.settings.MaxRestarts, s.settings.Window, err)
+
+		for _, listener := range s.kernel.restartListeners {
+			listener.ModuleRestarted(s.ms.config.stage, s.name, restart, err)
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.ms.restarting.Store(nil)
+
+			return err
+		case <-timer.C:
+		}
+
+		s.ms.restarting.Store(nil)
+	}
+}
+
+func (s *moduleSupervisor) runOnce(ctx context.Context, restarted bool) (err error) {
+	if s.settings.Policy == RestartPolicyNever {
+		return s.ms.getModule().Run(ctx)
+	}
+
+	// a panic should lead to a restart as well, so we have to recover from it already here
+	defer func() {
+		if panicErr := coffin.ResolveRecovery(recover()); panicErr != nil {
+			err = panicErr
+		}
+	}()
+
+	module := s.ms.getModule()
+
+	// the stopped instance can't be run again, e.g. the inputs of a consumer close their channels when they stop
+	if restarted {
+		if module, err = s.ms.rebuild(); err != nil {
+			return fmt.Errorf("can not rebuild module %s: %w", s.name, err)
+		}
+	}
+
+	return module.Run(ctx)
+}
+
+func (s *moduleSupervisor) shouldRestart(ctx context.Context, err error) bool {
+	// the module stopped because the kernel is stopping
+	if ctx.Err() != nil {
+		return false
+	}
+
+	switch s.settings.Policy {
+	case RestartPolicyAlways:
+		return true
+	case RestartPolicyOnFailure:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+func (s *moduleSupervisor) checkBudget() error {
+	now := time.Now()
+	recent := s.restarts[:0]
+
+	for _, restartedAt := range s.restarts {
+		if now.Sub(restartedAt) < s.settings.Window {
+			recent = append(recent, restartedAt)
+		}
+	}
+
+	s.restarts = recent
+
+	if len(s.restarts) >= s.settings.MaxRestarts {
+		return fmt.Errorf("module %s exceeded its budget of %d restarts in %s", s.name, s.settings.MaxRestarts, s.settings.Window)
+	}
+
+	s.restarts = append(s.restarts, now)
+
+	return nil
+}
+
+func (s *moduleSupervisor) wrapErr(err error, reason error) error {
+	if err == nil {
+		return reason
+	}
+
+	return fmt.Errorf("%s: %w", reason.Error(), err)
+}
+
+// isRestarting returns the status of the module if it is currently waiting for its restart.
+func (ms *moduleState) isRestarting() (*restartStatus, bool) {
+	status := ms.restarting.Load()
+
+	return status, status != nil
+}
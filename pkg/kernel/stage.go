@@ -1,10 +1,13 @@
 package kernel
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync/atomic"
 
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/clock"
@@ -33,6 +36,7 @@ type stage struct {
 	err                 error
 
 	running    conc.SignalOnce
+	booted     atomic.Bool
 	terminated conc.SignalOnce
 
 	modules modules
@@ -78,7 +82,7 @@ func (s *stage) run(k *kernel) error {
 				resultErr := k.runModule(s.ctx, name, ms)
 
 				if resultErr != nil {
-					k.Stop(fmt.Sprintf("module %s returned with an error", name))
+					k.stop(fmt.Sprintf("module %s returned with an error", name), false)
 				}
 
 				return resultErr
@@ -88,7 +92,13 @@ func (s *stage) run(k *kernel) error {
 
 	s.running.Signal()
 
-	return s.waitUntilHealthy()
+	if err := s.waitUntilHealthy(); err != nil {
+		return err
+	}
+
+	s.booted.Store(true)
+
+	return nil
 }
 
 func (s *stage) healthcheck() HealthCheckResult {
@@ -98,7 +108,19 @@ func (s *stage) healthcheck() HealthCheckResult {
 	var result HealthCheckResult
 
 	for name, ms := range s.modules.modules {
-		if healthAware, ok = ms.module.(HealthCheckedModule); !ok {
+		if status, restarting := ms.isRestarting(); restarting {
+			result = append(result, ModuleHealthCheckResult{
+				StageIndex: s.index,
+				Name:       name,
+				Healthy:    false,
+				Restarting: true,
+				Err:        fmt.Errorf("restarting (restart %d): %v", status.restart, status.err),
+			})
+
+			continue
+		}
+
+		if healthAware, ok = ms.getModule().(HealthCheckedModule); !ok {
 			continue
 		}
 
@@ -115,6 +137,38 @@ func (s *stage) healthcheck() HealthCheckResult {
 	return result
 }
 
+func (s *stage) readinesscheck() StageReadinessCheckResult {
+	var ok bool
+	var err error
+	var readinessAware ReadinessCheckedModule
+
+	result := StageReadinessCheckResult{
+		Index:   s.index,
+		Running: s.booted.Load(),
+	}
+
+	for name, ms := range s.modules.modules {
+		if readinessAware, ok = ms.getModule().(ReadinessCheckedModule); !ok {
+			continue
+		}
+
+		ok, err = readinessAware.IsReady(s.ctx)
+
+		result.Modules = append(result.Modules, ModuleReadinessCheckResult{
+			StageIndex: s.index,
+			Name:       name,
+			Ready:      ok,
+			Err:        err,
+		})
+	}
+
+	slices.SortFunc(result.Modules, func(a, b ModuleReadinessCheckResult) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return result
+}
+
 func (s *stage) waitUntilHealthy() error {
 	var result HealthCheckResult
 
@@ -0,0 +1,262 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/coffin"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/stream"
+)
+
+const configKeyConsumer = "lambda.consumer"
+
+// ConsumerSettings are read from lambda.consumer and describe how the records of an event are decoded.
+type ConsumerSettings struct {
+	// Encoding of the message bodies, the same as stream.consumer.<name>.encoding of a stream consumer
+	Encoding stream.EncodingType `cfg:"encoding" default:"application/json"`
+	// Unmarshaller used for the bodies of sqs records: msg, raw or sns if the queue is subscribed to a topic
+	// without raw message delivery
+	Unmarshaller string `cfg:"unmarshaller" default:"msg" validate:"oneof=msg raw sns"`
+}
+
+// Consumer runs a stream.ConsumerCallback for the records of sqs, sns and kinesis events. Every record is decoded
+// the same way a stream consumer would decode it, so existing callbacks (e.g. the one of a mdlsub subscriber) can
+// be used without any changes.
+type Consumer struct {
+	logger       log.Logger
+	callback     stream.ConsumerCallback
+	encoder      stream.MessageEncoder
+	unmarshaller stream.UnmarshallerFunc
+}
+
+// NewSqsHandler creates a handler for sqs events which reports the records the callback failed to consume as
+// batch item failures. You have to enable ReportBatchItemFailures on the event source mapping for this to work.
+func NewSqsHandler(callbackFactory stream.ConsumerCallbackFactory) HandlerFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (interface{}, error) {
+		consumer, err := NewConsumer(ctx, config, logger, callbackFactory)
+		if err != nil {
+			return nil, err
+		}
+
+		return consumer.HandleSqsEvent, nil
+	}
+}
+
+// NewSnsHandler creates a handler for sns events. As sns doesn't support partial batch responses, the invocation
+// fails if any of the records couldn't be consumed.
+func NewSnsHandler(callbackFactory stream.ConsumerCallbackFactory) HandlerFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (interface{}, error) {
+		consumer, err := NewConsumer(ctx, config, logger, callbackFactory)
+		if err != nil {
+			return nil, err
+		}
+
+		return consumer.HandleSnsEvent, nil
+	}
+}
+
+// NewKinesisHandler creates a handler for kinesis events which reports the records the callback failed to consume as
+// batch item failures. You have to enable ReportBatchItemFailures on the event source mapping for this to work.
+func NewKinesisHandler(callbackFactory stream.ConsumerCallbackFactory) HandlerFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (interface{}, error) {
+		consumer, err := NewConsumer(ctx, config, logger, callbackFactory)
+		if err != nil {
+			return nil, err
+		}
+
+		return consumer.HandleKinesisEvent, nil
+	}
+}
+
+func NewConsumer(ctx context.Context, config cfg.Config, logger log.Logger, callbackFactory stream.ConsumerCallbackFactory) (*Consumer, error) {
+	var err error
+	var callback stream.ConsumerCallback
+	var unmarshaller stream.UnmarshallerFunc
+
+	logger = logger.WithChannel("consumer")
+
+	settings := &ConsumerSettings{}
+	config.UnmarshalKey(configKeyConsumer, settings)
+
+	if unmarshaller, err = getUnmarshaller(settings.Unmarshaller); err != nil {
+		return nil, err
+	}
+
+	if callback, err = callbackFactory(ctx, config, logger.WithChannel("consumerCallback")); err != nil {
+		return nil, fmt.Errorf("can not initiate consumer callback: %w", err)
+	}
+
+	encoder := stream.NewMessageEncoder(&stream.MessageEncoderSettings{
+		Encoding: settings.Encoding,
+	})
+
+	return NewConsumerWithInterfaces(logger, callback, encoder, unmarshaller), nil
+}
+
+func NewConsumerWithInterfaces(logger log.Logger, callback stream.ConsumerCallback, encoder stream.MessageEncoder, unmarshaller stream.UnmarshallerFunc) *Consumer {
+	return &Consumer{
+		logger:       logger,
+		callback:     callback,
+		encoder:      encoder,
+		unmarshaller: unmarshaller,
+	}
+}
+
+// HandleSqsEvent consumes all records of the event and returns the ids of the failed ones. Records of a fifo queue
+// are consumed in order and all records following a failed one are reported as failed as well, so the order of
+// the messages of a group is kept.
+func (c *Consumer) HandleSqsEvent(ctx context.Context, event events.SQSEvent) (events.SQSEventResponse, error) {
+	response := events.SQSEventResponse{
+		BatchItemFailures: make([]events.SQSBatchItemFailure, 0),
+	}
+
+	failed := false
+
+	for _, record := range event.Records {
+		if failed && strings.HasSuffix(record.EventSourceARN, ".fifo") {
+			response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{
+				ItemIdentifier: record.MessageId,
+			})
+
+			continue
+		}
+
+		body := record.Body
+		msg, err := c.unmarshaller(&body)
+		if err == nil {
+			err = c.consume(ctx, msg)
+		}
+
+		if err != nil {
+			c.logger.Error("can not consume sqs message %s: %w", record.MessageId, err)
+
+			failed = true
+			response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{
+				ItemIdentifier: record.MessageId,
+			})
+		}
+	}
+
+	return response, nil
+}
+
+// HandleSnsEvent consumes all records of the event and returns an error if any of them failed, in which case sns
+// retries the delivery of the whole event.
+func (c *Consumer) HandleSnsEvent(ctx context.Context, event events.SNSEvent) error {
+	failed := 0
+
+	for _, record := range event.Records {
+		msg, err := stream.MessageUnmarshaller(&record.SNS.Message)
+		if err == nil {
+			err = c.consume(ctx, msg)
+		}
+
+		if err != nil {
+			c.logger.Error("can not consume sns message %s: %w", record.SNS.MessageID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("can not consume %d of %d sns messages", failed, len(event.Records))
+	}
+
+	return nil
+}
+
+// HandleKinesisEvent consumes all records of the event and returns the sequence numbers of the failed ones.
+func (c *Consumer) HandleKinesisEvent(ctx context.Context, event events.KinesisEvent) (events.KinesisEventResponse, error) {
+	response := events.KinesisEventResponse{
+		BatchItemFailures: make([]events.KinesisBatchItemFailure, 0),
+	}
+
+	for _, record := range event.Records {
+		msg := &stream.Message{}
+		err := msg.UnmarshalFromBytes(record.Kinesis.Data)
+		if err == nil {
+			err = c.consume(ctx, msg)
+		}
+
+		if err != nil {
+			c.logger.Error("can not consume kinesis record %s: %w", record.EventID, err)
+
+			response.BatchItemFailures = append(response.BatchItemFailures, events.KinesisBatchItemFailure{
+				ItemIdentifier: record.Kinesis.SequenceNumber,
+			})
+		}
+	}
+
+	return response, nil
+}
+
+// consume runs the callback for a message or for all messages of an aggregate. If a single message of an aggregate
+// fails, the whole aggregate is reported as failed and will be consumed again.
+func (c *Consumer) consume(ctx context.Context, msg *stream.Message) error {
+	if _, ok := msg.Attributes[stream.AttributeAggregate]; !ok {
+		return c.consumeMessage(ctx, msg)
+	}
+
+	var err error
+	batch := make([]*stream.Message, 0)
+
+	if _, _, err = c.encoder.Decode(ctx, msg, &batch); err != nil {
+		return fmt.Errorf("can not disaggregate the message: %w", err)
+	}
+
+	for i, m := range batch {
+		if err = c.consumeMessage(ctx, m); err != nil {
+			return fmt.Errorf("can not consume message %d of the aggregate: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Consumer) consumeMessage(ctx context.Context, msg *stream.Message) (err error) {
+	defer func() {
+		if panicErr := coffin.ResolveRecovery(recover()); panicErr != nil {
+			err = panicErr
+		}
+	}()
+
+	var ack bool
+	var model interface{}
+	var attributes map[string]string
+
+	if model = c.callback.GetModel(msg.Attributes); model == nil {
+		return fmt.Errorf("can not get model for message attributes %v", msg.Attributes)
+	}
+
+	if ctx, attributes, err = c.encoder.Decode(ctx, msg, model); err != nil {
+		return fmt.Errorf("can not decode message: %w", err)
+	}
+
+	ctx = log.InitContext(ctx)
+
+	if ack, err = c.callback.Consume(ctx, model, attributes); err != nil {
+		return fmt.Errorf("can not consume message: %w", err)
+	}
+
+	if !ack {
+		return fmt.Errorf("the message was not acknowledged by the callback")
+	}
+
+	return nil
+}
+
+func getUnmarshaller(name string) (stream.UnmarshallerFunc, error) {
+	switch name {
+	case stream.UnmarshallerMsg:
+		return stream.MessageUnmarshaller, nil
+	case stream.UnmarshallerRaw:
+		return stream.RawUnmarshaller, nil
+	case stream.UnmarshallerSns:
+		return stream.SnsUnmarshaller, nil
+	default:
+		return nil, fmt.Errorf("unknown unmarshaller %s", name)
+	}
+}
@@ -0,0 +1,182 @@
+package lambda_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/justtrackio/gosoline/pkg/lambda"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/stream"
+	"github.com/justtrackio/gosoline/pkg/stream/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type consumerTestModel struct {
+	Id int `json:"id"`
+}
+
+func TestConsumerTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsumerTestSuite))
+}
+
+type ConsumerTestSuite struct {
+	suite.Suite
+
+	ctx      context.Context
+	encoder  stream.MessageEncoder
+	callback *mocks.ConsumerCallback
+	consumer *lambda.Consumer
+}
+
+func (s *ConsumerTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.encoder = stream.NewMessageEncoder(&stream.MessageEncoderSettings{})
+	s.callback = mocks.NewConsumerCallback(s.T())
+	s.callback.EXPECT().GetModel(mock.Anything).RunAndReturn(func(map[string]string) interface{} {
+		return &consumerTestModel{}
+	}).Maybe()
+
+	s.consumer = lambda.NewConsumerWithInterfaces(logMocks.NewLoggerMockedAll(), s.callback, s.encoder, stream.MessageUnmarshaller)
+}
+
+func (s *ConsumerTestSuite) TestSqsPartialBatchFailure() {
+	s.expectConsume(1, true, nil)
+	s.expectConsume(2, false, nil)
+	s.expectConsume(3, false, fmt.Errorf("broken"))
+
+	response, err := s.consumer.HandleSqsEvent(s.ctx, events.SQSEvent{
+		Records: []events.SQSMessage{
+			{MessageId: "1", Body: s.encode(1)},
+			{MessageId: "2", Body: s.encode(2)},
+			{MessageId: "3", Body: s.encode(3)},
+			{MessageId: "4", Body: "not a message"},
+		},
+	})
+
+	s.NoError(err)
+	s.Equal([]events.SQSBatchItemFailure{
+		{ItemIdentifier: "2"},
+		{ItemIdentifier: "3"},
+		{ItemIdentifier: "4"},
+	}, response.BatchItemFailures)
+}
+
+func (s *ConsumerTestSuite) TestSqsFifoFailsRemainingRecords() {
+	s.expectConsume(1, false, fmt.Errorf("broken"))
+
+	response, err := s.consumer.HandleSqsEvent(s.ctx, events.SQSEvent{
+		Records: []events.SQSMessage{
+			{MessageId: "1", Body: s.encode(1), EventSourceARN: "arn:aws:sqs:eu-central-1:123456789012:queue.fifo"},
+			{MessageId: "2", Body: s.encode(2), EventSourceARN: "arn:aws:sqs:eu-central-1:123456789012:queue.fifo"},
+		},
+	})
+
+	s.NoError(err)
+	s.Equal([]events.SQSBatchItemFailure{
+		{ItemIdentifier: "1"},
+		{ItemIdentifier: "2"},
+	}, response.BatchItemFailures)
+}
+
+func (s *ConsumerTestSuite) TestSqsAggregate() {
+	s.expectConsume(1, true, nil)
+	s.expectConsume(2, true, nil)
+
+	first, err := s.encoder.Encode(s.ctx, &consumerTestModel{Id: 1})
+	s.NoError(err)
+
+	second, err := s.encoder.Encode(s.ctx, &consumerTestModel{Id: 2})
+	s.NoError(err)
+
+	aggregate, err := s.encoder.Encode(s.ctx, []*stream.Message{first, second}, map[string]string{
+		stream.AttributeAggregate: "true",
+	})
+	s.NoError(err)
+
+	body, err := aggregate.MarshalToString()
+	s.NoError(err)
+
+	response, err := s.consumer.HandleSqsEvent(s.ctx, events.SQSEvent{
+		Records: []events.SQSMessage{
+			{MessageId: "1", Body: body},
+		},
+	})
+
+	s.NoError(err)
+	s.Empty(response.BatchItemFailures)
+}
+
+func (s *ConsumerTestSuite) TestSqsPanic() {
+	s.callback.EXPECT().Consume(mock.Anything, &consumerTestModel{Id: 1}, mock.Anything).Run(func(context.Context, interface{}, map[string]string) {
+		panic("boom")
+	}).Once()
+
+	response, err := s.consumer.HandleSqsEvent(s.ctx, events.SQSEvent{
+		Records: []events.SQSMessage{
+			{MessageId: "1", Body: s.encode(1)},
+		},
+	})
+
+	s.NoError(err)
+	s.Equal([]events.SQSBatchItemFailure{{ItemIdentifier: "1"}}, response.BatchItemFailures)
+}
+
+func (s *ConsumerTestSuite) TestSns() {
+	s.expectConsume(1, true, nil)
+	s.expectConsume(2, true, nil)
+
+	err := s.consumer.HandleSnsEvent(s.ctx, events.SNSEvent{
+		Records: []events.SNSEventRecord{
+			{SNS: events.SNSEntity{MessageID: "1", Message: s.encode(1)}},
+			{SNS: events.SNSEntity{MessageID: "2", Message: s.encode(2)}},
+		},
+	})
+
+	s.NoError(err)
+}
+
+func (s *ConsumerTestSuite) TestSnsFailure() {
+	s.expectConsume(1, true, nil)
+	s.expectConsume(2, false, nil)
+
+	err := s.consumer.HandleSnsEvent(s.ctx, events.SNSEvent{
+		Records: []events.SNSEventRecord{
+			{SNS: events.SNSEntity{MessageID: "1", Message: s.encode(1)}},
+			{SNS: events.SNSEntity{MessageID: "2", Message: s.encode(2)}},
+		},
+	})
+
+	s.EqualError(err, "can not consume 1 of 2 sns messages")
+}
+
+func (s *ConsumerTestSuite) TestKinesisPartialBatchFailure() {
+	s.expectConsume(1, true, nil)
+	s.expectConsume(2, false, fmt.Errorf("broken"))
+
+	response, err := s.consumer.HandleKinesisEvent(s.ctx, events.KinesisEvent{
+		Records: []events.KinesisEventRecord{
+			{EventID: "shard-1:1", Kinesis: events.KinesisRecord{SequenceNumber: "1", Data: []byte(s.encode(1))}},
+			{EventID: "shard-1:2", Kinesis: events.KinesisRecord{SequenceNumber: "2", Data: []byte(s.encode(2))}},
+		},
+	})
+
+	s.NoError(err)
+	s.Equal([]events.KinesisBatchItemFailure{{ItemIdentifier: "2"}}, response.BatchItemFailures)
+}
+
+func (s *ConsumerTestSuite) expectConsume(id int, ack bool, err error) {
+	s.callback.EXPECT().Consume(mock.Anything, &consumerTestModel{Id: id}, mock.Anything).Return(ack, err).Once()
+}
+
+func (s *ConsumerTestSuite) encode(id int) string {
+	msg, err := s.encoder.Encode(s.ctx, &consumerTestModel{Id: id})
+	s.NoError(err)
+
+	data, err := msg.MarshalToString()
+	s.NoError(err)
+
+	return data
+}
@@ -0,0 +1,300 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/justtrackio/gosoline/pkg/apiserver"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+// Gateway serves api gateway and application load balancer events with the gin router of an apiserver.Definer,
+// so the same routes can be run by an ApiServer module or inside a lambda function.
+type Gateway struct {
+	handler http.Handler
+}
+
+// NewApiGatewayHandler creates a handler for the proxy integration of a REST api gateway.
+func NewApiGatewayHandler(definer apiserver.Definer) HandlerFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (interface{}, error) {
+		gateway, err := NewGateway(ctx, config, logger, definer)
+		if err != nil {
+			return nil, err
+		}
+
+		return gateway.HandleApiGatewayEvent, nil
+	}
+}
+
+// NewApiGatewayV2Handler creates a handler for the payload format 2.0 of a HTTP api gateway.
+func NewApiGatewayV2Handler(definer apiserver.Definer) HandlerFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (interface{}, error) {
+		gateway, err := NewGateway(ctx, config, logger, definer)
+		if err != nil {
+			return nil, err
+		}
+
+		return gateway.HandleApiGatewayV2Event, nil
+	}
+}
+
+// NewAlbHandler creates a handler for lambda functions registered as target of an application load balancer.
+func NewAlbHandler(definer apiserver.Definer) HandlerFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (interface{}, error) {
+		gateway, err := NewGateway(ctx, config, logger, definer)
+		if err != nil {
+			return nil, err
+		}
+
+		return gateway.HandleAlbEvent, nil
+	}
+}
+
+func NewGateway(ctx context.Context, config cfg.Config, logger log.Logger, definer apiserver.Definer) (*Gateway, error) {
+	router, err := apiserver.NewRouter(ctx, config, logger, definer)
+	if err != nil {
+		return nil, fmt.Errorf("can not create router: %w", err)
+	}
+
+	return NewGatewayWithInterfaces(router), nil
+}
+
+func NewGatewayWithInterfaces(handler http.Handler) *Gateway {
+	return &Gateway{
+		handler: handler,
+	}
+}
+
+func (g *Gateway) HandleApiGatewayEvent(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	query := event.MultiValueQueryStringParameters
+	if len(query) == 0 {
+		query = toMultiValue(event.QueryStringParameters)
+	}
+
+	headers := event.MultiValueHeaders
+	if len(headers) == 0 {
+		headers = toMultiValue(event.Headers)
+	}
+
+	request, err := newRequest(ctx, event.HTTPMethod, event.Path, url.Values(query).Encode(), headers, event.Body, event.IsBase64Encoded)
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, err
+	}
+
+	request.RemoteAddr = event.RequestContext.Identity.SourceIP
+	response := g.serve(request)
+	body, isBase64Encoded := response.encodeBody()
+
+	return events.APIGatewayProxyResponse{
+		StatusCode:        response.status,
+		MultiValueHeaders: response.header,
+		Body:              body,
+		IsBase64Encoded:   isBase64Encoded,
+	}, nil
+}
+
+func (g *Gateway) HandleApiGatewayV2Event(ctx context.Context, event events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	headers := toMultiValue(event.Headers)
+	if len(event.Cookies) > 0 {
+		headers["Cookie"] = []string{strings.Join(event.Cookies, "; ")}
+	}
+
+	request, err := newRequest(ctx, event.RequestContext.HTTP.Method, event.RawPath, event.RawQueryString, headers, event.Body, event.IsBase64Encoded)
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+
+	request.RemoteAddr = event.RequestContext.HTTP.SourceIP
+	response := g.serve(request)
+	body, isBase64Encoded := response.encodeBody()
+
+	// cookies have to be returned separately as multiple set-cookie headers can't be joined by a comma
+	cookies := response.header.Values("Set-Cookie")
+	response.header.Del("Set-Cookie")
+
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode:      response.status,
+		Headers:         fromMultiValue(response.header),
+		Body:            body,
+		IsBase64Encoded: isBase64Encoded,
+		Cookies:         cookies,
+	}, nil
+}
+
+// HandleAlbEvent answers with multi value headers if they are enabled for the target group, which is detected by
+// the request containing multi value headers as well.
+func (g *Gateway) HandleAlbEvent(ctx context.Context, event events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+	multiValue := len(event.MultiValueHeaders) > 0
+
+	query := event.MultiValueQueryStringParameters
+	if len(query) == 0 {
+		query = toMultiValue(event.QueryStringParameters)
+	}
+
+	headers := event.MultiValueHeaders
+	if !multiValue {
+		headers = toMultiValue(event.Headers)
+	}
+
+	// the load balancer passes the query parameters as they were received, so they are already url encoded
+	request, err := newRequest(ctx, event.HTTPMethod, event.Path, rawQuery(query), headers, event.Body, event.IsBase64Encoded)
+	if err != nil {
+		return events.ALBTargetGroupResponse{}, err
+	}
+
+	response := g.serve(request)
+	body, isBase64Encoded := response.encodeBody()
+
+	albResponse := events.ALBTargetGroupResponse{
+		StatusCode:        response.status,
+		StatusDescription: fmt.Sprintf("%d %s", response.status, http.StatusText(response.status)),
+		Body:              body,
+		IsBase64Encoded:   isBase64Encoded,
+	}
+
+	if multiValue {
+		albResponse.MultiValueHeaders = response.header
+	} else {
+		albResponse.Headers = fromMultiValue(response.header)
+	}
+
+	return albResponse, nil
+}
+
+func (g *Gateway) serve(request *http.Request) *responseWriter {
+	response := newResponseWriter()
+	g.handler.ServeHTTP(response, request)
+
+	return response
+}
+
+func newRequest(ctx context.Context, method string, path string, query string, headers map[string][]string, body string, isBase64Encoded bool) (*http.Request, error) {
+	var err error
+	var requestBody []byte
+
+	if isBase64Encoded {
+		if requestBody, err = base64.StdEncoding.DecodeString(body); err != nil {
+			return nil, fmt.Errorf("can not decode base64 encoded request body: %w", err)
+		}
+	} else {
+		requestBody = []byte(body)
+	}
+
+	target := &url.URL{
+		Path:     path,
+		RawQuery: query,
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("can not create request: %w", err)
+	}
+
+	for key, values := range headers {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+
+	request.Host = request.Header.Get("Host")
+	request.RequestURI = target.RequestURI()
+
+	return request, nil
+}
+
+func toMultiValue(values map[string]string) map[string][]string {
+	multiValues := make(map[string][]string, len(values))
+
+	for key, value := range values {
+		multiValues[key] = []string{value}
+	}
+
+	return multiValues
+}
+
+func fromMultiValue(values map[string][]string) map[string]string {
+	singleValues := make(map[string]string, len(values))
+
+	for key, value := range values {
+		singleValues[key] = strings.Join(value, ",")
+	}
+
+	return singleValues
+}
+
+func rawQuery(values map[string][]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	parts := make([]string, 0, len(values))
+
+	for _, key := range keys {
+		for _, value := range values[key] {
+			parts = append(parts, key+"="+value)
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+type responseWriter struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+var _ http.ResponseWriter = &responseWriter{}
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{
+		header: make(http.Header),
+	}
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.body.Write(data)
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.status != 0 {
+		return
+	}
+
+	w.status = statusCode
+}
+
+// encodeBody returns the body of the response and encodes it with base64 if it isn't valid utf-8, e.g. images or
+// compressed responses.
+func (w *responseWriter) encodeBody() (string, bool) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	body := w.body.Bytes()
+
+	if utf8.Valid(body) && w.header.Get("Content-Encoding") == "" {
+		return string(body), false
+	}
+
+	return base64.StdEncoding.EncodeToString(body), true
+}
@@ -0,0 +1,126 @@
+package lambda_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gin-gonic/gin"
+	"github.com/justtrackio/gosoline/pkg/lambda"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGateway() *lambda.Gateway {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.POST("/echo/:name", func(ginCtx *gin.Context) {
+		body, _ := io.ReadAll(ginCtx.Request.Body)
+		cookie, _ := ginCtx.Cookie("session")
+
+		ginCtx.SetCookie("first", "1", 0, "/", "", false, false)
+		ginCtx.SetCookie("second", "2", 0, "/", "", false, false)
+		ginCtx.JSON(http.StatusCreated, gin.H{
+			"name":    ginCtx.Param("name"),
+			"filter":  ginCtx.QueryArray("filter"),
+			"header":  ginCtx.GetHeader("X-Custom"),
+			"cookie":  cookie,
+			"body":    string(body),
+			"address": ginCtx.Request.RemoteAddr,
+		})
+	})
+
+	router.GET("/binary", func(ginCtx *gin.Context) {
+		ginCtx.Data(http.StatusOK, "image/png", []byte{0x89, 0x50, 0x4e, 0x47, 0xff})
+	})
+
+	return lambda.NewGatewayWithInterfaces(router)
+}
+
+func TestGateway_ApiGateway(t *testing.T) {
+	gateway := newTestGateway()
+
+	response, err := gateway.HandleApiGatewayEvent(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodPost,
+		Path:       "/echo/gosoline",
+		MultiValueQueryStringParameters: map[string][]string{
+			"filter": {"a b", "c"},
+		},
+		Headers: map[string]string{
+			"X-Custom": "custom",
+			"Cookie":   "session=abc",
+		},
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Identity: events.APIGatewayRequestIdentity{SourceIP: "10.0.0.1"},
+		},
+		Body:            base64.StdEncoding.EncodeToString([]byte("payload")),
+		IsBase64Encoded: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.False(t, response.IsBase64Encoded)
+	assert.Equal(t, []string{"first=1; Path=/", "second=2; Path=/"}, response.MultiValueHeaders["Set-Cookie"])
+	assert.JSONEq(t, `{"name": "gosoline", "filter": ["a b", "c"], "header": "custom", "cookie": "abc", "body": "payload", "address": "10.0.0.1"}`, response.Body)
+}
+
+func TestGateway_ApiGatewayV2(t *testing.T) {
+	gateway := newTestGateway()
+
+	response, err := gateway.HandleApiGatewayV2Event(context.Background(), events.APIGatewayV2HTTPRequest{
+		RawPath:        "/echo/gosoline",
+		RawQueryString: "filter=a+b&filter=c",
+		Cookies:        []string{"session=abc", "other=1"},
+		Headers: map[string]string{
+			"x-custom": "custom",
+		},
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method:   http.MethodPost,
+				SourceIP: "10.0.0.1",
+			},
+		},
+		Body: "payload",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.Equal(t, []string{"first=1; Path=/", "second=2; Path=/"}, response.Cookies)
+	assert.NotContains(t, response.Headers, "Set-Cookie")
+	assert.Equal(t, "application/json; charset=utf-8", response.Headers["Content-Type"])
+	assert.JSONEq(t, `{"name": "gosoline", "filter": ["a b", "c"], "header": "custom", "cookie": "abc", "body": "payload", "address": "10.0.0.1"}`, response.Body)
+}
+
+func TestGateway_Alb(t *testing.T) {
+	gateway := newTestGateway()
+
+	response, err := gateway.HandleAlbEvent(context.Background(), events.ALBTargetGroupRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/binary",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "200 OK", response.StatusDescription)
+	assert.Equal(t, "image/png", response.Headers["Content-Type"])
+	assert.Nil(t, response.MultiValueHeaders)
+	assert.True(t, response.IsBase64Encoded)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte{0x89, 0x50, 0x4e, 0x47, 0xff}), response.Body)
+
+	response, err = gateway.HandleAlbEvent(context.Background(), events.ALBTargetGroupRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/missing",
+		MultiValueHeaders: map[string][]string{
+			"accept": {"application/json"},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.Equal(t, "404 Not Found", response.StatusDescription)
+	assert.NotNil(t, response.MultiValueHeaders)
+	assert.Nil(t, response.Headers)
+}
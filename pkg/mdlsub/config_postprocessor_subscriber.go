@@ -71,6 +71,10 @@ func SubscriberConfigPostProcessor(config cfg.GosoConf) (bool, error) {
 			configOptions = append(configOptions, outputOption)
 		}
 
+		if subscriberSettings.Bootstrap.Enabled {
+			configOptions = append(configOptions, bootstrapSubscriberConfigPostProcessor(config))
+		}
+
 		if err := config.Option(configOptions...); err != nil {
 			return false, fmt.Errorf("can not apply config settings for subscriber %s: %w", name, err)
 		}
@@ -79,6 +83,18 @@ func SubscriberConfigPostProcessor(config cfg.GosoConf) (bool, error) {
 	return true, nil
 }
 
+func bootstrapSubscriberConfigPostProcessor(config cfg.GosoConf) cfg.Option {
+	kvstoreKey := kvstore.GetConfigurableKey(KvStoreNameBootstrap)
+
+	kvstoreSettings := &kvstore.ChainConfiguration{}
+	config.UnmarshalDefaults(kvstoreSettings)
+
+	// the state has to survive restarts and is read only once per start, so there is no need for a cache in front
+	kvstoreSettings.Elements = []string{kvstore.TypeDdb}
+
+	return cfg.WithConfigSetting(kvstoreKey, kvstoreSettings, cfg.SkipExisting)
+}
+
 func snsSubscriberInputConfigPostProcessor(config cfg.GosoConf, name string, subscriberSettings *SubscriberSettings) cfg.Option {
 	inputKey := getInputConfigKey(name, subscriberSettings.SourceModel)
 	consumerId := subscriberSettings.SourceModel.Name
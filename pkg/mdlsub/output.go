@@ -8,6 +8,7 @@ import (
 	"github.com/justtrackio/gosoline/pkg/log"
 )
 
+//go:generate mockery --name Output
 type Output interface {
 	Persist(ctx context.Context, model Model, op string) error
 }
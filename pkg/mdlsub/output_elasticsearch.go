@@ -0,0 +1,71 @@
+package mdlsub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/es"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+const (
+	OutputTypeElasticsearch = "elasticsearch"
+)
+
+func init() {
+	outputFactories[OutputTypeElasticsearch] = outputElasticsearchFactory
+}
+
+func outputElasticsearchFactory(_ context.Context, config cfg.Config, logger log.Logger, settings *SubscriberSettings, transformers VersionedModelTransformers) (map[int]Output, error) {
+	var err error
+	outputs := make(map[int]Output)
+
+	for version := range transformers {
+		if outputs[version], err = NewOutputElasticsearch(config, logger, settings); err != nil {
+			return nil, fmt.Errorf("can not create output: %w", err)
+		}
+	}
+
+	return outputs, nil
+}
+
+type OutputElasticsearch struct {
+	logger     log.Logger
+	repository es.Repository[Model]
+}
+
+func NewOutputElasticsearch(config cfg.Config, logger log.Logger, settings *SubscriberSettings) (*OutputElasticsearch, error) {
+	repository, err := es.NewRepository[Model](config, logger, settings.TargetModel.Name)
+	if err != nil {
+		return nil, fmt.Errorf("can not create es repository: %w", err)
+	}
+
+	return NewOutputElasticsearchWithInterfaces(logger, repository), nil
+}
+
+func NewOutputElasticsearchWithInterfaces(logger log.Logger, repository es.Repository[Model]) *OutputElasticsearch {
+	return &OutputElasticsearch{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+func (p *OutputElasticsearch) Persist(ctx context.Context, model Model, op string) error {
+	var err error
+	id := fmt.Sprint(model.GetId())
+
+	switch op {
+	case TypeCreate, TypeUpdate:
+		err = p.repository.Index(ctx, id, model)
+	case TypeDelete:
+		// the document might have never been indexed, e.g. if it was created and deleted before we subscribed
+		if err = p.repository.Delete(ctx, id); es.IsNotFound(err) {
+			err = nil
+		}
+	default:
+		err = fmt.Errorf("unknown operation %s in OutputElasticsearch", op)
+	}
+
+	return err
+}
@@ -26,6 +26,8 @@ type PublisherSettings struct {
 	Producer   string `cfg:"producer" validate:"required_without=OutputType"`
 	OutputType string `cfg:"output_type" validate:"required_without=Producer"`
 	Shared     bool   `cfg:"shared"`
+	// Snapshot configures exports of all current models, see NewSnapshotExporter
+	Snapshot SnapshotSettings `cfg:"snapshot"`
 }
 
 //go:generate mockery --name Publisher
@@ -0,0 +1,316 @@
+package mdlsub
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/blob"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/log/status"
+	"github.com/justtrackio/gosoline/pkg/mdl"
+)
+
+const (
+	// AttributeSnapshot marks messages published by a snapshot export with the id of the snapshot.
+	AttributeSnapshot = "snapshot"
+
+	SnapshotTypeBlob     = "blob"
+	SnapshotTypeProducer = "producer"
+)
+
+type SnapshotSettings struct {
+	// Type selects where snapshots are exported to: blob dumps all models as json lines into the blob store, producer
+	// publishes all models as update messages through the producer, e.g. a dedicated bootstrap output
+	Type string `cfg:"type" default:"blob" validate:"oneof=blob producer"`
+	// Producer is used by exports of type producer, it defaults to the producer of the publisher
+	Producer string `cfg:"producer"`
+	// Store is the blob store used by exports of type blob
+	Store string `cfg:"store" default:"mdlsub_snapshots"`
+	// PartSize is the number of models written into a single blob of the snapshot
+	PartSize int `cfg:"part_size" default:"10000" validate:"min=1"`
+}
+
+// SnapshotManifest describes the latest snapshot of a model in a specific version in the blob store. It is written
+// after all parts, so subscribers never see an incomplete snapshot.
+type SnapshotManifest struct {
+	Id        string    `json:"id"`
+	ModelId   string    `json:"modelId"`
+	Version   int       `json:"version"`
+	Parts     int       `json:"parts"`
+	PartSize  int       `json:"partSize"`
+	Count     int       `json:"count"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (m *SnapshotManifest) PartKey(part int) string {
+	return fmt.Sprintf("%s/%d/%s/part-%05d.jsonl", snapshotKeyPrefix(m.ModelId), m.Version, m.Id, part)
+}
+
+func SnapshotManifestKey(modelId string, version int) string {
+	return fmt.Sprintf("%s/%d/latest.json", snapshotKeyPrefix(modelId), version)
+}
+
+func snapshotKeyPrefix(modelId string) string {
+	return fmt.Sprintf("mdlsub-snapshots/%s", modelId)
+}
+
+// SnapshotSource provides all current models of the publisher, e.g. by scanning the table of the models.
+//
+//go:generate mockery --name SnapshotSource
+type SnapshotSource interface {
+	// Scan calls fn with batches of all models in the version the snapshot is exported in.
+	Scan(ctx context.Context, fn func([]interface{}) error) error
+}
+
+type SnapshotSourceFactory func(ctx context.Context, config cfg.Config, logger log.Logger) (SnapshotSource, error)
+
+//go:generate mockery --name SnapshotExporter
+type SnapshotExporter interface {
+	// Export writes all models of the source as snapshot in the given version and returns the number of models.
+	Export(ctx context.Context, version int, source SnapshotSource) (int, error)
+}
+
+// NewSnapshotExporter creates the exporter configured at mdlsub.publishers.<name>.snapshot.
+func NewSnapshotExporter(ctx context.Context, config cfg.Config, logger log.Logger, name string) (SnapshotExporter, error) {
+	settings := readPublisherSetting(config, name)
+	logger = logger.WithChannel("mdlsub_snapshot")
+
+	switch settings.Snapshot.Type {
+	case SnapshotTypeProducer:
+		if settings.Snapshot.Producer != "" {
+			settings.Producer = settings.Snapshot.Producer
+		}
+
+		publisher, err := NewPublisherWithSettings(ctx, config, logger, settings)
+		if err != nil {
+			return nil, fmt.Errorf("can not create snapshot publisher: %w", err)
+		}
+
+		return NewSnapshotProducerExporterWithInterfaces(logger, publisher, clock.Provider, status.ProvideManager(), settings), nil
+	case SnapshotTypeBlob:
+		store, err := blob.NewStore(ctx, config, logger, settings.Snapshot.Store)
+		if err != nil {
+			return nil, fmt.Errorf("can not create snapshot blob store: %w", err)
+		}
+
+		return NewSnapshotBlobExporterWithInterfaces(logger, store, clock.Provider, status.ProvideManager(), settings), nil
+	default:
+		return nil, fmt.Errorf("unknown snapshot type %s for publisher %s", settings.Snapshot.Type, name)
+	}
+}
+
+type snapshotProducerExporter struct {
+	logger        log.Logger
+	publisher     Publisher
+	clock         clock.Clock
+	statusManager status.Manager
+	settings      *PublisherSettings
+}
+
+func NewSnapshotProducerExporterWithInterfaces(logger log.Logger, publisher Publisher, clock clock.Clock, statusManager status.Manager, settings *PublisherSettings) SnapshotExporter {
+	return &snapshotProducerExporter{
+		logger:        logger,
+		publisher:     publisher,
+		clock:         clock,
+		statusManager: statusManager,
+		settings:      settings,
+	}
+}
+
+// Export publishes every model as update, which all outputs persist as upsert.
+func (e *snapshotProducerExporter) Export(ctx context.Context, version int, source SnapshotSource) (int, error) {
+	count := 0
+	attributes := map[string]string{
+		AttributeSnapshot: snapshotId(e.clock.Now()),
+	}
+
+	err := e.statusManager.MonitorWithContext(snapshotWorkKey(e.settings.ModelId), func(ctx context.Context) error {
+		return source.Scan(ctx, func(models []interface{}) error {
+			if err := e.publisher.PublishBatch(ctx, TypeUpdate, version, models, attributes); err != nil {
+				return err
+			}
+
+			count += len(models)
+			e.logger.Info("published %d models of the snapshot of %s in version %d", count, e.settings.ModelId.String(), version)
+
+			return nil
+		})
+	})(ctx)
+	if err != nil {
+		return count, fmt.Errorf("can not export snapshot of %s: %w", e.settings.ModelId.String(), err)
+	}
+
+	return count, nil
+}
+
+type snapshotBlobExporter struct {
+	logger        log.Logger
+	store         blob.Store
+	clock         clock.Clock
+	statusManager status.Manager
+	settings      *PublisherSettings
+}
+
+func NewSnapshotBlobExporterWithInterfaces(logger log.Logger, store blob.Store, clock clock.Clock, statusManager status.Manager, settings *PublisherSettings) SnapshotExporter {
+	return &snapshotBlobExporter{
+		logger:        logger,
+		store:         store,
+		clock:         clock,
+		statusManager: statusManager,
+		settings:      settings,
+	}
+}
+
+func (e *snapshotBlobExporter) Export(ctx context.Context, version int, source SnapshotSource) (int, error) {
+	createdAt := e.clock.Now().UTC()
+	manifest := &SnapshotManifest{
+		Id:        snapshotId(createdAt),
+		ModelId:   e.settings.ModelId.String(),
+		Version:   version,
+		PartSize:  e.settings.Snapshot.PartSize,
+		CreatedAt: createdAt,
+	}
+
+	buffer := &bytes.Buffer{}
+	buffered := 0
+
+	flush := func() error {
+		if buffered == 0 {
+			return nil
+		}
+
+		if err := e.write(manifest.PartKey(manifest.Parts), buffer.Bytes()); err != nil {
+			return fmt.Errorf("can not write part %d: %w", manifest.Parts, err)
+		}
+
+		manifest.Parts++
+		manifest.Count += buffered
+		e.logger.Info("wrote part %d with %d models of the snapshot of %s in version %d", manifest.Parts, buffered, manifest.ModelId, version)
+
+		buffer = &bytes.Buffer{}
+		buffered = 0
+
+		return nil
+	}
+
+	err := e.statusManager.MonitorWithContext(snapshotWorkKey(e.settings.ModelId), func(ctx context.Context) error {
+		err := source.Scan(ctx, func(models []interface{}) error {
+			for _, model := range models {
+				line, err := json.Marshal(model)
+				if err != nil {
+					return fmt.Errorf("can not marshal model: %w", err)
+				}
+
+				buffer.Write(line)
+				buffer.WriteByte('\n')
+				buffered++
+
+				if buffered < manifest.PartSize {
+					continue
+				}
+
+				if err = flush(); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = flush(); err != nil {
+			return err
+		}
+
+		body, err := json.Marshal(manifest)
+		if err != nil {
+			return fmt.Errorf("can not marshal manifest: %w", err)
+		}
+
+		if err = e.write(SnapshotManifestKey(manifest.ModelId, version), body); err != nil {
+			return fmt.Errorf("can not write manifest: %w", err)
+		}
+
+		return nil
+	})(ctx)
+	if err != nil {
+		return manifest.Count, fmt.Errorf("can not export snapshot of %s: %w", manifest.ModelId, err)
+	}
+
+	e.logger.Info("exported snapshot %s of %s in version %d with %d models", manifest.Id, manifest.ModelId, version, manifest.Count)
+
+	return manifest.Count, nil
+}
+
+func (e *snapshotBlobExporter) write(key string, body []byte) error {
+	return e.store.WriteOne(&blob.Object{
+		Key:         mdl.Box(key),
+		Body:        blob.StreamBytes(body),
+		ContentType: mdl.Box("application/x-ndjson"),
+	})
+}
+
+func snapshotId(createdAt time.Time) string {
+	return createdAt.UTC().Format("20060102T150405Z")
+}
+
+func snapshotWorkKey(modelId mdl.ModelId) string {
+	return fmt.Sprintf("mdlsub_snapshot_export_%s", modelId.String())
+}
+
+type snapshotExportModule struct {
+	kernel.EssentialModule
+	kernel.ApplicationStage
+
+	exporter SnapshotExporter
+	source   SnapshotSource
+	version  int
+}
+
+// NewSnapshotExportModuleFactory runs a single snapshot export of the publisher and stops the application afterwards.
+// The blob batch runner is added for exports of type blob.
+func NewSnapshotExportModuleFactory(name string, version int, sourceFactory SnapshotSourceFactory) kernel.ModuleMultiFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (map[string]kernel.ModuleFactory, error) {
+		settings := readPublisherSetting(config, name)
+
+		modules := map[string]kernel.ModuleFactory{
+			fmt.Sprintf("mdlsub_snapshot_export_%s", name): func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+				exporter, err := NewSnapshotExporter(ctx, config, logger, name)
+				if err != nil {
+					return nil, fmt.Errorf("can not create snapshot exporter: %w", err)
+				}
+
+				source, err := sourceFactory(ctx, config, logger)
+				if err != nil {
+					return nil, fmt.Errorf("can not create snapshot source: %w", err)
+				}
+
+				return &snapshotExportModule{
+					exporter: exporter,
+					source:   source,
+					version:  version,
+				}, nil
+			},
+		}
+
+		if settings.Snapshot.Type == SnapshotTypeBlob {
+			modules[moduleNameBlobRunner] = blob.ProvideBatchRunner("default")
+		}
+
+		return modules, nil
+	}
+}
+
+func (m *snapshotExportModule) Run(ctx context.Context) error {
+	_, err := m.exporter.Export(ctx, m.version, m.source)
+
+	return err
+}
@@ -0,0 +1,124 @@
+package mdlsub_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/blob"
+	blobMocks "github.com/justtrackio/gosoline/pkg/blob/mocks"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/log/status"
+	"github.com/justtrackio/gosoline/pkg/mdl"
+	"github.com/justtrackio/gosoline/pkg/mdlsub"
+	mdlsubMocks "github.com/justtrackio/gosoline/pkg/mdlsub/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type snapshotModel struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type SnapshotExporterTestSuite struct {
+	suite.Suite
+
+	ctx      context.Context
+	clock    clock.FakeClock
+	source   *mdlsubMocks.SnapshotSource
+	settings *mdlsub.PublisherSettings
+}
+
+func (s *SnapshotExporterTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.clock = clock.NewFakeClockAt(time.Date(2023, time.March, 1, 12, 30, 0, 0, time.UTC))
+	s.source = mdlsubMocks.NewSnapshotSource(s.T())
+	s.settings = &mdlsub.PublisherSettings{
+		ModelId: mdl.ModelId{
+			Project:     "gosoline",
+			Family:      "test",
+			Group:       "grp",
+			Application: "app",
+			Name:        "event",
+		},
+		Snapshot: mdlsub.SnapshotSettings{
+			Type:     mdlsub.SnapshotTypeBlob,
+			PartSize: 2,
+		},
+	}
+
+	s.source.EXPECT().Scan(mock.Anything, mock.AnythingOfType("func([]interface {}) error")).
+		RunAndReturn(func(ctx context.Context, fn func([]interface{}) error) error {
+			if err := fn([]interface{}{snapshotModel{Id: 1, Name: "a"}, snapshotModel{Id: 2, Name: "b"}}); err != nil {
+				return err
+			}
+
+			return fn([]interface{}{snapshotModel{Id: 3, Name: "c"}})
+		}).Once()
+}
+
+func (s *SnapshotExporterTestSuite) TestBlobExport() {
+	written := map[string]string{}
+
+	store := blobMocks.NewStore(s.T())
+	store.EXPECT().WriteOne(mock.AnythingOfType("*blob.Object")).Run(func(obj *blob.Object) {
+		body, err := obj.Body.ReadAll()
+		s.NoError(err)
+
+		written[*obj.Key] = string(body)
+	}).Return(nil).Times(3)
+
+	exporter := mdlsub.NewSnapshotBlobExporterWithInterfaces(logMocks.NewLoggerMockedAll(), store, s.clock, status.NewManager(), s.settings)
+
+	count, err := exporter.Export(s.ctx, 1, s.source)
+	s.NoError(err)
+	s.Equal(3, count)
+
+	prefix := "mdlsub-snapshots/gosoline.test.grp.event/1"
+	s.Len(written, 3)
+	s.Equal("{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n", written[fmt.Sprintf("%s/20230301T123000Z/part-00000.jsonl", prefix)])
+	s.Equal("{\"id\":3,\"name\":\"c\"}\n", written[fmt.Sprintf("%s/20230301T123000Z/part-00001.jsonl", prefix)])
+	s.JSONEq(`{
+		"id": "20230301T123000Z",
+		"modelId": "gosoline.test.grp.event",
+		"version": 1,
+		"parts": 2,
+		"partSize": 2,
+		"count": 3,
+		"createdAt": "2023-03-01T12:30:00Z"
+	}`, written[fmt.Sprintf("%s/latest.json", prefix)])
+}
+
+func (s *SnapshotExporterTestSuite) TestBlobExportFailsWithoutManifest() {
+	store := blobMocks.NewStore(s.T())
+	store.EXPECT().WriteOne(mock.AnythingOfType("*blob.Object")).Return(nil).Once()
+	store.EXPECT().WriteOne(mock.AnythingOfType("*blob.Object")).Return(fmt.Errorf("access denied")).Once()
+
+	exporter := mdlsub.NewSnapshotBlobExporterWithInterfaces(logMocks.NewLoggerMockedAll(), store, s.clock, status.NewManager(), s.settings)
+
+	_, err := exporter.Export(s.ctx, 1, s.source)
+	s.EqualError(err, "can not export snapshot of gosoline.test.grp.event: can not write part 1: access denied")
+}
+
+func (s *SnapshotExporterTestSuite) TestProducerExport() {
+	attributes := map[string]string{
+		mdlsub.AttributeSnapshot: "20230301T123000Z",
+	}
+
+	publisher := mdlsubMocks.NewPublisher(s.T())
+	publisher.EXPECT().PublishBatch(s.ctx, mdlsub.TypeUpdate, 1, []interface{}{snapshotModel{Id: 1, Name: "a"}, snapshotModel{Id: 2, Name: "b"}}, attributes).Return(nil).Once()
+	publisher.EXPECT().PublishBatch(s.ctx, mdlsub.TypeUpdate, 1, []interface{}{snapshotModel{Id: 3, Name: "c"}}, attributes).Return(nil).Once()
+
+	exporter := mdlsub.NewSnapshotProducerExporterWithInterfaces(logMocks.NewLoggerMockedAll(), publisher, s.clock, status.NewManager(), s.settings)
+
+	count, err := exporter.Export(s.ctx, 1, s.source)
+	s.NoError(err)
+	s.Equal(3, count)
+}
+
+func TestSnapshotExporterTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotExporterTestSuite))
+}
@@ -0,0 +1,306 @@
+package mdlsub
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/blob"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/kvstore"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/log/status"
+	"github.com/justtrackio/gosoline/pkg/mdl"
+)
+
+const (
+	// KvStoreNameBootstrap is the kvstore remembering which subscribers finished their bootstrap.
+	KvStoreNameBootstrap = "mdlsub_bootstrap"
+
+	moduleNameBlobRunner = "mdlsub_blob_runner"
+	// a single model of a snapshot shouldn't get anywhere close to this, but the default of the scanner is only 64kb
+	maxSnapshotLineSize = 16 * 1024 * 1024
+)
+
+type SubscriberBootstrapSettings struct {
+	Enabled bool `cfg:"enabled" default:"false"`
+	// Store is the blob store the publisher of the source model exports its snapshots to
+	Store string `cfg:"store" default:"mdlsub_snapshots"`
+	// Version of the snapshot to load, the transformer and output of this version are used for all models
+	Version int `cfg:"version" default:"0"`
+}
+
+// BootstrapState is stored after a subscriber finished its bootstrap, so it doesn't load the snapshot again on the
+// next start and overwrite the live messages consumed in the meantime.
+type BootstrapState struct {
+	SnapshotId  string    `json:"snapshotId"`
+	Count       int       `json:"count"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+//go:generate mockery --name SubscriberBootstrapper
+type SubscriberBootstrapper interface {
+	Bootstrap(ctx context.Context) error
+}
+
+type subscriberBootstrapper struct {
+	logger        log.Logger
+	store         blob.Store
+	states        kvstore.KvStore[BootstrapState]
+	clock         clock.Clock
+	statusManager status.Manager
+	transformer   ModelTransformer
+	output        Output
+	name          string
+	settings      *SubscriberSettings
+}
+
+func NewSubscriberBootstrapper(ctx context.Context, config cfg.Config, logger log.Logger, name string, settings *SubscriberSettings, transformers ModelTransformers, outputs Outputs) (SubscriberBootstrapper, error) {
+	var err error
+	var store blob.Store
+	var states kvstore.KvStore[BootstrapState]
+
+	modelId := settings.SourceModel.String()
+	version := settings.Bootstrap.Version
+
+	transformer, ok := transformers[modelId][version]
+	if !ok {
+		return nil, fmt.Errorf("there is no transformer for modelId %s and version %d", modelId, version)
+	}
+
+	output, ok := outputs[modelId][version]
+	if !ok {
+		return nil, fmt.Errorf("there is no output for modelId %s and version %d", modelId, version)
+	}
+
+	if store, err = blob.NewStore(ctx, config, logger, settings.Bootstrap.Store); err != nil {
+		return nil, fmt.Errorf("can not create snapshot blob store: %w", err)
+	}
+
+	if states, err = kvstore.NewConfigurableKvStore[BootstrapState](ctx, config, logger, KvStoreNameBootstrap); err != nil {
+		return nil, fmt.Errorf("can not create bootstrap state store: %w", err)
+	}
+
+	logger = logger.WithChannel("mdlsub_bootstrap")
+
+	return NewSubscriberBootstrapperWithInterfaces(logger, store, states, clock.Provider, status.ProvideManager(), transformer, output, name, settings), nil
+}
+
+func NewSubscriberBootstrapperWithInterfaces(
+	logger log.Logger,
+	store blob.Store,
+	states kvstore.KvStore[BootstrapState],
+	clock clock.Clock,
+	statusManager status.Manager,
+	transformer ModelTransformer,
+	output Output,
+	name string,
+	settings *SubscriberSettings,
+) SubscriberBootstrapper {
+	return &subscriberBootstrapper{
+		logger:        logger,
+		store:         store,
+		states:        states,
+		clock:         clock,
+		statusManager: statusManager,
+		transformer:   transformer,
+		output:        output,
+		name:          name,
+		settings:      settings,
+	}
+}
+
+// Bootstrap persists all models of the latest snapshot of the source model as updates. The messages published in
+// the meantime are consumed afterwards, so the input of the subscriber has to exist before the snapshot is exported.
+func (b *subscriberBootstrapper) Bootstrap(ctx context.Context) error {
+	var err error
+	var ok bool
+	var state BootstrapState
+	var manifest *SnapshotManifest
+
+	if ok, err = b.states.Get(ctx, b.name, &state); err != nil {
+		return fmt.Errorf("can not read bootstrap state of subscriber %s: %w", b.name, err)
+	}
+
+	if ok {
+		b.logger.Info("subscriber %s was already bootstrapped with snapshot %s at %s", b.name, state.SnapshotId, state.CompletedAt.Format(time.RFC3339))
+
+		return nil
+	}
+
+	if manifest, err = b.readManifest(); err != nil {
+		return err
+	}
+
+	b.logger.Info("bootstrapping subscriber %s with snapshot %s of %s containing %d models", b.name, manifest.Id, manifest.ModelId, manifest.Count)
+
+	work := b.statusManager.StartWork(fmt.Sprintf("mdlsub_bootstrap_%s", b.name), manifest.Parts)
+
+	for part := 0; part < manifest.Parts; part++ {
+		if err = b.loadPart(ctx, manifest, part, work); err != nil {
+			work.ReportError(err)
+
+			return fmt.Errorf("can not load part %d of snapshot %s for subscriber %s: %w", part, manifest.Id, b.name, err)
+		}
+
+		b.logger.Info("loaded part %d / %d of snapshot %s for subscriber %s", part+1, manifest.Parts, manifest.Id, b.name)
+	}
+
+	work.ReportDone()
+
+	state = BootstrapState{
+		SnapshotId:  manifest.Id,
+		Count:       manifest.Count,
+		CompletedAt: b.clock.Now(),
+	}
+
+	if err = b.states.Put(ctx, b.name, state); err != nil {
+		return fmt.Errorf("can not write bootstrap state of subscriber %s: %w", b.name, err)
+	}
+
+	b.logger.Info("bootstrapped subscriber %s with %d models", b.name, manifest.Count)
+
+	return nil
+}
+
+func (b *subscriberBootstrapper) readManifest() (*SnapshotManifest, error) {
+	key := SnapshotManifestKey(b.settings.SourceModel.String(), b.settings.Bootstrap.Version)
+	object := &blob.Object{
+		Key: mdl.Box(key),
+	}
+
+	if err := b.store.ReadOne(object); err != nil {
+		return nil, fmt.Errorf("can not read snapshot manifest %s: %w", key, err)
+	}
+
+	if !object.Exists {
+		return nil, fmt.Errorf("there is no snapshot at %s, the publisher has to export one first", key)
+	}
+
+	body, err := object.Body.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("can not read snapshot manifest %s: %w", key, err)
+	}
+
+	manifest := &SnapshotManifest{}
+	if err = json.Unmarshal(body, manifest); err != nil {
+		return nil, fmt.Errorf("can not unmarshal snapshot manifest %s: %w", key, err)
+	}
+
+	return manifest, nil
+}
+
+func (b *subscriberBootstrapper) loadPart(ctx context.Context, manifest *SnapshotManifest, part int, work status.WorkItem) error {
+	object := &blob.Object{
+		Key: mdl.Box(manifest.PartKey(part)),
+	}
+
+	if err := b.store.ReadOne(object); err != nil {
+		return fmt.Errorf("can not read blob: %w", err)
+	}
+
+	if !object.Exists {
+		return fmt.Errorf("the blob %s does not exist", manifest.PartKey(part))
+	}
+
+	reader := object.Body.AsReader()
+	defer reader.Close()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSnapshotLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		if err := b.persist(ctx, scanner.Bytes()); err != nil {
+			return fmt.Errorf("can not persist model in line %d: %w", line, err)
+		}
+
+		if manifest.PartSize > 0 && line%100 == 0 {
+			work.ReportProgress(part, 100*float64(line)/float64(manifest.PartSize))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("can not scan blob: %w", err)
+	}
+
+	work.ReportProgress(part+1, 0)
+
+	return nil
+}
+
+func (b *subscriberBootstrapper) persist(ctx context.Context, data []byte) error {
+	input := b.transformer.GetInput()
+
+	if err := json.Unmarshal(data, input); err != nil {
+		return fmt.Errorf("can not unmarshal model: %w", err)
+	}
+
+	model, err := b.transformer.Transform(ctx, input)
+	if err != nil {
+		return fmt.Errorf("can not transform model: %w", err)
+	}
+
+	if model == nil {
+		return nil
+	}
+
+	return b.output.Persist(ctx, model, TypeUpdate)
+}
+
+type subscriberBootstrapModule struct {
+	kernel.EssentialModule
+	kernel.ApplicationStage
+
+	logger        log.Logger
+	consumer      kernel.Module
+	bootstrappers []SubscriberBootstrapper
+}
+
+// NewSubscriberBootstrapModule bootstraps the subscribers before running the consumer they share.
+func NewSubscriberBootstrapModule(consumerFactory kernel.ModuleFactory, subscribers map[string]*SubscriberSettings, transformers ModelTransformers, outputs Outputs) kernel.ModuleFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (kernel.Module, error) {
+		consumer, err := consumerFactory(ctx, config, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, 0, len(subscribers))
+		for name := range subscribers {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		bootstrappers := make([]SubscriberBootstrapper, len(names))
+
+		for i, name := range names {
+			if bootstrappers[i], err = NewSubscriberBootstrapper(ctx, config, logger, name, subscribers[name], transformers, outputs); err != nil {
+				return nil, fmt.Errorf("can not create bootstrapper for subscriber %s: %w", name, err)
+			}
+		}
+
+		return NewSubscriberBootstrapModuleWithInterfaces(logger, consumer, bootstrappers), nil
+	}
+}
+
+func NewSubscriberBootstrapModuleWithInterfaces(logger log.Logger, consumer kernel.Module, bootstrappers []SubscriberBootstrapper) kernel.Module {
+	return &subscriberBootstrapModule{
+		logger:        logger,
+		consumer:      consumer,
+		bootstrappers: bootstrappers,
+	}
+}
+
+func (m *subscriberBootstrapModule) Run(ctx context.Context) error {
+	for _, bootstrapper := range m.bootstrappers {
+		if err := bootstrapper.Bootstrap(ctx); err != nil {
+			return fmt.Errorf("can not bootstrap subscriber: %w", err)
+		}
+	}
+
+	return m.consumer.Run(ctx)
+}
@@ -0,0 +1,193 @@
+package mdlsub_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/blob"
+	blobMocks "github.com/justtrackio/gosoline/pkg/blob/mocks"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	kernelMocks "github.com/justtrackio/gosoline/pkg/kernel/mocks"
+	"github.com/justtrackio/gosoline/pkg/kvstore"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/log/status"
+	"github.com/justtrackio/gosoline/pkg/mdl"
+	"github.com/justtrackio/gosoline/pkg/mdlsub"
+	mdlsubMocks "github.com/justtrackio/gosoline/pkg/mdlsub/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SubscriberBootstrapperTestSuite struct {
+	suite.Suite
+
+	ctx          context.Context
+	clock        clock.FakeClock
+	store        *blobMocks.Store
+	states       kvstore.KvStore[mdlsub.BootstrapState]
+	transformer  *mdlsubMocks.ModelTransformer
+	output       *mdlsubMocks.Output
+	bootstrapper mdlsub.SubscriberBootstrapper
+}
+
+func (s *SubscriberBootstrapperTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.clock = clock.NewFakeClockAt(time.Date(2023, time.March, 2, 8, 0, 0, 0, time.UTC))
+	s.store = blobMocks.NewStore(s.T())
+	s.states = kvstore.NewInMemoryKvStoreWithInterfaces[mdlsub.BootstrapState](&kvstore.Settings{})
+	s.transformer = mdlsubMocks.NewModelTransformer(s.T())
+	s.output = mdlsubMocks.NewOutput(s.T())
+
+	settings := &mdlsub.SubscriberSettings{
+		SourceModel: mdlsub.SubscriberModel{
+			ModelId: mdl.ModelId{
+				Project: "gosoline",
+				Family:  "test",
+				Group:   "grp",
+				Name:    "event",
+			},
+		},
+		Bootstrap: mdlsub.SubscriberBootstrapSettings{
+			Enabled: true,
+			Version: 1,
+		},
+	}
+
+	s.bootstrapper = mdlsub.NewSubscriberBootstrapperWithInterfaces(logMocks.NewLoggerMockedAll(), s.store, s.states, s.clock, status.NewManager(), s.transformer, s.output, "event", settings)
+}
+
+func (s *SubscriberBootstrapperTestSuite) mockBlob(key string, body string) {
+	s.store.EXPECT().ReadOne(mock.MatchedBy(func(obj *blob.Object) bool {
+		return *obj.Key == key
+	})).Run(func(obj *blob.Object) {
+		obj.Body = blob.StreamBytes([]byte(body))
+		obj.Exists = true
+	}).Return(nil).Once()
+}
+
+func (s *SubscriberBootstrapperTestSuite) TestBootstrap() {
+	prefix := "mdlsub-snapshots/gosoline.test.grp.event/1"
+	s.mockBlob(fmt.Sprintf("%s/latest.json", prefix), `{"id":"20230301T123000Z","modelId":"gosoline.test.grp.event","version":1,"parts":2,"partSize":2,"count":3}`)
+	s.mockBlob(fmt.Sprintf("%s/20230301T123000Z/part-00000.jsonl", prefix), "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n")
+	s.mockBlob(fmt.Sprintf("%s/20230301T123000Z/part-00001.jsonl", prefix), "{\"id\":3,\"name\":\"c\"}\n")
+
+	s.transformer.EXPECT().GetInput().RunAndReturn(func() interface{} {
+		return &snapshotModel{}
+	}).Times(3)
+	s.transformer.EXPECT().Transform(s.ctx, mock.AnythingOfType("*mdlsub_test.snapshotModel")).RunAndReturn(func(ctx context.Context, inp interface{}) (mdlsub.Model, error) {
+		model := inp.(*snapshotModel)
+
+		if model.Id == 2 {
+			return nil, nil
+		}
+
+		return &outputModel{Id: model.Id, Name: model.Name}, nil
+	}).Times(3)
+
+	s.output.EXPECT().Persist(s.ctx, &outputModel{Id: 1, Name: "a"}, mdlsub.TypeUpdate).Return(nil).Once()
+	s.output.EXPECT().Persist(s.ctx, &outputModel{Id: 3, Name: "c"}, mdlsub.TypeUpdate).Return(nil).Once()
+
+	err := s.bootstrapper.Bootstrap(s.ctx)
+	s.NoError(err)
+
+	state := mdlsub.BootstrapState{}
+	ok, err := s.states.Get(s.ctx, "event", &state)
+	s.NoError(err)
+	s.True(ok)
+	s.Equal(mdlsub.BootstrapState{
+		SnapshotId:  "20230301T123000Z",
+		Count:       3,
+		CompletedAt: s.clock.Now(),
+	}, state)
+}
+
+func (s *SubscriberBootstrapperTestSuite) TestAlreadyBootstrapped() {
+	err := s.states.Put(s.ctx, "event", mdlsub.BootstrapState{SnapshotId: "20230301T123000Z"})
+	s.NoError(err)
+
+	err = s.bootstrapper.Bootstrap(s.ctx)
+	s.NoError(err)
+}
+
+func (s *SubscriberBootstrapperTestSuite) TestMissingSnapshot() {
+	s.store.EXPECT().ReadOne(mock.AnythingOfType("*blob.Object")).Return(nil).Once()
+
+	err := s.bootstrapper.Bootstrap(s.ctx)
+	s.EqualError(err, "there is no snapshot at mdlsub-snapshots/gosoline.test.grp.event/1/latest.json, the publisher has to export one first")
+
+	ok, err := s.states.Get(s.ctx, "event", &mdlsub.BootstrapState{})
+	s.NoError(err)
+	s.False(ok)
+}
+
+func (s *SubscriberBootstrapperTestSuite) TestPersistFails() {
+	prefix := "mdlsub-snapshots/gosoline.test.grp.event/1"
+	s.mockBlob(fmt.Sprintf("%s/latest.json", prefix), `{"id":"20230301T123000Z","modelId":"gosoline.test.grp.event","version":1,"parts":1,"partSize":2,"count":1}`)
+	s.mockBlob(fmt.Sprintf("%s/20230301T123000Z/part-00000.jsonl", prefix), "{\"id\":1,\"name\":\"a\"}\n")
+
+	s.transformer.EXPECT().GetInput().Return(&snapshotModel{}).Once()
+	s.transformer.EXPECT().Transform(s.ctx, &snapshotModel{Id: 1, Name: "a"}).Return(&outputModel{Id: 1, Name: "a"}, nil).Once()
+	s.output.EXPECT().Persist(s.ctx, &outputModel{Id: 1, Name: "a"}, mdlsub.TypeUpdate).Return(fmt.Errorf("connection refused")).Once()
+
+	err := s.bootstrapper.Bootstrap(s.ctx)
+	s.EqualError(err, "can not load part 0 of snapshot 20230301T123000Z for subscriber event: can not persist model in line 1: connection refused")
+
+	ok, err := s.states.Get(s.ctx, "event", &mdlsub.BootstrapState{})
+	s.NoError(err)
+	s.False(ok)
+}
+
+func TestSubscriberBootstrapperTestSuite(t *testing.T) {
+	suite.Run(t, new(SubscriberBootstrapperTestSuite))
+}
+
+type outputModel struct {
+	Id   int
+	Name string
+}
+
+func (m *outputModel) GetId() interface{} {
+	return m.Id
+}
+
+func TestSubscriberBootstrapModule_BootstrapsBeforeConsuming(t *testing.T) {
+	ctx := context.Background()
+	calls := make([]string, 0)
+
+	first := mdlsubMocks.NewSubscriberBootstrapper(t)
+	first.EXPECT().Bootstrap(ctx).Run(func(ctx context.Context) {
+		calls = append(calls, "first")
+	}).Return(nil).Once()
+
+	second := mdlsubMocks.NewSubscriberBootstrapper(t)
+	second.EXPECT().Bootstrap(ctx).Run(func(ctx context.Context) {
+		calls = append(calls, "second")
+	}).Return(nil).Once()
+
+	consumer := kernelMocks.NewModule(t)
+	consumer.EXPECT().Run(ctx).Run(func(ctx context.Context) {
+		calls = append(calls, "consumer")
+	}).Return(nil).Once()
+
+	module := mdlsub.NewSubscriberBootstrapModuleWithInterfaces(logMocks.NewLoggerMockedAll(), consumer, []mdlsub.SubscriberBootstrapper{first, second})
+
+	err := module.Run(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "consumer"}, calls)
+}
+
+func TestSubscriberBootstrapModule_StopsOnBootstrapError(t *testing.T) {
+	ctx := context.Background()
+
+	bootstrapper := mdlsubMocks.NewSubscriberBootstrapper(t)
+	bootstrapper.EXPECT().Bootstrap(ctx).Return(fmt.Errorf("no snapshot")).Once()
+
+	consumer := kernelMocks.NewModule(t)
+	module := mdlsub.NewSubscriberBootstrapModuleWithInterfaces(logMocks.NewLoggerMockedAll(), consumer, []mdlsub.SubscriberBootstrapper{bootstrapper})
+
+	err := module.Run(ctx)
+	assert.EqualError(t, err, "can not bootstrap subscriber: no snapshot")
+}
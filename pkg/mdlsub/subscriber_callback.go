@@ -3,6 +3,7 @@ package mdlsub
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/justtrackio/gosoline/pkg/cfg"
@@ -16,6 +17,9 @@ const (
 	ConfigKeyMdlSubSubscribers = "mdlsub.subscribers"
 	MetricNameSuccess          = "ModelEventConsumeSuccess"
 	MetricNameFailure          = "ModelEventConsumeFailure"
+	// MetricNameVersion counts the consumed messages per version of a model, so old versions can be retired once
+	// publishers stopped emitting them
+	MetricNameVersion = "ModelEventConsumeVersion"
 )
 
 type SubscriberSettings struct {
@@ -24,6 +28,8 @@ type SubscriberSettings struct {
 	RunnerCount int             `cfg:"runner_count" default:"10" validate:"min=1"`
 	SourceModel SubscriberModel `cfg:"source"`
 	TargetModel SubscriberModel `cfg:"target"`
+	// Bootstrap loads the latest snapshot of the source model into the output before consuming live messages
+	Bootstrap SubscriberBootstrapSettings `cfg:"bootstrap"`
 }
 
 type SubscriberModel struct {
@@ -85,6 +91,8 @@ func (s *SubscriberCallback) Consume(ctx context.Context, input interface{}, att
 		return false, err
 	}
 
+	s.writeVersionMetric(spec)
+
 	logger := s.logger.WithContext(ctx).WithFields(log.Fields{
 		"modelId": spec.ModelId,
 		"type":    spec.CrudType,
@@ -175,6 +183,20 @@ func (s *SubscriberCallback) writeMetric(err error, spec *ModelSpecification) {
 	})
 }
 
+func (s *SubscriberCallback) writeVersionMetric(spec *ModelSpecification) {
+	s.metric.WriteOne(&metric.Datum{
+		Priority:   metric.PriorityHigh,
+		Timestamp:  time.Now(),
+		MetricName: MetricNameVersion,
+		Dimensions: map[string]string{
+			"ModelId": spec.ModelId,
+			"Version": strconv.Itoa(spec.Version),
+		},
+		Unit:  metric.UnitCount,
+		Value: 1.0,
+	})
+}
+
 func getSubscriberCallbackDefaultMetrics(transformers ModelTransformers) []*metric.Datum {
 	defaults := make([]*metric.Datum, 0)
 
@@ -200,6 +222,19 @@ func getSubscriberCallbackDefaultMetrics(transformers ModelTransformers) []*metr
 		}
 
 		defaults = append(defaults, success, failure)
+
+		for version := range transformers[modelId] {
+			defaults = append(defaults, &metric.Datum{
+				Priority:   metric.PriorityHigh,
+				MetricName: MetricNameVersion,
+				Dimensions: map[string]string{
+					"ModelId": modelId,
+					"Version": strconv.Itoa(version),
+				},
+				Unit:  metric.UnitCount,
+				Value: 0.0,
+			})
+		}
 	}
 
 	return defaults
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/justtrackio/gosoline/pkg/apiserver"
+	"github.com/justtrackio/gosoline/pkg/blob"
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/kernel"
 	"github.com/justtrackio/gosoline/pkg/log"
@@ -46,6 +47,21 @@ func SubscriberFactory(ctx context.Context, config cfg.Config, logger log.Logger
 	}
 
 	modules := make(map[string]kernel.ModuleFactory)
+	bootstraps := make(map[string]map[string]*SubscriberSettings)
+
+	for name, subscriberSettings := range settings.Subscribers {
+		if !subscriberSettings.Bootstrap.Enabled {
+			continue
+		}
+
+		subscriberFQN := GetSubscriberFQN(name, subscriberSettings.SourceModel)
+
+		if _, ok := bootstraps[subscriberFQN]; !ok {
+			bootstraps[subscriberFQN] = make(map[string]*SubscriberSettings)
+		}
+
+		bootstraps[subscriberFQN][name] = subscriberSettings
+	}
 
 	for name, subscriberSettings := range settings.Subscribers {
 		subscriberFQN := GetSubscriberFQN(name, subscriberSettings.SourceModel)
@@ -56,6 +72,15 @@ func SubscriberFactory(ctx context.Context, config cfg.Config, logger log.Logger
 
 		callbackFactory := NewSubscriberCallbackFactory(transformers, outputs)
 		modules[subscriberFQN] = stream.NewConsumer(subscriberFQN, callbackFactory)
+
+		if subscribers, ok := bootstraps[subscriberFQN]; ok {
+			modules[subscriberFQN] = NewSubscriberBootstrapModule(modules[subscriberFQN], subscribers, transformers, outputs)
+		}
+	}
+
+	// the snapshots are read through the blob store, which needs its batch runner
+	if len(bootstraps) > 0 {
+		modules[moduleNameBlobRunner] = blob.ProvideBatchRunner("default")
 	}
 
 	if !settings.SubscriberApi.Enabled {
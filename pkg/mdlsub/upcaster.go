@@ -0,0 +1,158 @@
+package mdlsub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+// Upcaster converts the model of a single version into the model of the next version.
+//
+//go:generate mockery --name Upcaster
+type Upcaster interface {
+	// GetInput returns a pointer to a new model in the version the upcaster reads.
+	GetInput() interface{}
+	// Upcast converts the model into the next version. The result has to be of the same type the next upcaster or the
+	// transformer of the next version returns from GetInput.
+	Upcast(ctx context.Context, inp interface{}) (out interface{}, err error)
+}
+
+type (
+	UpcasterFactory                 func(ctx context.Context, config cfg.Config, logger log.Logger) (Upcaster, error)
+	UpcasterMapTypeVersionFactories map[string]UpcasterMapVersionFactories
+	// UpcasterMapVersionFactories is keyed by the version the upcaster reads, it upcasts to the version + 1
+	UpcasterMapVersionFactories map[int]UpcasterFactory
+)
+
+// WithUpcasters adds a transformer for every version of a model which has an upcaster but no transformer. Messages of
+// such a version are upcasted version by version until there is a transformer. This way a subscriber only has to
+// register the transformer of the latest version and an upcaster from every older version to the next one.
+func WithUpcasters(transformerFactories TransformerMapTypeVersionFactories, upcasterFactories UpcasterMapTypeVersionFactories) TransformerMapTypeVersionFactories {
+	result := make(TransformerMapTypeVersionFactories, len(transformerFactories))
+
+	for modelId, versionedFactories := range transformerFactories {
+		result[modelId] = make(TransformerMapVersionFactories, len(versionedFactories))
+
+		for version, factory := range versionedFactories {
+			result[modelId][version] = factory
+		}
+	}
+
+	for modelId, versionedUpcasters := range upcasterFactories {
+		if _, ok := result[modelId]; !ok {
+			result[modelId] = make(TransformerMapVersionFactories)
+		}
+
+		for version := range versionedUpcasters {
+			if _, ok := transformerFactories[modelId][version]; ok {
+				continue
+			}
+
+			result[modelId][version] = newUpcastingTransformerFactory(modelId, version, transformerFactories[modelId], versionedUpcasters)
+		}
+	}
+
+	return result
+}
+
+func newUpcastingTransformerFactory(modelId string, version int, transformerFactories TransformerMapVersionFactories, upcasterFactories UpcasterMapVersionFactories) TransformerFactory {
+	return func(ctx context.Context, config cfg.Config, logger log.Logger) (ModelTransformer, error) {
+		var ok bool
+		var err error
+		var upcaster Upcaster
+		var upcasterFactory UpcasterFactory
+		var transformer ModelTransformer
+		var transformerFactory TransformerFactory
+
+		upcasters := make([]Upcaster, 0)
+		current := version
+
+		for {
+			if transformerFactory, ok = transformerFactories[current]; ok {
+				break
+			}
+
+			if upcasterFactory, ok = upcasterFactories[current]; !ok {
+				return nil, fmt.Errorf("there is neither a transformer nor an upcaster for modelId %s in version %d", modelId, current)
+			}
+
+			if upcaster, err = upcasterFactory(ctx, config, logger); err != nil {
+				return nil, fmt.Errorf("can not create upcaster for modelId %s from version %d: %w", modelId, current, err)
+			}
+
+			upcasters = append(upcasters, upcaster)
+			current++
+		}
+
+		if transformer, err = transformerFactory(ctx, config, logger); err != nil {
+			return nil, fmt.Errorf("can not create transformer for modelId %s in version %d: %w", modelId, current, err)
+		}
+
+		return NewUpcastingTransformer(version, upcasters, transformer), nil
+	}
+}
+
+type upcastingTransformer struct {
+	version     int
+	upcasters   []Upcaster
+	transformer ModelTransformer
+}
+
+// NewUpcastingTransformer reads models of the given version and runs them through all upcasters before passing them
+// to the transformer of the version the last upcaster returns.
+func NewUpcastingTransformer(version int, upcasters []Upcaster, transformer ModelTransformer) ModelTransformer {
+	return &upcastingTransformer{
+		version:     version,
+		upcasters:   upcasters,
+		transformer: transformer,
+	}
+}
+
+func (t *upcastingTransformer) GetInput() interface{} {
+	if len(t.upcasters) == 0 {
+		return t.transformer.GetInput()
+	}
+
+	return t.upcasters[0].GetInput()
+}
+
+func (t *upcastingTransformer) Transform(ctx context.Context, inp interface{}) (out Model, err error) {
+	model := inp
+
+	for i, upcaster := range t.upcasters {
+		if model, err = upcaster.Upcast(ctx, model); err != nil {
+			return nil, fmt.Errorf("can not upcast model from version %d to %d: %w", t.version+i, t.version+i+1, err)
+		}
+	}
+
+	return t.transformer.Transform(ctx, model)
+}
+
+type genericUpcaster[I any, O any] struct {
+	upcast func(ctx context.Context, inp *I) (*O, error)
+}
+
+// NewGenericUpcaster creates an upcaster from a function converting a pointer to the model of a version into a pointer
+// to the model of the next version.
+func NewGenericUpcaster[I any, O any](upcast func(ctx context.Context, inp *I) (*O, error)) UpcasterFactory {
+	return func(_ context.Context, _ cfg.Config, _ log.Logger) (Upcaster, error) {
+		return &genericUpcaster[I, O]{
+			upcast: upcast,
+		}, nil
+	}
+}
+
+func (u *genericUpcaster[I, O]) GetInput() interface{} {
+	return new(I)
+}
+
+func (u *genericUpcaster[I, O]) Upcast(ctx context.Context, inp interface{}) (interface{}, error) {
+	model, ok := inp.(*I)
+	if !ok {
+		return nil, fmt.Errorf("expected input of type %T but got %T", new(I), inp)
+	}
+
+	return u.upcast(ctx, model)
+}
@@ -0,0 +1,208 @@
+package mdlsub_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	cfgMocks "github.com/justtrackio/gosoline/pkg/cfg/mocks"
+	"github.com/justtrackio/gosoline/pkg/log"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/mdlsub"
+	mdlsubMocks "github.com/justtrackio/gosoline/pkg/mdlsub/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+const upcasterModelId = "gosoline.test.grp.event"
+
+type eventV0 struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type eventV1 struct {
+	Id        int    `json:"id"`
+	FirstName string `json:"firstName"`
+}
+
+type eventV2 struct {
+	Id        int    `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+type eventV2Transformer struct{}
+
+func (t eventV2Transformer) GetInput() interface{} {
+	return &eventV2{}
+}
+
+func (t eventV2Transformer) Transform(_ context.Context, inp interface{}) (mdlsub.Model, error) {
+	event := inp.(*eventV2)
+
+	return &outputModel{
+		Id:   event.Id,
+		Name: fmt.Sprintf("%s %s", event.FirstName, event.LastName),
+	}, nil
+}
+
+type UpcasterTestSuite struct {
+	suite.Suite
+
+	ctx       context.Context
+	config    cfg.Config
+	logger    log.Logger
+	upcasters mdlsub.UpcasterMapTypeVersionFactories
+}
+
+func (s *UpcasterTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.config = cfgMocks.NewConfig(s.T())
+	s.logger = logMocks.NewLoggerMockedAll()
+	s.upcasters = mdlsub.UpcasterMapTypeVersionFactories{
+		upcasterModelId: {
+			0: mdlsub.NewGenericUpcaster(func(_ context.Context, inp *eventV0) (*eventV1, error) {
+				return &eventV1{Id: inp.Id, FirstName: inp.Name}, nil
+			}),
+			1: mdlsub.NewGenericUpcaster(func(_ context.Context, inp *eventV1) (*eventV2, error) {
+				return &eventV2{Id: inp.Id, FirstName: inp.FirstName, LastName: "unknown"}, nil
+			}),
+		},
+	}
+}
+
+func (s *UpcasterTestSuite) TestUpcastChain() {
+	transformers := mdlsub.WithUpcasters(mdlsub.TransformerMapTypeVersionFactories{
+		upcasterModelId: {
+			2: mdlsub.NewGenericTransformer(eventV2Transformer{}),
+		},
+	}, s.upcasters)
+
+	s.Len(transformers[upcasterModelId], 3)
+
+	transformer, err := transformers[upcasterModelId][0](s.ctx, s.config, s.logger)
+	s.NoError(err)
+
+	input := transformer.GetInput()
+	s.IsType(&eventV0{}, input)
+
+	input.(*eventV0).Id = 1
+	input.(*eventV0).Name = "john"
+
+	model, err := transformer.Transform(s.ctx, input)
+	s.NoError(err)
+	s.Equal(&outputModel{Id: 1, Name: "john unknown"}, model)
+
+	transformer, err = transformers[upcasterModelId][1](s.ctx, s.config, s.logger)
+	s.NoError(err)
+	s.IsType(&eventV1{}, transformer.GetInput())
+
+	model, err = transformer.Transform(s.ctx, &eventV1{Id: 2, FirstName: "jane"})
+	s.NoError(err)
+	s.Equal(&outputModel{Id: 2, Name: "jane unknown"}, model)
+}
+
+func (s *UpcasterTestSuite) TestRegisteredTransformerWins() {
+	transformer := mdlsubMocks.NewModelTransformer(s.T())
+
+	transformers := mdlsub.WithUpcasters(mdlsub.TransformerMapTypeVersionFactories{
+		upcasterModelId: {
+			1: mdlsub.NewGenericTransformer(transformer),
+			2: mdlsub.NewGenericTransformer(eventV2Transformer{}),
+		},
+	}, s.upcasters)
+
+	actual, err := transformers[upcasterModelId][1](s.ctx, s.config, s.logger)
+	s.NoError(err)
+	s.Same(transformer, actual)
+
+	// version 0 is upcasted to 1 and then handled by the registered transformer of version 1
+	transformer.EXPECT().Transform(s.ctx, &eventV1{Id: 1, FirstName: "john"}).Return(&outputModel{Id: 1}, nil).Once()
+
+	actual, err = transformers[upcasterModelId][0](s.ctx, s.config, s.logger)
+	s.NoError(err)
+
+	model, err := actual.Transform(s.ctx, &eventV0{Id: 1, Name: "john"})
+	s.NoError(err)
+	s.Equal(&outputModel{Id: 1}, model)
+}
+
+func (s *UpcasterTestSuite) TestMissingUpcaster() {
+	delete(s.upcasters[upcasterModelId], 1)
+
+	transformers := mdlsub.WithUpcasters(mdlsub.TransformerMapTypeVersionFactories{
+		upcasterModelId: {
+			2: mdlsub.NewGenericTransformer(eventV2Transformer{}),
+		},
+	}, s.upcasters)
+
+	_, err := transformers[upcasterModelId][0](s.ctx, s.config, s.logger)
+	s.EqualError(err, "there is neither a transformer nor an upcaster for modelId gosoline.test.grp.event in version 1")
+}
+
+func (s *UpcasterTestSuite) TestUpcastFails() {
+	upcaster := mdlsubMocks.NewUpcaster(s.T())
+	upcaster.EXPECT().Upcast(s.ctx, &eventV1{Id: 1}).Return(nil, fmt.Errorf("last name is required")).Once()
+
+	transformer := mdlsub.NewUpcastingTransformer(1, []mdlsub.Upcaster{upcaster}, eventV2Transformer{})
+
+	_, err := transformer.Transform(s.ctx, &eventV1{Id: 1})
+	s.EqualError(err, "can not upcast model from version 1 to 2: last name is required")
+}
+
+func (s *UpcasterTestSuite) TestGenericUpcasterWrongInput() {
+	upcaster, err := s.upcasters[upcasterModelId][0](s.ctx, s.config, s.logger)
+	s.NoError(err)
+
+	_, err = upcaster.Upcast(s.ctx, &eventV1{})
+	s.EqualError(err, "expected input of type *mdlsub_test.eventV0 but got *mdlsub_test.eventV1")
+}
+
+func (s *UpcasterTestSuite) TestSubscriberCallbackConsumesUpcastedVersion() {
+	output := mdlsubMocks.NewOutput(s.T())
+	output.EXPECT().Persist(s.ctx, &outputModel{Id: 1, Name: "john unknown"}, mdlsub.TypeCreate).Return(nil).Once()
+
+	factories := mdlsub.WithUpcasters(mdlsub.TransformerMapTypeVersionFactories{
+		upcasterModelId: {
+			2: mdlsub.NewGenericTransformer(eventV2Transformer{}),
+		},
+	}, s.upcasters)
+
+	transformer, err := factories[upcasterModelId][0](s.ctx, s.config, s.logger)
+	s.NoError(err)
+
+	transformers := mdlsub.ModelTransformers{
+		upcasterModelId: {
+			0: transformer,
+		},
+	}
+	outputs := mdlsub.Outputs{
+		upcasterModelId: {
+			0: output,
+		},
+	}
+
+	callback, err := mdlsub.NewSubscriberCallbackFactory(transformers, outputs)(s.ctx, s.config, s.logger)
+	s.NoError(err)
+
+	attributes := map[string]string{
+		"modelId": upcasterModelId,
+		"type":    mdlsub.TypeCreate,
+		"version": "0",
+	}
+
+	input := callback.GetModel(attributes)
+	s.IsType(&eventV0{}, input)
+
+	input.(*eventV0).Id = 1
+	input.(*eventV0).Name = "john"
+
+	ack, err := callback.Consume(s.ctx, input, attributes)
+	s.NoError(err)
+	s.True(ack)
+}
+
+func TestUpcasterTestSuite(t *testing.T) {
+	suite.Run(t, new(UpcasterTestSuite))
+}
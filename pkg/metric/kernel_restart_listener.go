@@ -0,0 +1,33 @@
+package metric
+
+import "fmt"
+
+const MetricNameModuleRestart = "ModuleRestart"
+
+// KernelRestartListener counts the restarts of the kernel modules per module and stage.
+type KernelRestartListener struct {
+	writer Writer
+}
+
+func NewKernelRestartListener() *KernelRestartListener {
+	return NewKernelRestartListenerWithInterfaces(NewWriter())
+}
+
+func NewKernelRestartListenerWithInterfaces(writer Writer) *KernelRestartListener {
+	return &KernelRestartListener{
+		writer: writer,
+	}
+}
+
+func (l KernelRestartListener) ModuleRestarted(stageIndex int, name string, _ int, _ error) {
+	l.writer.WriteOne(&Datum{
+		Priority:   PriorityHigh,
+		MetricName: MetricNameModuleRestart,
+		Dimensions: Dimensions{
+			"Module": name,
+			"Stage":  fmt.Sprintf("%d", stageIndex),
+		},
+		Unit:  UnitCount,
+		Value: 1.0,
+	})
+}
@@ -0,0 +1,27 @@
+package metric_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/kernel"
+	"github.com/justtrackio/gosoline/pkg/metric"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+)
+
+func TestKernelRestartListener_ModuleRestarted(t *testing.T) {
+	writer := metricMocks.NewWriter(t)
+	writer.EXPECT().WriteOne(&metric.Datum{
+		Priority:   metric.PriorityHigh,
+		MetricName: metric.MetricNameModuleRestart,
+		Dimensions: metric.Dimensions{
+			"Module": "consumer",
+			"Stage":  "2048",
+		},
+		Unit:  metric.UnitCount,
+		Value: 1.0,
+	}).Once()
+
+	var listener kernel.RestartListener = metric.NewKernelRestartListenerWithInterfaces(writer)
+	listener.ModuleRestarted(kernel.StageApplication, "consumer", 1, fmt.Errorf("connection lost"))
+}
@@ -0,0 +1,344 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	netHttp "net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	httpHeaders "github.com/go-http-utils/headers"
+	"github.com/justtrackio/gosoline/pkg/appctx"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/http"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+const (
+	AuthStyleBasic = "basic"
+	AuthStylePost  = "post"
+
+	defaultDevicePollInterval = 5 * time.Second
+	slowDownIncrement         = 5 * time.Second
+)
+
+type ClientSettings struct {
+	// Issuer is used to discover the endpoints of the identity provider which aren't configured explicitly
+	Issuer                 string   `cfg:"issuer"`
+	TokenUrl               string   `cfg:"token_url"`
+	DeviceAuthorizationUrl string   `cfg:"device_authorization_url"`
+	ClientId               string   `cfg:"client_id" validate:"required"`
+	ClientSecret           string   `cfg:"client_secret"`
+	Scopes                 []string `cfg:"scopes"`
+	Audience               string   `cfg:"audience"`
+	// AuthStyle defines if the client credentials are sent with basic auth or in the body of the token requests
+	AuthStyle string `cfg:"auth_style" default:"basic" validate:"oneof=basic post"`
+	// ExpiryLeeway is subtracted from the expiry of the tokens, so they are refreshed before they actually expire
+	ExpiryLeeway time.Duration `cfg:"expiry_leeway" default:"30s"`
+	HttpClient   string        `cfg:"http_client" default:"oauth2"`
+}
+
+// DeviceAuthorization is the response of the device authorization endpoint as defined by RFC 8628, section 3.2.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationUri         string `json:"verification_uri"`
+	VerificationUriComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval,omitempty"`
+}
+
+//go:generate mockery --name Client
+type Client interface {
+	// ClientCredentials requests a token for the client itself
+	ClientCredentials(ctx context.Context) (*Token, error)
+	// RefreshToken exchanges the refresh token for a new token. The identity provider might rotate the refresh token,
+	// so the refresh token of the returned token has to be used for the next refresh if it is set.
+	RefreshToken(ctx context.Context, refreshToken string) (*Token, error)
+	// DeviceAuthorization starts the device code flow. Show the user code and verification uri to the user and call
+	// PollDeviceToken afterwards.
+	DeviceAuthorization(ctx context.Context) (*DeviceAuthorization, error)
+	// PollDeviceToken polls the token endpoint until the user approved or denied the device authorization or it expired.
+	PollDeviceToken(ctx context.Context, authorization *DeviceAuthorization) (*Token, error)
+}
+
+type endpoints struct {
+	tokenUrl               string
+	deviceAuthorizationUrl string
+}
+
+type client struct {
+	logger     log.Logger
+	clock      clock.Clock
+	httpClient http.Client
+	settings   *ClientSettings
+
+	lck       sync.Mutex
+	endpoints *endpoints
+}
+
+type clientCtxKey string
+
+// ProvideClient returns the client with the given name, the settings are read from oauth2.clients.<name>.
+func ProvideClient(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Client, error) {
+	return appctx.Provide(ctx, clientCtxKey(name), func() (Client, error) {
+		return NewClient(ctx, config, logger, name)
+	})
+}
+
+func NewClient(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Client, error) {
+	settings := ReadClientSettings(config, name)
+
+	httpClient, err := http.ProvideHttpClient(ctx, config, logger, settings.HttpClient)
+	if err != nil {
+		return nil, fmt.Errorf("can not create http client: %w", err)
+	}
+
+	return NewClientWithInterfaces(logger, clock.Provider, httpClient, settings), nil
+}
+
+func NewClientWithInterfaces(logger log.Logger, clock clock.Clock, httpClient http.Client, settings *ClientSettings) Client {
+	return &client{
+		logger:     logger.WithChannel("oauth2"),
+		clock:      clock,
+		httpClient: httpClient,
+		settings:   settings,
+	}
+}
+
+func ReadClientSettings(config cfg.Config, name string) *ClientSettings {
+	key := fmt.Sprintf("oauth2.clients.%s", name)
+	settings := &ClientSettings{}
+	config.UnmarshalKey(key, settings)
+
+	return settings
+}
+
+func (c *client) ClientCredentials(ctx context.Context) (*Token, error) {
+	params := url.Values{}
+	params.Set("grant_type", GrantTypeClientCredentials)
+	c.addScopeAndAudience(params)
+
+	token, err := c.requestToken(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("can not request client credentials token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (c *client) RefreshToken(ctx context.Context, refreshToken string) (*Token, error) {
+	params := url.Values{}
+	params.Set("grant_type", GrantTypeRefreshToken)
+	params.Set("refresh_token", refreshToken)
+
+	token, err := c.requestToken(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("can not refresh token: %w", err)
+	}
+
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+
+	return token, nil
+}
+
+func (c *client) DeviceAuthorization(ctx context.Context) (*DeviceAuthorization, error) {
+	endpoints, err := c.resolveEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoints.deviceAuthorizationUrl == "" {
+		return nil, fmt.Errorf("no device authorization url configured or discovered")
+	}
+
+	params := url.Values{}
+	params.Set("client_id", c.settings.ClientId)
+	c.addScopeAndAudience(params)
+
+	response, err := c.postForm(ctx, endpoints.deviceAuthorizationUrl, params, false)
+	if err != nil {
+		return nil, fmt.Errorf("can not request device authorization: %w", err)
+	}
+
+	if response.StatusCode != netHttp.StatusOK {
+		return nil, fmt.Errorf("can not request device authorization: %w", parseTokenError(response))
+	}
+
+	authorization := &DeviceAuthorization{}
+	if err = json.Unmarshal(response.Body, authorization); err != nil {
+		return nil, fmt.Errorf("can not unmarshal device authorization: %w", err)
+	}
+
+	return authorization, nil
+}
+
+func (c *client) PollDeviceToken(ctx context.Context, authorization *DeviceAuthorization) (*Token, error) {
+	interval := time.Duration(authorization.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+
+	var expiresAt time.Time
+	if authorization.ExpiresIn > 0 {
+		expiresAt = c.clock.Now().Add(time.Duration(authorization.ExpiresIn) * time.Second)
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", GrantTypeDeviceCode)
+	params.Set("device_code", authorization.DeviceCode)
+
+	for {
+		if err := c.wait(ctx, interval); err != nil {
+			return nil, err
+		}
+
+		token, err := c.requestToken(ctx, params)
+
+		tokenErr := &TokenError{}
+		switch {
+		case err == nil:
+			return token, nil
+		case errors.As(err, &tokenErr) && tokenErr.Code == ErrorCodeAuthorizationPending:
+		case errors.As(err, &tokenErr) && tokenErr.Code == ErrorCodeSlowDown:
+			interval += slowDownIncrement
+		default:
+			return nil, fmt.Errorf("can not request device token: %w", err)
+		}
+
+		if !expiresAt.IsZero() && !c.clock.Now().Before(expiresAt) {
+			return nil, fmt.Errorf("can not request device token: %w", &TokenError{
+				Code:        ErrorCodeExpiredToken,
+				Description: "the device authorization expired before it was approved",
+			})
+		}
+	}
+}
+
+func (c *client) wait(ctx context.Context, interval time.Duration) error {
+	timer := c.clock.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.Chan():
+		return nil
+	}
+}
+
+func (c *client) addScopeAndAudience(params url.Values) {
+	if len(c.settings.Scopes) > 0 {
+		params.Set("scope", strings.Join(c.settings.Scopes, " "))
+	}
+
+	if c.settings.Audience != "" {
+		params.Set("audience", c.settings.Audience)
+	}
+}
+
+func (c *client) requestToken(ctx context.Context, params url.Values) (*Token, error) {
+	endpoints, err := c.resolveEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.postForm(ctx, endpoints.tokenUrl, params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != netHttp.StatusOK {
+		return nil, parseTokenError(response)
+	}
+
+	body := &tokenResponse{}
+	if err = json.Unmarshal(response.Body, body); err != nil {
+		return nil, fmt.Errorf("can not unmarshal token response: %w", err)
+	}
+
+	if body.AccessToken == "" {
+		return nil, fmt.Errorf("token response doesn't contain an access token")
+	}
+
+	return body.token(c.clock.Now()), nil
+}
+
+func (c *client) postForm(ctx context.Context, endpoint string, params url.Values, authenticate bool) (*http.Response, error) {
+	request := c.httpClient.NewJsonRequest().
+		WithUrl(endpoint).
+		WithHeader(httpHeaders.ContentType, http.MimeTypeApplicationFormUrlencoded)
+
+	switch {
+	case !authenticate || c.settings.ClientSecret == "":
+		params.Set("client_id", c.settings.ClientId)
+	case c.settings.AuthStyle == AuthStylePost:
+		params.Set("client_id", c.settings.ClientId)
+		params.Set("client_secret", c.settings.ClientSecret)
+	default:
+		request.WithBasicAuth(escape(c.settings.ClientId), escape(c.settings.ClientSecret))
+	}
+
+	return c.httpClient.Post(ctx, request.WithBody(params.Encode()))
+}
+
+// resolveEndpoints discovers the endpoints which aren't configured explicitly. A failed discovery is retried with the
+// next request.
+func (c *client) resolveEndpoints(ctx context.Context) (*endpoints, error) {
+	c.lck.Lock()
+	defer c.lck.Unlock()
+
+	if c.endpoints != nil {
+		return c.endpoints, nil
+	}
+
+	resolved := &endpoints{
+		tokenUrl:               c.settings.TokenUrl,
+		deviceAuthorizationUrl: c.settings.DeviceAuthorizationUrl,
+	}
+
+	if c.settings.Issuer != "" && (resolved.tokenUrl == "" || resolved.deviceAuthorizationUrl == "") {
+		metadata, err := Discover(ctx, c.httpClient, c.settings.Issuer)
+		if err != nil {
+			return nil, err
+		}
+
+		if resolved.tokenUrl == "" {
+			resolved.tokenUrl = metadata.TokenEndpoint
+		}
+
+		if resolved.deviceAuthorizationUrl == "" {
+			resolved.deviceAuthorizationUrl = metadata.DeviceAuthorizationEndpoint
+		}
+	}
+
+	if resolved.tokenUrl == "" {
+		return nil, fmt.Errorf("no token url configured or discovered")
+	}
+
+	c.endpoints = resolved
+
+	return resolved, nil
+}
+
+func parseTokenError(response *http.Response) error {
+	tokenErr := &TokenError{}
+
+	// the body isn't required to be json, so we keep the status code as the only information in that case
+	_ = json.Unmarshal(response.Body, tokenErr)
+	tokenErr.StatusCode = response.StatusCode
+
+	return tokenErr
+}
+
+// escape encodes the client credentials for basic auth as required by RFC 6749, section 2.3.1.
+func escape(value string) string {
+	return url.QueryEscape(value)
+}
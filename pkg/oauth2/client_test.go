@@ -0,0 +1,177 @@
+package oauth2_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/http"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/justtrackio/gosoline/pkg/oauth2"
+	"github.com/justtrackio/gosoline/pkg/oauth2/oauth2test"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+type ClientTestSuite struct {
+	suite.Suite
+
+	idp        *oauth2test.FakeIdentityProvider
+	httpClient http.Client
+	settings   *oauth2.ClientSettings
+}
+
+func (s *ClientTestSuite) SetupTest() {
+	var err error
+
+	s.idp, err = oauth2test.NewFakeIdentityProvider("client", "secret")
+	s.Require().NoError(err)
+
+	s.httpClient = http.NewHttpClientWithInterfaces(logMocks.NewLoggerMockedAll(), clock.NewRealClock(), metricMocks.NewWriterMockedAll(), resty.New())
+	s.settings = &oauth2.ClientSettings{
+		Issuer:       s.idp.Issuer(),
+		ClientId:     "client",
+		ClientSecret: "secret",
+		Scopes:       []string{"read", "write"},
+		AuthStyle:    oauth2.AuthStyleBasic,
+	}
+}
+
+func (s *ClientTestSuite) TearDownTest() {
+	s.idp.Close()
+}
+
+func (s *ClientTestSuite) TestDiscover() {
+	metadata, err := oauth2.Discover(context.Background(), s.httpClient, s.idp.Issuer()+"/")
+	s.NoError(err)
+	s.Equal(s.idp.Issuer(), metadata.Issuer)
+	s.Equal(s.idp.TokenUrl(), metadata.TokenEndpoint)
+	s.Equal(s.idp.JwksUrl(), metadata.JwksUri)
+}
+
+func (s *ClientTestSuite) TestClientCredentials() {
+	for _, authStyle := range []string{oauth2.AuthStyleBasic, oauth2.AuthStylePost} {
+		s.settings.AuthStyle = authStyle
+		client := s.newClient()
+
+		token, err := client.ClientCredentials(context.Background())
+		s.NoError(err, authStyle)
+		s.NotEmpty(token.AccessToken)
+		s.Equal("Bearer", token.TokenType)
+		s.Equal("read write", token.Scope)
+		s.WithinDuration(time.Now().Add(time.Hour), token.Expiry, time.Minute)
+	}
+}
+
+func (s *ClientTestSuite) TestClientCredentials_InvalidClient() {
+	s.settings.ClientSecret = "wrong"
+	client := s.newClient()
+
+	_, err := client.ClientCredentials(context.Background())
+
+	tokenErr := &oauth2.TokenError{}
+	s.ErrorAs(err, &tokenErr)
+	s.Equal(oauth2.ErrorCodeInvalidClient, tokenErr.Code)
+	s.Equal(401, tokenErr.StatusCode)
+}
+
+func (s *ClientTestSuite) TestClientCredentials_NoTokenUrl() {
+	s.settings.Issuer = ""
+	client := s.newClient()
+
+	_, err := client.ClientCredentials(context.Background())
+	s.EqualError(err, "can not request client credentials token: no token url configured or discovered")
+}
+
+func (s *ClientTestSuite) TestRefreshToken() {
+	client := s.newClient()
+	refreshToken := s.idp.IssueRefreshToken("user")
+
+	token, err := client.RefreshToken(context.Background(), refreshToken)
+	s.NoError(err)
+	s.NotEmpty(token.AccessToken)
+	s.NotEqual(refreshToken, token.RefreshToken)
+
+	// the refresh token was rotated, so it can't be used again
+	_, err = client.RefreshToken(context.Background(), refreshToken)
+
+	tokenErr := &oauth2.TokenError{}
+	s.ErrorAs(err, &tokenErr)
+	s.Equal(oauth2.ErrorCodeInvalidGrant, tokenErr.Code)
+}
+
+func (s *ClientTestSuite) TestDeviceCode() {
+	fakeClock := clock.NewFakeClock()
+	client := oauth2.NewClientWithInterfaces(logMocks.NewLoggerMockedAll(), fakeClock, s.httpClient, s.settings)
+
+	authorization, err := client.DeviceAuthorization(context.Background())
+	s.NoError(err)
+	s.NotEmpty(authorization.UserCode)
+	s.Equal(int64(1), authorization.Interval)
+
+	done := make(chan struct{})
+	var token *oauth2.Token
+
+	go func() {
+		defer close(done)
+		token, err = client.PollDeviceToken(context.Background(), authorization)
+	}()
+
+	// the first poll is still pending
+	fakeClock.BlockUntilTimers(1)
+	fakeClock.Advance(time.Second)
+	fakeClock.BlockUntilTimers(1)
+
+	s.NoError(s.idp.ApproveDevice(authorization.UserCode, "user"))
+	fakeClock.Advance(time.Second)
+	<-done
+
+	s.NoError(err)
+	s.NotEmpty(token.AccessToken)
+	s.NotEmpty(token.RefreshToken)
+	s.Equal(2, s.idp.TokenRequests())
+}
+
+func (s *ClientTestSuite) TestDeviceCode_Denied() {
+	fakeClock := clock.NewFakeClock()
+	client := oauth2.NewClientWithInterfaces(logMocks.NewLoggerMockedAll(), fakeClock, s.httpClient, s.settings)
+
+	authorization, err := client.DeviceAuthorization(context.Background())
+	s.NoError(err)
+	s.NoError(s.idp.DenyDevice(authorization.UserCode))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		_, err = client.PollDeviceToken(context.Background(), authorization)
+	}()
+
+	fakeClock.BlockUntilTimers(1)
+	fakeClock.Advance(time.Second)
+	<-done
+
+	tokenErr := &oauth2.TokenError{}
+	s.ErrorAs(err, &tokenErr)
+	s.Equal(oauth2.ErrorCodeAccessDenied, tokenErr.Code)
+}
+
+func (s *ClientTestSuite) TestDeviceCode_Canceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := oauth2.NewClientWithInterfaces(logMocks.NewLoggerMockedAll(), clock.NewFakeClock(), s.httpClient, s.settings)
+
+	cancel()
+
+	_, err := client.PollDeviceToken(ctx, &oauth2.DeviceAuthorization{DeviceCode: "code"})
+	s.ErrorIs(err, context.Canceled)
+}
+
+func (s *ClientTestSuite) newClient() oauth2.Client {
+	return oauth2.NewClientWithInterfaces(logMocks.NewLoggerMockedAll(), clock.NewRealClock(), s.httpClient, s.settings)
+}
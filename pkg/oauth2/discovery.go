@@ -0,0 +1,55 @@
+package oauth2
+
+import (
+	"context"
+	"fmt"
+	netHttp "net/http"
+	"strings"
+
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/http"
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+// ProviderMetadata is the subset of the OpenID Connect discovery document used by the clients of this package.
+type ProviderMetadata struct {
+	Issuer                      string   `json:"issuer"`
+	AuthorizationEndpoint       string   `json:"authorization_endpoint"`
+	TokenEndpoint               string   `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string   `json:"device_authorization_endpoint"`
+	UserinfoEndpoint            string   `json:"userinfo_endpoint"`
+	JwksUri                     string   `json:"jwks_uri"`
+	ScopesSupported             []string `json:"scopes_supported"`
+	GrantTypesSupported         []string `json:"grant_types_supported"`
+	IdTokenSigningAlgValues     []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// Discover fetches the OpenID Connect discovery document of the issuer. The issuer in the document has to match the
+// requested one, otherwise an attacker controlling the document could impersonate another issuer.
+func Discover(ctx context.Context, httpClient http.Client, issuer string) (*ProviderMetadata, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	request := httpClient.NewRequest().
+		WithUrl(issuer + discoveryPath)
+
+	response, err := httpClient.Get(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("can not fetch discovery document of issuer %s: %w", issuer, err)
+	}
+
+	if response.StatusCode != netHttp.StatusOK {
+		return nil, fmt.Errorf("can not fetch discovery document of issuer %s: unexpected status %d", issuer, response.StatusCode)
+	}
+
+	metadata := &ProviderMetadata{}
+	if err = json.Unmarshal(response.Body, metadata); err != nil {
+		return nil, fmt.Errorf("can not unmarshal discovery document of issuer %s: %w", issuer, err)
+	}
+
+	if strings.TrimSuffix(metadata.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("issuer %s of the discovery document doesn't match the expected issuer %s", metadata.Issuer, issuer)
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,358 @@
+package oauth2
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	netHttp "net/http"
+	"sync"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/appctx"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/http"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"golang.org/x/sync/singleflight"
+)
+
+var ErrKeyNotFound = errors.New("key not found in key set")
+
+type KeySetSettings struct {
+	// Url of the json web key set, if it isn't set it is discovered from the issuer
+	Url    string `cfg:"url"`
+	Issuer string `cfg:"issuer"`
+	// CacheTtl defines how long the keys are used before the key set is fetched again
+	CacheTtl time.Duration `cfg:"cache_ttl" default:"1h"`
+	// MinRefreshInterval limits how often the key set is fetched because of an unknown key id, e.g. after a key rotation
+	MinRefreshInterval time.Duration `cfg:"min_refresh_interval" default:"1m"`
+	HttpClient         string        `cfg:"http_client" default:"oauth2"`
+}
+
+// JsonWebKey is a public key of a json web key set as defined by RFC 7517. Only RSA and EC keys are supported.
+type JsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	// Key is the parsed public key, either a *rsa.PublicKey or a *ecdsa.PublicKey
+	Key crypto.PublicKey `json:"-"`
+}
+
+type JsonWebKeySet struct {
+	Keys []*JsonWebKey `json:"keys"`
+}
+
+//go:generate mockery --name KeySet
+type KeySet interface {
+	// Key returns the key with the given key id. An empty key id is only accepted if the key set contains a single key.
+	Key(ctx context.Context, kid string) (*JsonWebKey, error)
+}
+
+type keySet struct {
+	logger     log.Logger
+	clock      clock.Clock
+	httpClient http.Client
+	settings   *KeySetSettings
+	refreshes  singleflight.Group
+
+	lck         sync.RWMutex
+	url         string
+	keys        map[string]*JsonWebKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	fetchErr    error
+}
+
+type keySetCtxKey string
+
+// ProvideKeySet returns the key set with the given name, the settings are read from oauth2.key_sets.<name>.
+func ProvideKeySet(ctx context.Context, config cfg.Config, logger log.Logger, name string) (KeySet, error) {
+	return appctx.Provide(ctx, keySetCtxKey(name), func() (KeySet, error) {
+		key := fmt.Sprintf("oauth2.key_sets.%s", name)
+		settings := &KeySetSettings{}
+		config.UnmarshalKey(key, settings)
+
+		return NewKeySet(ctx, config, logger, settings)
+	})
+}
+
+func NewKeySet(ctx context.Context, config cfg.Config, logger log.Logger, settings *KeySetSettings) (KeySet, error) {
+	if settings.Url == "" && settings.Issuer == "" {
+		return nil, fmt.Errorf("either the url or the issuer of the key set has to be configured")
+	}
+
+	httpClient, err := http.ProvideHttpClient(ctx, config, logger, settings.HttpClient)
+	if err != nil {
+		return nil, fmt.Errorf("can not create http client: %w", err)
+	}
+
+	return NewKeySetWithInterfaces(logger, clock.Provider, httpClient, settings), nil
+}
+
+func NewKeySetWithInterfaces(logger log.Logger, clock clock.Clock, httpClient http.Client, settings *KeySetSettings) KeySet {
+	return &keySet{
+		logger:     logger.WithChannel("oauth2-jwks"),
+		clock:      clock,
+		httpClient: httpClient,
+		settings:   settings,
+		url:        settings.Url,
+	}
+}
+
+func (s *keySet) Key(ctx context.Context, kid string) (*JsonWebKey, error) {
+	s.lck.RLock()
+	now := s.clock.Now()
+	key, found := s.lookup(kid)
+	fetched := !s.fetchedAt.IsZero()
+	fresh := fetched && now.Sub(s.fetchedAt) < s.settings.CacheTtl
+	// the key set was fetched or at least tried to be fetched recently, so we don't ask the identity provider again
+	backoff := !s.attemptedAt.IsZero() && now.Sub(s.attemptedAt) < s.settings.MinRefreshInterval
+	fetchErr := s.fetchErr
+	s.lck.RUnlock()
+
+	switch {
+	case found && (fresh || backoff):
+		// a known key stays usable while the identity provider isn't reachable
+		return key, nil
+	case backoff && !fetched:
+		return nil, fmt.Errorf("can not fetch key set: %w", fetchErr)
+	case backoff:
+		// an unknown key id might be caused by a key rotation, but we don't want to fetch the key set for every token
+		// with a made up key id
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+	}
+
+	err := s.refresh(ctx)
+
+	s.lck.RLock()
+	defer s.lck.RUnlock()
+
+	if refreshed, ok := s.lookup(kid); ok && (err == nil || found) {
+		if err != nil {
+			s.logger.Warn("can not refresh key set, using the cached key %s: %s", kid, err.Error())
+		}
+
+		return refreshed, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+}
+
+func (s *keySet) lookup(kid string) (*JsonWebKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+
+	return key, ok
+}
+
+// refresh fetches the key set once for all concurrent callers. The cached keys stay readable in the meantime.
+func (s *keySet) refresh(ctx context.Context) error {
+	// the fetch is shared with other callers, so it mustn't be canceled if the request of the first caller is
+	ctx = context.WithoutCancel(ctx)
+
+	_, err, _ := s.refreshes.Do("refresh", func() (interface{}, error) {
+		keys, err := s.fetch(ctx)
+
+		s.lck.Lock()
+		defer s.lck.Unlock()
+
+		s.attemptedAt = s.clock.Now()
+		s.fetchErr = err
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.keys = keys
+		s.fetchedAt = s.attemptedAt
+
+		return nil, nil
+	})
+
+	return err
+}
+
+func (s *keySet) fetch(ctx context.Context) (map[string]*JsonWebKey, error) {
+	if s.url == "" {
+		metadata, err := Discover(ctx, s.httpClient, s.settings.Issuer)
+		if err != nil {
+			return nil, err
+		}
+
+		if metadata.JwksUri == "" {
+			return nil, fmt.Errorf("the discovery document of issuer %s doesn't contain a jwks uri", s.settings.Issuer)
+		}
+
+		s.url = metadata.JwksUri
+	}
+
+	request := s.httpClient.NewJsonRequest().
+		WithUrl(s.url)
+
+	response, err := s.httpClient.Get(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("can not fetch key set %s: %w", s.url, err)
+	}
+
+	if response.StatusCode != netHttp.StatusOK {
+		return nil, fmt.Errorf("can not fetch key set %s: unexpected status %d", s.url, response.StatusCode)
+	}
+
+	set := &JsonWebKeySet{}
+	if err = json.Unmarshal(response.Body, set); err != nil {
+		return nil, fmt.Errorf("can not unmarshal key set %s: %w", s.url, err)
+	}
+
+	keys := make(map[string]*JsonWebKey, len(set.Keys))
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		// keys of unsupported types are skipped, the identity provider might publish keys we don't need
+		if key.Key, err = key.PublicKey(); err != nil {
+			s.logger.Warn("skipping key %s of key set %s: %s", key.Kid, s.url, err.Error())
+
+			continue
+		}
+
+		keys[key.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// PublicKey parses the public key of the json web key.
+func (k *JsonWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("can not decode modulus: %w", err)
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("can not decode exponent: %w", err)
+		}
+
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponent is too large")
+		}
+
+		return &rsa.PublicKey{
+			N: n,
+			E: int(e.Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("can not decode x coordinate: %w", err)
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("can not decode y coordinate: %w", err)
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     x,
+			Y:     y,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+// NewJsonWebKey converts a RSA or EC public key into a json web key.
+func NewJsonWebKey(kid string, alg string, publicKey crypto.PublicKey) (*JsonWebKey, error) {
+	key := &JsonWebKey{
+		Kid: kid,
+		Use: "sig",
+		Alg: alg,
+		Key: publicKey,
+	}
+
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		key.Kty = "RSA"
+		key.N = encodeBigInt(publicKey.N, 0)
+		key.E = encodeBigInt(big.NewInt(int64(publicKey.E)), 0)
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+
+		key.Kty = "EC"
+		key.Crv = publicKey.Curve.Params().Name
+		key.X = encodeBigInt(publicKey.X, size)
+		key.Y = encodeBigInt(publicKey.Y, size)
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	return key, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, fmt.Errorf("value is empty")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+func encodeBigInt(value *big.Int, size int) string {
+	data := value.Bytes()
+
+	// coordinates of ec keys have a fixed size, so leading zeros have to be kept
+	if len(data) < size {
+		data = append(make([]byte, size-len(data)), data...)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,184 @@
+package oauth2_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/http"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/justtrackio/gosoline/pkg/oauth2"
+	"github.com/justtrackio/gosoline/pkg/oauth2/oauth2test"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestKeySetTestSuite(t *testing.T) {
+	suite.Run(t, new(KeySetTestSuite))
+}
+
+type KeySetTestSuite struct {
+	suite.Suite
+
+	idp    *oauth2test.FakeIdentityProvider
+	clock  clock.FakeClock
+	keySet oauth2.KeySet
+}
+
+func (s *KeySetTestSuite) SetupTest() {
+	var err error
+
+	s.idp, err = oauth2test.NewFakeIdentityProvider("client", "secret")
+	s.Require().NoError(err)
+
+	httpClient := http.NewHttpClientWithInterfaces(logMocks.NewLoggerMockedAll(), clock.NewRealClock(), metricMocks.NewWriterMockedAll(), resty.New())
+
+	s.clock = clock.NewFakeClock()
+	s.keySet = oauth2.NewKeySetWithInterfaces(logMocks.NewLoggerMockedAll(), s.clock, httpClient, &oauth2.KeySetSettings{
+		Issuer:             s.idp.Issuer(),
+		CacheTtl:           time.Hour,
+		MinRefreshInterval: time.Minute,
+	})
+}
+
+func (s *KeySetTestSuite) TearDownTest() {
+	s.idp.Close()
+}
+
+func (s *KeySetTestSuite) TestKeys() {
+	rsaKey, err := s.keySet.Key(context.Background(), "RS256-1")
+	s.NoError(err)
+	s.Equal("RS256", rsaKey.Alg)
+	s.IsType(&rsa.PublicKey{}, rsaKey.Key)
+
+	ecKey, err := s.keySet.Key(context.Background(), "ES256-1")
+	s.NoError(err)
+	s.Equal("P-256", ecKey.Crv)
+	s.IsType(&ecdsa.PublicKey{}, ecKey.Key)
+
+	s.Equal(1, s.idp.JwksRequests())
+
+	// the cache expired
+	s.clock.Advance(time.Hour)
+	_, err = s.keySet.Key(context.Background(), "RS256-1")
+	s.NoError(err)
+	s.Equal(2, s.idp.JwksRequests())
+}
+
+func (s *KeySetTestSuite) TestRotation() {
+	_, err := s.keySet.Key(context.Background(), "RS256-1")
+	s.NoError(err)
+
+	s.NoError(s.idp.RotateKeys())
+
+	// unknown key ids don't cause a refresh within the min refresh interval
+	_, err = s.keySet.Key(context.Background(), "RS256-2")
+	s.ErrorIs(err, oauth2.ErrKeyNotFound)
+	s.Equal(1, s.idp.JwksRequests())
+
+	s.clock.Advance(time.Minute)
+
+	key, err := s.keySet.Key(context.Background(), "RS256-2")
+	s.NoError(err)
+	s.Equal("RS256-2", key.Kid)
+	s.Equal(2, s.idp.JwksRequests())
+
+	_, err = s.keySet.Key(context.Background(), "RS256-1")
+	s.ErrorIs(err, oauth2.ErrKeyNotFound)
+}
+
+func (s *KeySetTestSuite) TestCachedKeyIsUsedIfIdpIsDown() {
+	_, err := s.keySet.Key(context.Background(), "ES256-1")
+	s.NoError(err)
+
+	s.idp.Close()
+	s.clock.Advance(time.Hour)
+
+	key, err := s.keySet.Key(context.Background(), "ES256-1")
+	s.NoError(err)
+	s.Equal("ES256-1", key.Kid)
+}
+
+func (s *KeySetTestSuite) TestFailedRefreshBacksOff() {
+	_, err := s.keySet.Key(context.Background(), "ES256-1")
+	s.NoError(err)
+
+	s.idp.SetJwksDown(true)
+	s.clock.Advance(time.Hour)
+
+	// the first validation after the cache expired tries to refresh the key set, the following ones don't
+	for i := 0; i < 3; i++ {
+		key, err := s.keySet.Key(context.Background(), "ES256-1")
+		s.NoError(err)
+		s.Equal("ES256-1", key.Kid)
+	}
+
+	_, err = s.keySet.Key(context.Background(), "ES256-2")
+	s.ErrorIs(err, oauth2.ErrKeyNotFound)
+	s.Equal(2, s.idp.JwksRequests())
+
+	s.clock.Advance(time.Minute)
+	s.idp.SetJwksDown(false)
+
+	_, err = s.keySet.Key(context.Background(), "ES256-1")
+	s.NoError(err)
+	s.Equal(3, s.idp.JwksRequests())
+}
+
+func (s *KeySetTestSuite) TestFailedInitialFetchBacksOff() {
+	s.idp.SetJwksDown(true)
+
+	_, err := s.keySet.Key(context.Background(), "ES256-1")
+	s.Error(err)
+
+	_, err = s.keySet.Key(context.Background(), "ES256-1")
+	s.ErrorContains(err, "unexpected status 503")
+	s.Equal(1, s.idp.JwksRequests())
+}
+
+func (s *KeySetTestSuite) TestConcurrentRefresh() {
+	_, err := s.keySet.Key(context.Background(), "RS256-1")
+	s.NoError(err)
+
+	s.NoError(s.idp.RotateKeys())
+	s.clock.Advance(time.Minute)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			key, err := s.keySet.Key(context.Background(), "RS256-2")
+			s.NoError(err)
+			s.Equal("RS256-2", key.Kid)
+		}()
+	}
+
+	wg.Wait()
+
+	s.Equal(2, s.idp.JwksRequests())
+}
+
+func (s *KeySetTestSuite) TestJsonWebKeyRoundTrip() {
+	signed, err := s.idp.SignToken("ES256", map[string]interface{}{"sub": "user"})
+	s.NoError(err)
+	s.NotEmpty(signed)
+
+	key, err := s.keySet.Key(context.Background(), "ES256-1")
+	s.NoError(err)
+
+	converted, err := oauth2.NewJsonWebKey(key.Kid, key.Alg, key.Key)
+	s.NoError(err)
+	s.Equal(key.X, converted.X)
+	s.Equal(key.Y, converted.Y)
+
+	_, err = (&oauth2.JsonWebKey{Kty: "oct"}).PublicKey()
+	s.EqualError(err, "unsupported key type oct")
+}
@@ -0,0 +1,446 @@
+// Package oauth2test provides a fake identity provider to test oauth2 clients and token validation.
+package oauth2test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	netHttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/justtrackio/gosoline/pkg/oauth2"
+)
+
+const (
+	fakeIdpDiscoveryPath           = "/.well-known/openid-configuration"
+	fakeIdpTokenPath               = "/token"
+	fakeIdpDeviceAuthorizationPath = "/device/authorize"
+	fakeIdpJwksPath                = "/jwks"
+	fakeIdpDeviceExpiresIn         = 600
+)
+
+type fakeTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type fakeDeviceState struct {
+	deviceCode string
+	subject    string
+	approved   bool
+	denied     bool
+}
+
+// FakeIdentityProvider is a local OpenID Connect identity provider for tests. It serves the discovery document, a key
+// set with a RSA and an EC key, and a token endpoint supporting the client credentials, refresh token and device code
+// flows. The access tokens are jwts signed with the RSA key.
+type FakeIdentityProvider struct {
+	server       *httptest.Server
+	clientId     string
+	clientSecret string
+
+	lck           sync.Mutex
+	tokenTtl      time.Duration
+	rsaKey        *rsa.PrivateKey
+	ecKey         *ecdsa.PrivateKey
+	keyGeneration int
+	refreshTokens map[string]string
+	devices       map[string]*fakeDeviceState
+	counter       int
+	tokenRequests int
+	jwksRequests  int
+	jwksDown      bool
+}
+
+func NewFakeIdentityProvider(clientId string, clientSecret string) (*FakeIdentityProvider, error) {
+	idp := &FakeIdentityProvider{
+		clientId:      clientId,
+		clientSecret:  clientSecret,
+		tokenTtl:      time.Hour,
+		refreshTokens: make(map[string]string),
+		devices:       make(map[string]*fakeDeviceState),
+	}
+
+	if err := idp.RotateKeys(); err != nil {
+		return nil, err
+	}
+
+	mux := netHttp.NewServeMux()
+	mux.HandleFunc(fakeIdpDiscoveryPath, idp.handleDiscovery)
+	mux.HandleFunc(fakeIdpJwksPath, idp.handleJwks)
+	mux.HandleFunc(fakeIdpTokenPath, idp.handleToken)
+	mux.HandleFunc(fakeIdpDeviceAuthorizationPath, idp.handleDeviceAuthorization)
+
+	idp.server = httptest.NewServer(mux)
+
+	return idp, nil
+}
+
+func (p *FakeIdentityProvider) Close() {
+	p.server.Close()
+}
+
+// Issuer is the url of the identity provider, use it as the issuer of the settings of a client or key set.
+func (p *FakeIdentityProvider) Issuer() string {
+	return p.server.URL
+}
+
+func (p *FakeIdentityProvider) TokenUrl() string {
+	return p.server.URL + fakeIdpTokenPath
+}
+
+func (p *FakeIdentityProvider) JwksUrl() string {
+	return p.server.URL + fakeIdpJwksPath
+}
+
+// SetTokenTtl changes the lifetime of the issued access tokens.
+func (p *FakeIdentityProvider) SetTokenTtl(ttl time.Duration) {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	p.tokenTtl = ttl
+}
+
+// RotateKeys replaces the signing keys, tokens signed with the old keys can't be validated with the key set anymore.
+func (p *FakeIdentityProvider) RotateKeys() error {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("can not generate rsa key: %w", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("can not generate ec key: %w", err)
+	}
+
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	p.rsaKey = rsaKey
+	p.ecKey = ecKey
+	p.keyGeneration++
+
+	return nil
+}
+
+// SignToken signs the claims with the current RS256 or ES256 key of the identity provider.
+func (p *FakeIdentityProvider) SignToken(alg string, claims jwt.MapClaims) (string, error) {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	return p.signToken(alg, claims)
+}
+
+// IssueRefreshToken creates a refresh token for the subject, e.g. to test a refresh token source.
+func (p *FakeIdentityProvider) IssueRefreshToken(subject string) string {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	return p.issueRefreshToken(subject)
+}
+
+// ApproveDevice approves the device authorization with the given user code for the subject.
+func (p *FakeIdentityProvider) ApproveDevice(userCode string, subject string) error {
+	return p.updateDevice(userCode, func(state *fakeDeviceState) {
+		state.approved = true
+		state.subject = subject
+	})
+}
+
+func (p *FakeIdentityProvider) DenyDevice(userCode string) error {
+	return p.updateDevice(userCode, func(state *fakeDeviceState) {
+		state.denied = true
+	})
+}
+
+// TokenRequests returns the number of requests to the token endpoint.
+func (p *FakeIdentityProvider) TokenRequests() int {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	return p.tokenRequests
+}
+
+// JwksRequests returns the number of requests to the key set.
+func (p *FakeIdentityProvider) JwksRequests() int {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	return p.jwksRequests
+}
+
+// SetJwksDown makes the key set endpoint respond with an error, the requests are counted nevertheless.
+func (p *FakeIdentityProvider) SetJwksDown(down bool) {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	p.jwksDown = down
+}
+
+func (p *FakeIdentityProvider) updateDevice(userCode string, update func(state *fakeDeviceState)) error {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	state, ok := p.devices[userCode]
+	if !ok {
+		return fmt.Errorf("there is no device authorization with user code %s", userCode)
+	}
+
+	update(state)
+
+	return nil
+}
+
+func (p *FakeIdentityProvider) handleDiscovery(w netHttp.ResponseWriter, _ *netHttp.Request) {
+	writeFakeIdpJson(w, netHttp.StatusOK, &oauth2.ProviderMetadata{
+		Issuer:                      p.server.URL,
+		TokenEndpoint:               p.server.URL + fakeIdpTokenPath,
+		DeviceAuthorizationEndpoint: p.server.URL + fakeIdpDeviceAuthorizationPath,
+		JwksUri:                     p.server.URL + fakeIdpJwksPath,
+		GrantTypesSupported:         []string{oauth2.GrantTypeClientCredentials, oauth2.GrantTypeRefreshToken, oauth2.GrantTypeDeviceCode},
+		IdTokenSigningAlgValues:     []string{"RS256", "ES256"},
+	})
+}
+
+func (p *FakeIdentityProvider) handleJwks(w netHttp.ResponseWriter, _ *netHttp.Request) {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	p.jwksRequests++
+
+	if p.jwksDown {
+		netHttp.Error(w, "unavailable", netHttp.StatusServiceUnavailable)
+
+		return
+	}
+
+	rsaKey, err := oauth2.NewJsonWebKey(p.kid("RS256"), "RS256", &p.rsaKey.PublicKey)
+	if err != nil {
+		netHttp.Error(w, err.Error(), netHttp.StatusInternalServerError)
+
+		return
+	}
+
+	ecKey, err := oauth2.NewJsonWebKey(p.kid("ES256"), "ES256", &p.ecKey.PublicKey)
+	if err != nil {
+		netHttp.Error(w, err.Error(), netHttp.StatusInternalServerError)
+
+		return
+	}
+
+	writeFakeIdpJson(w, netHttp.StatusOK, &oauth2.JsonWebKeySet{
+		Keys: []*oauth2.JsonWebKey{rsaKey, ecKey},
+	})
+}
+
+func (p *FakeIdentityProvider) handleDeviceAuthorization(w netHttp.ResponseWriter, r *netHttp.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("client_id") != p.clientId {
+		writeFakeIdpError(w, netHttp.StatusUnauthorized, oauth2.ErrorCodeInvalidClient)
+
+		return
+	}
+
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	p.counter++
+	userCode := fmt.Sprintf("USER-%d", p.counter)
+	deviceCode := fmt.Sprintf("device-code-%d", p.counter)
+
+	p.devices[userCode] = &fakeDeviceState{
+		deviceCode: deviceCode,
+	}
+
+	writeFakeIdpJson(w, netHttp.StatusOK, &oauth2.DeviceAuthorization{
+		DeviceCode:      deviceCode,
+		UserCode:        userCode,
+		VerificationUri: p.server.URL + "/device",
+		ExpiresIn:       fakeIdpDeviceExpiresIn,
+		Interval:        1,
+	})
+}
+
+func (p *FakeIdentityProvider) handleToken(w netHttp.ResponseWriter, r *netHttp.Request) {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+
+	p.tokenRequests++
+
+	if err := r.ParseForm(); err != nil {
+		writeFakeIdpError(w, netHttp.StatusBadRequest, "invalid_request")
+
+		return
+	}
+
+	grantType := r.PostForm.Get("grant_type")
+
+	if !p.authenticateClient(r, grantType) {
+		writeFakeIdpError(w, netHttp.StatusUnauthorized, oauth2.ErrorCodeInvalidClient)
+
+		return
+	}
+
+	switch grantType {
+	case oauth2.GrantTypeClientCredentials:
+		p.writeToken(w, p.clientId, r.PostForm.Get("scope"), r.PostForm.Get("audience"), false)
+	case oauth2.GrantTypeRefreshToken:
+		refreshToken := r.PostForm.Get("refresh_token")
+		subject, ok := p.refreshTokens[refreshToken]
+
+		if !ok {
+			writeFakeIdpError(w, netHttp.StatusBadRequest, oauth2.ErrorCodeInvalidGrant)
+
+			return
+		}
+
+		// refresh tokens are rotated, so every refresh token can only be used once
+		delete(p.refreshTokens, refreshToken)
+		p.writeToken(w, subject, "", "", true)
+	case oauth2.GrantTypeDeviceCode:
+		p.handleDeviceToken(w, r.PostForm.Get("device_code"))
+	default:
+		writeFakeIdpError(w, netHttp.StatusBadRequest, oauth2.ErrorCodeUnsupportedGrantType)
+	}
+}
+
+func (p *FakeIdentityProvider) handleDeviceToken(w netHttp.ResponseWriter, deviceCode string) {
+	for userCode, state := range p.devices {
+		if state.deviceCode != deviceCode {
+			continue
+		}
+
+		switch {
+		case state.denied:
+			delete(p.devices, userCode)
+			writeFakeIdpError(w, netHttp.StatusBadRequest, oauth2.ErrorCodeAccessDenied)
+		case state.approved:
+			delete(p.devices, userCode)
+			p.writeToken(w, state.subject, "", "", true)
+		default:
+			writeFakeIdpError(w, netHttp.StatusBadRequest, oauth2.ErrorCodeAuthorizationPending)
+		}
+
+		return
+	}
+
+	writeFakeIdpError(w, netHttp.StatusBadRequest, oauth2.ErrorCodeExpiredToken)
+}
+
+// authenticateClient accepts the client credentials with basic auth or in the body. Public clients of the device code
+// flow only send their client id.
+func (p *FakeIdentityProvider) authenticateClient(r *netHttp.Request, grantType string) bool {
+	clientId, clientSecret, ok := r.BasicAuth()
+
+	if ok {
+		clientId, _ = url.QueryUnescape(clientId)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientId = r.PostForm.Get("client_id")
+		clientSecret = r.PostForm.Get("client_secret")
+	}
+
+	if clientId != p.clientId {
+		return false
+	}
+
+	if grantType == oauth2.GrantTypeDeviceCode && clientSecret == "" {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare([]byte(clientSecret), []byte(p.clientSecret)) == 1
+}
+
+func (p *FakeIdentityProvider) writeToken(w netHttp.ResponseWriter, subject string, scope string, audience string, withRefreshToken bool) {
+	if audience == "" {
+		audience = p.clientId
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": p.server.URL,
+		"sub": subject,
+		"aud": audience,
+		"iat": now.Unix(),
+		"exp": now.Add(p.tokenTtl).Unix(),
+	}
+
+	if scope != "" {
+		claims["scope"] = scope
+	}
+
+	accessToken, err := p.signToken("RS256", claims)
+	if err != nil {
+		writeFakeIdpError(w, netHttp.StatusInternalServerError, "server_error")
+
+		return
+	}
+
+	response := &fakeTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		Scope:       scope,
+		ExpiresIn:   int64(p.tokenTtl / time.Second),
+	}
+
+	if withRefreshToken {
+		response.RefreshToken = p.issueRefreshToken(subject)
+	}
+
+	writeFakeIdpJson(w, netHttp.StatusOK, response)
+}
+
+func (p *FakeIdentityProvider) signToken(alg string, claims jwt.MapClaims) (string, error) {
+	var token *jwt.Token
+	var key interface{}
+
+	switch alg {
+	case "RS256":
+		token = jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		key = p.rsaKey
+	case "ES256":
+		token = jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+		key = p.ecKey
+	default:
+		return "", fmt.Errorf("unsupported signing algorithm %s", alg)
+	}
+
+	token.Header["kid"] = p.kid(alg)
+
+	return token.SignedString(key)
+}
+
+func (p *FakeIdentityProvider) issueRefreshToken(subject string) string {
+	p.counter++
+	refreshToken := fmt.Sprintf("refresh-token-%d", p.counter)
+	p.refreshTokens[refreshToken] = subject
+
+	return refreshToken
+}
+
+func (p *FakeIdentityProvider) kid(alg string) string {
+	return fmt.Sprintf("%s-%d", alg, p.keyGeneration)
+}
+
+func writeFakeIdpError(w netHttp.ResponseWriter, statusCode int, code string) {
+	writeFakeIdpJson(w, statusCode, &oauth2.TokenError{
+		Code: code,
+	})
+}
+
+func writeFakeIdpJson(w netHttp.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,87 @@
+package oauth2
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+
+	ErrorCodeAuthorizationPending = "authorization_pending"
+	ErrorCodeSlowDown             = "slow_down"
+	ErrorCodeAccessDenied         = "access_denied"
+	ErrorCodeExpiredToken         = "expired_token"
+	ErrorCodeInvalidClient        = "invalid_client"
+	ErrorCodeInvalidGrant         = "invalid_grant"
+	ErrorCodeUnsupportedGrantType = "unsupported_grant_type"
+)
+
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	IdToken      string    `json:"id_token,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// Valid reports if the token can still be used at the given time. A token expiring within the leeway is treated as
+// expired already, so it isn't used for a request which reaches the server after the expiry.
+func (t *Token) Valid(now time.Time, leeway time.Duration) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+
+	if t.Expiry.IsZero() {
+		return true
+	}
+
+	return now.Add(leeway).Before(t.Expiry)
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	IdToken      string `json:"id_token"`
+	Scope        string `json:"scope"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+func (r *tokenResponse) token(now time.Time) *Token {
+	token := &Token{
+		AccessToken:  r.AccessToken,
+		TokenType:    r.TokenType,
+		RefreshToken: r.RefreshToken,
+		IdToken:      r.IdToken,
+		Scope:        r.Scope,
+	}
+
+	if r.ExpiresIn > 0 {
+		token.Expiry = now.Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+
+	return token
+}
+
+// A TokenError is the error response of a token endpoint as defined by RFC 6749, section 5.2.
+type TokenError struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *TokenError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("token request failed with status %d", e.StatusCode)
+	}
+
+	if e.Description == "" {
+		return fmt.Sprintf("token request failed with status %d: %s", e.StatusCode, e.Code)
+	}
+
+	return fmt.Sprintf("token request failed with status %d: %s: %s", e.StatusCode, e.Code, e.Description)
+}
@@ -0,0 +1,82 @@
+package oauth2
+
+import (
+	"context"
+	"fmt"
+	netHttp "net/http"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/http"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+type tokenClient struct {
+	http.Client
+	tokenSource TokenSource
+}
+
+// NewTokenClient decorates the http client with the given name, every request gets a bearer token of the client
+// credentials flow of the oauth2 client with the given name.
+func NewTokenClient(ctx context.Context, config cfg.Config, logger log.Logger, httpClientName string, oauth2ClientName string) (http.Client, error) {
+	baseClient, err := http.ProvideHttpClient(ctx, config, logger, httpClientName)
+	if err != nil {
+		return nil, fmt.Errorf("can not create http client: %w", err)
+	}
+
+	tokenSource, err := ProvideClientCredentialsTokenSource(ctx, config, logger, oauth2ClientName)
+	if err != nil {
+		return nil, fmt.Errorf("can not create token source: %w", err)
+	}
+
+	return NewTokenClientWithInterfaces(baseClient, tokenSource), nil
+}
+
+// NewTokenClientWithInterfaces adds a bearer token of the token source to every request. If the server responds with
+// 401, the token is invalidated and the request is retried once with a new token.
+func NewTokenClientWithInterfaces(baseClient http.Client, tokenSource TokenSource) http.Client {
+	return &tokenClient{
+		Client:      baseClient,
+		tokenSource: tokenSource,
+	}
+}
+
+func (c *tokenClient) Delete(ctx context.Context, request *http.Request) (*http.Response, error) {
+	return c.doRequest(ctx, request, c.Client.Delete)
+}
+
+func (c *tokenClient) Get(ctx context.Context, request *http.Request) (*http.Response, error) {
+	return c.doRequest(ctx, request, c.Client.Get)
+}
+
+func (c *tokenClient) Patch(ctx context.Context, request *http.Request) (*http.Response, error) {
+	return c.doRequest(ctx, request, c.Client.Patch)
+}
+
+func (c *tokenClient) Post(ctx context.Context, request *http.Request) (*http.Response, error) {
+	return c.doRequest(ctx, request, c.Client.Post)
+}
+
+func (c *tokenClient) Put(ctx context.Context, request *http.Request) (*http.Response, error) {
+	return c.doRequest(ctx, request, c.Client.Put)
+}
+
+func (c *tokenClient) doRequest(ctx context.Context, request *http.Request, performRequest func(ctx context.Context, request *http.Request) (*http.Response, error)) (*http.Response, error) {
+	response, err := c.doAuthorizedRequest(ctx, request, performRequest)
+	if err != nil || response.StatusCode != netHttp.StatusUnauthorized {
+		return response, err
+	}
+
+	// the token might have been revoked or the server already considers it expired, so we try again with a new one
+	c.tokenSource.Invalidate()
+
+	return c.doAuthorizedRequest(ctx, request, performRequest)
+}
+
+func (c *tokenClient) doAuthorizedRequest(ctx context.Context, request *http.Request, performRequest func(ctx context.Context, request *http.Request) (*http.Response, error)) (*http.Response, error) {
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can not get oauth2 token: %w", err)
+	}
+
+	return performRequest(ctx, request.WithAuthToken(token.AccessToken))
+}
@@ -0,0 +1,60 @@
+package oauth2_test
+
+import (
+	"context"
+	netHttp "net/http"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/http"
+	httpMocks "github.com/justtrackio/gosoline/pkg/http/mocks"
+	"github.com/justtrackio/gosoline/pkg/oauth2"
+	"github.com/justtrackio/gosoline/pkg/oauth2/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTokenClient(t *testing.T) {
+	ctx := context.Background()
+	request := http.NewRequest(nil).WithUrl("https://api.example.com/items")
+
+	tokenSource := mocks.NewTokenSource(t)
+	tokenSource.EXPECT().Token(ctx).Return(&oauth2.Token{AccessToken: "token"}, nil).Once()
+
+	baseClient := httpMocks.NewClient(t)
+	baseClient.EXPECT().Get(ctx, mock.AnythingOfType("*http.Request")).Run(func(_ context.Context, request *http.Request) {
+		assert.Equal(t, "token", request.GetToken())
+	}).Return(&http.Response{StatusCode: netHttp.StatusOK}, nil).Once()
+
+	client := oauth2.NewTokenClientWithInterfaces(baseClient, tokenSource)
+
+	response, err := client.Get(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, netHttp.StatusOK, response.StatusCode)
+}
+
+func TestTokenClient_RetryUnauthorized(t *testing.T) {
+	ctx := context.Background()
+	request := http.NewRequest(nil).WithUrl("https://api.example.com/items")
+
+	tokenSource := mocks.NewTokenSource(t)
+	tokenSource.EXPECT().Token(ctx).Return(&oauth2.Token{AccessToken: "revoked"}, nil).Once()
+	tokenSource.EXPECT().Invalidate().Once()
+	tokenSource.EXPECT().Token(ctx).Return(&oauth2.Token{AccessToken: "fresh"}, nil).Once()
+
+	var tokens []string
+
+	baseClient := httpMocks.NewClient(t)
+	baseClient.EXPECT().Post(ctx, mock.AnythingOfType("*http.Request")).Run(func(_ context.Context, request *http.Request) {
+		tokens = append(tokens, request.GetToken())
+	}).Return(&http.Response{StatusCode: netHttp.StatusUnauthorized}, nil).Once()
+	baseClient.EXPECT().Post(ctx, mock.AnythingOfType("*http.Request")).Run(func(_ context.Context, request *http.Request) {
+		tokens = append(tokens, request.GetToken())
+	}).Return(&http.Response{StatusCode: netHttp.StatusCreated}, nil).Once()
+
+	client := oauth2.NewTokenClientWithInterfaces(baseClient, tokenSource)
+
+	response, err := client.Post(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, netHttp.StatusCreated, response.StatusCode)
+	assert.Equal(t, []string{"revoked", "fresh"}, tokens)
+}
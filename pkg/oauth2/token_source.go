@@ -0,0 +1,106 @@
+package oauth2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/appctx"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+// A TokenSource caches a token and fetches a new one shortly before it expires.
+//
+//go:generate mockery --name TokenSource
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+	// Invalidate drops the cached token, e.g. because the server rejected it although it wasn't expired yet
+	Invalidate()
+}
+
+type tokenFetcher func(ctx context.Context, current *Token) (*Token, error)
+
+type cachedTokenSource struct {
+	clock  clock.Clock
+	leeway time.Duration
+	fetch  tokenFetcher
+
+	lck   sync.Mutex
+	token *Token
+	// last keeps the previous token after an invalidation, so a refresh token isn't lost
+	last *Token
+}
+
+type tokenSourceCtxKey string
+
+// ProvideClientCredentialsTokenSource returns a token source for the client credentials flow of the client with the
+// given name, which is shared by all users of the client.
+func ProvideClientCredentialsTokenSource(ctx context.Context, config cfg.Config, logger log.Logger, name string) (TokenSource, error) {
+	return appctx.Provide(ctx, tokenSourceCtxKey(name), func() (TokenSource, error) {
+		client, err := ProvideClient(ctx, config, logger, name)
+		if err != nil {
+			return nil, fmt.Errorf("can not create oauth2 client %s: %w", name, err)
+		}
+
+		settings := ReadClientSettings(config, name)
+
+		return NewClientCredentialsTokenSource(client, clock.Provider, settings.ExpiryLeeway), nil
+	})
+}
+
+func NewClientCredentialsTokenSource(client Client, clock clock.Clock, leeway time.Duration) TokenSource {
+	return newCachedTokenSource(clock, leeway, nil, func(ctx context.Context, _ *Token) (*Token, error) {
+		return client.ClientCredentials(ctx)
+	})
+}
+
+// NewRefreshTokenSource uses the refresh token of the given token to get new tokens. The given token is used until it
+// expires, a rotated refresh token replaces the previous one.
+func NewRefreshTokenSource(client Client, clock clock.Clock, leeway time.Duration, token *Token) TokenSource {
+	return newCachedTokenSource(clock, leeway, token, func(ctx context.Context, current *Token) (*Token, error) {
+		if current == nil || current.RefreshToken == "" {
+			return nil, fmt.Errorf("no refresh token available")
+		}
+
+		return client.RefreshToken(ctx, current.RefreshToken)
+	})
+}
+
+func newCachedTokenSource(clock clock.Clock, leeway time.Duration, token *Token, fetch tokenFetcher) *cachedTokenSource {
+	return &cachedTokenSource{
+		clock:  clock,
+		leeway: leeway,
+		fetch:  fetch,
+		token:  token,
+		last:   token,
+	}
+}
+
+func (s *cachedTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.lck.Lock()
+	defer s.lck.Unlock()
+
+	if s.token.Valid(s.clock.Now(), s.leeway) {
+		return s.token, nil
+	}
+
+	token, err := s.fetch(ctx, s.last)
+	if err != nil {
+		return nil, err
+	}
+
+	s.token = token
+	s.last = token
+
+	return token, nil
+}
+
+func (s *cachedTokenSource) Invalidate() {
+	s.lck.Lock()
+	defer s.lck.Unlock()
+
+	s.token = nil
+}
@@ -0,0 +1,91 @@
+package oauth2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/oauth2"
+	"github.com/justtrackio/gosoline/pkg/oauth2/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCredentialsTokenSource(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clock.NewFakeClock()
+
+	first := &oauth2.Token{AccessToken: "first", Expiry: fakeClock.Now().Add(time.Hour)}
+	second := &oauth2.Token{AccessToken: "second", Expiry: fakeClock.Now().Add(2 * time.Hour)}
+	third := &oauth2.Token{AccessToken: "third", Expiry: fakeClock.Now().Add(3 * time.Hour)}
+
+	client := mocks.NewClient(t)
+	client.EXPECT().ClientCredentials(ctx).Return(first, nil).Once()
+	client.EXPECT().ClientCredentials(ctx).Return(second, nil).Once()
+	client.EXPECT().ClientCredentials(ctx).Return(third, nil).Once()
+
+	source := oauth2.NewClientCredentialsTokenSource(client, fakeClock, time.Minute)
+
+	token, err := source.Token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, first, token)
+
+	// the token is cached until it expires within the leeway
+	fakeClock.Advance(58 * time.Minute)
+	token, err = source.Token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, first, token)
+
+	fakeClock.Advance(time.Minute)
+	token, err = source.Token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, second, token)
+
+	source.Invalidate()
+	token, err = source.Token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, third, token)
+}
+
+func TestClientCredentialsTokenSource_Error(t *testing.T) {
+	ctx := context.Background()
+
+	client := mocks.NewClient(t)
+	client.EXPECT().ClientCredentials(ctx).Return(nil, fmt.Errorf("idp not reachable")).Once()
+
+	source := oauth2.NewClientCredentialsTokenSource(client, clock.NewFakeClock(), time.Minute)
+
+	_, err := source.Token(ctx)
+	assert.EqualError(t, err, "idp not reachable")
+}
+
+func TestRefreshTokenSource(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clock.NewFakeClock()
+
+	initial := &oauth2.Token{AccessToken: "initial", RefreshToken: "refresh-1", Expiry: fakeClock.Now().Add(time.Hour)}
+	refreshed := &oauth2.Token{AccessToken: "refreshed", RefreshToken: "refresh-2", Expiry: fakeClock.Now().Add(2 * time.Hour)}
+	again := &oauth2.Token{AccessToken: "again", RefreshToken: "refresh-3", Expiry: fakeClock.Now().Add(3 * time.Hour)}
+
+	client := mocks.NewClient(t)
+	client.EXPECT().RefreshToken(ctx, "refresh-1").Return(refreshed, nil).Once()
+	client.EXPECT().RefreshToken(ctx, "refresh-2").Return(again, nil).Once()
+
+	source := oauth2.NewRefreshTokenSource(client, fakeClock, time.Minute, initial)
+
+	token, err := source.Token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, initial, token)
+
+	fakeClock.Advance(time.Hour)
+	token, err = source.Token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, refreshed, token)
+
+	// the rotated refresh token is used after an invalidation
+	source.Invalidate()
+	token, err = source.Token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, again, token)
+}
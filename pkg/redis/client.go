@@ -9,6 +9,7 @@ import (
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/exec"
 	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/metric"
 )
 
 const (
@@ -46,6 +47,64 @@ type ZRangeArgs struct {
 	Count   int64
 }
 
+type XAddArgs struct {
+	Stream     string
+	NoMkStream bool
+	// MaxLen trims the stream to at most this many entries, 0 disables trimming
+	MaxLen int64
+	MinID  string
+	// Approx trims the stream only when a whole node can be removed, which is much more efficient than exact trimming
+	Approx bool
+	Limit  int64
+	ID     string
+	Values interface{}
+}
+
+type XReadGroupArgs struct {
+	Group    string
+	Consumer string
+	// Streams contains the names of the streams followed by the ids to read from, e.g. stream1 stream2 id1 id2
+	Streams []string
+	Count   int64
+	Block   time.Duration
+	NoAck   bool
+}
+
+type XPendingExtArgs struct {
+	Stream   string
+	Group    string
+	Idle     time.Duration
+	Start    string
+	End      string
+	Count    int64
+	Consumer string
+}
+
+type XClaimArgs struct {
+	Stream   string
+	Group    string
+	Consumer string
+	MinIdle  time.Duration
+	Messages []string
+}
+
+type XMessage struct {
+	ID     string
+	Values map[string]interface{}
+}
+
+type XStream struct {
+	Stream   string
+	Messages []XMessage
+}
+
+type XPendingExt struct {
+	ID         string
+	Consumer   string
+	Idle       time.Duration
+	RetryCount int64
+}
+
 //go:generate mockery --name Pipeliner
 type Pipeliner interface {
 	baseRedis.Pipeliner
@@ -128,6 +187,30 @@ type Client interface {
 	ZRem(ctx context.Context, key string, members ...string) (int64, error)
 	ZRevRank(ctx context.Context, key string, member string) (int64, error)
 
+	XAck(ctx context.Context, stream string, group string, ids ...string) (int64, error)
+	XAdd(ctx context.Context, args XAddArgs) (string, error)
+	XClaim(ctx context.Context, args XClaimArgs) ([]XMessage, error)
+	XGroupCreateMkStream(ctx context.Context, stream string, group string, start string) (string, error)
+	XLen(ctx context.Context, stream string) (int64, error)
+	XPendingExt(ctx context.Context, args XPendingExtArgs) ([]XPendingExt, error)
+	XReadGroup(ctx context.Context, args XReadGroupArgs) ([]XStream, error)
+
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+	EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) (interface{}, error)
+	ScriptExists(ctx context.Context, hashes ...string) ([]bool, error)
+	ScriptLoad(ctx context.Context, script string) (string, error)
+	// LoadScripts loads the scripts into the script cache of redis, so RunScript doesn't have to do it on first use.
+	LoadScripts(ctx context.Context, scripts ...*Script) error
+	// RunScript executes the script by its hash and only sends the source of the script if redis doesn't know it yet.
+	RunScript(ctx context.Context, script *Script, keys []string, args ...interface{}) (interface{}, error)
+
+	Publish(ctx context.Context, channel string, message interface{}) (int64, error)
+	// Subscribe returns the messages published to the channels until ctx is canceled. The subscription survives
+	// reconnects, but messages published while the connection is lost are not delivered.
+	Subscribe(ctx context.Context, channels ...string) (<-chan *PubSubMessage, error)
+	// PSubscribe works like Subscribe, but for all channels matching the patterns.
+	PSubscribe(ctx context.Context, patterns ...string) (<-chan *PubSubMessage, error)
+
 	IsAlive(ctx context.Context) bool
 
 	Pipeline() Pipeliner
@@ -135,8 +218,10 @@ type Client interface {
 
 type redisClient struct {
 	base     baseRedis.Cmdable
+	cluster  *baseRedis.ClusterClient
 	logger   log.Logger
 	executor exec.Executor
+	metric   metric.Writer
 	settings *Settings
 }
 
@@ -149,23 +234,26 @@ func NewClient(config cfg.Config, logger log.Logger, name string) (Client, error
 
 	executor := NewExecutor(logger, settings.BackoffSettings, name)
 
-	if _, ok := dialers[settings.Dialer]; !ok {
-		return nil, fmt.Errorf("there is no redis dialer of type %s", settings.Dialer)
+	baseClient, err := newBaseClient(logger, settings)
+	if err != nil {
+		return nil, err
 	}
 
-	dialer := dialers[settings.Dialer](logger, settings)
-	baseClient := baseRedis.NewClient(&baseRedis.Options{
-		Dialer: dialer,
-	})
+	mw := metric.NewWriter()
 
-	return NewClientWithInterfaces(logger, baseClient, executor, settings), nil
+	return NewClientWithInterfaces(logger, baseClient, executor, mw, settings), nil
 }
 
-func NewClientWithInterfaces(logger log.Logger, baseRedis baseRedis.Cmdable, executor exec.Executor, settings *Settings) Client {
+func NewClientWithInterfaces(logger log.Logger, base baseRedis.Cmdable, executor exec.Executor, mw metric.Writer, settings *Settings) Client {
+	// the cluster client is not able to execute commands with keys in multiple slots, so we have to split those
+	cluster, _ := base.(*baseRedis.ClusterClient)
+
 	return &redisClient{
 		logger:   logger,
-		base:     baseRedis,
+		base:     base,
+		cluster:  cluster,
 		executor: executor,
+		metric:   mw,
 		settings: settings,
 	}
 }
@@ -178,6 +266,10 @@ func (c *redisClient) GetBaseClient(ctx context.Context) baseRedis.Cmdable {
 
 func (c *redisClient) Exists(ctx context.Context, keys ...string) (int64, error) {
 	cmd, err := c.execute(ctx, func() ErrCmder {
+		if c.cluster != nil {
+			return c.clusterExists(ctx, keys)
+		}
+
 		return c.base.Exists(ctx, keys...)
 	})
 
@@ -186,6 +278,10 @@ func (c *redisClient) Exists(ctx context.Context, keys ...string) (int64, error)
 
 func (c *redisClient) FlushDB(ctx context.Context) (string, error) {
 	cmd, err := c.execute(ctx, func() ErrCmder {
+		if c.cluster != nil {
+			return c.clusterFlushDB(ctx)
+		}
+
 		return c.base.FlushDB(ctx)
 	})
 
@@ -220,6 +316,10 @@ func (c *redisClient) SetNX(ctx context.Context, key string, value interface{},
 
 func (c *redisClient) MSet(ctx context.Context, pairs ...interface{}) error {
 	_, err := c.execute(ctx, func() ErrCmder {
+		if c.cluster != nil {
+			return c.clusterMSet(ctx, pairs)
+		}
+
 		return c.base.MSet(ctx, pairs...)
 	})
 
@@ -244,6 +344,10 @@ func (c *redisClient) Get(ctx context.Context, key string) (string, error) {
 
 func (c *redisClient) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
 	cmd, err := c.execute(ctx, func() ErrCmder {
+		if c.cluster != nil {
+			return c.clusterMGet(ctx, keys)
+		}
+
 		return c.base.MGet(ctx, keys...)
 	})
 
@@ -260,6 +364,10 @@ func (c *redisClient) HMGet(ctx context.Context, key string, fields ...string) (
 
 func (c *redisClient) Del(ctx context.Context, keys ...string) (int64, error) {
 	cmd, err := c.execute(ctx, func() ErrCmder {
+		if c.cluster != nil {
+			return c.clusterDel(ctx, keys)
+		}
+
 		return c.base.Del(ctx, keys...)
 	})
 
@@ -719,6 +827,109 @@ func (c *redisClient) ZRevRank(ctx context.Context, key string, member string) (
 	return cmd.(*baseRedis.IntCmd).Val(), err
 }
 
+func (c *redisClient) XAck(ctx context.Context, stream string, group string, ids ...string) (int64, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.XAck(ctx, stream, group, ids...)
+	})
+
+	return cmd.(*baseRedis.IntCmd).Val(), err
+}
+
+func (c *redisClient) XAdd(ctx context.Context, args XAddArgs) (string, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.XAdd(ctx, &baseRedis.XAddArgs{
+			Stream:     args.Stream,
+			NoMkStream: args.NoMkStream,
+			MaxLen:     args.MaxLen,
+			MinID:      args.MinID,
+			Approx:     args.Approx,
+			Limit:      args.Limit,
+			ID:         args.ID,
+			Values:     args.Values,
+		})
+	})
+
+	return cmd.(*baseRedis.StringCmd).Val(), err
+}
+
+func (c *redisClient) XClaim(ctx context.Context, args XClaimArgs) ([]XMessage, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.XClaim(ctx, &baseRedis.XClaimArgs{
+			Stream:   args.Stream,
+			Group:    args.Group,
+			Consumer: args.Consumer,
+			MinIdle:  args.MinIdle,
+			Messages: args.Messages,
+		})
+	})
+
+	return c.toGosolineXMessages(cmd.(*baseRedis.XMessageSliceCmd).Val()), err
+}
+
+func (c *redisClient) XGroupCreateMkStream(ctx context.Context, stream string, group string, start string) (string, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.XGroupCreateMkStream(ctx, stream, group, start)
+	})
+
+	return cmd.(*baseRedis.StatusCmd).Val(), err
+}
+
+func (c *redisClient) XLen(ctx context.Context, stream string) (int64, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.XLen(ctx, stream)
+	})
+
+	return cmd.(*baseRedis.IntCmd).Val(), err
+}
+
+func (c *redisClient) XPendingExt(ctx context.Context, args XPendingExtArgs) ([]XPendingExt, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.XPendingExt(ctx, &baseRedis.XPendingExtArgs{
+			Stream:   args.Stream,
+			Group:    args.Group,
+			Idle:     args.Idle,
+			Start:    args.Start,
+			End:      args.End,
+			Count:    args.Count,
+			Consumer: args.Consumer,
+		})
+	})
+
+	pending := cmd.(*baseRedis.XPendingExtCmd).Val()
+	result := make([]XPendingExt, len(pending))
+
+	for i := range pending {
+		result[i] = XPendingExt(pending[i])
+	}
+
+	return result, err
+}
+
+func (c *redisClient) XReadGroup(ctx context.Context, args XReadGroupArgs) ([]XStream, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.XReadGroup(ctx, &baseRedis.XReadGroupArgs{
+			Group:    args.Group,
+			Consumer: args.Consumer,
+			Streams:  args.Streams,
+			Count:    args.Count,
+			Block:    args.Block,
+			NoAck:    args.NoAck,
+		})
+	})
+
+	streams := cmd.(*baseRedis.XStreamSliceCmd).Val()
+	result := make([]XStream, len(streams))
+
+	for i := range streams {
+		result[i] = XStream{
+			Stream:   streams[i].Stream,
+			Messages: c.toGosolineXMessages(streams[i].Messages),
+		}
+	}
+
+	return result, err
+}
+
 func (c *redisClient) IsAlive(ctx context.Context) bool {
 	cmd, err := c.execute(ctx, func() ErrCmder {
 		return c.base.Ping(ctx)
@@ -746,6 +957,12 @@ func (c *redisClient) GetSet(ctx context.Context, key string, value interface{})
 }
 
 func (c *redisClient) Pipeline() Pipeliner {
+	if c.cluster != nil {
+		return &clusterPipeliner{
+			Pipeliner: c.cluster.Pipeline(),
+		}
+	}
+
 	return c.base.Pipeline()
 }
 
@@ -766,6 +983,26 @@ func (c *redisClient) toGosolineZs(zs []baseRedis.Z) []Z {
 	return result
 }
 
+func (c *redisClient) writeMetric(metricName string) {
+	c.metric.WriteOne(&metric.Datum{
+		MetricName: metricName,
+		Dimensions: map[string]string{
+			"Redis": c.settings.Name,
+		},
+		Unit:  metric.UnitCount,
+		Value: 1.0,
+	})
+}
+
+func (c *redisClient) toGosolineXMessages(messages []baseRedis.XMessage) []XMessage {
+	result := make([]XMessage, len(messages))
+	for i := range messages {
+		result[i] = XMessage(messages[i])
+	}
+
+	return result
+}
+
 func (c *redisClient) toGoRedisZs(zs []Z) []baseRedis.Z {
 	result := make([]baseRedis.Z, len(zs))
 	for i := range zs {
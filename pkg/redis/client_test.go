@@ -11,6 +11,7 @@ import (
 	baseRedis "github.com/go-redis/redis/v8"
 	"github.com/justtrackio/gosoline/pkg/exec"
 	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
 	"github.com/justtrackio/gosoline/pkg/redis"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -42,7 +43,7 @@ func (s *ClientWithMiniRedisTestSuite) SetupTest() {
 	})
 
 	s.server = server
-	s.client = redis.NewClientWithInterfaces(logger, s.baseClient, executor, s.settings)
+	s.client = redis.NewClientWithInterfaces(logger, s.baseClient, executor, metricMocks.NewWriterMockedAll(), s.settings)
 }
 
 func (s *ClientWithMiniRedisTestSuite) TestGetNotFound() {
@@ -58,7 +59,7 @@ func (s *ClientWithMiniRedisTestSuite) TestGetNotFound() {
 		MaxInterval:     time.Second * 3,
 		MaxElapsedTime:  0,
 	}, "test")
-	s.client = redis.NewClientWithInterfaces(logger, s.baseClient, executor, s.settings)
+	s.client = redis.NewClientWithInterfaces(logger, s.baseClient, executor, metricMocks.NewWriterMockedAll(), s.settings)
 
 	res, err := s.client.Get(context.Background(), "missing")
 
@@ -672,6 +673,67 @@ func (s *ClientWithMiniRedisTestSuite) TestExpire() {
 	s.NoError(err, "there should be no error on Exists")
 }
 
+func (s *ClientWithMiniRedisTestSuite) TestStreamConsumerGroup() {
+	ctx := context.Background()
+
+	_, err := s.client.XGroupCreateMkStream(ctx, "stream", "group", "$")
+	s.NoError(err, "there should be no error on XGroupCreateMkStream")
+
+	for _, value := range []string{"v1", "v2", "v3"} {
+		_, err = s.client.XAdd(ctx, redis.XAddArgs{
+			Stream: "stream",
+			MaxLen: 2,
+			Values: map[string]interface{}{"value": value},
+		})
+		s.NoError(err, "there should be no error on XAdd")
+	}
+
+	length, err := s.client.XLen(ctx, "stream")
+	s.NoError(err, "there should be no error on XLen")
+	s.Equal(int64(2), length, "the stream should be trimmed to its max length")
+
+	streams, err := s.client.XReadGroup(ctx, redis.XReadGroupArgs{
+		Group:    "group",
+		Consumer: "consumer",
+		Streams:  []string{"stream", ">"},
+		Count:    10,
+	})
+	s.NoError(err, "there should be no error on XReadGroup")
+	s.Len(streams, 1)
+	s.Equal("stream", streams[0].Stream)
+	s.Len(streams[0].Messages, 2)
+	s.Equal("v2", streams[0].Messages[0].Values["value"])
+	s.Equal("v3", streams[0].Messages[1].Values["value"])
+
+	first, second := streams[0].Messages[0].ID, streams[0].Messages[1].ID
+
+	acked, err := s.client.XAck(ctx, "stream", "group", first)
+	s.NoError(err, "there should be no error on XAck")
+	s.Equal(int64(1), acked)
+
+	pending, err := s.client.XPendingExt(ctx, redis.XPendingExtArgs{
+		Stream: "stream",
+		Group:  "group",
+		Start:  "-",
+		End:    "+",
+		Count:  10,
+	})
+	s.NoError(err, "there should be no error on XPendingExt")
+	s.Len(pending, 1)
+	s.Equal(second, pending[0].ID)
+	s.Equal("consumer", pending[0].Consumer)
+
+	claimed, err := s.client.XClaim(ctx, redis.XClaimArgs{
+		Stream:   "stream",
+		Group:    "group",
+		Consumer: "other",
+		Messages: []string{second},
+	})
+	s.NoError(err, "there should be no error on XClaim")
+	s.Len(claimed, 1)
+	s.Equal(second, claimed[0].ID)
+}
+
 func (s *ClientWithMiniRedisTestSuite) TestIsAlive() {
 	alive := s.client.IsAlive(context.Background())
 	s.True(alive)
@@ -693,7 +755,7 @@ func (s *ClientWithMockTestSuite) SetupTest() {
 	executor := redis.NewBackoffExecutor(logger, settings.BackoffSettings, "test")
 
 	s.redisMock = redismock.NewMock()
-	s.client = redis.NewClientWithInterfaces(logger, s.redisMock, executor, settings)
+	s.client = redis.NewClientWithInterfaces(logger, s.redisMock, executor, metricMocks.NewWriterMockedAll(), settings)
 }
 
 func (s *ClientWithMockTestSuite) TestSetWithOOM() {
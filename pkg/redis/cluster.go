@@ -0,0 +1,160 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	baseRedis "github.com/go-redis/redis/v8"
+)
+
+// A redis cluster rejects commands with keys in different hash slots. The client splits the multi key commands we
+// rely on (e.g. in the kvstore) into single key commands, which the pipeline of the cluster client routes to the nodes
+// owning the keys. Other multi key commands still need all of their keys in the same slot, use hash tags for that.
+
+func (c *redisClient) clusterExists(ctx context.Context, keys []string) ErrCmder {
+	return c.clusterSumPerKey(ctx, keys, func(pipe baseRedis.Pipeliner, key string) *baseRedis.IntCmd {
+		return pipe.Exists(ctx, key)
+	})
+}
+
+func (c *redisClient) clusterDel(ctx context.Context, keys []string) ErrCmder {
+	return c.clusterSumPerKey(ctx, keys, func(pipe baseRedis.Pipeliner, key string) *baseRedis.IntCmd {
+		return pipe.Del(ctx, key)
+	})
+}
+
+func (c *redisClient) clusterMGet(ctx context.Context, keys []string) ErrCmder {
+	cmds := make([]*baseRedis.StringCmd, len(keys))
+
+	_, _ = c.cluster.Pipelined(ctx, func(pipe baseRedis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, key)
+		}
+
+		return nil
+	})
+
+	// missing keys are nil, just like MGET returns them
+	values := make([]interface{}, len(keys))
+
+	for i, cmd := range cmds {
+		switch err := cmd.Err(); {
+		case err == nil:
+			values[i] = cmd.Val()
+		case !errors.Is(err, Nil):
+			return baseRedis.NewSliceResult(nil, err)
+		}
+	}
+
+	return baseRedis.NewSliceResult(values, nil)
+}
+
+func (c *redisClient) clusterMSet(ctx context.Context, pairs []interface{}) ErrCmder {
+	_, err := c.cluster.Pipelined(ctx, func(pipe baseRedis.Pipeliner) error {
+		return setPairs(ctx, pipe, pairs)
+	})
+
+	return baseRedis.NewStatusResult("OK", err)
+}
+
+func (c *redisClient) clusterFlushDB(ctx context.Context) ErrCmder {
+	err := c.cluster.ForEachMaster(ctx, func(ctx context.Context, client *baseRedis.Client) error {
+		return client.FlushDB(ctx).Err()
+	})
+
+	return baseRedis.NewStatusResult("OK", err)
+}
+
+func (c *redisClient) clusterSumPerKey(ctx context.Context, keys []string, command func(pipe baseRedis.Pipeliner, key string) *baseRedis.IntCmd) ErrCmder {
+	cmds := make([]*baseRedis.IntCmd, len(keys))
+
+	_, err := c.cluster.Pipelined(ctx, func(pipe baseRedis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = command(pipe, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return baseRedis.NewIntResult(0, err)
+	}
+
+	sum := int64(0)
+	for _, cmd := range cmds {
+		sum += cmd.Val()
+	}
+
+	return baseRedis.NewIntResult(sum, nil)
+}
+
+// clusterPipeliner splits MSET into single SETs, so the cluster client can route every key to its node. A pipeline of
+// the cluster client is never a transaction, as a transaction can't span multiple nodes.
+type clusterPipeliner struct {
+	baseRedis.Pipeliner
+}
+
+func (p *clusterPipeliner) MSet(ctx context.Context, values ...interface{}) *baseRedis.StatusCmd {
+	if err := setPairs(ctx, p.Pipeliner, values); err != nil {
+		return baseRedis.NewStatusResult("", err)
+	}
+
+	return baseRedis.NewStatusResult("OK", nil)
+}
+
+func (p *clusterPipeliner) Pipeline() baseRedis.Pipeliner {
+	return p
+}
+
+func (p *clusterPipeliner) TxPipeline() baseRedis.Pipeliner {
+	return p
+}
+
+func setPairs(ctx context.Context, pipe baseRedis.Pipeliner, values []interface{}) error {
+	pairs := flattenPairs(values)
+
+	if len(pairs)%2 != 0 {
+		return fmt.Errorf("expected key value pairs, got an odd number of %d arguments", len(pairs))
+	}
+
+	for i := 0; i < len(pairs); i += 2 {
+		pipe.Set(ctx, fmt.Sprint(pairs[i]), pairs[i+1], 0)
+	}
+
+	return nil
+}
+
+// flattenPairs accepts the same arguments as MSET of go-redis: either the pairs themselves or a single slice or map
+func flattenPairs(values []interface{}) []interface{} {
+	if len(values) != 1 {
+		return values
+	}
+
+	switch v := values[0].(type) {
+	case []interface{}:
+		return v
+	case []string:
+		pairs := make([]interface{}, len(v))
+		for i := range v {
+			pairs[i] = v[i]
+		}
+
+		return pairs
+	case map[string]interface{}:
+		pairs := make([]interface{}, 0, len(v)*2)
+		for key, value := range v {
+			pairs = append(pairs, key, value)
+		}
+
+		return pairs
+	case map[string]string:
+		pairs := make([]interface{}, 0, len(v)*2)
+		for key, value := range v {
+			pairs = append(pairs, key, value)
+		}
+
+		return pairs
+	default:
+		return values
+	}
+}
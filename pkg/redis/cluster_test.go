@@ -0,0 +1,99 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	baseRedis "github.com/go-redis/redis/v8"
+	"github.com/justtrackio/gosoline/pkg/exec"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/justtrackio/gosoline/pkg/redis"
+	"github.com/stretchr/testify/suite"
+)
+
+// miniredis answers the cluster commands as a cluster with a single node, which is enough to test the splitting of
+// the multi key commands
+type ClusterClientWithMiniRedisTestSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	server *miniredis.Miniredis
+	client redis.Client
+}
+
+func (s *ClusterClientWithMiniRedisTestSuite) SetupTest() {
+	server, err := miniredis.Run()
+	if err != nil {
+		s.FailNow(err.Error(), "can not start miniredis")
+		return
+	}
+
+	baseClient := baseRedis.NewClusterClient(&baseRedis.ClusterOptions{
+		Addrs: []string{server.Addr()},
+	})
+
+	s.ctx = context.Background()
+	s.server = server
+	s.client = redis.NewClientWithInterfaces(logMocks.NewLoggerMockedAll(), baseClient, exec.NewDefaultExecutor(), metricMocks.NewWriterMockedAll(), &redis.Settings{})
+}
+
+func (s *ClusterClientWithMiniRedisTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ClusterClientWithMiniRedisTestSuite) TestMSetMGet() {
+	err := s.client.MSet(s.ctx, "key1", "value1", "key2", "value2")
+	s.NoError(err, "there should be no error on MSet")
+
+	err = s.client.MSet(s.ctx, map[string]interface{}{"key3": "value3"})
+	s.NoError(err, "there should be no error on MSet")
+
+	values, err := s.client.MGet(s.ctx, "key1", "missing", "key2", "key3")
+	s.NoError(err, "there should be no error on MGet")
+	s.Equal([]interface{}{"value1", nil, "value2", "value3"}, values)
+}
+
+func (s *ClusterClientWithMiniRedisTestSuite) TestExistsDel() {
+	s.NoError(s.server.Set("key1", "value1"))
+	s.NoError(s.server.Set("key2", "value2"))
+
+	count, err := s.client.Exists(s.ctx, "key1", "key2", "missing")
+	s.NoError(err, "there should be no error on Exists")
+	s.Equal(int64(2), count)
+
+	count, err = s.client.Del(s.ctx, "key1", "key2", "missing")
+	s.NoError(err, "there should be no error on Del")
+	s.Equal(int64(2), count)
+
+	s.False(s.server.Exists("key1"))
+	s.False(s.server.Exists("key2"))
+}
+
+func (s *ClusterClientWithMiniRedisTestSuite) TestFlushDB() {
+	s.NoError(s.server.Set("key", "value"))
+
+	_, err := s.client.FlushDB(s.ctx)
+	s.NoError(err, "there should be no error on FlushDB")
+
+	s.False(s.server.Exists("key"))
+}
+
+func (s *ClusterClientWithMiniRedisTestSuite) TestPipelineMSet() {
+	pipe := s.client.Pipeline().TxPipeline()
+	pipe.MSet(s.ctx, []interface{}{"key1", "value1", "key2", "value2"})
+	pipe.Expire(s.ctx, "key1", time.Minute)
+
+	_, err := pipe.Exec(s.ctx)
+	s.NoError(err, "there should be no error on Exec")
+
+	s.server.CheckGet(s.T(), "key1", "value1")
+	s.server.CheckGet(s.T(), "key2", "value2")
+	s.Equal(time.Minute, s.server.TTL("key1"))
+}
+
+func TestClusterClientWithMiniRedisTestSuite(t *testing.T) {
+	suite.Run(t, new(ClusterClientWithMiniRedisTestSuite))
+}
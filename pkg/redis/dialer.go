@@ -6,13 +6,16 @@ import (
 	"net"
 	"strings"
 
+	baseRedis "github.com/go-redis/redis/v8"
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/log"
 )
 
 const (
-	DialerSrv = "srv"
-	DialerTcp = "tcp"
+	DialerCluster  = "cluster"
+	DialerSentinel = "sentinel"
+	DialerSrv      = "srv"
+	DialerTcp      = "tcp"
 )
 
 var dialers = map[string]Dialer{
@@ -25,6 +28,51 @@ type (
 	SrvNamingFactory func(appId cfg.AppId, name string) string
 )
 
+// newBaseClient creates a client for a single node connected by one of the dialers, a client for a cluster or a
+// client for a master monitored by sentinels, which follows the master on a failover.
+func newBaseClient(logger log.Logger, settings *Settings) (baseRedis.Cmdable, error) {
+	switch settings.Dialer {
+	case DialerCluster:
+		addresses := settings.Cluster.Addresses
+		if len(addresses) == 0 {
+			addresses = []string{settings.Address}
+		}
+
+		logger.Info("using cluster nodes %s for redis %s", strings.Join(addresses, ","), settings.Name)
+
+		return baseRedis.NewClusterClient(&baseRedis.ClusterOptions{
+			Addrs:    addresses,
+			ReadOnly: settings.Cluster.ReadOnly,
+		}), nil
+	case DialerSentinel:
+		if settings.Sentinel.MasterName == "" {
+			return nil, fmt.Errorf("there is no sentinel master name configured for redis %s", settings.Name)
+		}
+
+		addresses := settings.Sentinel.Addresses
+		if len(addresses) == 0 {
+			addresses = []string{settings.Address}
+		}
+
+		logger.Info("using master %s of sentinels %s for redis %s", settings.Sentinel.MasterName, strings.Join(addresses, ","), settings.Name)
+
+		return baseRedis.NewFailoverClient(&baseRedis.FailoverOptions{
+			MasterName:    settings.Sentinel.MasterName,
+			SentinelAddrs: addresses,
+		}), nil
+	}
+
+	if _, ok := dialers[settings.Dialer]; !ok {
+		return nil, fmt.Errorf("there is no redis dialer of type %s", settings.Dialer)
+	}
+
+	dialer := dialers[settings.Dialer](logger, settings)
+
+	return baseRedis.NewClient(&baseRedis.Options{
+		Dialer: dialer,
+	}), nil
+}
+
 func dialerSrv(logger log.Logger, settings *Settings) func(ctx context.Context, network, addr string) (net.Conn, error) {
 	return func(ctx context.Context, _ string, _ string) (net.Conn, error) {
 		address := settings.Address
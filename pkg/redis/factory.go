@@ -13,12 +13,27 @@ type Naming struct {
 	Pattern string `cfg:"pattern,nodecode" default:"{name}.{group}.redis.{env}.{family}"`
 }
 
+type ClusterSettings struct {
+	// Addresses of some nodes of the cluster, all other nodes are discovered from them. Address is used if it is empty.
+	Addresses []string `cfg:"addresses"`
+	// ReadOnly sends read commands to the replicas of a shard instead of its master
+	ReadOnly bool `cfg:"read_only" default:"false"`
+}
+
+type SentinelSettings struct {
+	MasterName string `cfg:"master_name"`
+	// Addresses of the sentinels, Address is used if it is empty
+	Addresses []string `cfg:"addresses"`
+}
+
 type Settings struct {
 	cfg.AppId
-	Name            string `cfg:"name"`
-	Dialer          string `cfg:"dialer" default:"tcp"`
-	Address         string `cfg:"address" default:"127.0.0.1:6379"`
-	Naming          Naming `cfg:"naming"`
+	Name            string           `cfg:"name"`
+	Dialer          string           `cfg:"dialer" default:"tcp"`
+	Address         string           `cfg:"address" default:"127.0.0.1:6379"`
+	Naming          Naming           `cfg:"naming"`
+	Cluster         ClusterSettings  `cfg:"cluster"`
+	Sentinel        SentinelSettings `cfg:"sentinel"`
 	BackoffSettings exec.BackoffSettings
 }
 
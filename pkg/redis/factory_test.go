@@ -145,6 +145,41 @@ func (s *FactoryTestSuite) TestWithDefaults() {
 	s.Equal(expected, settings)
 }
 
+func (s *FactoryTestSuite) TestClusterAndSentinel() {
+	s.initConfig(map[string]interface{}{
+		"redis": map[string]interface{}{
+			"cluster": map[string]interface{}{
+				"dialer": "cluster",
+				"cluster": map[string]interface{}{
+					"addresses": []string{"node1:6379", "node2:6379"},
+					"read_only": true,
+				},
+			},
+			"sentinel": map[string]interface{}{
+				"dialer": "sentinel",
+				"sentinel": map[string]interface{}{
+					"master_name": "master",
+					"addresses":   []string{"sentinel1:26379", "sentinel2:26379"},
+				},
+			},
+		},
+	})
+
+	settings := redis.ReadSettings(s.config, "cluster")
+	s.Equal(redis.DialerCluster, settings.Dialer)
+	s.Equal(redis.ClusterSettings{
+		Addresses: []string{"node1:6379", "node2:6379"},
+		ReadOnly:  true,
+	}, settings.Cluster)
+
+	settings = redis.ReadSettings(s.config, "sentinel")
+	s.Equal(redis.DialerSentinel, settings.Dialer)
+	s.Equal(redis.SentinelSettings{
+		MasterName: "master",
+		Addresses:  []string{"sentinel1:26379", "sentinel2:26379"},
+	}, settings.Sentinel)
+}
+
 func TestFactoryTestSuite(t *testing.T) {
 	suite.Run(t, new(FactoryTestSuite))
 }
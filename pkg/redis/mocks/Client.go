@@ -4,11 +4,10 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	redis "github.com/justtrackio/gosoline/pkg/redis"
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -320,6 +319,148 @@ func (_c *Client_Del_Call) RunAndReturn(run func(context.Context, ...string) (in
 	return _c
 }
 
+// Eval provides a mock function with given fields: ctx, script, keys, args
+func (_m *Client) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	_va := make([]interface{}, len(args))
+	for _i := range args {
+		_va[_i] = args[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, script, keys)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, ...interface{}) (interface{}, error)); ok {
+		return rf(ctx, script, keys, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, ...interface{}) interface{}); ok {
+		r0 = rf(ctx, script, keys, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, ...interface{}) error); ok {
+		r1 = rf(ctx, script, keys, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Eval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Eval'
+type Client_Eval_Call struct {
+	*mock.Call
+}
+
+// Eval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - script string
+//   - keys []string
+//   - args ...interface{}
+func (_e *Client_Expecter) Eval(ctx interface{}, script interface{}, keys interface{}, args ...interface{}) *Client_Eval_Call {
+	return &Client_Eval_Call{Call: _e.mock.On("Eval",
+		append([]interface{}{ctx, script, keys}, args...)...)}
+}
+
+func (_c *Client_Eval_Call) Run(run func(ctx context.Context, script string, keys []string, args ...interface{})) *Client_Eval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_Eval_Call) Return(_a0 interface{}, _a1 error) *Client_Eval_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Eval_Call) RunAndReturn(run func(context.Context, string, []string, ...interface{}) (interface{}, error)) *Client_Eval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EvalSha provides a mock function with given fields: ctx, sha1, keys, args
+func (_m *Client) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) (interface{}, error) {
+	_va := make([]interface{}, len(args))
+	for _i := range args {
+		_va[_i] = args[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, sha1, keys)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, ...interface{}) (interface{}, error)); ok {
+		return rf(ctx, sha1, keys, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, ...interface{}) interface{}); ok {
+		r0 = rf(ctx, sha1, keys, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, ...interface{}) error); ok {
+		r1 = rf(ctx, sha1, keys, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_EvalSha_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvalSha'
+type Client_EvalSha_Call struct {
+	*mock.Call
+}
+
+// EvalSha is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sha1 string
+//   - keys []string
+//   - args ...interface{}
+func (_e *Client_Expecter) EvalSha(ctx interface{}, sha1 interface{}, keys interface{}, args ...interface{}) *Client_EvalSha_Call {
+	return &Client_EvalSha_Call{Call: _e.mock.On("EvalSha",
+		append([]interface{}{ctx, sha1, keys}, args...)...)}
+}
+
+func (_c *Client_EvalSha_Call) Run(run func(ctx context.Context, sha1 string, keys []string, args ...interface{})) *Client_EvalSha_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_EvalSha_Call) Return(_a0 interface{}, _a1 error) *Client_EvalSha_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_EvalSha_Call) RunAndReturn(run func(context.Context, string, []string, ...interface{}) (interface{}, error)) *Client_EvalSha_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Exists provides a mock function with given fields: ctx, keys
 func (_m *Client) Exists(ctx context.Context, keys ...string) (int64, error) {
 	_va := make([]interface{}, len(keys))
@@ -1414,9 +1555,13 @@ func (_c *Client_LPop_Call) RunAndReturn(run func(context.Context, string) (stri
 
 // LPush provides a mock function with given fields: ctx, key, values
 func (_m *Client) LPush(ctx context.Context, key string, values ...interface{}) (int64, error) {
+	_va := make([]interface{}, len(values))
+	for _i := range values {
+		_va[_i] = values[_i]
+	}
 	var _ca []interface{}
 	_ca = append(_ca, ctx, key)
-	_ca = append(_ca, values...)
+	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 int64
@@ -1531,6 +1676,63 @@ func (_c *Client_LRem_Call) RunAndReturn(run func(context.Context, string, int64
 	return _c
 }
 
+// LoadScripts provides a mock function with given fields: ctx, scripts
+func (_m *Client) LoadScripts(ctx context.Context, scripts ...*redis.Script) error {
+	_va := make([]interface{}, len(scripts))
+	for _i := range scripts {
+		_va[_i] = scripts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...*redis.Script) error); ok {
+		r0 = rf(ctx, scripts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_LoadScripts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadScripts'
+type Client_LoadScripts_Call struct {
+	*mock.Call
+}
+
+// LoadScripts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scripts ...*redis.Script
+func (_e *Client_Expecter) LoadScripts(ctx interface{}, scripts ...interface{}) *Client_LoadScripts_Call {
+	return &Client_LoadScripts_Call{Call: _e.mock.On("LoadScripts",
+		append([]interface{}{ctx}, scripts...)...)}
+}
+
+func (_c *Client_LoadScripts_Call) Run(run func(ctx context.Context, scripts ...*redis.Script)) *Client_LoadScripts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]*redis.Script, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(*redis.Script)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_LoadScripts_Call) Return(_a0 error) *Client_LoadScripts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_LoadScripts_Call) RunAndReturn(run func(context.Context, ...*redis.Script) error) *Client_LoadScripts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MGet provides a mock function with given fields: ctx, keys
 func (_m *Client) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
 	_va := make([]interface{}, len(keys))
@@ -1602,9 +1804,13 @@ func (_c *Client_MGet_Call) RunAndReturn(run func(context.Context, ...string) ([
 
 // MSet provides a mock function with given fields: ctx, pairs
 func (_m *Client) MSet(ctx context.Context, pairs ...interface{}) error {
+	_va := make([]interface{}, len(pairs))
+	for _i := range pairs {
+		_va[_i] = pairs[_i]
+	}
 	var _ca []interface{}
 	_ca = append(_ca, ctx)
-	_ca = append(_ca, pairs...)
+	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 error
@@ -1655,9 +1861,13 @@ func (_c *Client_MSet_Call) RunAndReturn(run func(context.Context, ...interface{
 
 // PFAdd provides a mock function with given fields: ctx, key, els
 func (_m *Client) PFAdd(ctx context.Context, key string, els ...interface{}) (int64, error) {
+	_va := make([]interface{}, len(els))
+	for _i := range els {
+		_va[_i] = els[_i]
+	}
 	var _ca []interface{}
 	_ca = append(_ca, ctx, key)
-	_ca = append(_ca, els...)
+	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 int64
@@ -1852,6 +2062,75 @@ func (_c *Client_PFMerge_Call) RunAndReturn(run func(context.Context, string, ..
 	return _c
 }
 
+// PSubscribe provides a mock function with given fields: ctx, patterns
+func (_m *Client) PSubscribe(ctx context.Context, patterns ...string) (<-chan *redis.PubSubMessage, error) {
+	_va := make([]interface{}, len(patterns))
+	for _i := range patterns {
+		_va[_i] = patterns[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan *redis.PubSubMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) (<-chan *redis.PubSubMessage, error)); ok {
+		return rf(ctx, patterns...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) <-chan *redis.PubSubMessage); ok {
+		r0 = rf(ctx, patterns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *redis.PubSubMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, patterns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_PSubscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PSubscribe'
+type Client_PSubscribe_Call struct {
+	*mock.Call
+}
+
+// PSubscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - patterns ...string
+func (_e *Client_Expecter) PSubscribe(ctx interface{}, patterns ...interface{}) *Client_PSubscribe_Call {
+	return &Client_PSubscribe_Call{Call: _e.mock.On("PSubscribe",
+		append([]interface{}{ctx}, patterns...)...)}
+}
+
+func (_c *Client_PSubscribe_Call) Run(run func(ctx context.Context, patterns ...string)) *Client_PSubscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_PSubscribe_Call) Return(_a0 <-chan *redis.PubSubMessage, _a1 error) *Client_PSubscribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_PSubscribe_Call) RunAndReturn(run func(context.Context, ...string) (<-chan *redis.PubSubMessage, error)) *Client_PSubscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Pipeline provides a mock function with given fields:
 func (_m *Client) Pipeline() redis.Pipeliner {
 	ret := _m.Called()
@@ -1860,9 +2139,7 @@ func (_m *Client) Pipeline() redis.Pipeliner {
 	if rf, ok := ret.Get(0).(func() redis.Pipeliner); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(redis.Pipeliner)
-		}
+		r0 = ret.Get(0).(redis.Pipeliner)
 	}
 
 	return r0
@@ -1895,6 +2172,60 @@ func (_c *Client_Pipeline_Call) RunAndReturn(run func() redis.Pipeliner) *Client
 	return _c
 }
 
+// Publish provides a mock function with given fields: ctx, channel, message
+func (_m *Client) Publish(ctx context.Context, channel string, message interface{}) (int64, error) {
+	ret := _m.Called(ctx, channel, message)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (int64, error)); ok {
+		return rf(ctx, channel, message)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) int64); ok {
+		r0 = rf(ctx, channel, message)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, channel, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type Client_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+//   - message interface{}
+func (_e *Client_Expecter) Publish(ctx interface{}, channel interface{}, message interface{}) *Client_Publish_Call {
+	return &Client_Publish_Call{Call: _e.mock.On("Publish", ctx, channel, message)}
+}
+
+func (_c *Client_Publish_Call) Run(run func(ctx context.Context, channel string, message interface{})) *Client_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *Client_Publish_Call) Return(_a0 int64, _a1 error) *Client_Publish_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Publish_Call) RunAndReturn(run func(context.Context, string, interface{}) (int64, error)) *Client_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RPop provides a mock function with given fields: ctx, key
 func (_m *Client) RPop(ctx context.Context, key string) (string, error) {
 	ret := _m.Called(ctx, key)
@@ -1950,9 +2281,13 @@ func (_c *Client_RPop_Call) RunAndReturn(run func(context.Context, string) (stri
 
 // RPush provides a mock function with given fields: ctx, key, values
 func (_m *Client) RPush(ctx context.Context, key string, values ...interface{}) (int64, error) {
+	_va := make([]interface{}, len(values))
+	for _i := range values {
+		_va[_i] = values[_i]
+	}
 	var _ca []interface{}
 	_ca = append(_ca, ctx, key)
-	_ca = append(_ca, values...)
+	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 int64
@@ -2012,26 +2347,32 @@ func (_c *Client_RPush_Call) RunAndReturn(run func(context.Context, string, ...i
 	return _c
 }
 
-// SAdd provides a mock function with given fields: ctx, key, values
-func (_m *Client) SAdd(ctx context.Context, key string, values ...interface{}) (int64, error) {
+// RunScript provides a mock function with given fields: ctx, script, keys, args
+func (_m *Client) RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	_va := make([]interface{}, len(args))
+	for _i := range args {
+		_va[_i] = args[_i]
+	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, key)
-	_ca = append(_ca, values...)
+	_ca = append(_ca, ctx, script, keys)
+	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 int64
+	var r0 interface{}
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
-		return rf(ctx, key, values...)
+	if rf, ok := ret.Get(0).(func(context.Context, *redis.Script, []string, ...interface{}) (interface{}, error)); ok {
+		return rf(ctx, script, keys, args...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
-		r0 = rf(ctx, key, values...)
+	if rf, ok := ret.Get(0).(func(context.Context, *redis.Script, []string, ...interface{}) interface{}); ok {
+		r0 = rf(ctx, script, keys, args...)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
-		r1 = rf(ctx, key, values...)
+	if rf, ok := ret.Get(1).(func(context.Context, *redis.Script, []string, ...interface{}) error); ok {
+		r1 = rf(ctx, script, keys, args...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2039,46 +2380,115 @@ func (_m *Client) SAdd(ctx context.Context, key string, values ...interface{}) (
 	return r0, r1
 }
 
-// Client_SAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SAdd'
-type Client_SAdd_Call struct {
+// Client_RunScript_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunScript'
+type Client_RunScript_Call struct {
 	*mock.Call
 }
 
-// SAdd is a helper method to define mock.On call
+// RunScript is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key string
-//   - values ...interface{}
-func (_e *Client_Expecter) SAdd(ctx interface{}, key interface{}, values ...interface{}) *Client_SAdd_Call {
-	return &Client_SAdd_Call{Call: _e.mock.On("SAdd",
-		append([]interface{}{ctx, key}, values...)...)}
+//   - script *redis.Script
+//   - keys []string
+//   - args ...interface{}
+func (_e *Client_Expecter) RunScript(ctx interface{}, script interface{}, keys interface{}, args ...interface{}) *Client_RunScript_Call {
+	return &Client_RunScript_Call{Call: _e.mock.On("RunScript",
+		append([]interface{}{ctx, script, keys}, args...)...)}
 }
 
-func (_c *Client_SAdd_Call) Run(run func(ctx context.Context, key string, values ...interface{})) *Client_SAdd_Call {
+func (_c *Client_RunScript_Call) Run(run func(ctx context.Context, script *redis.Script, keys []string, args ...interface{})) *Client_RunScript_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]interface{}, len(args)-2)
-		for i, a := range args[2:] {
+		variadicArgs := make([]interface{}, len(args)-3)
+		for i, a := range args[3:] {
 			if a != nil {
 				variadicArgs[i] = a.(interface{})
 			}
 		}
-		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+		run(args[0].(context.Context), args[1].(*redis.Script), args[2].([]string), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Client_SAdd_Call) Return(_a0 int64, _a1 error) *Client_SAdd_Call {
+func (_c *Client_RunScript_Call) Return(_a0 interface{}, _a1 error) *Client_RunScript_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Client_SAdd_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *Client_SAdd_Call {
+func (_c *Client_RunScript_Call) RunAndReturn(run func(context.Context, *redis.Script, []string, ...interface{}) (interface{}, error)) *Client_RunScript_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SCard provides a mock function with given fields: ctx, key
-func (_m *Client) SCard(ctx context.Context, key string) (int64, error) {
-	ret := _m.Called(ctx, key)
+// SAdd provides a mock function with given fields: ctx, key, values
+func (_m *Client) SAdd(ctx context.Context, key string, values ...interface{}) (int64, error) {
+	_va := make([]interface{}, len(values))
+	for _i := range values {
+		_va[_i] = values[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) (int64, error)); ok {
+		return rf(ctx, key, values...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) int64); ok {
+		r0 = rf(ctx, key, values...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...interface{}) error); ok {
+		r1 = rf(ctx, key, values...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_SAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SAdd'
+type Client_SAdd_Call struct {
+	*mock.Call
+}
+
+// SAdd is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - values ...interface{}
+func (_e *Client_Expecter) SAdd(ctx interface{}, key interface{}, values ...interface{}) *Client_SAdd_Call {
+	return &Client_SAdd_Call{Call: _e.mock.On("SAdd",
+		append([]interface{}{ctx, key}, values...)...)}
+}
+
+func (_c *Client_SAdd_Call) Run(run func(ctx context.Context, key string, values ...interface{})) *Client_SAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_SAdd_Call) Return(_a0 int64, _a1 error) *Client_SAdd_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_SAdd_Call) RunAndReturn(run func(context.Context, string, ...interface{}) (int64, error)) *Client_SAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SCard provides a mock function with given fields: ctx, key
+func (_m *Client) SCard(ctx context.Context, key string) (int64, error) {
+	ret := _m.Called(ctx, key)
 
 	var r0 int64
 	var r1 error
@@ -2675,9 +3085,13 @@ func (_c *Client_SRandMember_Call) RunAndReturn(run func(context.Context, string
 
 // SRem provides a mock function with given fields: ctx, key, values
 func (_m *Client) SRem(ctx context.Context, key string, values ...interface{}) (int64, error) {
+	_va := make([]interface{}, len(values))
+	for _i := range values {
+		_va[_i] = values[_i]
+	}
 	var _ca []interface{}
 	_ca = append(_ca, ctx, key)
-	_ca = append(_ca, values...)
+	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 int64
@@ -2874,6 +3288,128 @@ func (_c *Client_SUnionStore_Call) RunAndReturn(run func(context.Context, string
 	return _c
 }
 
+// ScriptExists provides a mock function with given fields: ctx, hashes
+func (_m *Client) ScriptExists(ctx context.Context, hashes ...string) ([]bool, error) {
+	_va := make([]interface{}, len(hashes))
+	for _i := range hashes {
+		_va[_i] = hashes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) ([]bool, error)); ok {
+		return rf(ctx, hashes...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) []bool); ok {
+		r0 = rf(ctx, hashes...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, hashes...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ScriptExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScriptExists'
+type Client_ScriptExists_Call struct {
+	*mock.Call
+}
+
+// ScriptExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hashes ...string
+func (_e *Client_Expecter) ScriptExists(ctx interface{}, hashes ...interface{}) *Client_ScriptExists_Call {
+	return &Client_ScriptExists_Call{Call: _e.mock.On("ScriptExists",
+		append([]interface{}{ctx}, hashes...)...)}
+}
+
+func (_c *Client_ScriptExists_Call) Run(run func(ctx context.Context, hashes ...string)) *Client_ScriptExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_ScriptExists_Call) Return(_a0 []bool, _a1 error) *Client_ScriptExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ScriptExists_Call) RunAndReturn(run func(context.Context, ...string) ([]bool, error)) *Client_ScriptExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScriptLoad provides a mock function with given fields: ctx, script
+func (_m *Client) ScriptLoad(ctx context.Context, script string) (string, error) {
+	ret := _m.Called(ctx, script)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, script)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, script)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, script)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ScriptLoad_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScriptLoad'
+type Client_ScriptLoad_Call struct {
+	*mock.Call
+}
+
+// ScriptLoad is a helper method to define mock.On call
+//   - ctx context.Context
+//   - script string
+func (_e *Client_Expecter) ScriptLoad(ctx interface{}, script interface{}) *Client_ScriptLoad_Call {
+	return &Client_ScriptLoad_Call{Call: _e.mock.On("ScriptLoad", ctx, script)}
+}
+
+func (_c *Client_ScriptLoad_Call) Run(run func(ctx context.Context, script string)) *Client_ScriptLoad_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_ScriptLoad_Call) Return(_a0 string, _a1 error) *Client_ScriptLoad_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ScriptLoad_Call) RunAndReturn(run func(context.Context, string) (string, error)) *Client_ScriptLoad_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Set provides a mock function with given fields: ctx, key, value, ttl
 func (_m *Client) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	ret := _m.Called(ctx, key, value, ttl)
@@ -2974,6 +3510,470 @@ func (_c *Client_SetNX_Call) RunAndReturn(run func(context.Context, string, inte
 	return _c
 }
 
+// Subscribe provides a mock function with given fields: ctx, channels
+func (_m *Client) Subscribe(ctx context.Context, channels ...string) (<-chan *redis.PubSubMessage, error) {
+	_va := make([]interface{}, len(channels))
+	for _i := range channels {
+		_va[_i] = channels[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 <-chan *redis.PubSubMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) (<-chan *redis.PubSubMessage, error)); ok {
+		return rf(ctx, channels...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) <-chan *redis.PubSubMessage); ok {
+		r0 = rf(ctx, channels...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *redis.PubSubMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, channels...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type Client_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channels ...string
+func (_e *Client_Expecter) Subscribe(ctx interface{}, channels ...interface{}) *Client_Subscribe_Call {
+	return &Client_Subscribe_Call{Call: _e.mock.On("Subscribe",
+		append([]interface{}{ctx}, channels...)...)}
+}
+
+func (_c *Client_Subscribe_Call) Run(run func(ctx context.Context, channels ...string)) *Client_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_Subscribe_Call) Return(_a0 <-chan *redis.PubSubMessage, _a1 error) *Client_Subscribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Subscribe_Call) RunAndReturn(run func(context.Context, ...string) (<-chan *redis.PubSubMessage, error)) *Client_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// XAck provides a mock function with given fields: ctx, stream, group, ids
+func (_m *Client) XAck(ctx context.Context, stream string, group string, ids ...string) (int64, error) {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, stream, group)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...string) (int64, error)); ok {
+		return rf(ctx, stream, group, ids...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...string) int64); ok {
+		r0 = rf(ctx, stream, group, ids...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...string) error); ok {
+		r1 = rf(ctx, stream, group, ids...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_XAck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'XAck'
+type Client_XAck_Call struct {
+	*mock.Call
+}
+
+// XAck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stream string
+//   - group string
+//   - ids ...string
+func (_e *Client_Expecter) XAck(ctx interface{}, stream interface{}, group interface{}, ids ...interface{}) *Client_XAck_Call {
+	return &Client_XAck_Call{Call: _e.mock.On("XAck",
+		append([]interface{}{ctx, stream, group}, ids...)...)}
+}
+
+func (_c *Client_XAck_Call) Run(run func(ctx context.Context, stream string, group string, ids ...string)) *Client_XAck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_XAck_Call) Return(_a0 int64, _a1 error) *Client_XAck_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_XAck_Call) RunAndReturn(run func(context.Context, string, string, ...string) (int64, error)) *Client_XAck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// XAdd provides a mock function with given fields: ctx, args
+func (_m *Client) XAdd(ctx context.Context, args redis.XAddArgs) (string, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, redis.XAddArgs) (string, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, redis.XAddArgs) string); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, redis.XAddArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_XAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'XAdd'
+type Client_XAdd_Call struct {
+	*mock.Call
+}
+
+// XAdd is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args redis.XAddArgs
+func (_e *Client_Expecter) XAdd(ctx interface{}, args interface{}) *Client_XAdd_Call {
+	return &Client_XAdd_Call{Call: _e.mock.On("XAdd", ctx, args)}
+}
+
+func (_c *Client_XAdd_Call) Run(run func(ctx context.Context, args redis.XAddArgs)) *Client_XAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(redis.XAddArgs))
+	})
+	return _c
+}
+
+func (_c *Client_XAdd_Call) Return(_a0 string, _a1 error) *Client_XAdd_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_XAdd_Call) RunAndReturn(run func(context.Context, redis.XAddArgs) (string, error)) *Client_XAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// XClaim provides a mock function with given fields: ctx, args
+func (_m *Client) XClaim(ctx context.Context, args redis.XClaimArgs) ([]redis.XMessage, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 []redis.XMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, redis.XClaimArgs) ([]redis.XMessage, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, redis.XClaimArgs) []redis.XMessage); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]redis.XMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, redis.XClaimArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_XClaim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'XClaim'
+type Client_XClaim_Call struct {
+	*mock.Call
+}
+
+// XClaim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args redis.XClaimArgs
+func (_e *Client_Expecter) XClaim(ctx interface{}, args interface{}) *Client_XClaim_Call {
+	return &Client_XClaim_Call{Call: _e.mock.On("XClaim", ctx, args)}
+}
+
+func (_c *Client_XClaim_Call) Run(run func(ctx context.Context, args redis.XClaimArgs)) *Client_XClaim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(redis.XClaimArgs))
+	})
+	return _c
+}
+
+func (_c *Client_XClaim_Call) Return(_a0 []redis.XMessage, _a1 error) *Client_XClaim_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_XClaim_Call) RunAndReturn(run func(context.Context, redis.XClaimArgs) ([]redis.XMessage, error)) *Client_XClaim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// XGroupCreateMkStream provides a mock function with given fields: ctx, stream, group, start
+func (_m *Client) XGroupCreateMkStream(ctx context.Context, stream string, group string, start string) (string, error) {
+	ret := _m.Called(ctx, stream, group, start)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, stream, group, start)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, stream, group, start)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, stream, group, start)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_XGroupCreateMkStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'XGroupCreateMkStream'
+type Client_XGroupCreateMkStream_Call struct {
+	*mock.Call
+}
+
+// XGroupCreateMkStream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stream string
+//   - group string
+//   - start string
+func (_e *Client_Expecter) XGroupCreateMkStream(ctx interface{}, stream interface{}, group interface{}, start interface{}) *Client_XGroupCreateMkStream_Call {
+	return &Client_XGroupCreateMkStream_Call{Call: _e.mock.On("XGroupCreateMkStream", ctx, stream, group, start)}
+}
+
+func (_c *Client_XGroupCreateMkStream_Call) Run(run func(ctx context.Context, stream string, group string, start string)) *Client_XGroupCreateMkStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Client_XGroupCreateMkStream_Call) Return(_a0 string, _a1 error) *Client_XGroupCreateMkStream_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_XGroupCreateMkStream_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *Client_XGroupCreateMkStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// XLen provides a mock function with given fields: ctx, stream
+func (_m *Client) XLen(ctx context.Context, stream string) (int64, error) {
+	ret := _m.Called(ctx, stream)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, stream)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, stream)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stream)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_XLen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'XLen'
+type Client_XLen_Call struct {
+	*mock.Call
+}
+
+// XLen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stream string
+func (_e *Client_Expecter) XLen(ctx interface{}, stream interface{}) *Client_XLen_Call {
+	return &Client_XLen_Call{Call: _e.mock.On("XLen", ctx, stream)}
+}
+
+func (_c *Client_XLen_Call) Run(run func(ctx context.Context, stream string)) *Client_XLen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_XLen_Call) Return(_a0 int64, _a1 error) *Client_XLen_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_XLen_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *Client_XLen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// XPendingExt provides a mock function with given fields: ctx, args
+func (_m *Client) XPendingExt(ctx context.Context, args redis.XPendingExtArgs) ([]redis.XPendingExt, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 []redis.XPendingExt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, redis.XPendingExtArgs) ([]redis.XPendingExt, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, redis.XPendingExtArgs) []redis.XPendingExt); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]redis.XPendingExt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, redis.XPendingExtArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_XPendingExt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'XPendingExt'
+type Client_XPendingExt_Call struct {
+	*mock.Call
+}
+
+// XPendingExt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args redis.XPendingExtArgs
+func (_e *Client_Expecter) XPendingExt(ctx interface{}, args interface{}) *Client_XPendingExt_Call {
+	return &Client_XPendingExt_Call{Call: _e.mock.On("XPendingExt", ctx, args)}
+}
+
+func (_c *Client_XPendingExt_Call) Run(run func(ctx context.Context, args redis.XPendingExtArgs)) *Client_XPendingExt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(redis.XPendingExtArgs))
+	})
+	return _c
+}
+
+func (_c *Client_XPendingExt_Call) Return(_a0 []redis.XPendingExt, _a1 error) *Client_XPendingExt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_XPendingExt_Call) RunAndReturn(run func(context.Context, redis.XPendingExtArgs) ([]redis.XPendingExt, error)) *Client_XPendingExt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// XReadGroup provides a mock function with given fields: ctx, args
+func (_m *Client) XReadGroup(ctx context.Context, args redis.XReadGroupArgs) ([]redis.XStream, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 []redis.XStream
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, redis.XReadGroupArgs) ([]redis.XStream, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, redis.XReadGroupArgs) []redis.XStream); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]redis.XStream)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, redis.XReadGroupArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_XReadGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'XReadGroup'
+type Client_XReadGroup_Call struct {
+	*mock.Call
+}
+
+// XReadGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args redis.XReadGroupArgs
+func (_e *Client_Expecter) XReadGroup(ctx interface{}, args interface{}) *Client_XReadGroup_Call {
+	return &Client_XReadGroup_Call{Call: _e.mock.On("XReadGroup", ctx, args)}
+}
+
+func (_c *Client_XReadGroup_Call) Run(run func(ctx context.Context, args redis.XReadGroupArgs)) *Client_XReadGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(redis.XReadGroupArgs))
+	})
+	return _c
+}
+
+func (_c *Client_XReadGroup_Call) Return(_a0 []redis.XStream, _a1 error) *Client_XReadGroup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_XReadGroup_Call) RunAndReturn(run func(context.Context, redis.XReadGroupArgs) ([]redis.XStream, error)) *Client_XReadGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ZAdd provides a mock function with given fields: ctx, key, score, member
 func (_m *Client) ZAdd(ctx context.Context, key string, score float64, member string) (int64, error) {
 	ret := _m.Called(ctx, key, score, member)
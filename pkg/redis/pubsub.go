@@ -0,0 +1,126 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	baseRedis "github.com/go-redis/redis/v8"
+	"github.com/justtrackio/gosoline/pkg/exec"
+)
+
+const (
+	metricNamePubSubMessages   = "RedisPubSubMessages"
+	metricNamePubSubReconnects = "RedisPubSubReconnects"
+)
+
+type PubSubMessage struct {
+	Channel string
+	// Pattern is the pattern the channel matched if the message was received by PSubscribe
+	Pattern string
+	Payload string
+}
+
+type subscriber interface {
+	Subscribe(ctx context.Context, channels ...string) *baseRedis.PubSub
+	PSubscribe(ctx context.Context, channels ...string) *baseRedis.PubSub
+}
+
+func (c *redisClient) Publish(ctx context.Context, channel string, message interface{}) (int64, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.Publish(ctx, channel, message)
+	})
+
+	return cmd.(*baseRedis.IntCmd).Val(), err
+}
+
+func (c *redisClient) Subscribe(ctx context.Context, channels ...string) (<-chan *PubSubMessage, error) {
+	return c.subscribe(ctx, func(sub subscriber) *baseRedis.PubSub {
+		return sub.Subscribe(ctx, channels...)
+	})
+}
+
+func (c *redisClient) PSubscribe(ctx context.Context, patterns ...string) (<-chan *PubSubMessage, error) {
+	return c.subscribe(ctx, func(sub subscriber) *baseRedis.PubSub {
+		return sub.PSubscribe(ctx, patterns...)
+	})
+}
+
+func (c *redisClient) subscribe(ctx context.Context, subscribe func(sub subscriber) *baseRedis.PubSub) (<-chan *PubSubMessage, error) {
+	sub, ok := c.base.(subscriber)
+	if !ok {
+		return nil, fmt.Errorf("the redis client %T does not support pub/sub", c.base)
+	}
+
+	pubSub := subscribe(sub)
+
+	// waiting for the confirmation of the subscription makes sure no message published after we return gets lost
+	_, err := c.executor.Execute(ctx, func(ctx context.Context) (interface{}, error) {
+		return pubSub.Receive(ctx)
+	})
+	if err != nil {
+		_ = pubSub.Close()
+
+		return nil, fmt.Errorf("can not subscribe: %w", err)
+	}
+
+	messages := make(chan *PubSubMessage)
+
+	go c.receive(ctx, pubSub, messages)
+
+	return messages, nil
+}
+
+// receive forwards the messages until ctx is canceled. The connection is reestablished and all channels are subscribed
+// again on the next receive after an error, we only have to back off while redis is not reachable. Messages published
+// while we are disconnected are lost.
+func (c *redisClient) receive(ctx context.Context, pubSub *baseRedis.PubSub, messages chan<- *PubSubMessage) {
+	defer close(messages)
+
+	// receiving doesn't return on a canceled context while waiting for a message, but it does on a closed connection
+	go func() {
+		<-ctx.Done()
+
+		if err := pubSub.Close(); err != nil {
+			c.logger.Warn("can not close pub/sub connection: %s", err.Error())
+		}
+	}()
+
+	backoff := exec.NewExponentialBackOff(&c.settings.BackoffSettings)
+	backoff.MaxElapsedTime = 0
+
+	for {
+		msg, err := pubSub.ReceiveMessage(ctx)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			wait := backoff.NextBackOff()
+
+			c.logger.Warn("lost pub/sub connection, reconnecting in %s: %s", wait, err.Error())
+			c.writeMetric(metricNamePubSubReconnects)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+				continue
+			}
+		}
+
+		backoff.Reset()
+		c.writeMetric(metricNamePubSubMessages)
+
+		select {
+		case <-ctx.Done():
+			return
+		case messages <- &PubSubMessage{
+			Channel: msg.Channel,
+			Pattern: msg.Pattern,
+			Payload: msg.Payload,
+		}:
+		}
+	}
+}
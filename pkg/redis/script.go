@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+
+	baseRedis "github.com/go-redis/redis/v8"
+)
+
+const metricNameScriptLoads = "RedisScriptLoads"
+
+// Script is a lua script which is executed by its hash. Create scripts once, e.g. in a package variable, and run them
+// with Client.RunScript: the script is only sent to redis again if redis doesn't know it yet, e.g. after a restart.
+type Script struct {
+	src  string
+	hash string
+}
+
+func NewScript(src string) *Script {
+	hash := sha1.Sum([]byte(src))
+
+	return &Script{
+		src:  src,
+		hash: hex.EncodeToString(hash[:]),
+	}
+}
+
+func (s *Script) Hash() string {
+	return s.hash
+}
+
+func (s *Script) Source() string {
+	return s.src
+}
+
+func (c *redisClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.Eval(ctx, script, keys, args...)
+	})
+
+	return cmd.(*baseRedis.Cmd).Val(), err
+}
+
+func (c *redisClient) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) (interface{}, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.EvalSha(ctx, sha1, keys, args...)
+	})
+
+	return cmd.(*baseRedis.Cmd).Val(), err
+}
+
+func (c *redisClient) ScriptExists(ctx context.Context, hashes ...string) ([]bool, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.base.ScriptExists(ctx, hashes...)
+	})
+
+	return cmd.(*baseRedis.BoolSliceCmd).Val(), err
+}
+
+func (c *redisClient) ScriptLoad(ctx context.Context, script string) (string, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		return c.loadScript(ctx, script)
+	})
+
+	return cmd.(*baseRedis.StringCmd).Val(), err
+}
+
+// LoadScripts loads all scripts at once, which avoids the roundtrip for the NOSCRIPT error when running them first.
+func (c *redisClient) LoadScripts(ctx context.Context, scripts ...*Script) error {
+	for _, script := range scripts {
+		if _, err := c.ScriptLoad(ctx, script.src); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *redisClient) RunScript(ctx context.Context, script *Script, keys []string, args ...interface{}) (interface{}, error) {
+	cmd, err := c.execute(ctx, func() ErrCmder {
+		cmd := c.base.EvalSha(ctx, script.hash, keys, args...)
+
+		if !IsNoScriptError(cmd.Err()) {
+			return cmd
+		}
+
+		if err := c.loadScript(ctx, script.src).Err(); err != nil {
+			return baseRedis.NewCmdResult(nil, err)
+		}
+
+		return c.base.EvalSha(ctx, script.hash, keys, args...)
+	})
+
+	return cmd.(*baseRedis.Cmd).Val(), err
+}
+
+func (c *redisClient) loadScript(ctx context.Context, script string) *baseRedis.StringCmd {
+	cmd := c.base.ScriptLoad(ctx, script)
+
+	if cmd.Err() == nil {
+		c.writeMetric(metricNameScriptLoads)
+	}
+
+	return cmd
+}
+
+func IsNoScriptError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT")
+}
@@ -0,0 +1,126 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	baseRedis "github.com/go-redis/redis/v8"
+	"github.com/justtrackio/gosoline/pkg/exec"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/justtrackio/gosoline/pkg/redis"
+	"github.com/stretchr/testify/suite"
+)
+
+var incrByScript = redis.NewScript(`return redis.call("INCRBY", KEYS[1], ARGV[1])`)
+
+type ScriptAndPubSubTestSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	server     *miniredis.Miniredis
+	baseClient *baseRedis.Client
+	client     redis.Client
+}
+
+func (s *ScriptAndPubSubTestSuite) SetupTest() {
+	server, err := miniredis.Run()
+	if err != nil {
+		s.FailNow(err.Error(), "can not start miniredis")
+		return
+	}
+
+	baseClient := baseRedis.NewClient(&baseRedis.Options{
+		Addr: server.Addr(),
+	})
+
+	s.ctx = context.Background()
+	s.server = server
+	s.baseClient = baseClient
+	s.client = redis.NewClientWithInterfaces(logMocks.NewLoggerMockedAll(), baseClient, exec.NewDefaultExecutor(), metricMocks.NewWriterMockedAll(), &redis.Settings{
+		Name: "test",
+	})
+}
+
+func (s *ScriptAndPubSubTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ScriptAndPubSubTestSuite) TestEval() {
+	result, err := s.client.Eval(s.ctx, incrByScript.Source(), []string{"counter"}, 3)
+	s.NoError(err, "there should be no error on Eval")
+	s.Equal(int64(3), result)
+}
+
+func (s *ScriptAndPubSubTestSuite) TestRunScriptLoadsScriptOnce() {
+	exists, err := s.client.ScriptExists(s.ctx, incrByScript.Hash())
+	s.NoError(err, "there should be no error on ScriptExists")
+	s.Equal([]bool{false}, exists)
+
+	result, err := s.client.RunScript(s.ctx, incrByScript, []string{"counter"}, 2)
+	s.NoError(err, "there should be no error on RunScript")
+	s.Equal(int64(2), result)
+
+	exists, err = s.client.ScriptExists(s.ctx, incrByScript.Hash())
+	s.NoError(err, "there should be no error on ScriptExists")
+	s.Equal([]bool{true}, exists)
+
+	result, err = s.client.EvalSha(s.ctx, incrByScript.Hash(), []string{"counter"}, 3)
+	s.NoError(err, "there should be no error on EvalSha")
+	s.Equal(int64(5), result)
+}
+
+func (s *ScriptAndPubSubTestSuite) TestRunScriptAfterFlush() {
+	err := s.client.LoadScripts(s.ctx, incrByScript)
+	s.NoError(err, "there should be no error on LoadScripts")
+
+	// simulates a restart of redis, which empties the script cache
+	s.NoError(s.baseClient.ScriptFlush(s.ctx).Err())
+
+	result, err := s.client.RunScript(s.ctx, incrByScript, []string{"counter"}, 1)
+	s.NoError(err, "there should be no error on RunScript")
+	s.Equal(int64(1), result)
+}
+
+func (s *ScriptAndPubSubTestSuite) TestPublishSubscribe() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	messages, err := s.client.Subscribe(ctx, "channel")
+	s.NoError(err, "there should be no error on Subscribe")
+
+	receivers, err := s.client.Publish(s.ctx, "channel", "hello")
+	s.NoError(err, "there should be no error on Publish")
+	s.Equal(int64(1), receivers)
+
+	s.Equal(&redis.PubSubMessage{
+		Channel: "channel",
+		Payload: "hello",
+	}, <-messages)
+
+	cancel()
+
+	_, ok := <-messages
+	s.False(ok, "the channel should be closed after the context is canceled")
+}
+
+func (s *ScriptAndPubSubTestSuite) TestPSubscribe() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	messages, err := s.client.PSubscribe(ctx, "events.*")
+	s.NoError(err, "there should be no error on PSubscribe")
+
+	s.server.Publish("events.created", "payload")
+
+	s.Equal(&redis.PubSubMessage{
+		Channel: "events.created",
+		Pattern: "events.*",
+		Payload: "payload",
+	}, <-messages)
+}
+
+func TestScriptAndPubSubTestSuite(t *testing.T) {
+	suite.Run(t, new(ScriptAndPubSubTestSuite))
+}
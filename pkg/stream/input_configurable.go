@@ -12,25 +12,27 @@ import (
 )
 
 const (
-	InputTypeFile     = "file"
-	InputTypeInMemory = "inMemory"
-	InputTypeKinesis  = "kinesis"
-	InputTypeRedis    = "redis"
-	InputTypeSns      = "sns"
-	InputTypeSqs      = "sqs"
-	InputTypeKafka    = "kafka"
+	InputTypeFile        = "file"
+	InputTypeInMemory    = "inMemory"
+	InputTypeKinesis     = "kinesis"
+	InputTypeRedis       = "redis"
+	InputTypeRedisStream = "redis_stream"
+	InputTypeSns         = "sns"
+	InputTypeSqs         = "sqs"
+	InputTypeKafka       = "kafka"
 )
 
 type InputFactory func(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Input, error)
 
 var inputFactories = map[string]InputFactory{
-	InputTypeFile:     newFileInputFromConfig,
-	InputTypeInMemory: newInMemoryInputFromConfig,
-	InputTypeKinesis:  newKinesisInputFromConfig,
-	InputTypeRedis:    newRedisInputFromConfig,
-	InputTypeSns:      newSnsInputFromConfig,
-	InputTypeSqs:      newSqsInputFromConfig,
-	InputTypeKafka:    newKafkaInputFromConfig,
+	InputTypeFile:        newFileInputFromConfig,
+	InputTypeInMemory:    newInMemoryInputFromConfig,
+	InputTypeKinesis:     newKinesisInputFromConfig,
+	InputTypeRedis:       newRedisInputFromConfig,
+	InputTypeRedisStream: newRedisStreamInputFromConfig,
+	InputTypeSns:         newSnsInputFromConfig,
+	InputTypeSqs:         newSqsInputFromConfig,
+	InputTypeKafka:       newKafkaInputFromConfig,
 }
 
 func SetInputFactory(typ string, factory InputFactory) {
@@ -139,6 +141,47 @@ func newRedisInputFromConfig(_ context.Context, config cfg.Config, logger log.Lo
 	return NewRedisListInput(config, logger, settings)
 }
 
+type redisStreamInputConfiguration struct {
+	Project       string        `cfg:"project"`
+	Family        string        `cfg:"family"`
+	Group         string        `cfg:"group"`
+	Application   string        `cfg:"application"`
+	ServerName    string        `cfg:"server_name" default:"default" validate:"min=1"`
+	Key           string        `cfg:"key" validate:"required,min=1"`
+	ConsumerGroup string        `cfg:"consumer_group" default:"{app_name}" validate:"required,min=1"`
+	Consumer      string        `cfg:"consumer"`
+	BatchSize     int           `cfg:"batch_size" default:"10" validate:"min=1"`
+	WaitTime      time.Duration `cfg:"wait_time" default:"3s"`
+	ClaimMinIdle  time.Duration `cfg:"claim_min_idle" default:"5m"`
+	ClaimInterval time.Duration `cfg:"claim_interval" default:"30s"`
+}
+
+func newRedisStreamInputFromConfig(_ context.Context, config cfg.Config, logger log.Logger, name string) (Input, error) {
+	key := ConfigurableInputKey(name)
+
+	configuration := redisStreamInputConfiguration{}
+	config.UnmarshalKey(key, &configuration)
+
+	settings := &RedisStreamInputSettings{
+		AppId: cfg.AppId{
+			Project:     configuration.Project,
+			Family:      configuration.Family,
+			Group:       configuration.Group,
+			Application: configuration.Application,
+		},
+		ServerName:    configuration.ServerName,
+		Key:           configuration.Key,
+		ConsumerGroup: configuration.ConsumerGroup,
+		Consumer:      configuration.Consumer,
+		BatchSize:     configuration.BatchSize,
+		WaitTime:      configuration.WaitTime,
+		ClaimMinIdle:  configuration.ClaimMinIdle,
+		ClaimInterval: configuration.ClaimInterval,
+	}
+
+	return NewRedisStreamInput(config, logger, settings)
+}
+
 type SnsInputTargetConfiguration struct {
 	Family      string            `cfg:"family"`
 	Group       string            `cfg:"group" validate:"required"`
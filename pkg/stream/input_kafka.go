@@ -8,13 +8,16 @@ import (
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/coffin"
 	kafkaConsumer "github.com/justtrackio/gosoline/pkg/kafka/consumer"
+	"github.com/justtrackio/gosoline/pkg/kafka/schemaregistry"
 	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/segmentio/kafka-go"
 )
 
 type KafkaInput struct {
-	consumer *kafkaConsumer.Consumer
-	data     chan *Message
-	pool     coffin.Coffin
+	consumer     *kafkaConsumer.Consumer
+	deserializer schemaregistry.Deserializer
+	data         chan *Message
+	pool         coffin.Coffin
 }
 
 var _ AcknowledgeableInput = &KafkaInput{}
@@ -25,14 +28,25 @@ func NewKafkaInput(ctx context.Context, config cfg.Config, logger log.Logger, ke
 		return nil, fmt.Errorf("failed to init consumer: %w", err)
 	}
 
-	return NewKafkaInputWithInterfaces(consumer)
+	var deserializer schemaregistry.Deserializer
+
+	if registry := consumer.Settings().SchemaRegistry; registry != "" {
+		if deserializer, err = schemaregistry.NewDeserializer(ctx, config, logger, registry); err != nil {
+			return nil, fmt.Errorf("failed to init schema registry deserializer: %w", err)
+		}
+	}
+
+	return NewKafkaInputWithInterfaces(consumer, deserializer)
 }
 
-func NewKafkaInputWithInterfaces(consumer *kafkaConsumer.Consumer) (*KafkaInput, error) {
+// NewKafkaInputWithInterfaces creates an input reading from the consumer. The deserializer is optional, without
+// one the message values are used as bodies as they are.
+func NewKafkaInputWithInterfaces(consumer *kafkaConsumer.Consumer, deserializer schemaregistry.Deserializer) (*KafkaInput, error) {
 	return &KafkaInput{
-		consumer: consumer,
-		data:     make(chan *Message, cap(consumer.Data())),
-		pool:     coffin.New(),
+		consumer:     consumer,
+		deserializer: deserializer,
+		data:         make(chan *Message, cap(consumer.Data())),
+		pool:         coffin.New(),
 	}, nil
 }
 
@@ -53,11 +67,34 @@ func (i *KafkaInput) Run(ctx context.Context) error {
 			return i.pool.Err()
 
 		case msg := <-i.consumer.Data():
-			i.data <- KafkaToGosoMessage(msg)
+			gosoMsg, err := i.buildMessage(ctx, msg)
+			if err != nil {
+				return err
+			}
+
+			i.data <- gosoMsg
 		}
 	}
 }
 
+// buildMessage converts the kafka message, decoding its value from the wire format of the schema registry first if
+// configured. A message which can not be decoded stops the input, as skipping it would lose the message.
+func (i *KafkaInput) buildMessage(ctx context.Context, msg kafka.Message) (*Message, error) {
+	if i.deserializer == nil {
+		return KafkaToGosoMessage(msg), nil
+	}
+
+	body, err := i.deserializer.Deserialize(ctx, msg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("can not deserialize message at offset %d of partition %s/%d: %w", msg.Offset, msg.Topic, msg.Partition, err)
+	}
+
+	gosoMsg := KafkaToGosoMessage(msg)
+	gosoMsg.Body = string(body)
+
+	return gosoMsg, nil
+}
+
 // Stop causes Run to return as fast as possible. Calling Stop is preferable to canceling the context passed to Run
 // as it allows Run to shut down cleaner (and might take a bit longer, e.g., to finish processing the current batch
 // of messages).
@@ -80,3 +117,9 @@ func (i *KafkaInput) Ack(ctx context.Context, msg *Message, _ bool) error {
 func (i *KafkaInput) AckBatch(ctx context.Context, msgs []*Message, _ []bool) error {
 	return i.consumer.Commit(ctx, GosoToKafkaMessages(msgs...)...)
 }
+
+// Generation returns the consumer group generation the input is currently consuming in. Offsets committed as part
+// of a producer transaction have to reference this generation.
+func (i *KafkaInput) Generation() (kafkaConsumer.GroupGeneration, error) {
+	return i.consumer.Generation()
+}
@@ -0,0 +1,342 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/metric"
+	"github.com/justtrackio/gosoline/pkg/redis"
+	"github.com/justtrackio/gosoline/pkg/uuid"
+)
+
+const (
+	AttributeRedisStreamEntryId = "redisStreamEntryId"
+
+	// redisStreamMessageField is the field of a stream entry holding the encoded message
+	redisStreamMessageField = "message"
+
+	metricNameRedisStreamInputReads   = "StreamRedisStreamInputReads"
+	metricNameRedisStreamInputClaimed = "StreamRedisStreamInputClaimed"
+)
+
+type RedisStreamInputSettings struct {
+	cfg.AppId
+	ServerName string
+	Key        string
+	// ConsumerGroup shares the entries of the stream between all of its consumers, every entry is delivered to one of them
+	ConsumerGroup string
+	// Consumer is the name of this consumer in the group, a random one is used if it is empty
+	Consumer  string
+	BatchSize int
+	WaitTime  time.Duration
+	// Entries of other consumers which are pending for longer than ClaimMinIdle get claimed by this consumer. This way
+	// entries of crashed consumers or entries which were not acknowledged are delivered again.
+	ClaimMinIdle  time.Duration
+	ClaimInterval time.Duration
+}
+
+type redisStreamInput struct {
+	logger   log.Logger
+	mw       metric.Writer
+	client   redis.Client
+	clock    clock.Clock
+	settings *RedisStreamInputSettings
+
+	channel           chan *Message
+	stopOnce          sync.Once
+	stop              chan struct{}
+	fullyQualifiedKey string
+}
+
+func NewRedisStreamInput(config cfg.Config, logger log.Logger, settings *RedisStreamInputSettings) (AcknowledgeableInput, error) {
+	settings.PadFromConfig(config)
+
+	client, err := redis.ProvideClient(config, logger, settings.ServerName)
+	if err != nil {
+		return nil, fmt.Errorf("can not create redis client: %w", err)
+	}
+
+	defaultMetrics := getRedisStreamInputDefaultMetrics(settings.AppId, settings.Key)
+	mw := metric.NewWriter(defaultMetrics...)
+
+	return NewRedisStreamInputWithInterfaces(logger, client, mw, clock.Provider, settings), nil
+}
+
+func NewRedisStreamInputWithInterfaces(logger log.Logger, client redis.Client, mw metric.Writer, clock clock.Clock, settings *RedisStreamInputSettings) AcknowledgeableInput {
+	if settings.Consumer == "" {
+		settings.Consumer = uuid.New().NewV4()
+	}
+
+	fullyQualifiedKey := redis.GetFullyQualifiedKey(settings.AppId, settings.Key)
+
+	return &redisStreamInput{
+		logger:            logger,
+		mw:                mw,
+		client:            client,
+		clock:             clock,
+		settings:          settings,
+		channel:           make(chan *Message),
+		stop:              make(chan struct{}),
+		fullyQualifiedKey: fullyQualifiedKey,
+	}
+}
+
+func (i *redisStreamInput) Data() <-chan *Message {
+	return i.channel
+}
+
+func (i *redisStreamInput) Run(ctx context.Context) error {
+	defer close(i.channel)
+
+	if i.settings.WaitTime <= 0 {
+		return errors.New("wait time should be bigger than 0")
+	}
+
+	if err := i.createConsumerGroup(ctx); err != nil {
+		return err
+	}
+
+	var lastClaim time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-i.stop:
+			return nil
+		default:
+		}
+
+		if i.settings.ClaimMinIdle > 0 && i.clock.Since(lastClaim) >= i.settings.ClaimInterval {
+			lastClaim = i.clock.Now()
+
+			if err := i.claim(ctx); err != nil {
+				return err
+			}
+		}
+
+		if err := i.read(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (i *redisStreamInput) Stop() {
+	i.stopOnce.Do(func() {
+		close(i.stop)
+	})
+}
+
+func (i *redisStreamInput) Ack(ctx context.Context, msg *Message, ack bool) error {
+	return i.AckBatch(ctx, []*Message{msg}, []bool{ack})
+}
+
+func (i *redisStreamInput) AckBatch(ctx context.Context, msgs []*Message, acks []bool) error {
+	ids := make([]string, 0, len(msgs))
+	multiError := new(multierror.Error)
+
+	for j, msg := range msgs {
+		// not acknowledged entries stay pending and get claimed again after the claim min idle time
+		if !acks[j] {
+			continue
+		}
+
+		id, ok := msg.Attributes[AttributeRedisStreamEntryId]
+		if !ok || id == "" {
+			multiError = multierror.Append(multiError, fmt.Errorf("the message has no attribute %s", AttributeRedisStreamEntryId))
+
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return multiError.ErrorOrNil()
+	}
+
+	if _, err := i.client.XAck(ctx, i.fullyQualifiedKey, i.settings.ConsumerGroup, ids...); err != nil {
+		multiError = multierror.Append(multiError, fmt.Errorf("can not ack stream entries: %w", err))
+	}
+
+	return multiError.ErrorOrNil()
+}
+
+func (i *redisStreamInput) createConsumerGroup(ctx context.Context) error {
+	// starting at 0 makes a new group read all entries already in the stream
+	_, err := i.client.XGroupCreateMkStream(ctx, i.fullyQualifiedKey, i.settings.ConsumerGroup, "0")
+
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("can not create consumer group %s for stream %s: %w", i.settings.ConsumerGroup, i.fullyQualifiedKey, err)
+	}
+
+	return nil
+}
+
+func (i *redisStreamInput) read(ctx context.Context) error {
+	streams, err := i.client.XReadGroup(ctx, redis.XReadGroupArgs{
+		Group:    i.settings.ConsumerGroup,
+		Consumer: i.settings.Consumer,
+		Streams:  []string{i.fullyQualifiedKey, ">"},
+		Count:    int64(i.settings.BatchSize),
+		Block:    i.settings.WaitTime,
+	})
+
+	// there were no new entries within the wait time, which is fine for an idle stream
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("can not read from stream %s: %w", i.fullyQualifiedKey, err)
+	}
+
+	for _, stream := range streams {
+		i.publish(ctx, stream.Messages, metricNameRedisStreamInputReads)
+	}
+
+	return nil
+}
+
+func (i *redisStreamInput) claim(ctx context.Context) error {
+	pending, err := i.client.XPendingExt(ctx, redis.XPendingExtArgs{
+		Stream: i.fullyQualifiedKey,
+		Group:  i.settings.ConsumerGroup,
+		Idle:   i.settings.ClaimMinIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  int64(i.settings.BatchSize),
+	})
+
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("can not get pending entries of stream %s: %w", i.fullyQualifiedKey, err)
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(pending))
+	for j := range pending {
+		ids[j] = pending[j].ID
+	}
+
+	// another consumer might have claimed some of the entries in the meantime, XCLAIM only returns the ones we got
+	messages, err := i.client.XClaim(ctx, redis.XClaimArgs{
+		Stream:   i.fullyQualifiedKey,
+		Group:    i.settings.ConsumerGroup,
+		Consumer: i.settings.Consumer,
+		MinIdle:  i.settings.ClaimMinIdle,
+		Messages: ids,
+	})
+
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("can not claim pending entries of stream %s: %w", i.fullyQualifiedKey, err)
+	}
+
+	if len(messages) > 0 {
+		i.logger.Info("claimed %d stale entries of stream %s", len(messages), i.fullyQualifiedKey)
+	}
+
+	i.publish(ctx, messages, metricNameRedisStreamInputClaimed)
+
+	return nil
+}
+
+func (i *redisStreamInput) publish(ctx context.Context, entries []redis.XMessage, metricName string) {
+	undecodable := make([]string, 0)
+
+	for _, entry := range entries {
+		msg, err := i.decode(entry)
+		if err != nil {
+			i.logger.Error("could not decode stream entry %s: %s", entry.ID, err.Error())
+			undecodable = append(undecodable, entry.ID)
+
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case i.channel <- msg:
+		}
+	}
+
+	i.ackUndecodable(ctx, undecodable)
+	i.writeMetric(metricName, len(entries))
+}
+
+// ackUndecodable removes entries from the pending entries list which will never be decodable. Otherwise, they would be
+// claimed again and again and block the claiming of the entries behind them.
+func (i *redisStreamInput) ackUndecodable(ctx context.Context, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	if _, err := i.client.XAck(ctx, i.fullyQualifiedKey, i.settings.ConsumerGroup, ids...); err != nil {
+		i.logger.Error("can not ack undecodable stream entries %s: %s", strings.Join(ids, ", "), err.Error())
+	}
+}
+
+func (i *redisStreamInput) decode(entry redis.XMessage) (*Message, error) {
+	raw, ok := entry.Values[redisStreamMessageField].(string)
+	if !ok {
+		return nil, fmt.Errorf("the entry has no field %s", redisStreamMessageField)
+	}
+
+	msg := &Message{}
+	if err := json.Unmarshal([]byte(raw), msg); err != nil {
+		return nil, fmt.Errorf("can not unmarshal message: %w", err)
+	}
+
+	if msg.Attributes == nil {
+		msg.Attributes = make(map[string]string)
+	}
+
+	msg.Attributes[AttributeRedisStreamEntryId] = entry.ID
+
+	return msg, nil
+}
+
+func (i *redisStreamInput) writeMetric(metricName string, count int) {
+	if count == 0 {
+		return
+	}
+
+	i.mw.Write(metric.Data{{
+		MetricName: metricName,
+		Dimensions: map[string]string{
+			"StreamName": i.fullyQualifiedKey,
+		},
+		Unit:  metric.UnitCount,
+		Value: float64(count),
+	}})
+}
+
+func getRedisStreamInputDefaultMetrics(appId cfg.AppId, key string) metric.Data {
+	fullyQualifiedKey := redis.GetFullyQualifiedKey(appId, key)
+	data := metric.Data{}
+
+	for _, metricName := range []string{metricNameRedisStreamInputReads, metricNameRedisStreamInputClaimed} {
+		data = append(data, &metric.Datum{
+			Priority:   metric.PriorityHigh,
+			MetricName: metricName,
+			Dimensions: map[string]string{
+				"StreamName": fullyQualifiedKey,
+			},
+			Unit:  metric.UnitCount,
+			Value: 0.0,
+		})
+	}
+
+	return data
+}
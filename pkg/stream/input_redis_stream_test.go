@@ -0,0 +1,234 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/clock"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/justtrackio/gosoline/pkg/redis"
+	redisMocks "github.com/justtrackio/gosoline/pkg/redis/mocks"
+	"github.com/justtrackio/gosoline/pkg/stream"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const redisStreamKey = "mcoins-test-fam-grp-app-my-stream"
+
+type RedisStreamInputTestSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	client *redisMocks.Client
+	input  stream.AcknowledgeableInput
+}
+
+func (s *RedisStreamInputTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.client = redisMocks.NewClient(s.T())
+
+	s.input = stream.NewRedisStreamInputWithInterfaces(logMocks.NewLoggerMockedAll(), s.client, metricMocks.NewWriterMockedAll(), clock.NewFakeClock(), &stream.RedisStreamInputSettings{
+		AppId: cfg.AppId{
+			Project:     "mcoins",
+			Environment: "test",
+			Family:      "fam",
+			Group:       "grp",
+			Application: "app",
+		},
+		Key:           "my-stream",
+		ConsumerGroup: "group",
+		Consumer:      "consumer",
+		BatchSize:     10,
+		WaitTime:      time.Second,
+		ClaimMinIdle:  time.Minute,
+		ClaimInterval: time.Minute,
+	})
+}
+
+func (s *RedisStreamInputTestSuite) TestRun() {
+	s.client.EXPECT().XGroupCreateMkStream(s.ctx, redisStreamKey, "group", "0").Return("", errors.New("BUSYGROUP Consumer Group name already exists")).Once()
+
+	s.client.EXPECT().XPendingExt(s.ctx, redis.XPendingExtArgs{
+		Stream: redisStreamKey,
+		Group:  "group",
+		Idle:   time.Minute,
+		Start:  "-",
+		End:    "+",
+		Count:  10,
+	}).Return([]redis.XPendingExt{{ID: "1-0", Consumer: "crashed"}}, nil).Once()
+
+	s.client.EXPECT().XClaim(s.ctx, redis.XClaimArgs{
+		Stream:   redisStreamKey,
+		Group:    "group",
+		Consumer: "consumer",
+		MinIdle:  time.Minute,
+		Messages: []string{"1-0"},
+	}).Return([]redis.XMessage{
+		{ID: "1-0", Values: map[string]interface{}{"message": `{"attributes":{},"body":"claimed"}`}},
+	}, nil).Once()
+
+	readArgs := redis.XReadGroupArgs{
+		Group:    "group",
+		Consumer: "consumer",
+		Streams:  []string{redisStreamKey, ">"},
+		Count:    10,
+		Block:    time.Second,
+	}
+
+	s.client.EXPECT().XReadGroup(s.ctx, readArgs).Return([]redis.XStream{
+		{
+			Stream: redisStreamKey,
+			Messages: []redis.XMessage{
+				{ID: "2-0", Values: map[string]interface{}{"message": `{"attributes":{"encoding":"application/json"},"body":"read"}`}},
+			},
+		},
+	}, nil).Run(func(ctx context.Context, args redis.XReadGroupArgs) {
+		s.input.Stop()
+	}).Once()
+
+	var err error
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		err = s.input.Run(s.ctx)
+	}()
+
+	messages := make([]*stream.Message, 0)
+	for msg := range s.input.Data() {
+		messages = append(messages, msg)
+	}
+
+	<-done
+
+	s.NoError(err)
+	s.Equal([]*stream.Message{
+		{
+			Attributes: map[string]string{stream.AttributeRedisStreamEntryId: "1-0"},
+			Body:       "claimed",
+		},
+		{
+			Attributes: map[string]string{"encoding": "application/json", stream.AttributeRedisStreamEntryId: "2-0"},
+			Body:       "read",
+		},
+	}, messages)
+}
+
+func (s *RedisStreamInputTestSuite) TestRunReadTimeout() {
+	s.client.EXPECT().XGroupCreateMkStream(s.ctx, redisStreamKey, "group", "0").Return("OK", nil).Once()
+	s.client.EXPECT().XPendingExt(s.ctx, mock.AnythingOfType("redis.XPendingExtArgs")).Return(nil, nil).Once()
+
+	readArgs := redis.XReadGroupArgs{
+		Group:    "group",
+		Consumer: "consumer",
+		Streams:  []string{redisStreamKey, ">"},
+		Count:    10,
+		Block:    time.Second,
+	}
+
+	// the first read times out without any new entries, the input has to keep on reading
+	s.client.EXPECT().XReadGroup(s.ctx, readArgs).Return(nil, redis.Nil).Once()
+	s.client.EXPECT().XReadGroup(s.ctx, readArgs).Return(nil, redis.Nil).Run(func(ctx context.Context, args redis.XReadGroupArgs) {
+		s.input.Stop()
+	}).Once()
+
+	var err error
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		err = s.input.Run(s.ctx)
+	}()
+
+	for range s.input.Data() {
+		s.Fail("there should be no messages")
+	}
+
+	<-done
+
+	s.NoError(err)
+}
+
+func (s *RedisStreamInputTestSuite) TestRunUndecodableEntries() {
+	s.client.EXPECT().XGroupCreateMkStream(s.ctx, redisStreamKey, "group", "0").Return("OK", nil).Once()
+
+	s.client.EXPECT().XPendingExt(s.ctx, mock.AnythingOfType("redis.XPendingExtArgs")).Return([]redis.XPendingExt{{ID: "1-0", Consumer: "crashed"}}, nil).Once()
+	s.client.EXPECT().XClaim(s.ctx, mock.AnythingOfType("redis.XClaimArgs")).Return([]redis.XMessage{
+		{ID: "1-0", Values: map[string]interface{}{"message": "not json"}},
+	}, nil).Once()
+
+	s.client.EXPECT().XReadGroup(s.ctx, mock.AnythingOfType("redis.XReadGroupArgs")).Return([]redis.XStream{
+		{
+			Stream: redisStreamKey,
+			Messages: []redis.XMessage{
+				{ID: "2-0", Values: map[string]interface{}{"other": "field"}},
+				{ID: "3-0", Values: map[string]interface{}{"message": `{"attributes":{},"body":"read"}`}},
+			},
+		},
+	}, nil).Run(func(ctx context.Context, args redis.XReadGroupArgs) {
+		s.input.Stop()
+	}).Once()
+
+	// the undecodable entries have to be acknowledged, otherwise they would be claimed again after the claim min idle time
+	s.client.EXPECT().XAck(s.ctx, redisStreamKey, "group", "1-0").Return(int64(1), nil).Once()
+	s.client.EXPECT().XAck(s.ctx, redisStreamKey, "group", "2-0").Return(int64(1), nil).Once()
+
+	var err error
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		err = s.input.Run(s.ctx)
+	}()
+
+	messages := make([]*stream.Message, 0)
+	for msg := range s.input.Data() {
+		messages = append(messages, msg)
+	}
+
+	<-done
+
+	s.NoError(err)
+	s.Equal([]*stream.Message{
+		{
+			Attributes: map[string]string{stream.AttributeRedisStreamEntryId: "3-0"},
+			Body:       "read",
+		},
+	}, messages)
+}
+
+func (s *RedisStreamInputTestSuite) TestRunGroupCreationFails() {
+	s.client.EXPECT().XGroupCreateMkStream(s.ctx, redisStreamKey, "group", "0").Return("", errors.New("WRONGTYPE")).Once()
+
+	err := s.input.Run(s.ctx)
+	s.EqualError(err, "can not create consumer group group for stream mcoins-test-fam-grp-app-my-stream: WRONGTYPE")
+}
+
+func (s *RedisStreamInputTestSuite) TestAckBatch() {
+	s.client.EXPECT().XAck(s.ctx, redisStreamKey, "group", "1-0", "3-0").Return(int64(2), nil).Once()
+
+	msgs := []*stream.Message{
+		{Attributes: map[string]string{stream.AttributeRedisStreamEntryId: "1-0"}},
+		{Attributes: map[string]string{stream.AttributeRedisStreamEntryId: "2-0"}},
+		{Attributes: map[string]string{stream.AttributeRedisStreamEntryId: "3-0"}},
+		{Attributes: map[string]string{}},
+	}
+
+	err := s.input.AckBatch(s.ctx, msgs, []bool{true, false, true, true})
+	s.EqualError(err, "1 error occurred:\n\t* the message has no attribute redisStreamEntryId\n\n")
+}
+
+func (s *RedisStreamInputTestSuite) TestAckNotAcknowledged() {
+	err := s.input.Ack(s.ctx, &stream.Message{}, false)
+	s.NoError(err)
+
+	s.client.AssertNotCalled(s.T(), "XAck", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRedisStreamInputTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisStreamInputTestSuite))
+}
@@ -18,6 +18,7 @@ type KafkaSourceMessage struct {
 
 func (k KafkaSourceMessage) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]interface{}{
+		"Topic":     k.Topic,
 		"Time":      k.Time,
 		"Partition": k.Partition,
 		"Offset":    k.Offset,
@@ -10,15 +10,17 @@ import (
 )
 
 const (
-	OutputTypeFile     = "file"
-	OutputTypeInMemory = "inMemory"
-	OutputTypeKinesis  = "kinesis"
-	OutputTypeMultiple = "multiple"
-	OutputTypeNoOp     = "noop"
-	OutputTypeRedis    = "redis"
-	OutputTypeSns      = "sns"
-	OutputTypeSqs      = "sqs"
-	OutputTypeKafka    = "kafka"
+	OutputTypeElasticsearch = "elasticsearch"
+	OutputTypeFile          = "file"
+	OutputTypeInMemory      = "inMemory"
+	OutputTypeKinesis       = "kinesis"
+	OutputTypeMultiple      = "multiple"
+	OutputTypeNoOp          = "noop"
+	OutputTypeRedis         = "redis"
+	OutputTypeRedisStream   = "redis_stream"
+	OutputTypeSns           = "sns"
+	OutputTypeSqs           = "sqs"
+	OutputTypeKafka         = "kafka"
 )
 
 type BaseOutputConfigurationAware interface {
@@ -39,15 +41,17 @@ type BaseOutputConfigurationTracing struct {
 
 func NewConfigurableOutput(ctx context.Context, config cfg.Config, logger log.Logger, name string) (Output, error) {
 	outputFactories := map[string]OutputFactory{
-		OutputTypeFile:     newFileOutputFromConfig,
-		OutputTypeInMemory: newInMemoryOutputFromConfig,
-		OutputTypeKinesis:  newKinesisOutputFromConfig,
-		OutputTypeMultiple: NewConfigurableMultiOutput,
-		OutputTypeNoOp:     newNoOpOutput,
-		OutputTypeRedis:    newRedisListOutputFromConfig,
-		OutputTypeSns:      newSnsOutputFromConfig,
-		OutputTypeSqs:      newSqsOutputFromConfig,
-		OutputTypeKafka:    newKafkaOutputFromConfig,
+		OutputTypeElasticsearch: newElasticsearchOutputFromConfig,
+		OutputTypeFile:          newFileOutputFromConfig,
+		OutputTypeInMemory:      newInMemoryOutputFromConfig,
+		OutputTypeKinesis:       newKinesisOutputFromConfig,
+		OutputTypeMultiple:      NewConfigurableMultiOutput,
+		OutputTypeNoOp:          newNoOpOutput,
+		OutputTypeRedis:         newRedisListOutputFromConfig,
+		OutputTypeRedisStream:   newRedisStreamOutputFromConfig,
+		OutputTypeSns:           newSnsOutputFromConfig,
+		OutputTypeSqs:           newSqsOutputFromConfig,
+		OutputTypeKafka:         newKafkaOutputFromConfig,
 	}
 
 	key := fmt.Sprintf("%s.type", ConfigurableOutputKey(name))
@@ -69,6 +73,25 @@ func NewConfigurableOutput(ctx context.Context, config cfg.Config, logger log.Lo
 	return NewOutputTracer(config, logger, output, name)
 }
 
+type elasticsearchOutputConfiguration struct {
+	BaseOutputConfiguration
+	Type        string `cfg:"type" default:"elasticsearch"`
+	Repository  string `cfg:"repository" validate:"required,min=1"`
+	IdAttribute string `cfg:"id_attribute"`
+}
+
+func newElasticsearchOutputFromConfig(_ context.Context, config cfg.Config, logger log.Logger, name string) (Output, error) {
+	key := ConfigurableOutputKey(name)
+
+	configuration := elasticsearchOutputConfiguration{}
+	config.UnmarshalKey(key, &configuration, cfg.UnmarshalWithDefaultForKey("repository", name))
+
+	return NewElasticsearchOutput(config, logger, &ElasticsearchOutputSettings{
+		Repository:  configuration.Repository,
+		IdAttribute: configuration.IdAttribute,
+	})
+}
+
 func newFileOutputFromConfig(_ context.Context, config cfg.Config, logger log.Logger, name string) (Output, error) {
 	key := ConfigurableOutputKey(name)
 	settings := &FileOutputSettings{}
@@ -152,6 +175,37 @@ func newRedisListOutputFromConfig(_ context.Context, config cfg.Config, logger l
 	})
 }
 
+type redisStreamOutputConfiguration struct {
+	Project     string `cfg:"project"`
+	Family      string `cfg:"family"`
+	Group       string `cfg:"group"`
+	Application string `cfg:"application"`
+	ServerName  string `cfg:"server_name" default:"default" validate:"required,min=1"`
+	Key         string `cfg:"key" validate:"required,min=1"`
+	MaxLen      int64  `cfg:"max_len" default:"0"`
+	ExactTrim   bool   `cfg:"exact_trim" default:"false"`
+}
+
+func newRedisStreamOutputFromConfig(_ context.Context, config cfg.Config, logger log.Logger, name string) (Output, error) {
+	key := ConfigurableOutputKey(name)
+
+	configuration := redisStreamOutputConfiguration{}
+	config.UnmarshalKey(key, &configuration)
+
+	return NewRedisStreamOutput(config, logger, &RedisStreamOutputSettings{
+		AppId: cfg.AppId{
+			Project:     configuration.Project,
+			Family:      configuration.Family,
+			Group:       configuration.Group,
+			Application: configuration.Application,
+		},
+		ServerName: configuration.ServerName,
+		Key:        configuration.Key,
+		MaxLen:     configuration.MaxLen,
+		ExactTrim:  configuration.ExactTrim,
+	})
+}
+
 type SnsOutputConfiguration struct {
 	BaseOutputConfiguration
 	Type        string `cfg:"type" default:"sns"`
@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/encoding/json"
+	"github.com/justtrackio/gosoline/pkg/es"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+type ElasticsearchOutputSettings struct {
+	// Repository selects the repository configured at es.repositories.<repository>
+	Repository string
+	// IdAttribute is the message attribute used as document id, elasticsearch generates an id if it is empty
+	IdAttribute string
+}
+
+type elasticsearchOutput struct {
+	logger     log.Logger
+	repository es.Repository[es.RawDocument]
+	settings   *ElasticsearchOutputSettings
+}
+
+func NewElasticsearchOutput(config cfg.Config, logger log.Logger, settings *ElasticsearchOutputSettings) (Output, error) {
+	repository, err := es.NewRepository[es.RawDocument](config, logger, settings.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("can not create es repository %s: %w", settings.Repository, err)
+	}
+
+	return NewElasticsearchOutputWithInterfaces(logger, repository, settings), nil
+}
+
+func NewElasticsearchOutputWithInterfaces(logger log.Logger, repository es.Repository[es.RawDocument], settings *ElasticsearchOutputSettings) Output {
+	return &elasticsearchOutput{
+		logger:     logger,
+		repository: repository,
+		settings:   settings,
+	}
+}
+
+func (o *elasticsearchOutput) WriteOne(ctx context.Context, record WritableMessage) error {
+	return o.Write(ctx, []WritableMessage{record})
+}
+
+// Write indexes the bodies of all messages with a single bulk request. The bodies have to be uncompressed json
+// documents, as elasticsearch can't index anything else.
+func (o *elasticsearchOutput) Write(ctx context.Context, batch []WritableMessage) error {
+	operations := make([]es.BulkOperation[es.RawDocument], 0, len(batch))
+
+	for i, record := range batch {
+		msg, ok := record.(*Message)
+		if !ok {
+			return fmt.Errorf("message %d of the batch is of type %T, expected *stream.Message", i, record)
+		}
+
+		if encoding, ok := msg.Attributes[AttributeEncoding]; ok && encoding != EncodingJson.String() {
+			return fmt.Errorf("message %d of the batch has the encoding %s, only %s can be indexed", i, encoding, EncodingJson)
+		}
+
+		if compression, ok := msg.Attributes[AttributeCompression]; ok && compression != CompressionNone.String() {
+			return fmt.Errorf("message %d of the batch is compressed with %s, only uncompressed messages can be indexed", i, compression)
+		}
+
+		if !json.Valid([]byte(msg.Body)) {
+			return fmt.Errorf("the body of message %d of the batch is no valid json document", i)
+		}
+
+		id := ""
+		if o.settings.IdAttribute != "" {
+			id = msg.Attributes[o.settings.IdAttribute]
+		}
+
+		operations = append(operations, es.BulkIndexOperation(id, es.RawDocument(msg.Body)))
+	}
+
+	if err := o.repository.Bulk(ctx, operations...); err != nil {
+		return fmt.Errorf("can not bulk index %d documents: %w", len(operations), err)
+	}
+
+	return nil
+}
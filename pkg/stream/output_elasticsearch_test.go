@@ -0,0 +1,70 @@
+package stream_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/es"
+	esMocks "github.com/justtrackio/gosoline/pkg/es/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/stream"
+	"github.com/stretchr/testify/suite"
+)
+
+type ElasticsearchOutputTestSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	repository *esMocks.Repository[es.RawDocument]
+	output     stream.Output
+}
+
+func (s *ElasticsearchOutputTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repository = esMocks.NewRepository[es.RawDocument](s.T())
+	s.output = stream.NewElasticsearchOutputWithInterfaces(logMocks.NewLoggerMockedAll(), s.repository, &stream.ElasticsearchOutputSettings{
+		Repository:  "events",
+		IdAttribute: "id",
+	})
+}
+
+func (s *ElasticsearchOutputTestSuite) TestWrite() {
+	s.repository.EXPECT().Bulk(s.ctx,
+		es.BulkIndexOperation("1", es.RawDocument(`{"name":"foo"}`)),
+		es.BulkIndexOperation("", es.RawDocument(`{"name":"bar"}`)),
+	).Return(nil).Once()
+
+	err := s.output.Write(s.ctx, []stream.WritableMessage{
+		stream.NewJsonMessage(`{"name":"foo"}`, map[string]string{"id": "1"}),
+		stream.NewJsonMessage(`{"name":"bar"}`),
+	})
+
+	s.NoError(err)
+}
+
+func (s *ElasticsearchOutputTestSuite) TestWriteBulkError() {
+	s.repository.EXPECT().Bulk(s.ctx, es.BulkIndexOperation("1", es.RawDocument(`{"name":"foo"}`))).Return(fmt.Errorf("bulk failed")).Once()
+
+	err := s.output.WriteOne(s.ctx, stream.NewJsonMessage(`{"name":"foo"}`, map[string]string{"id": "1"}))
+
+	s.EqualError(err, "can not bulk index 1 documents: bulk failed")
+}
+
+func (s *ElasticsearchOutputTestSuite) TestWriteNonJsonEncoding() {
+	err := s.output.WriteOne(s.ctx, stream.NewMessage("Zm9v", map[string]string{
+		stream.AttributeEncoding: stream.EncodingProtobuf.String(),
+	}))
+
+	s.EqualError(err, "message 0 of the batch has the encoding application/x-protobuf, only application/json can be indexed")
+}
+
+func (s *ElasticsearchOutputTestSuite) TestWriteInvalidJson() {
+	err := s.output.WriteOne(s.ctx, stream.NewJsonMessage(`{"name":`))
+
+	s.EqualError(err, "the body of message 0 of the batch is no valid json document")
+}
+
+func TestElasticsearchOutputTestSuite(t *testing.T) {
+	suite.Run(t, new(ElasticsearchOutputTestSuite))
+}
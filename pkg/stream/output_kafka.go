@@ -3,16 +3,23 @@ package stream
 import (
 	"context"
 	"fmt"
+	"sync"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/justtrackio/gosoline/pkg/cfg"
 	"github.com/justtrackio/gosoline/pkg/coffin"
 	kafkaProducer "github.com/justtrackio/gosoline/pkg/kafka/producer"
+	"github.com/justtrackio/gosoline/pkg/kafka/schemaregistry"
 	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/segmentio/kafka-go"
 )
 
 type KafkaOutput struct {
-	producer *kafkaProducer.Producer
-	pool     coffin.Coffin
+	producer   *kafkaProducer.Producer
+	serializer schemaregistry.Serializer
+	pool       coffin.Coffin
+	// the transactional producer can only run one transaction at a time, so concurrent transactions have to wait
+	transactionLck sync.Mutex
 }
 
 var _ Output = &KafkaOutput{}
@@ -23,20 +30,126 @@ func NewKafkaOutput(ctx context.Context, config cfg.Config, logger log.Logger, k
 		return nil, fmt.Errorf("failed to init producer: %w", err)
 	}
 
-	return NewKafkaOutputWithInterfaces(ctx, prod)
+	var serializer schemaregistry.Serializer
+
+	if prod.Settings.Schema.Enabled() {
+		if serializer, err = schemaregistry.NewSerializer(ctx, config, logger, &prod.Settings.Schema, prod.Settings.FQTopic); err != nil {
+			return nil, fmt.Errorf("failed to init schema registry serializer: %w", err)
+		}
+	}
+
+	return NewKafkaOutputWithInterfaces(ctx, prod, serializer)
 }
 
-func NewKafkaOutputWithInterfaces(ctx context.Context, producer *kafkaProducer.Producer) (*KafkaOutput, error) {
+// NewKafkaOutputWithInterfaces creates an output writing with the producer. The serializer is optional, without
+// one the message bodies are written as they are.
+func NewKafkaOutputWithInterfaces(ctx context.Context, producer *kafkaProducer.Producer, serializer schemaregistry.Serializer) (*KafkaOutput, error) {
 	pool := coffin.New()
 	pool.GoWithContext(ctx, producer.Run)
 
-	return &KafkaOutput{producer: producer, pool: pool}, nil
+	return &KafkaOutput{producer: producer, serializer: serializer, pool: pool}, nil
 }
 
 func (o *KafkaOutput) WriteOne(ctx context.Context, m WritableMessage) error {
-	return o.producer.WriteOne(ctx, NewKafkaMessage(m))
+	return o.Write(ctx, []WritableMessage{m})
 }
 
+// Write writes the messages. A transactional producer can't write outside of a transaction, so the messages are
+// written within their own transaction in that case.
 func (o *KafkaOutput) Write(ctx context.Context, ms []WritableMessage) error {
-	return o.producer.Write(ctx, NewKafkaMessages(ms)...)
+	if !o.producer.Settings.Transactional() {
+		return o.write(ctx, ms)
+	}
+
+	o.transactionLck.Lock()
+	defer o.transactionLck.Unlock()
+
+	return o.inTransaction(ctx, func() error {
+		if err := o.write(ctx, ms); err != nil {
+			return fmt.Errorf("can not write messages in transaction: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// WriteTransactional writes the messages and commits the offsets of the consumed messages of the input within a
+// single transaction. Either both become visible or neither does, which makes a kafka to kafka stream exactly-once.
+// The consumed messages still have to be acknowledged at the input afterwards, which is a no-op for the committed
+// offsets but keeps the bookkeeping of the consumer intact. Concurrent calls, e.g. by multiple runners of a consumer,
+// are executed one after another.
+func (o *KafkaOutput) WriteTransactional(ctx context.Context, ms []WritableMessage, input *KafkaInput, consumed []*Message) error {
+	o.transactionLck.Lock()
+	defer o.transactionLck.Unlock()
+
+	generation, err := input.Generation()
+	if err != nil {
+		return fmt.Errorf("can not get consumer group generation: %w", err)
+	}
+
+	return o.inTransaction(ctx, func() error {
+		if err := o.write(ctx, ms); err != nil {
+			return fmt.Errorf("can not write messages in transaction: %w", err)
+		}
+
+		if err := o.producer.SendOffsetsToTransaction(ctx, generation, GosoToKafkaMessages(consumed...)...); err != nil {
+			return fmt.Errorf("can not send offsets to transaction: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// inTransaction has to be called while holding the transaction lock. The transaction is committed if fn succeeds and
+// aborted otherwise.
+func (o *KafkaOutput) inTransaction(ctx context.Context, fn func() error) (err error) {
+	if err = o.producer.BeginTransaction(); err != nil {
+		return fmt.Errorf("can not begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if abortErr := o.producer.AbortTransaction(ctx); abortErr != nil {
+			err = multierror.Append(err, fmt.Errorf("can not abort transaction: %w", abortErr))
+		}
+	}()
+
+	if err = fn(); err != nil {
+		return err
+	}
+
+	if err = o.producer.CommitTransaction(ctx); err != nil {
+		return fmt.Errorf("can not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (o *KafkaOutput) write(ctx context.Context, ms []WritableMessage) error {
+	msgs, err := o.buildMessages(ms)
+	if err != nil {
+		return err
+	}
+
+	return o.producer.Write(ctx, msgs...)
+}
+
+func (o *KafkaOutput) buildMessages(ms []WritableMessage) ([]kafka.Message, error) {
+	var err error
+	msgs := NewKafkaMessages(ms)
+
+	if o.serializer == nil {
+		return msgs, nil
+	}
+
+	for i := range msgs {
+		if msgs[i].Value, err = o.serializer.Serialize(msgs[i].Value); err != nil {
+			return nil, fmt.Errorf("can not serialize message with schema: %w", err)
+		}
+	}
+
+	return msgs, nil
 }
@@ -0,0 +1,106 @@
+package stream_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kafkaProducer "github.com/justtrackio/gosoline/pkg/kafka/producer"
+	producerMocks "github.com/justtrackio/gosoline/pkg/kafka/producer/mocks"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	"github.com/justtrackio/gosoline/pkg/stream"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newKafkaOutput(t *testing.T, settings *kafkaProducer.Settings, writer kafkaProducer.Writer) *stream.KafkaOutput {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	prod, err := kafkaProducer.NewProducerWithInterfaces(settings, logMocks.NewLoggerMockedAll(), writer)
+	assert.NoError(t, err)
+
+	output, err := stream.NewKafkaOutputWithInterfaces(ctx, prod, nil)
+	assert.NoError(t, err)
+
+	return output
+}
+
+func TestKafkaOutputWrite(t *testing.T) {
+	writer := producerMocks.NewWriter(t)
+	writer.On("WriteMessages", mock.Anything, []kafka.Message{{Topic: "fq-topic", Value: []byte("out")}}).Return(nil).Once()
+	writer.On("Close").Return(nil).Maybe()
+
+	output := newKafkaOutput(t, &kafkaProducer.Settings{FQTopic: "fq-topic"}, writer)
+
+	err := output.WriteOne(context.Background(), &stream.Message{Body: "out"})
+	assert.NoError(t, err)
+}
+
+func TestKafkaOutputWriteTransactionalProducer(t *testing.T) {
+	ctx := context.Background()
+	writer := producerMocks.NewTransactionalWriter(t)
+
+	// a transactional producer can't write outside of a transaction, so every write gets its own one
+	writer.On("BeginTransaction").Return(nil).Once()
+	writer.On("WriteMessages", mock.Anything, []kafka.Message{{Topic: "fq-topic", Value: []byte("out")}}).Return(nil).Once()
+	writer.On("CommitTransaction", ctx).Return(nil).Once()
+	writer.On("Close").Return(nil).Maybe()
+
+	output := newKafkaOutput(t, &kafkaProducer.Settings{FQTopic: "fq-topic", TransactionalID: "txn"}, writer)
+
+	err := output.WriteOne(ctx, &stream.Message{Body: "out"})
+	assert.NoError(t, err)
+}
+
+func TestKafkaOutputWriteTransactionalProducerFails(t *testing.T) {
+	ctx := context.Background()
+	writer := producerMocks.NewTransactionalWriter(t)
+
+	writer.On("BeginTransaction").Return(nil).Once()
+	writer.On("WriteMessages", mock.Anything, mock.Anything).Return(fmt.Errorf("broker not available")).Once()
+	writer.On("AbortTransaction", ctx).Return(nil).Once()
+	writer.On("Close").Return(nil).Maybe()
+
+	output := newKafkaOutput(t, &kafkaProducer.Settings{FQTopic: "fq-topic", TransactionalID: "txn"}, writer)
+
+	err := output.WriteOne(ctx, &stream.Message{Body: "out"})
+	assert.EqualError(t, err, "can not write messages in transaction: broker not available")
+}
+
+func TestKafkaOutputWriteTransactionalProducerConcurrently(t *testing.T) {
+	ctx := context.Background()
+	writer := producerMocks.NewTransactionalWriter(t)
+	inTransaction := atomic.Bool{}
+
+	writer.On("BeginTransaction").Return(nil).Run(func(_ mock.Arguments) {
+		assert.True(t, inTransaction.CompareAndSwap(false, true), "a transaction was started while another one was running")
+		// give the other writers a chance to start a transaction at the same time
+		time.Sleep(time.Millisecond)
+	}).Times(10)
+	writer.On("WriteMessages", mock.Anything, mock.Anything).Return(nil).Times(10)
+	writer.On("CommitTransaction", ctx).Return(nil).Run(func(_ mock.Arguments) {
+		inTransaction.Store(false)
+	}).Times(10)
+	writer.On("Close").Return(nil).Maybe()
+
+	output := newKafkaOutput(t, &kafkaProducer.Settings{FQTopic: "fq-topic", TransactionalID: "txn"}, writer)
+
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, output.WriteOne(ctx, &stream.Message{Body: "out"}))
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,121 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/metric"
+	"github.com/justtrackio/gosoline/pkg/redis"
+)
+
+const (
+	metricNameRedisStreamOutputWrites = "StreamRedisStreamOutputWrites"
+)
+
+type RedisStreamOutputSettings struct {
+	cfg.AppId
+	ServerName string
+	Key        string
+	// MaxLen trims the stream to about this many entries on every write, 0 disables trimming
+	MaxLen int64
+	// ExactTrim trims the stream to exactly MaxLen entries instead of letting redis remove whole nodes only, which is
+	// considerably more expensive
+	ExactTrim bool
+}
+
+type redisStreamOutput struct {
+	logger            log.Logger
+	metricWriter      metric.Writer
+	client            redis.Client
+	settings          *RedisStreamOutputSettings
+	fullyQualifiedKey string
+}
+
+func NewRedisStreamOutput(config cfg.Config, logger log.Logger, settings *RedisStreamOutputSettings) (Output, error) {
+	settings.PadFromConfig(config)
+
+	client, err := redis.ProvideClient(config, logger, settings.ServerName)
+	if err != nil {
+		return nil, fmt.Errorf("can not create redis client: %w", err)
+	}
+
+	defaultMetrics := getRedisStreamOutputDefaultMetrics(settings.AppId, settings.Key)
+	mw := metric.NewWriter(defaultMetrics...)
+
+	return NewRedisStreamOutputWithInterfaces(logger, mw, client, settings), nil
+}
+
+func NewRedisStreamOutputWithInterfaces(logger log.Logger, mw metric.Writer, client redis.Client, settings *RedisStreamOutputSettings) Output {
+	fullyQualifiedKey := redis.GetFullyQualifiedKey(settings.AppId, settings.Key)
+
+	return &redisStreamOutput{
+		logger:            logger,
+		metricWriter:      mw,
+		client:            client,
+		settings:          settings,
+		fullyQualifiedKey: fullyQualifiedKey,
+	}
+}
+
+func (o *redisStreamOutput) WriteOne(ctx context.Context, record WritableMessage) error {
+	return o.Write(ctx, []WritableMessage{record})
+}
+
+func (o *redisStreamOutput) Write(ctx context.Context, batch []WritableMessage) error {
+	for _, msg := range batch {
+		bytes, err := msg.MarshalToBytes()
+		if err != nil {
+			return fmt.Errorf("can not marshal message: %w", err)
+		}
+
+		_, err = o.client.XAdd(ctx, redis.XAddArgs{
+			Stream: o.fullyQualifiedKey,
+			MaxLen: o.settings.MaxLen,
+			Approx: !o.settings.ExactTrim,
+			Values: map[string]interface{}{
+				redisStreamMessageField: bytes,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("can not add message to stream %s: %w", o.fullyQualifiedKey, err)
+		}
+	}
+
+	o.writeStreamWriteMetric(len(batch))
+
+	return nil
+}
+
+func (o *redisStreamOutput) writeStreamWriteMetric(length int) {
+	data := metric.Data{{
+		Priority:   metric.PriorityHigh,
+		Timestamp:  time.Now(),
+		MetricName: metricNameRedisStreamOutputWrites,
+		Dimensions: map[string]string{
+			"StreamName": o.fullyQualifiedKey,
+		},
+		Unit:  metric.UnitCount,
+		Value: float64(length),
+	}}
+
+	o.metricWriter.Write(data)
+}
+
+func getRedisStreamOutputDefaultMetrics(appId cfg.AppId, key string) metric.Data {
+	fullyQualifiedKey := redis.GetFullyQualifiedKey(appId, key)
+
+	return metric.Data{
+		{
+			Priority:   metric.PriorityHigh,
+			MetricName: metricNameRedisStreamOutputWrites,
+			Dimensions: map[string]string{
+				"StreamName": fullyQualifiedKey,
+			},
+			Unit:  metric.UnitCount,
+			Value: 0.0,
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package stream_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	logMocks "github.com/justtrackio/gosoline/pkg/log/mocks"
+	metricMocks "github.com/justtrackio/gosoline/pkg/metric/mocks"
+	"github.com/justtrackio/gosoline/pkg/redis"
+	redisMocks "github.com/justtrackio/gosoline/pkg/redis/mocks"
+	"github.com/justtrackio/gosoline/pkg/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisStreamOutput_Write(t *testing.T) {
+	ctx := context.Background()
+	client := redisMocks.NewClient(t)
+
+	output := stream.NewRedisStreamOutputWithInterfaces(logMocks.NewLoggerMockedAll(), metricMocks.NewWriterMockedAll(), client, &stream.RedisStreamOutputSettings{
+		AppId: cfg.AppId{
+			Project:     "mcoins",
+			Environment: "test",
+			Family:      "fam",
+			Group:       "grp",
+			Application: "app",
+		},
+		Key:    "my-stream",
+		MaxLen: 1000,
+	})
+
+	for _, body := range []string{"foo", "bar"} {
+		client.EXPECT().XAdd(ctx, redis.XAddArgs{
+			Stream: "mcoins-test-fam-grp-app-my-stream",
+			MaxLen: 1000,
+			Approx: true,
+			Values: map[string]interface{}{
+				"message": []byte(`{"attributes":{},"body":"` + body + `"}`),
+			},
+		}).Return("1-0", nil).Once()
+	}
+
+	err := output.Write(ctx, []stream.WritableMessage{
+		stream.NewMessage("foo"),
+		stream.NewMessage("bar"),
+	})
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,29 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/es"
+)
+
+type ElasticsearchComponent struct {
+	baseComponent
+	endpoint string
+	client   *es.ClientV7
+}
+
+func (c *ElasticsearchComponent) CfgOptions() []cfg.Option {
+	return []cfg.Option{
+		cfg.WithConfigSetting(fmt.Sprintf("es_%s_type", c.name), "default"),
+		cfg.WithConfigSetting(fmt.Sprintf("es_%s_endpoint", c.name), c.endpoint),
+	}
+}
+
+func (c *ElasticsearchComponent) Endpoint() string {
+	return c.endpoint
+}
+
+func (c *ElasticsearchComponent) Client() *es.ClientV7 {
+	return c.client
+}
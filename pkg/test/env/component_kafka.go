@@ -0,0 +1,147 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/uuid"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaRecord is a raw record of a topic, independent of any encoding or schema registry wire format.
+type KafkaRecord struct {
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Partition int
+	Offset    int64
+	Time      time.Time
+}
+
+type KafkaComponent struct {
+	baseComponent
+	address string
+}
+
+func (c *KafkaComponent) CfgOptions() []cfg.Option {
+	return []cfg.Option{
+		cfg.WithConfigSetting(fmt.Sprintf("kafka.connection.%s", c.name), map[string]interface{}{
+			"bootstrap":   []string{c.address},
+			"disable_tls": true,
+			"username":    "",
+			"password":    "",
+		}),
+	}
+}
+
+func (c *KafkaComponent) Address() string {
+	return c.address
+}
+
+// CreateTopic creates the topic with the given number of partitions, existing topics are left untouched.
+func (c *KafkaComponent) CreateTopic(topic string, partitions int) {
+	if err := c.createTopic(topic, partitions); err != nil {
+		c.failNow(err.Error(), "can not create topic %s", topic)
+	}
+}
+
+// Produce writes the records to the fully-qualified topic, e.g. to feed a kafka input of the application under test.
+func (c *KafkaComponent) Produce(topic string, records ...KafkaRecord) {
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(c.address),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+	defer writer.Close()
+
+	messages := make([]kafka.Message, len(records))
+
+	for i, record := range records {
+		messages[i] = kafka.Message{
+			Key:     record.Key,
+			Value:   record.Value,
+			Headers: c.toHeaders(record.Headers),
+			Time:    record.Time,
+		}
+	}
+
+	if err := writer.WriteMessages(context.Background(), messages...); err != nil {
+		c.failNow(err.Error(), "can not produce %d records to topic %s", len(records), topic)
+	}
+}
+
+// Consume reads count records of all partitions of the fully-qualified topic from the beginning, e.g. to assert the
+// output of the application under test. It fails if there are less records available within the timeout.
+func (c *KafkaComponent) Consume(topic string, count int, timeout time.Duration) []KafkaRecord {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// a fresh group makes the reader consume every partition of the topic from the first offset
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        []string{c.address},
+		Topic:          topic,
+		GroupID:        fmt.Sprintf("gosoline-test-env-%s", uuid.New().NewV4()),
+		StartOffset:    kafka.FirstOffset,
+		IsolationLevel: kafka.ReadCommitted,
+	})
+	defer reader.Close()
+
+	records := make([]KafkaRecord, 0, count)
+
+	for len(records) < count {
+		message, err := reader.FetchMessage(ctx)
+		if err != nil {
+			c.failNow(err.Error(), "can not consume %d records from topic %s, got %d", count, topic, len(records))
+
+			return records
+		}
+
+		records = append(records, KafkaRecord{
+			Key:       message.Key,
+			Value:     message.Value,
+			Headers:   c.fromHeaders(message.Headers),
+			Partition: message.Partition,
+			Offset:    message.Offset,
+			Time:      message.Time,
+		})
+	}
+
+	return records
+}
+
+func (c *KafkaComponent) createTopic(topic string, partitions int) error {
+	conn, err := kafka.DialContext(context.Background(), "tcp", c.address)
+	if err != nil {
+		return fmt.Errorf("can not connect to broker: %w", err)
+	}
+	defer conn.Close()
+
+	return conn.CreateTopics(kafka.TopicConfig{
+		Topic:             topic,
+		NumPartitions:     partitions,
+		ReplicationFactor: 1,
+	})
+}
+
+func (c *KafkaComponent) toHeaders(headers map[string]string) []kafka.Header {
+	result := make([]kafka.Header, 0, len(headers))
+
+	for key, value := range headers {
+		result = append(result, kafka.Header{Key: key, Value: []byte(value)})
+	}
+
+	return result
+}
+
+func (c *KafkaComponent) fromHeaders(headers []kafka.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+
+	for _, header := range headers {
+		result[header.Key] = string(header.Value)
+	}
+
+	return result
+}
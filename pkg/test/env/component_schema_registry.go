@@ -0,0 +1,26 @@
+package env
+
+import (
+	"fmt"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+)
+
+type SchemaRegistryComponent struct {
+	baseComponent
+	url string
+}
+
+func (c *SchemaRegistryComponent) CfgOptions() []cfg.Option {
+	return []cfg.Option{
+		cfg.WithConfigSetting(fmt.Sprintf("kafka.schema_registry.%s", c.name), map[string]interface{}{
+			"url":      c.url,
+			"username": "",
+			"password": "",
+		}),
+	}
+}
+
+func (c *SchemaRegistryComponent) Url() string {
+	return c.url
+}
@@ -171,6 +171,18 @@ func (e *Environment) Redis(name string) *RedisComponent {
 	return e.Component(componentRedis, name).(*RedisComponent)
 }
 
+func (e *Environment) Elasticsearch(name string) *ElasticsearchComponent {
+	return e.Component(componentElasticsearch, name).(*ElasticsearchComponent)
+}
+
+func (e *Environment) Kafka(name string) *KafkaComponent {
+	return e.Component(componentKafka, name).(*KafkaComponent)
+}
+
+func (e *Environment) SchemaRegistry(name string) *SchemaRegistryComponent {
+	return e.Component(componentSchemaRegistry, name).(*SchemaRegistryComponent)
+}
+
 func (e *Environment) S3(name string) *S3Component {
 	return e.Component(componentS3, name).(*S3Component)
 }
@@ -187,6 +199,10 @@ func (e *Environment) MySql(name string) *mysqlComponent {
 	return e.Component(componentMySql, name).(*mysqlComponent)
 }
 
+func (e *Environment) Postgres(name string) *postgresComponent {
+	return e.Component(componentPostgres, name).(*postgresComponent)
+}
+
 func (e *Environment) Wiremock(name string) *wiremockComponent {
 	return e.Component(componentWiremock, name).(*wiremockComponent)
 }
@@ -0,0 +1,159 @@
+package env
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/es"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+func init() {
+	componentFactories[componentElasticsearch] = new(elasticsearchFactory)
+}
+
+const (
+	componentElasticsearch = "elasticsearch"
+
+	elasticsearchFlavorElasticsearch = "elasticsearch"
+	elasticsearchFlavorOpenSearch    = "opensearch"
+)
+
+type elasticsearchSettings struct {
+	ComponentBaseSettings
+	ComponentContainerSettings
+	Port int `cfg:"port" default:"0"`
+	// Flavor selects the server image, both speak the same api for everything the es package uses
+	Flavor string `cfg:"flavor" default:"elasticsearch" validate:"oneof=elasticsearch opensearch"`
+	// Version is the image tag, it defaults to a recent version of the flavor
+	Version string `cfg:"version"`
+}
+
+type elasticsearchFactory struct{}
+
+func (f *elasticsearchFactory) Detect(config cfg.Config, manager *ComponentsConfigManager) error {
+	if !config.IsSet("es.repositories") {
+		return nil
+	}
+
+	if !manager.ShouldAutoDetect(componentElasticsearch) {
+		return nil
+	}
+
+	if manager.HasType(componentElasticsearch) {
+		return nil
+	}
+
+	clientNames := map[string]struct{}{}
+
+	for name := range config.GetStringMap("es.repositories") {
+		settings := es.ReadRepositorySettings(config, name)
+		clientNames[settings.ClientName] = struct{}{}
+	}
+
+	for name := range clientNames {
+		settings := &elasticsearchSettings{}
+		config.UnmarshalDefaults(settings)
+
+		settings.Type = componentElasticsearch
+		settings.Name = name
+
+		if err := manager.Add(settings); err != nil {
+			return fmt.Errorf("can not add default elasticsearch component: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *elasticsearchFactory) GetSettingsSchema() ComponentBaseSettingsAware {
+	return &elasticsearchSettings{}
+}
+
+func (f *elasticsearchFactory) DescribeContainers(settings interface{}) componentContainerDescriptions {
+	return componentContainerDescriptions{
+		"main": {
+			containerConfig: f.configureContainer(settings),
+			healthCheck:     f.healthCheck(),
+		},
+	}
+}
+
+func (f *elasticsearchFactory) configureContainer(settings interface{}) *containerConfig {
+	s := settings.(*elasticsearchSettings)
+
+	config := &containerConfig{
+		Repository: "docker.elastic.co/elasticsearch/elasticsearch",
+		Tag:        "7.17.10",
+		Env: []string{
+			"discovery.type=single-node",
+			"xpack.security.enabled=false",
+			"ES_JAVA_OPTS=-Xms512m -Xmx512m",
+		},
+		PortBindings: portBindings{
+			"9200/tcp": s.Port,
+		},
+		ExpireAfter: s.ExpireAfter,
+	}
+
+	if s.Flavor == elasticsearchFlavorOpenSearch {
+		config.Repository = "opensearchproject/opensearch"
+		config.Tag = "2.11.1"
+		config.Env = []string{
+			"discovery.type=single-node",
+			"DISABLE_SECURITY_PLUGIN=true",
+			"OPENSEARCH_JAVA_OPTS=-Xms512m -Xmx512m",
+		}
+	}
+
+	if s.Version != "" {
+		config.Tag = s.Version
+	}
+
+	return config
+}
+
+func (f *elasticsearchFactory) healthCheck() ComponentHealthCheck {
+	return func(container *container) error {
+		url := fmt.Sprintf("%s/_cluster/health?wait_for_status=yellow&timeout=1s", f.endpoint(container))
+
+		res, err := http.Get(url)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("cluster is not healthy yet: status %d", res.StatusCode)
+		}
+
+		return nil
+	}
+}
+
+func (f *elasticsearchFactory) Component(_ cfg.Config, logger log.Logger, containers map[string]*container, settings interface{}) (Component, error) {
+	s := settings.(*elasticsearchSettings)
+	endpoint := f.endpoint(containers["main"])
+
+	client, err := es.NewSimpleClient(logger, endpoint, "default")
+	if err != nil {
+		return nil, fmt.Errorf("can not create es client: %w", err)
+	}
+
+	component := &ElasticsearchComponent{
+		baseComponent: baseComponent{
+			name: s.Name,
+		},
+		endpoint: endpoint,
+		client:   client,
+	}
+
+	return component, nil
+}
+
+func (f *elasticsearchFactory) endpoint(container *container) string {
+	binding := container.bindings["9200/tcp"]
+
+	return fmt.Sprintf("http://%s:%s", binding.host, binding.port)
+}
@@ -0,0 +1,191 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/funk"
+	gosoKafka "github.com/justtrackio/gosoline/pkg/kafka"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/segmentio/kafka-go"
+)
+
+func init() {
+	componentFactories[componentKafka] = new(kafkaFactory)
+}
+
+const componentKafka = "kafka"
+
+type kafkaSettings struct {
+	ComponentBaseSettings
+	ComponentContainerSettings
+	Port    int    `cfg:"port" default:"0"`
+	Version string `cfg:"version" default:"v23.2.17"`
+	// Topics are fully-qualified topic names created on startup in addition to the topics of all producers, consumers
+	// and kafka stream inputs and outputs using the connection of the component.
+	Topics     []string `cfg:"topics"`
+	Partitions int      `cfg:"partitions" default:"1"`
+}
+
+type kafkaFactory struct{}
+
+func (f *kafkaFactory) Detect(config cfg.Config, manager *ComponentsConfigManager) error {
+	if !config.IsSet("kafka.connection") {
+		return nil
+	}
+
+	if !manager.ShouldAutoDetect(componentKafka) {
+		return nil
+	}
+
+	if manager.HasType(componentKafka) {
+		return nil
+	}
+
+	for name := range config.GetStringMap("kafka.connection") {
+		settings := &kafkaSettings{}
+		config.UnmarshalDefaults(settings)
+
+		settings.Type = componentKafka
+		settings.Name = name
+
+		if err := manager.Add(settings); err != nil {
+			return fmt.Errorf("can not add default kafka component: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *kafkaFactory) GetSettingsSchema() ComponentBaseSettingsAware {
+	return &kafkaSettings{}
+}
+
+func (f *kafkaFactory) DescribeContainers(settings interface{}) componentContainerDescriptions {
+	return componentContainerDescriptions{
+		"main": {
+			containerConfig: f.configureContainer(settings),
+			healthCheck:     f.healthCheck(),
+		},
+	}
+}
+
+func (f *kafkaFactory) configureContainer(settings interface{}) *containerConfig {
+	s := settings.(*kafkaSettings)
+
+	// the broker advertises its address to the clients, so the host port has to be known before the container starts
+	if s.Port == 0 {
+		s.Port = f.freePort()
+	}
+
+	return &containerConfig{
+		Repository: "redpandadata/redpanda",
+		Tag:        s.Version,
+		Cmd: []string{
+			"redpanda", "start",
+			"--overprovisioned",
+			"--smp", "1",
+			"--memory", "512M",
+			"--reserve-memory", "0M",
+			"--node-id", "0",
+			"--check=false",
+			"--kafka-addr", "PLAINTEXT://0.0.0.0:9092",
+			"--advertise-kafka-addr", fmt.Sprintf("PLAINTEXT://127.0.0.1:%d", s.Port),
+			"--set", "redpanda.auto_create_topics_enabled=true",
+			"--set", "redpanda.enable_transactions=true",
+			"--set", "redpanda.enable_idempotence=true",
+		},
+		PortBindings: portBindings{
+			"9092/tcp": s.Port,
+		},
+		ExpireAfter: s.ExpireAfter,
+	}
+}
+
+func (f *kafkaFactory) healthCheck() ComponentHealthCheck {
+	return func(container *container) error {
+		conn, err := kafka.DialContext(context.Background(), "tcp", f.address(container))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		_, err = conn.Brokers()
+
+		return err
+	}
+}
+
+func (f *kafkaFactory) Component(config cfg.Config, _ log.Logger, containers map[string]*container, settings interface{}) (Component, error) {
+	s := settings.(*kafkaSettings)
+
+	component := &KafkaComponent{
+		baseComponent: baseComponent{
+			name: s.Name,
+		},
+		address: f.address(containers["main"]),
+	}
+
+	// consumers subscribing by pattern or joining a group before the first write only see existing topics, so we
+	// don't rely on the auto creation on first write of the broker
+	topics := append(f.configuredTopics(config, s.Name), s.Topics...)
+
+	for _, topic := range funk.Uniq(topics) {
+		if err := component.createTopic(topic, s.Partitions); err != nil {
+			return nil, fmt.Errorf("can not create topic %s: %w", topic, err)
+		}
+	}
+
+	return component, nil
+}
+
+// configuredTopics returns the fully-qualified names of all topics of producers, consumers and kafka stream inputs and
+// outputs which use the connection.
+func (f *kafkaFactory) configuredTopics(config cfg.Config, connection string) []string {
+	appId := cfg.GetAppIdFromConfig(config)
+	topics := make([]string, 0)
+
+	for _, root := range []string{"kafka.producer", "kafka.consumer", "stream.input", "stream.output"} {
+		for name := range config.GetStringMap(root, map[string]interface{}{}) {
+			key := fmt.Sprintf("%s.%s", root, name)
+
+			if strings.HasPrefix(root, "stream.") && config.GetString(fmt.Sprintf("%s.type", key), "") != componentKafka {
+				continue
+			}
+
+			if config.GetString(fmt.Sprintf("%s.connection", key), "") != connection {
+				continue
+			}
+
+			ids := config.GetStringSlice(fmt.Sprintf("%s.topics", key), []string{})
+			ids = append(ids, config.GetString(fmt.Sprintf("%s.topic", key), ""))
+
+			for _, id := range ids {
+				if id != "" {
+					topics = append(topics, gosoKafka.FQTopicName(config, appId, id))
+				}
+			}
+		}
+	}
+
+	return topics
+}
+
+func (f *kafkaFactory) address(container *container) string {
+	binding := container.bindings["9092/tcp"]
+
+	return fmt.Sprintf("%s:%s", binding.host, binding.port)
+}
+
+func (f *kafkaFactory) freePort() int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
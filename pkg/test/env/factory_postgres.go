@@ -0,0 +1,256 @@
+package env
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
+	"github.com/justtrackio/gosoline/pkg/uuid"
+	"github.com/lib/pq"
+)
+
+func init() {
+	componentFactories[componentPostgres] = new(postgresFactory)
+}
+
+const componentPostgres = "postgres"
+
+type postgresCredentials struct {
+	DatabaseName string `cfg:"database_name" default:"gosoline"`
+	UserName     string `cfg:"user_name" default:"gosoline"`
+	UserPassword string `cfg:"user_password" default:"gosoline"`
+}
+
+type postgresSettings struct {
+	ComponentBaseSettings
+	ComponentContainerSettings
+	ContainerBindingSettings
+	UseExternalContainer bool                `cfg:"use_external_container" default:"false"`
+	Version              string              `cfg:"version" default:"15-alpine"`
+	Credentials          postgresCredentials `cfg:"credentials"`
+}
+
+type postgresFactory struct{}
+
+func (f postgresFactory) Detect(config cfg.Config, manager *ComponentsConfigManager) error {
+	if !config.IsSet("db") {
+		return nil
+	}
+
+	if !manager.ShouldAutoDetect(componentPostgres) {
+		return nil
+	}
+
+	if manager.HasType(componentPostgres) {
+		return nil
+	}
+
+	components := config.GetStringMap("db")
+
+	for name := range components {
+		driver := config.Get(fmt.Sprintf("db.%s.driver", name))
+
+		if driver != componentPostgres {
+			continue
+		}
+
+		settings := &postgresSettings{}
+		config.UnmarshalDefaults(settings)
+
+		settings.Type = componentPostgres
+		settings.Name = name
+
+		if err := manager.Add(settings); err != nil {
+			return fmt.Errorf("can not add default postgres component: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f postgresFactory) GetSettingsSchema() ComponentBaseSettingsAware {
+	return &postgresSettings{}
+}
+
+func (f postgresFactory) DescribeContainers(settings interface{}) componentContainerDescriptions {
+	return componentContainerDescriptions{
+		"main": {
+			containerConfig:  f.configureContainer(settings),
+			healthCheck:      f.healthCheck(settings),
+			shutdownCallback: f.dropDatabase(settings),
+		},
+	}
+}
+
+func (f postgresFactory) configureContainer(settings interface{}) *containerConfig {
+	s := settings.(*postgresSettings)
+
+	if s.UseExternalContainer {
+		// when using an external instance we need to generate a new database. Dashes are not allowed in unquoted
+		// identifiers, so we strip them from the uuid to keep the name usable in plain sql
+		s.Credentials.DatabaseName = fmt.Sprintf("test_%s", strings.ReplaceAll(uuid.New().NewV4(), "-", ""))
+	} else {
+		// ensure to use a free port for the new container
+		s.Port = 0
+	}
+
+	env := []string{
+		fmt.Sprintf("POSTGRES_DB=%s", s.Credentials.DatabaseName),
+		fmt.Sprintf("POSTGRES_USER=%s", s.Credentials.UserName),
+		fmt.Sprintf("POSTGRES_PASSWORD=%s", s.Credentials.UserPassword),
+	}
+
+	if len(s.Tmpfs) == 0 {
+		s.Tmpfs = append(s.Tmpfs, TmpfsSettings{
+			Path: "/var/lib/postgresql/data",
+		})
+	}
+
+	if s.UseExternalContainer {
+		return &containerConfig{
+			UseExternalContainer: true,
+			ContainerBindings: containerBindings{
+				"5432/tcp": containerBinding{
+					host: s.Host,
+					port: strconv.Itoa(s.Port),
+				},
+			},
+		}
+	}
+
+	return &containerConfig{
+		Repository: "postgres",
+		Tmpfs:      s.Tmpfs,
+		Tag:        s.Version,
+		Env:        env,
+		Cmd:        []string{"-c", "fsync=off"},
+		PortBindings: portBindings{
+			"5432/tcp": s.Port,
+		},
+		ExpireAfter: s.ExpireAfter,
+	}
+}
+
+func (f postgresFactory) healthCheck(settings interface{}) ComponentHealthCheck {
+	return func(container *container) error {
+		s := settings.(*postgresSettings)
+		binding := container.bindings["5432/tcp"]
+		client, err := f.connection(s, binding)
+		if err != nil {
+			return fmt.Errorf("can not create client: %w", err)
+		}
+
+		return client.Ping()
+	}
+}
+
+func (f postgresFactory) Component(_ cfg.Config, _ log.Logger, containers map[string]*container, settings interface{}) (Component, error) {
+	s := settings.(*postgresSettings)
+	binding := containers["main"].bindings["5432/tcp"]
+	client, err := f.connection(s, binding)
+	if err != nil {
+		return nil, fmt.Errorf("can not create client: %w", err)
+	}
+
+	component := &postgresComponent{
+		baseComponent: baseComponent{
+			name: s.Name,
+		},
+		client:      client,
+		credentials: s.Credentials,
+		binding:     binding,
+	}
+
+	return component, nil
+}
+
+func (f postgresFactory) connection(settings *postgresSettings, binding containerBinding) (*sqlx.DB, error) {
+	err := f.setup(settings, binding)
+	if err != nil {
+		return nil, fmt.Errorf("can not prepare database: %w", err)
+	}
+
+	client, err := sqlx.Open("postgres", f.dsn(settings, binding, settings.Credentials.DatabaseName))
+	if err != nil {
+		return nil, fmt.Errorf("can not create client: %w", err)
+	}
+
+	return client, nil
+}
+
+// setup creates the database if it doesn't exist yet, which is only the case for external containers. The container
+// we start on our own creates the database on startup already.
+func (f postgresFactory) setup(settings *postgresSettings, binding containerBinding) error {
+	client, err := sql.Open("postgres", f.dsn(settings, binding, "postgres"))
+	if err != nil {
+		return fmt.Errorf("can not create maintenance client: %w", err)
+	}
+	defer client.Close()
+
+	err = client.Ping()
+	if err != nil {
+		return fmt.Errorf("unable to connect to maintenance database: %w", err)
+	}
+
+	var exists bool
+	err = client.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = $1)", settings.Credentials.DatabaseName).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("can not check if database exists: %w", err)
+	}
+
+	if exists {
+		return nil
+	}
+
+	_, err = client.Exec(fmt.Sprintf("CREATE DATABASE %s", pq.QuoteIdentifier(settings.Credentials.DatabaseName)))
+	if err != nil {
+		return fmt.Errorf("can not create database: %w", err)
+	}
+
+	return nil
+}
+
+func (f postgresFactory) dropDatabase(settings interface{}) ComponentShutdownCallback {
+	return func(container *container) func() error {
+		return func() error {
+			s := settings.(*postgresSettings)
+			binding := container.bindings["5432/tcp"]
+
+			// we can't drop the database we are connected to, so we have to use the maintenance database
+			client, err := sql.Open("postgres", f.dsn(s, binding, "postgres"))
+			if err != nil {
+				return fmt.Errorf("can not connect to database: %w", err)
+			}
+			defer client.Close()
+
+			dropDatabase := fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", pq.QuoteIdentifier(s.Credentials.DatabaseName))
+
+			_, err = client.Exec(dropDatabase)
+			if err != nil {
+				return fmt.Errorf("can not drop database: %w", err)
+			}
+
+			return nil
+		}
+	}
+}
+
+func (f postgresFactory) dsn(settings *postgresSettings, binding containerBinding, database string) string {
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(settings.Credentials.UserName, settings.Credentials.UserPassword),
+		Host:   fmt.Sprintf("%s:%v", binding.host, binding.port),
+		Path:   database,
+	}
+
+	qry := dsn.Query()
+	qry.Set("sslmode", "disable")
+	dsn.RawQuery = qry.Encode()
+
+	return dsn.String()
+}
@@ -0,0 +1,125 @@
+package env
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/justtrackio/gosoline/pkg/cfg"
+	"github.com/justtrackio/gosoline/pkg/log"
+)
+
+func init() {
+	componentFactories[componentSchemaRegistry] = new(schemaRegistryFactory)
+}
+
+const componentSchemaRegistry = "schemaRegistry"
+
+type schemaRegistrySettings struct {
+	ComponentBaseSettings
+	ComponentContainerSettings
+	Port    int    `cfg:"port" default:"0"`
+	Version string `cfg:"version" default:"v23.2.17"`
+}
+
+// schemaRegistryFactory runs the schema registry embedded in redpanda. The registry stores the schemas in a topic
+// of its own broker, so it works independently of any kafka component.
+type schemaRegistryFactory struct{}
+
+func (f *schemaRegistryFactory) Detect(config cfg.Config, manager *ComponentsConfigManager) error {
+	if !config.IsSet("kafka.schema_registry") {
+		return nil
+	}
+
+	if !manager.ShouldAutoDetect(componentSchemaRegistry) {
+		return nil
+	}
+
+	if manager.HasType(componentSchemaRegistry) {
+		return nil
+	}
+
+	for name := range config.GetStringMap("kafka.schema_registry") {
+		settings := &schemaRegistrySettings{}
+		config.UnmarshalDefaults(settings)
+
+		settings.Type = componentSchemaRegistry
+		settings.Name = name
+
+		if err := manager.Add(settings); err != nil {
+			return fmt.Errorf("can not add default schema registry component: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *schemaRegistryFactory) GetSettingsSchema() ComponentBaseSettingsAware {
+	return &schemaRegistrySettings{}
+}
+
+func (f *schemaRegistryFactory) DescribeContainers(settings interface{}) componentContainerDescriptions {
+	return componentContainerDescriptions{
+		"main": {
+			containerConfig: f.configureContainer(settings),
+			healthCheck:     f.healthCheck(),
+		},
+	}
+}
+
+func (f *schemaRegistryFactory) configureContainer(settings interface{}) *containerConfig {
+	s := settings.(*schemaRegistrySettings)
+
+	return &containerConfig{
+		Repository: "redpandadata/redpanda",
+		Tag:        s.Version,
+		Cmd: []string{
+			"redpanda", "start",
+			"--overprovisioned",
+			"--smp", "1",
+			"--memory", "512M",
+			"--reserve-memory", "0M",
+			"--node-id", "0",
+			"--check=false",
+			"--schema-registry-addr", "0.0.0.0:8081",
+		},
+		PortBindings: portBindings{
+			"8081/tcp": s.Port,
+		},
+		ExpireAfter: s.ExpireAfter,
+	}
+}
+
+func (f *schemaRegistryFactory) healthCheck() ComponentHealthCheck {
+	return func(container *container) error {
+		resp, err := http.Get(fmt.Sprintf("%s/subjects", f.url(container)))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 399 {
+			return fmt.Errorf("schema registry did return status '%s'", resp.Status)
+		}
+
+		return nil
+	}
+}
+
+func (f *schemaRegistryFactory) Component(_ cfg.Config, _ log.Logger, containers map[string]*container, settings interface{}) (Component, error) {
+	s := settings.(*schemaRegistrySettings)
+
+	component := &SchemaRegistryComponent{
+		baseComponent: baseComponent{
+			name: s.Name,
+		},
+		url: f.url(containers["main"]),
+	}
+
+	return component, nil
+}
+
+func (f *schemaRegistryFactory) url(container *container) string {
+	binding := container.bindings["8081/tcp"]
+
+	return fmt.Sprintf("http://%s:%s", binding.host, binding.port)
+}
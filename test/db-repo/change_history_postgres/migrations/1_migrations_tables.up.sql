@@ -0,0 +1,17 @@
+create table test_model1
+(
+  id serial primary key,
+  name varchar(255) null,
+  updated_at timestamp with time zone null,
+  created_at timestamp with time zone null
+);
+
+create table test_model2
+(
+  id serial primary key,
+  name varchar(255) null,
+  foo  varchar(8) null,
+  change_author varchar(255),
+  updated_at timestamp with time zone null,
+  created_at timestamp with time zone null
+);
@@ -0,0 +1,75 @@
+//go:build integration
+// +build integration
+
+package es_test
+
+import (
+	"testing"
+
+	"github.com/justtrackio/gosoline/pkg/es"
+	"github.com/justtrackio/gosoline/pkg/fixtures"
+	"github.com/justtrackio/gosoline/pkg/test/suite"
+)
+
+type document struct {
+	Id     int    `json:"id"`
+	Status string `json:"status"`
+}
+
+type EsTestSuite struct {
+	suite.Suite
+}
+
+func (s *EsTestSuite) SetupSuite() []suite.Option {
+	return []suite.Option{
+		suite.WithLogLevel("debug"),
+		suite.WithConfigFile("./config.dist.yml"),
+	}
+}
+
+func (s *EsTestSuite) TestFixturesAndSearch() {
+	ctx := s.Env().Context()
+
+	err := s.Env().LoadFixtureBuilderFactories(fixtures.SimpleFixtureBuilderFactory([]*fixtures.FixtureSet{
+		{
+			Enabled: true,
+			Purge:   true,
+			Writer:  fixtures.ElasticsearchFixtureWriterFactory[document]("documents"),
+			Fixtures: []interface{}{
+				&fixtures.ElasticsearchFixture{Id: "1", Document: document{Id: 1, Status: "active"}},
+				&fixtures.ElasticsearchFixture{Id: "2", Document: document{Id: 2, Status: "inactive"}},
+				&fixtures.ElasticsearchFixture{Id: "3", Document: document{Id: 3, Status: "active"}},
+			},
+		},
+	}))
+	s.NoError(err)
+
+	repository, err := es.NewRepository[document](s.Env().Config(), s.Env().Logger(), "documents")
+	s.NoError(err)
+
+	doc, err := repository.Get(ctx, "2")
+	s.NoError(err)
+	s.Equal(document{Id: 2, Status: "inactive"}, doc)
+
+	ids := make([]int, 0)
+	query := es.NewQuery().Filter(es.Term("status.keyword", "active")).Sort("id", es.SortAsc).Size(1)
+
+	err = repository.SearchAfter(ctx, query, func(hits []es.Hit[document]) error {
+		for _, hit := range hits {
+			ids = append(ids, hit.Source.Id)
+		}
+
+		return nil
+	})
+	s.NoError(err)
+	s.Equal([]int{1, 3}, ids)
+
+	s.NoError(repository.Delete(ctx, "1"))
+
+	_, err = repository.Get(ctx, "1")
+	s.True(es.IsNotFound(err))
+}
+
+func TestEs(t *testing.T) {
+	suite.Run(t, new(EsTestSuite))
+}
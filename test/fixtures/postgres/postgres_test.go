@@ -0,0 +1,182 @@
+//go:build integration && fixtures
+// +build integration,fixtures
+
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	gosoAws "github.com/justtrackio/gosoline/pkg/cloud/aws"
+	"github.com/justtrackio/gosoline/pkg/db-repo"
+	"github.com/justtrackio/gosoline/pkg/fixtures"
+	"github.com/justtrackio/gosoline/pkg/mdl"
+	gosoAssert "github.com/justtrackio/gosoline/pkg/test/assert"
+	"github.com/justtrackio/gosoline/pkg/test/suite"
+)
+
+type PostgresTestSuite struct {
+	suite.Suite
+}
+
+type PostgresTestModel struct {
+	db_repo.Model
+	Name *string
+}
+
+func (s *PostgresTestSuite) SetupSuite() []suite.Option {
+	err := os.Setenv("AWS_ACCESS_KEY_ID", gosoAws.DefaultAccessKeyID)
+	s.NoError(err)
+
+	err = os.Setenv("AWS_SECRET_ACCESS_KEY", gosoAws.DefaultSecretAccessKey)
+	s.NoError(err)
+
+	return []suite.Option{
+		suite.WithLogLevel("debug"),
+		suite.WithConfigFile("config.test.yml"),
+	}
+}
+
+func (s *PostgresTestSuite) TestOrmFixturesPostgres() {
+	envConfig := s.Env().Config()
+	envLogger := s.Env().Logger()
+	envClient := s.Env().Postgres("default").Client()
+
+	loader := fixtures.NewFixtureLoader(context.Background(), envConfig, envLogger)
+	err := loader.Load(context.Background(), ormPostgresTestFixtures())
+	s.NoError(err)
+
+	gosoAssert.SqlTableHasOneRowOnly(s.T(), envClient, "postgres_test_models")
+	gosoAssert.SqlColumnHasSpecificValue(s.T(), envClient, "postgres_test_models", "name", "testName")
+}
+
+func (s *PostgresTestSuite) TestPlainFixturesPostgres() {
+	envConfig := s.Env().Config()
+	envLogger := s.Env().Logger()
+	envClient := s.Env().Postgres("default").Client()
+
+	loader := fixtures.NewFixtureLoader(context.Background(), envConfig, envLogger)
+	err := loader.Load(context.Background(), plainPostgresTestFixtures())
+	s.NoError(err)
+
+	gosoAssert.SqlTableHasOneRowOnly(s.T(), envClient, "postgres_plain_writer_test")
+	gosoAssert.SqlColumnHasSpecificValue(s.T(), envClient, "postgres_plain_writer_test", "name", "testName3")
+}
+
+func (s *PostgresTestSuite) TestPurgedOrmFixturesPostgres() {
+	envConfig := s.Env().Config()
+	envLogger := s.Env().Logger()
+	envClient := s.Env().Postgres("default").Client()
+
+	loader := fixtures.NewFixtureLoader(context.Background(), envConfig, envLogger)
+	err := loader.Load(context.Background(), ormPostgresTestFixtures())
+	s.NoError(err)
+
+	gosoAssert.SqlColumnHasSpecificValue(s.T(), envClient, "postgres_test_models", "name", "testName")
+
+	err = loader.Load(context.Background(), ormPostgresTestFixturesWithPurge())
+	s.NoError(err)
+
+	gosoAssert.SqlTableHasOneRowOnly(s.T(), envClient, "postgres_test_models")
+	gosoAssert.SqlColumnHasSpecificValue(s.T(), envClient, "postgres_test_models", "name", "purgedBefore")
+}
+
+func (s *PostgresTestSuite) TestPurgedPlainFixturesPostgres() {
+	envConfig := s.Env().Config()
+	envLogger := s.Env().Logger()
+	envClient := s.Env().Postgres("default").Client()
+
+	loader := fixtures.NewFixtureLoader(context.Background(), envConfig, envLogger)
+	err := loader.Load(context.Background(), plainPostgresTestFixtures())
+	s.NoError(err)
+
+	gosoAssert.SqlColumnHasSpecificValue(s.T(), envClient, "postgres_plain_writer_test", "name", "testName3")
+
+	err = loader.Load(context.Background(), plainPostgresTestFixturesWithPurge())
+	s.NoError(err)
+
+	gosoAssert.SqlTableHasOneRowOnly(s.T(), envClient, "postgres_plain_writer_test")
+	gosoAssert.SqlColumnHasSpecificValue(s.T(), envClient, "postgres_plain_writer_test", "name", "purgedBefore")
+}
+
+var PostgresTestModelMetadata = db_repo.Metadata{
+	ModelId: mdl.ModelId{
+		Name: "test_model",
+	},
+	TableName:  "postgres_test_models",
+	PrimaryKey: "model.id",
+	Mappings: db_repo.FieldMappings{
+		"test_model.id":   db_repo.NewFieldMapping("test_model.id"),
+		"test_model.name": db_repo.NewFieldMapping("test_model.name"),
+	},
+}
+
+func ormPostgresTestFixtures() []*fixtures.FixtureSet {
+	return []*fixtures.FixtureSet{
+		{
+			Enabled: true,
+			Purge:   false,
+			Writer:  fixtures.PostgresOrmFixtureWriterFactory(&PostgresTestModelMetadata),
+			Fixtures: []interface{}{
+				&PostgresTestModel{
+					Name: mdl.Box("testName"),
+				},
+			},
+		},
+	}
+}
+
+func ormPostgresTestFixturesWithPurge() []*fixtures.FixtureSet {
+	return []*fixtures.FixtureSet{
+		{
+			Enabled: true,
+			Purge:   true,
+			Writer:  fixtures.PostgresOrmFixtureWriterFactory(&PostgresTestModelMetadata),
+			Fixtures: []interface{}{
+				&PostgresTestModel{
+					Name: mdl.Box("purgedBefore"),
+				},
+			},
+		},
+	}
+}
+
+func plainPostgresTestFixtures() []*fixtures.FixtureSet {
+	return []*fixtures.FixtureSet{
+		{
+			Enabled: true,
+			Purge:   false,
+			Writer: fixtures.PostgresPlainFixtureWriterFactory(&fixtures.PostgresPlainMetaData{
+				TableName:   "postgres_plain_writer_test",
+				Columns:     []string{"id", "name"},
+				PrimaryKeys: []string{"id"},
+			}),
+			Fixtures: []interface{}{
+				fixtures.PostgresPlainFixtureValues{2, "testName2"},
+				fixtures.PostgresPlainFixtureValues{2, "testName3"},
+			},
+		},
+	}
+}
+
+func plainPostgresTestFixturesWithPurge() []*fixtures.FixtureSet {
+	return []*fixtures.FixtureSet{
+		{
+			Enabled: true,
+			Purge:   true,
+			Writer: fixtures.PostgresPlainFixtureWriterFactory(&fixtures.PostgresPlainMetaData{
+				TableName:   "postgres_plain_writer_test",
+				Columns:     []string{"id", "name"},
+				PrimaryKeys: []string{"id"},
+			}),
+			Fixtures: []interface{}{
+				fixtures.PostgresPlainFixtureValues{1, "purgedBefore"},
+			},
+		},
+	}
+}
+
+func TestPostgresTestSuite(t *testing.T) {
+	suite.Run(t, new(PostgresTestSuite))
+}
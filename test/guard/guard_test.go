@@ -31,7 +31,7 @@ func (s *GuardTestSuite) SetupTest() error {
 	logger := s.Env().Logger()
 
 	var err error
-	s.guard, err = guard.NewGuard(s.Env().Config(), logger)
+	s.guard, err = guard.NewGuard(s.Env().Context(), s.Env().Config(), logger)
 	if err != nil {
 		return fmt.Errorf("could not create guard: %w", err)
 	}
@@ -0,0 +1,155 @@
+//go:build integration
+// +build integration
+
+package kafka_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/justtrackio/gosoline/pkg/kafka/consumer"
+	"github.com/justtrackio/gosoline/pkg/kafka/producer"
+	"github.com/justtrackio/gosoline/pkg/kafka/schemaregistry"
+	"github.com/justtrackio/gosoline/pkg/test/env"
+	"github.com/justtrackio/gosoline/pkg/test/suite"
+	"github.com/segmentio/kafka-go"
+)
+
+type KafkaTestSuite struct {
+	suite.Suite
+}
+
+func (s *KafkaTestSuite) SetupSuite() []suite.Option {
+	return []suite.Option{
+		suite.WithLogLevel("debug"),
+		suite.WithConfigFile("./config.dist.yml"),
+	}
+}
+
+func (s *KafkaTestSuite) TestConsumeTransformProduce() {
+	ctx, cancel := context.WithTimeout(s.Env().Context(), time.Minute)
+	defer cancel()
+
+	config := s.Env().Config()
+	logger := s.Env().Logger()
+
+	input, err := producer.NewProducer(ctx, config, logger, "kafka.producer.input")
+	s.NoError(err)
+	s.NoError(input.WriteOne(ctx, kafka.Message{Key: []byte("1"), Value: []byte("hello")}))
+
+	cons, err := consumer.NewConsumer(ctx, config, logger, "kafka.consumer.input")
+	s.NoError(err)
+
+	go func() {
+		_ = cons.Run(ctx)
+	}()
+
+	var consumed kafka.Message
+
+	select {
+	case consumed = <-cons.Data():
+	case <-ctx.Done():
+		s.FailNow("no message consumed", ctx.Err())
+	}
+
+	s.Equal("hello", string(consumed.Value))
+
+	generation, err := cons.Generation()
+	s.NoError(err)
+
+	output, err := producer.NewProducer(ctx, config, logger, "kafka.producer.output")
+	s.NoError(err)
+
+	s.NoError(output.BeginTransaction())
+	s.NoError(output.WriteOne(ctx, kafka.Message{Key: consumed.Key, Value: []byte("HELLO")}))
+	s.NoError(output.SendOffsetsToTransaction(ctx, generation, consumed))
+	s.NoError(output.CommitTransaction(ctx))
+	s.NoError(output.Writer.Close())
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        []string{s.Env().Kafka("default").Address()},
+		Topic:          output.Settings.FQTopic,
+		IsolationLevel: kafka.ReadCommitted,
+	})
+	defer reader.Close()
+
+	produced, err := reader.ReadMessage(ctx)
+	s.NoError(err)
+	s.Equal("HELLO", string(produced.Value))
+
+	client := &kafka.Client{Addr: kafka.TCP(s.Env().Kafka("default").Address())}
+	offsets, err := client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: generation.GroupID,
+		Topics:  map[string][]int{consumed.Topic: {consumed.Partition}},
+	})
+	s.NoError(err)
+	s.Equal(consumed.Offset+1, offsets.Topics[consumed.Topic][0].CommittedOffset)
+}
+
+func (s *KafkaTestSuite) TestProduceAndConsumeRecords() {
+	component := s.Env().Kafka("default")
+
+	// topics of configured producers and consumers are created on startup
+	topic := producer.ParseSettings(s.Env().Config(), "kafka.producer.input").FQTopic
+	conn, err := kafka.Dial("tcp", component.Address())
+	s.NoError(err)
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(topic)
+	s.NoError(err)
+	s.Len(partitions, 1)
+
+	component.CreateTopic("test-records", 3)
+	component.Produce("test-records",
+		env.KafkaRecord{Key: []byte("1"), Value: []byte("foo"), Headers: map[string]string{"encoding": "text/plain"}},
+		env.KafkaRecord{Key: []byte("2"), Value: []byte("bar")},
+	)
+
+	records := component.Consume("test-records", 2, time.Minute)
+
+	values := map[string]string{}
+	for _, record := range records {
+		values[string(record.Key)] = string(record.Value)
+
+		if string(record.Key) == "1" {
+			s.Equal(map[string]string{"encoding": "text/plain"}, record.Headers)
+		}
+	}
+
+	s.Equal(map[string]string{"1": "foo", "2": "bar"}, values)
+}
+
+func (s *KafkaTestSuite) TestSchemaRegistry() {
+	ctx := s.Env().Context()
+	settings := &schemaregistry.SchemaSettings{
+		Registry:           "default",
+		Type:               schemaregistry.SchemaTypeAvro,
+		Schema:             `{"type":"record","name":"Event","fields":[{"name":"id","type":"int"}]}`,
+		AutoRegister:       true,
+		CompatibilityCheck: true,
+	}
+
+	serializer, err := schemaregistry.NewSerializer(ctx, s.Env().Config(), s.Env().Logger(), settings, "test-events")
+	s.NoError(err)
+
+	data, err := serializer.Serialize([]byte(`{"id":42}`))
+	s.NoError(err)
+
+	deserializer, err := schemaregistry.NewDeserializer(ctx, s.Env().Config(), s.Env().Logger(), "default")
+	s.NoError(err)
+
+	body, err := deserializer.Deserialize(ctx, data)
+	s.NoError(err)
+	s.JSONEq(`{"id":42}`, string(body))
+
+	// adding a field without default is not backward compatible
+	settings.Schema = `{"type":"record","name":"Event","fields":[{"name":"id","type":"int"},{"name":"name","type":"string"}]}`
+
+	_, err = schemaregistry.NewSerializer(ctx, s.Env().Config(), s.Env().Logger(), settings, "test-events")
+	s.ErrorIs(err, schemaregistry.ErrIncompatibleSchema)
+}
+
+func TestKafka(t *testing.T) {
+	suite.Run(t, new(KafkaTestSuite))
+}